@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/localgit"
+	"docgent/internal/infrastructure/transcript"
+)
+
+func HandleAsk(ctx context.Context, cli *CLI, rt *Runtime) error {
+	question := port.ConversationMessage{Author: "user", Content: cli.Ask.Question, YouMentioned: true}
+	conversationService, err := newConversationService(rt, cli.Ask.Transcript, question)
+	if err != nil {
+		return err
+	}
+
+	var options []application.NewConversationUsecaseOption
+	if rt.RAGCorpus != nil {
+		options = append(options, application.WithConversationRAGCorpus(rt.RAGCorpus))
+	}
+	usecase := application.NewConversationUsecase(
+		rt.ChatModel,
+		conversationService,
+		localgit.NewFileQueryService(rt.Repository, cli.BaseBranch),
+		[]port.SourceRepository{transcript.NewSourceRepository(conversationService)},
+		github.NewResponseFormatter(),
+		options...,
+	)
+
+	if err := usecase.Execute(ctx); err != nil {
+		return fmt.Errorf("回答の生成に失敗しました: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/application/redaction"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/localgit"
+	"docgent/internal/infrastructure/transcript"
+)
+
+func HandlePropose(ctx context.Context, cli *CLI, rt *Runtime) error {
+	conversationService, err := newConversationService(rt, cli.Propose.Transcript)
+	if err != nil {
+		return err
+	}
+
+	headBranch := cli.Propose.Branch
+	if headBranch == "" {
+		headBranch = fmt.Sprintf("docgent/proposal-%d", time.Now().Unix())
+	}
+	if err := localgit.NewBranchService(rt.Repository).CreateBranch(ctx, cli.BaseBranch, headBranch); err != nil {
+		return fmt.Errorf("ブランチの作成に失敗しました: %w", err)
+	}
+
+	proposalRepository := localgit.NewProposalRepository(rt.Repository, cli.BaseBranch, headBranch)
+	var options []application.NewProposalGenerateUsecaseOption
+	if rt.RAGCorpus != nil {
+		options = append(options, application.WithProposalGenerateRAGCorpus(rt.RAGCorpus))
+	}
+	usecase := application.NewProposalGenerateUsecase(
+		rt.ChatModel,
+		conversationService,
+		localgit.NewFileQueryService(rt.Repository, headBranch),
+		redaction.NewFileRepository(localgit.NewFileRepository(rt.Repository, headBranch), rt.Redactor),
+		[]port.SourceRepository{transcript.NewSourceRepository(conversationService)},
+		proposalRepository,
+		github.NewResponseFormatter(),
+		options...,
+	)
+
+	handle, err := usecase.Execute(ctx)
+	if err != nil {
+		return fmt.Errorf("提案の生成に失敗しました: %w", err)
+	}
+
+	proposal, err := proposalRepository.GetProposal(handle)
+	if err != nil {
+		return fmt.Errorf("提案の取得に失敗しました: %w", err)
+	}
+	printProposal(proposal, headBranch)
+	return nil
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/application/redaction"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/localgit"
+	"docgent/internal/infrastructure/transcript"
+)
+
+func HandleRefine(ctx context.Context, cli *CLI, rt *Runtime) error {
+	conversationService, err := newConversationService(rt, cli.Refine.Transcript)
+	if err != nil {
+		return err
+	}
+
+	// 提案のヘッドブランチはメタデータから引く
+	proposalRepository := localgit.NewProposalRepository(rt.Repository, cli.BaseBranch, "")
+	handle := proposalRepository.NewProposalHandle(strconv.Itoa(cli.Refine.PR))
+	headBranch, err := proposalRepository.GetHeadBranch(handle)
+	if err != nil {
+		return fmt.Errorf("提案 #%d が見つかりません: %w", cli.Refine.PR, err)
+	}
+
+	var options []application.NewProposalRefineUsecaseOption
+	if rt.RAGCorpus != nil {
+		options = append(options, application.WithProposalRefineRAGCorpus(rt.RAGCorpus))
+	}
+	usecase := application.NewProposalRefineUsecase(
+		rt.ChatModel,
+		conversationService,
+		localgit.NewFileQueryService(rt.Repository, headBranch),
+		redaction.NewFileRepository(localgit.NewFileRepository(rt.Repository, headBranch), rt.Redactor),
+		[]port.SourceRepository{transcript.NewSourceRepository(conversationService)},
+		proposalRepository,
+		github.NewResponseFormatter(),
+		options...,
+	)
+
+	if err := usecase.Refine(handle, cli.Refine.Feedback); err != nil {
+		return fmt.Errorf("提案の修正に失敗しました: %w", err)
+	}
+
+	proposal, err := proposalRepository.GetProposal(handle)
+	if err != nil {
+		return fmt.Errorf("提案の取得に失敗しました: %w", err)
+	}
+	printProposal(proposal, headBranch)
+	return nil
+}
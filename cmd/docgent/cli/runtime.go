@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"docgent/internal/application/port"
+	"docgent/internal/application/redaction"
+	"docgent/internal/domain"
+	"docgent/internal/infrastructure/localgit"
+	"docgent/internal/infrastructure/transcript"
+)
+
+// Runtime holds the services shared by the commands
+type Runtime struct {
+	ChatModel  domain.ChatModel
+	Redactor   *redaction.Redactor
+	Repository *localgit.Repository
+	// RAGCorpus is nil if no corpus is specified
+	RAGCorpus port.RAGCorpus
+}
+
+// newConversationService creates a conversation printed to stdout.
+// It starts from the transcript if the path is given, otherwise it is a conversation about the repository.
+func newConversationService(rt *Runtime, transcriptPath string, messages ...port.ConversationMessage) (*transcript.ConversationService, error) {
+	if transcriptPath == "" {
+		uri, err := transcript.FileURI(rt.Repository.Dir())
+		if err != nil {
+			return nil, err
+		}
+		return transcript.NewConversationService(uri, messages, os.Stdout), nil
+	}
+
+	t, err := transcript.Load(transcriptPath)
+	if err != nil {
+		return nil, err
+	}
+	uri, err := t.ConversationURI()
+	if err != nil {
+		return nil, err
+	}
+	return transcript.NewConversationService(uri, append(t.ConversationMessages(), messages...), os.Stdout), nil
+}
+
+// printProposal prints the proposal in the form of a pull request
+func printProposal(proposal domain.Proposal, headBranch string) {
+	fmt.Printf("\n# Proposal #%s: %s\n\n", proposal.Handle.Value, proposal.Title)
+	fmt.Printf("%s\n\n", proposal.Body)
+	fmt.Printf("Branch: %s\n\n", headBranch)
+	for _, diff := range proposal.Diffs {
+		oldName := "a/" + diff.OldName
+		if diff.IsNewFile {
+			oldName = "/dev/null"
+		}
+		fmt.Printf("--- %s\n+++ b/%s\n%s\n", oldName, diff.NewName, diff.Body)
+	}
+}
@@ -0,0 +1,29 @@
+package cli
+
+type CLI struct {
+	Propose struct {
+		Transcript string `required:"" help:"Path to the JSON transcript of the conversation" type:"existingfile"`
+		Branch     string `help:"Branch to commit the proposal to (defaults to docgent/proposal-<timestamp>)"`
+	} `cmd:"" help:"Generate a document proposal from a conversation and commit it to a new branch"`
+
+	Ask struct {
+		Question   string `arg:"" required:"" help:"Question to ask about the documents"`
+		Transcript string `help:"Path to the JSON transcript of the conversation that the question follows" type:"existingfile"`
+	} `cmd:"" help:"Ask a question about the documents"`
+
+	Refine struct {
+		PR         int    `name:"pr" required:"" help:"Number of the local proposal to refine"`
+		Feedback   string `required:"" help:"Feedback on the proposal"`
+		Transcript string `help:"Path to the JSON transcript of the conversation that the proposal was generated from" type:"existingfile"`
+	} `cmd:"" help:"Refine a local proposal with feedback"`
+
+	Repo        string `help:"Path to the git repository of the documents" default:"." type:"existingdir"`
+	BaseBranch  string `help:"Branch that documents are read from and proposals are based on" default:"main"`
+	ProjectID   string `required:"" help:"Google Cloud Project ID" env:"VERTEXAI_PROJECT_ID"`
+	Location    string `help:"Google Cloud location" default:"us-central1" env:"VERTEXAI_LOCATION"`
+	ModelName   string `help:"Name of the Gemini model" default:"gemini-2.0-pro-exp-02-05" env:"VERTEXAI_MODEL_NAME"`
+	RAGCorpusID int64  `name:"rag-corpus-id" help:"ID of the RAG corpus to search (RAG is disabled if not specified)" env:"VERTEXAI_RAG_CORPUS_ID"`
+	LocalRAGDir string `name:"local-rag-dir" help:"Directory of local RAG corpora to search instead of Vertex AI (the corpus ID defaults to 1)" type:"path" env:"LOCAL_RAG_DIR"`
+	NoRedaction bool   `help:"Send conversations and documents to the model without redacting secrets"`
+	Verbose     bool   `short:"v" help:"Print logs of the model to stderr"`
+}
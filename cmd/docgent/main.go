@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/alecthomas/kong"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2/google"
+
+	"docgent/cmd/docgent/cli"
+	"docgent/internal/application/redaction"
+	"docgent/internal/infrastructure/google/vertexai/genai"
+	"docgent/internal/infrastructure/google/vertexai/rag"
+	raglib "docgent/internal/infrastructure/google/vertexai/rag/lib"
+	"docgent/internal/infrastructure/localgit"
+	"docgent/internal/infrastructure/localrag"
+)
+
+const defaultRedactionEntropyThreshold = 4.0
+
+// defaultLocalRAGCorpusID is searched if --local-rag-dir is given without --rag-corpus-id
+const defaultLocalRAGCorpusID = 1
+
+var CLI cli.CLI
+
+func main() {
+	ctx := context.Background()
+
+	kongCtx := kong.Parse(&CLI)
+
+	rt, err := newRuntime(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var cmdErr error
+	switch kongCtx.Command() {
+	case "propose":
+		cmdErr = cli.HandlePropose(ctx, &CLI, rt)
+	case "ask <question>":
+		cmdErr = cli.HandleAsk(ctx, &CLI, rt)
+	case "refine":
+		cmdErr = cli.HandleRefine(ctx, &CLI, rt)
+	default:
+		fmt.Printf("invalid command: %s\n", kongCtx.Command())
+		os.Exit(1)
+	}
+
+	if cmdErr != nil {
+		fmt.Println(cmdErr)
+		os.Exit(1)
+	}
+}
+
+func newRuntime(ctx context.Context) (*cli.Runtime, error) {
+	repository, err := localgit.Open(ctx, CLI.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := zap.NewNop()
+	if CLI.Verbose {
+		logger, err = zap.NewDevelopment()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create logger: %w", err)
+		}
+	}
+
+	chatModel, err := genai.NewChatModel(genai.ChatModelParams{
+		Logger: logger,
+		Config: genai.Config{
+			ProjectID: CLI.ProjectID,
+			Location:  CLI.Location,
+			ModelName: CLI.ModelName,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	redactor, err := redaction.NewRedactorFromConfig(redaction.Config{
+		Enabled:          !CLI.NoRedaction,
+		EntropyThreshold: defaultRedactionEntropyThreshold,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rt := &cli.Runtime{
+		ChatModel:  redaction.NewChatModel(chatModel, redactor),
+		Redactor:   redactor,
+		Repository: repository,
+	}
+
+	if CLI.LocalRAGDir != "" {
+		corpusID := CLI.RAGCorpusID
+		if corpusID == 0 {
+			corpusID = defaultLocalRAGCorpusID
+		}
+		rt.RAGCorpus = localrag.NewService(CLI.LocalRAGDir, localrag.NewHashEmbedder(0)).GetCorpus(corpusID)
+	} else if CLI.RAGCorpusID != 0 {
+		credentials, err := google.FindDefaultCredentials(ctx, "https://www.googleapis.com/auth/cloud-platform")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Google Cloud credentials: %w", err)
+		}
+		ragService := rag.NewService(raglib.NewClientWithCredentials(credentials, CLI.ProjectID, CLI.Location))
+		rt.RAGCorpus = ragService.GetCorpus(CLI.RAGCorpusID)
+	}
+
+	return rt, nil
+}
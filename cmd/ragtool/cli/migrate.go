@@ -42,6 +42,12 @@ func HandleCorpusMigrate(ctx context.Context, cli *CLI, client *lib.Client) erro
 	)
 	tc := oauth2.NewClient(ctx, ts)
 	githubClient := gogithub.NewClient(tc)
+	if cli.Corpus.Migrate.GithubURL != "" {
+		githubClient, err = githubClient.WithEnterpriseURLs(cli.Corpus.Migrate.GithubURL, cli.Corpus.Migrate.GithubURL)
+		if err != nil {
+			return fmt.Errorf("invalid GitHub URL: %w", err)
+		}
+	}
 
 	// Create GitHub file query service
 	fileQueryService := github.NewFileQueryService(
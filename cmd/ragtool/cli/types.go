@@ -33,6 +33,7 @@ type CLI struct {
 			Repo        string `required:"" help:"GitHub repository name"`
 			Branch      string `help:"GitHub repository branch" default:"main"`
 			GithubToken string `required:"" help:"GitHub personal access token"`
+			GithubURL   string `help:"API URL of GitHub Enterprise Server (e.g. https://ghes.example.com/api/v3/)" env:"GITHUB_BASE_URL"`
 		} `cmd:"" help:"Migrate RAG corpus to use GitHub permalinks"`
 	} `cmd:"" help:"Manage RAG corpus"`
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"strconv"
+	"strings"
 )
 
 type applicationConfigService struct {
@@ -36,6 +37,10 @@ func NewApplicationConfigServiceFromJSON() handler.ApplicationConfigService {
 		if workspace.GitHubDefaultBranch == "" {
 			workspaces[i].GitHubDefaultBranch = "main"
 		}
+		if workspace.GitHubIssueLabel == "" {
+			workspaces[i].GitHubIssueLabel = handler.DefaultGitHubIssueLabel
+		}
+		applyRepositoryDefaults(&workspaces[i])
 	}
 
 	return newApplicationConfigService(workspaces)
@@ -62,6 +67,11 @@ func NewApplicationConfigServiceFromEnv() handler.ApplicationConfigService {
 		githubDefaultBranch = "main"
 	}
 
+	githubIssueLabel := os.Getenv("GITHUB_ISSUE_LABEL")
+	if githubIssueLabel == "" {
+		githubIssueLabel = handler.DefaultGitHubIssueLabel
+	}
+
 	githubInstallationIDStr := os.Getenv("GITHUB_INSTALLATION_ID")
 	if githubInstallationIDStr == "" {
 		panic("GITHUB_INSTALLATION_ID is not set")
@@ -71,10 +81,22 @@ func NewApplicationConfigServiceFromEnv() handler.ApplicationConfigService {
 		panic("GITHUB_INSTALLATION_ID is not a valid integer")
 	}
 
+	ragBackend := os.Getenv("RAG_BACKEND")
+	if ragBackend == "" {
+		ragBackend = handler.RAGBackendVertexAI
+	}
+	if ragBackend != handler.RAGBackendVertexAI && ragBackend != handler.RAGBackendLocal {
+		panic("RAG_BACKEND must be vertexai or local")
+	}
+
 	vertexaiRagCorpusIDStr := os.Getenv("VERTEXAI_RAG_CORPUS_ID")
 	var vertexaiRagCorpusID int64
 	if vertexaiRagCorpusIDStr == "" {
-		panic("VERTEXAI_RAG_CORPUS_ID is not set")
+		if ragBackend != handler.RAGBackendLocal {
+			panic("VERTEXAI_RAG_CORPUS_ID is not set")
+		}
+		// ローカルのコーパスはIDを決めずに使えるようにする
+		vertexaiRagCorpusID = defaultLocalRAGCorpusID
 	} else {
 		vertexaiRagCorpusID, err = strconv.ParseInt(vertexaiRagCorpusIDStr, 10, 64)
 		if err != nil {
@@ -82,20 +104,90 @@ func NewApplicationConfigServiceFromEnv() handler.ApplicationConfigService {
 		}
 	}
 
+	// GITHUB_REPOSITORIES is a JSON array of additional docs repositories and their routing rules
+	var repositories []handler.DocsRepository
+	if repositoriesJSON := os.Getenv("GITHUB_REPOSITORIES"); repositoriesJSON != "" {
+		if err := json.Unmarshal([]byte(repositoriesJSON), &repositories); err != nil {
+			panic("GITHUB_REPOSITORIES is not a valid JSON: " + err.Error())
+		}
+	}
+
+	// SLACK_GITHUB_USER_MAP is a JSON object from Slack user IDs to GitHub logins
+	var slackGitHubUsers map[string]string
+	if usersJSON := os.Getenv("SLACK_GITHUB_USER_MAP"); usersJSON != "" {
+		if err := json.Unmarshal([]byte(usersJSON), &slackGitHubUsers); err != nil {
+			panic("SLACK_GITHUB_USER_MAP is not a valid JSON: " + err.Error())
+		}
+	}
+
 	workspaces := []handler.Workspace{
 		{
-			SlackWorkspaceID:     slackWorkspaceID,
-			GitHubOwner:          githubOwner,
-			GitHubRepo:           githubRepo,
-			GitHubInstallationID: githubInstallationID,
-			GitHubDefaultBranch:  githubDefaultBranch,
-			VertexAICorpusID:     vertexaiRagCorpusID,
+			SlackWorkspaceID:       slackWorkspaceID,
+			GitHubOwner:            githubOwner,
+			GitHubRepo:             githubRepo,
+			GitHubInstallationID:   githubInstallationID,
+			GitHubDefaultBranch:    githubDefaultBranch,
+			VertexAICorpusID:       vertexaiRagCorpusID,
+			RAGBackend:             ragBackend,
+			SourcePolicy:           newSourcePolicyConfigFromEnv(),
+			GitHubIssueLabel:       githubIssueLabel,
+			Repositories:           repositories,
+			AgentSelectsRepository: os.Getenv("AGENT_SELECTS_REPOSITORY") == "true",
+			Proposal: handler.ProposalConfig{
+				Draft:                os.Getenv("PROPOSAL_DRAFT") == "true",
+				Labels:               splitList(os.Getenv("PROPOSAL_LABELS")),
+				DisableAutoReviewers: os.Getenv("PROPOSAL_DISABLE_AUTO_REVIEWERS") == "true",
+			},
+			SlackGitHubUsers: slackGitHubUsers,
+			GitLab:           newGitLabProjectConfigFromEnv(),
+			Discord:          newDiscordGuildConfigFromEnv(),
+			Mattermost:       newMattermostTeamConfigFromEnv(),
 		},
 	}
+	applyRepositoryDefaults(&workspaces[0])
 
 	return newApplicationConfigService(workspaces)
 }
 
+// applyRepositoryDefaults fills the settings of the additional repositories that default to the workspace's
+func applyRepositoryDefaults(workspace *handler.Workspace) {
+	if workspace.GitLab.Project != "" && workspace.GitLab.DefaultBranch == "" {
+		workspace.GitLab.DefaultBranch = "main"
+	}
+	for i, repository := range workspace.Repositories {
+		if repository.GitHubInstallationID == 0 {
+			workspace.Repositories[i].GitHubInstallationID = workspace.GitHubInstallationID
+		}
+		if repository.GitHubDefaultBranch == "" {
+			workspace.Repositories[i].GitHubDefaultBranch = "main"
+		}
+		if repository.Name == "" {
+			workspace.Repositories[i].Name = repository.GitHubOwner + "/" + repository.GitHubRepo
+		}
+	}
+}
+
+func newSourcePolicyConfigFromEnv() handler.SourcePolicyConfig {
+	return handler.SourcePolicyConfig{
+		AllowedChannelIDs:               splitList(os.Getenv("SOURCE_POLICY_ALLOWED_CHANNEL_IDS")),
+		DeniedChannelIDs:                splitList(os.Getenv("SOURCE_POLICY_DENIED_CHANNEL_IDS")),
+		DenyPrivateChannels:             os.Getenv("SOURCE_POLICY_DENY_PRIVATE_CHANNELS") == "true",
+		DenyDirectMessages:              os.Getenv("SOURCE_POLICY_DENY_DIRECT_MESSAGES") == "true",
+		AllowPrivateSourcesInPublicRepo: os.Getenv("SOURCE_POLICY_ALLOW_PRIVATE_SOURCES_IN_PUBLIC_REPO") == "true",
+	}
+}
+
+// splitList splits a comma separated list, ignoring empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func (s *applicationConfigService) GetWorkspaceBySlackWorkspaceID(slackWorkspaceID string) (handler.Workspace, error) {
 	for _, workspace := range s.workspaces {
 		if workspace.SlackWorkspaceID == slackWorkspaceID {
@@ -108,10 +200,46 @@ func (s *applicationConfigService) GetWorkspaceBySlackWorkspaceID(slackWorkspace
 
 func (s *applicationConfigService) GetWorkspaceByGitHubInstallationID(githubInstallationID int64) (handler.Workspace, error) {
 	for _, workspace := range s.workspaces {
-		if workspace.GitHubInstallationID == githubInstallationID {
+		for _, repository := range workspace.AllRepositories() {
+			if repository.GitHubInstallationID == githubInstallationID {
+				return workspace, nil
+			}
+		}
+	}
+
+	return handler.Workspace{}, handler.ErrWorkspaceNotFound
+}
+
+func (s *applicationConfigService) GetWorkspaceByGitLabProject(project string) (handler.Workspace, error) {
+	for _, workspace := range s.workspaces {
+		if workspace.GitLab.Project != "" && strings.EqualFold(workspace.GitLab.Project, project) {
+			return workspace, nil
+		}
+	}
+
+	return handler.Workspace{}, handler.ErrWorkspaceNotFound
+}
+
+func (s *applicationConfigService) GetWorkspaceByDiscordGuildID(guildID string) (handler.Workspace, error) {
+	for _, workspace := range s.workspaces {
+		if workspace.Discord.GuildID != "" && workspace.Discord.GuildID == guildID {
 			return workspace, nil
 		}
 	}
 
 	return handler.Workspace{}, handler.ErrWorkspaceNotFound
 }
+
+func (s *applicationConfigService) GetWorkspaceByMattermostTeamID(teamID string) (handler.Workspace, error) {
+	for _, workspace := range s.workspaces {
+		if workspace.Mattermost.TeamID != "" && workspace.Mattermost.TeamID == teamID {
+			return workspace, nil
+		}
+	}
+
+	return handler.Workspace{}, handler.ErrWorkspaceNotFound
+}
+
+func (s *applicationConfigService) ListWorkspaces() []handler.Workspace {
+	return s.workspaces
+}
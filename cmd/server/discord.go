@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"go.uber.org/fx"
+
+	"docgent/internal/infrastructure/discord"
+	"docgent/internal/infrastructure/handler"
+)
+
+// newDiscordAPI creates the Discord API. Discord is optional, so the token may be empty if no workspace uses Discord
+func newDiscordAPI() *discord.API {
+	return discord.NewAPI(os.Getenv("DISCORD_BOT_TOKEN"))
+}
+
+func newDiscordGuildConfigFromEnv() handler.DiscordGuildConfig {
+	guildID := os.Getenv("DISCORD_GUILD_ID")
+	if guildID == "" {
+		return handler.DiscordGuildConfig{}
+	}
+
+	// DISCORD_GITHUB_USER_MAP is a JSON object from Discord user IDs to GitHub logins
+	var githubUsers map[string]string
+	if usersJSON := os.Getenv("DISCORD_GITHUB_USER_MAP"); usersJSON != "" {
+		if err := json.Unmarshal([]byte(usersJSON), &githubUsers); err != nil {
+			panic("DISCORD_GITHUB_USER_MAP is not a valid JSON: " + err.Error())
+		}
+	}
+
+	return handler.DiscordGuildConfig{
+		GuildID:     guildID,
+		Reaction:    os.Getenv("DISCORD_DOC_REACTION"),
+		GitHubUsers: githubUsers,
+	}
+}
+
+// discordOptions registers the consumers of Discord events and the Gateway listener which receives them.
+// The listener does not connect if DISCORD_BOT_TOKEN is not set.
+func discordOptions() fx.Option {
+	return fx.Options(
+		fx.Provide(
+			newDiscordAPI,
+			discord.NewServiceProvider,
+			asDiscordEventRoute(handler.NewDiscordMentionEventConsumer),
+			asDiscordEventRoute(handler.NewDiscordReactionEventConsumer),
+			handler.NewDiscordGatewayListener,
+		),
+		fx.Invoke(func(*handler.DiscordGatewayListener) {}),
+	)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"net/url"
 	"os"
 	"strconv"
 
@@ -23,7 +24,24 @@ func newGitHubAPI() *github.API {
 		log.Fatal("GITHUB_APP_PRIVATE_KEY is not set")
 	}
 
-	return github.NewAPI(appID, []byte(privateKey))
+	var options []github.NewAPIOption
+	// If GITHUB_APP_SLUG is set, mentioning the app in a comment works like the refine command
+	if appSlug := os.Getenv("GITHUB_APP_SLUG"); appSlug != "" {
+		options = append(options, github.WithAppSlug(appSlug))
+	}
+
+	// If GITHUB_BASE_URL is set, connect to GitHub Enterprise Server instead of github.com
+	if baseURL := os.Getenv("GITHUB_BASE_URL"); baseURL != "" {
+		uploadURL := os.Getenv("GITHUB_UPLOAD_URL")
+		for _, u := range []string{baseURL, uploadURL} {
+			if _, err := url.Parse(u); err != nil {
+				log.Fatalf("GITHUB_BASE_URL or GITHUB_UPLOAD_URL is invalid: %v", err)
+			}
+		}
+		options = append(options, github.WithEnterpriseURLs(baseURL, uploadURL))
+	}
+
+	return github.NewAPI(appID, []byte(privateKey), options...)
 }
 
 func newGitHubWebhookRequestParser() *github.WebhookRequestParser {
@@ -32,5 +50,15 @@ func newGitHubWebhookRequestParser() *github.WebhookRequestParser {
 		log.Fatal("GITHUB_WEBHOOK_SECRET is not set")
 	}
 
-	return github.NewWebhookRequestParser(secret)
+	var options []github.NewWebhookRequestParserOption
+	// Reject webhooks from other GitHub Enterprise Server instances
+	if baseURL := os.Getenv("GITHUB_BASE_URL"); baseURL != "" {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			log.Fatalf("GITHUB_BASE_URL is invalid: %v", err)
+		}
+		options = append(options, github.WithEnterpriseHost(u.Host))
+	}
+
+	return github.NewWebhookRequestParser(secret, options...)
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+
+	"docgent/internal/infrastructure/gitlab"
+	"docgent/internal/infrastructure/handler"
+)
+
+// newGitLabAPI creates the GitLab API. GitLab is optional, so the token may be empty if no workspace uses GitLab
+func newGitLabAPI() *gitlab.API {
+	webURL := os.Getenv("GITLAB_URL")
+	if webURL != "" {
+		if _, err := url.Parse(webURL); err != nil {
+			log.Fatalf("GITLAB_URL is invalid: %v", err)
+		}
+	}
+	return gitlab.NewAPI(webURL, os.Getenv("GITLAB_TOKEN"))
+}
+
+// newGitLabWebhookRequestParser creates the parser of GitLab webhooks. Requests are rejected if GITLAB_WEBHOOK_SECRET is not set
+func newGitLabWebhookRequestParser() *gitlab.WebhookRequestParser {
+	return gitlab.NewWebhookRequestParser(os.Getenv("GITLAB_WEBHOOK_SECRET"))
+}
+
+func newGitLabProjectConfigFromEnv() handler.GitLabProjectConfig {
+	project := os.Getenv("GITLAB_PROJECT")
+	if project == "" {
+		return handler.GitLabProjectConfig{}
+	}
+
+	var corpusID int64
+	if corpusIDStr := os.Getenv("GITLAB_VERTEXAI_RAG_CORPUS_ID"); corpusIDStr != "" {
+		var err error
+		corpusID, err = strconv.ParseInt(corpusIDStr, 10, 64)
+		if err != nil {
+			panic("GITLAB_VERTEXAI_RAG_CORPUS_ID is not a valid integer")
+		}
+	}
+
+	return handler.GitLabProjectConfig{
+		Project:          project,
+		DefaultBranch:    os.Getenv("GITLAB_DEFAULT_BRANCH"),
+		VertexAICorpusID: corpusID,
+	}
+}
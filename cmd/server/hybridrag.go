@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"docgent/internal/application/port"
+	"docgent/internal/infrastructure/hybridrag"
+)
+
+// defaultKeywordIndexDir is where keyword indexes are stored if RAG_KEYWORD_INDEX_DIR is not set
+const defaultKeywordIndexDir = "data/keyword_index"
+
+// withKeywordSearch adds keyword search to the corpora of the backend if RAG_HYBRID_SEARCH is true.
+// Indexes of each backend are stored in their own directory since corpus IDs of backends overlap.
+func withKeywordSearch(ragService port.RAGService, backend string) port.RAGService {
+	if os.Getenv("RAG_HYBRID_SEARCH") != "true" {
+		return ragService
+	}
+
+	dir := os.Getenv("RAG_KEYWORD_INDEX_DIR")
+	if dir == "" {
+		dir = defaultKeywordIndexDir
+	}
+	return hybridrag.NewService(ragService, filepath.Join(dir, backend))
+}
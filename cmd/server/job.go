@@ -0,0 +1,14 @@
+package main
+
+import (
+	"os"
+
+	"docgent/internal/infrastructure/handler"
+)
+
+func newJobConfig() handler.JobConfig {
+	// If JOB_AUTH_TOKEN is not set, all job requests are rejected
+	return handler.JobConfig{
+		AuthToken: os.Getenv("JOB_AUTH_TOKEN"),
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"os"
+
+	"docgent/internal/application/port"
+	"docgent/internal/infrastructure/handler"
+	"docgent/internal/infrastructure/localrag"
+)
+
+// defaultLocalRAGDir is where local corpora are stored if LOCAL_RAG_DIR is not set
+const defaultLocalRAGDir = "data/rag"
+
+// defaultLocalRAGCorpusID is the corpus of the workspace if RAG_BACKEND is local and VERTEXAI_RAG_CORPUS_ID is not set
+const defaultLocalRAGCorpusID = 1
+
+// newLocalRAGService creates the service of the corpora of workspaces whose RAG backend is local
+func newLocalRAGService() port.RAGService {
+	dir := os.Getenv("LOCAL_RAG_DIR")
+	if dir == "" {
+		dir = defaultLocalRAGDir
+	}
+	return withKeywordSearch(localrag.NewService(dir, localrag.NewHashEmbedder(0)), handler.RAGBackendLocal)
+}
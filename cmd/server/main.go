@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/gitlab"
 	"docgent/internal/infrastructure/google/vertexai/genai"
 	"docgent/internal/infrastructure/handler"
 	"docgent/internal/infrastructure/slack"
@@ -25,8 +26,16 @@ func main() {
 			newSlackAPI,
 			newGitHubAPI,
 			newGitHubWebhookRequestParser,
+			newGitLabAPI,
+			newGitLabWebhookRequestParser,
+			newJobConfig,
+			newRedactor,
 			newGenAIConfig,
 			newRAGService,
+			fx.Annotate(
+				newLocalRAGService,
+				fx.ResultTags(`name:"local_rag_service"`),
+			),
 			newHTTPServer,
 			slack.NewServiceProvider,
 			fx.Annotate(
@@ -34,16 +43,28 @@ func main() {
 				fx.ParamTags(`group:"routes"`),
 			),
 			asRoute(handler.NewHealthHandler),
-			asRoute(handler.NewSlackEventHandler),
 			asRoute(handler.NewGitHubWebhookHandler),
+			asRoute(handler.NewGitLabWebhookHandler),
+			asRoute(handler.NewStaleDocumentCheckHandler),
 			asSlackEventRoute(handler.NewSlackReactionAddedEventConsumer),
 			asSlackEventRoute(handler.NewSlackMentionEventConsumer),
 			asGitHubEventRoute(handler.NewGitHubIssueCommentEventConsumer),
+			asGitHubEventRoute(handler.NewGitHubReviewCommentEventConsumer),
+			asGitHubEventRoute(handler.NewGitHubIssuesEventConsumer),
 			asGitHubEventRoute(handler.NewGitHubPushEventConsumer),
+			asGitHubEventRoute(handler.NewGitHubPullRequestEventConsumer),
+			asGitHubEventRoute(handler.NewGitHubPullRequestReviewEventConsumer),
+			asGitLabEventRoute(handler.NewGitLabNoteEventConsumer),
+			asGitLabEventRoute(handler.NewGitLabPushEventConsumer),
 			genai.NewChatModel,
 			github.NewServiceProvider,
+			gitlab.NewServiceProvider,
 			zap.NewExample,
 		),
+		slackTransportOptions(),
+		discordOptions(),
+		mattermostOptions(),
+		fx.Decorate(decorateChatModel),
 		fx.Invoke(func(*http.Server) {}),
 	).Run()
 }
@@ -89,3 +110,18 @@ func asGitHubEventRoute(f any, anns ...fx.Annotation) any {
 	anns = append([]fx.Annotation{fx.As(new(handler.GitHubEventRoute)), fx.ResultTags(`group:"github_event_routes"`)}, anns...)
 	return fx.Annotate(f, anns...)
 }
+
+func asGitLabEventRoute(f any, anns ...fx.Annotation) any {
+	anns = append([]fx.Annotation{fx.As(new(handler.GitLabEventRoute)), fx.ResultTags(`group:"gitlab_event_routes"`)}, anns...)
+	return fx.Annotate(f, anns...)
+}
+
+func asDiscordEventRoute(f any, anns ...fx.Annotation) any {
+	anns = append([]fx.Annotation{fx.As(new(handler.DiscordEventRoute)), fx.ResultTags(`group:"discord_event_routes"`)}, anns...)
+	return fx.Annotate(f, anns...)
+}
+
+func asMattermostEventRoute(f any, anns ...fx.Annotation) any {
+	anns = append([]fx.Annotation{fx.As(new(handler.MattermostEventRoute)), fx.ResultTags(`group:"mattermost_event_routes"`)}, anns...)
+	return fx.Annotate(f, anns...)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/url"
+	"os"
+
+	"go.uber.org/fx"
+
+	"docgent/internal/infrastructure/handler"
+	"docgent/internal/infrastructure/mattermost"
+)
+
+// Mattermost transports selected by MATTERMOST_TRANSPORT
+const (
+	mattermostTransportWebSocket = "websocket"
+	mattermostTransportWebhook   = "webhook"
+)
+
+func mattermostTransport() string {
+	transport := os.Getenv("MATTERMOST_TRANSPORT")
+	switch transport {
+	case "":
+		return mattermostTransportWebSocket
+	case mattermostTransportWebSocket, mattermostTransportWebhook:
+		return transport
+	}
+	panic("MATTERMOST_TRANSPORT must be websocket or webhook")
+}
+
+// newMattermostAPI creates the Mattermost API. Mattermost is optional, so the URL and the token may be empty if no workspace uses Mattermost
+func newMattermostAPI() *mattermost.API {
+	serverURL := os.Getenv("MATTERMOST_URL")
+	if serverURL != "" {
+		if _, err := url.Parse(serverURL); err != nil {
+			log.Fatalf("MATTERMOST_URL is invalid: %v", err)
+		}
+	}
+	return mattermost.NewAPI(serverURL, os.Getenv("MATTERMOST_TOKEN"))
+}
+
+func newMattermostTeamConfigFromEnv() handler.MattermostTeamConfig {
+	teamID := os.Getenv("MATTERMOST_TEAM_ID")
+	if teamID == "" {
+		return handler.MattermostTeamConfig{}
+	}
+
+	// MATTERMOST_GITHUB_USER_MAP is a JSON object from Mattermost user IDs to GitHub logins
+	var githubUsers map[string]string
+	if usersJSON := os.Getenv("MATTERMOST_GITHUB_USER_MAP"); usersJSON != "" {
+		if err := json.Unmarshal([]byte(usersJSON), &githubUsers); err != nil {
+			panic("MATTERMOST_GITHUB_USER_MAP is not a valid JSON: " + err.Error())
+		}
+	}
+
+	return handler.MattermostTeamConfig{
+		TeamID:               teamID,
+		Reaction:             os.Getenv("MATTERMOST_DOC_REACTION"),
+		OutgoingWebhookToken: os.Getenv("MATTERMOST_OUTGOING_WEBHOOK_TOKEN"),
+		GitHubUsers:          githubUsers,
+	}
+}
+
+// mattermostOptions registers the consumers of Mattermost events and the WebSocket listener or the outgoing webhook endpoint which receives them.
+// The listener does not connect if MATTERMOST_URL or MATTERMOST_TOKEN is not set.
+// Outgoing webhooks do not send reactions, so only mentions are handled with the webhook transport.
+func mattermostOptions() fx.Option {
+	options := []fx.Option{fx.Provide(
+		newMattermostAPI,
+		mattermost.NewServiceProvider,
+		asMattermostEventRoute(handler.NewMattermostMentionEventConsumer),
+		asMattermostEventRoute(handler.NewMattermostReactionEventConsumer),
+	)}
+	switch mattermostTransport() {
+	case mattermostTransportWebSocket:
+		options = append(options,
+			fx.Provide(handler.NewMattermostWebSocketListener),
+			fx.Invoke(func(*handler.MattermostWebSocketListener) {}),
+		)
+	case mattermostTransportWebhook:
+		options = append(options, fx.Provide(asRoute(handler.NewMattermostWebhookHandler)))
+	}
+	return fx.Options(options...)
+}
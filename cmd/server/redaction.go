@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"docgent/internal/application/redaction"
+	"docgent/internal/domain"
+)
+
+const defaultRedactionEntropyThreshold = 4.0
+
+func newRedactor() (*redaction.Redactor, error) {
+	config := redaction.Config{
+		Enabled:          true,
+		EntropyThreshold: defaultRedactionEntropyThreshold,
+	}
+
+	if v := os.Getenv("REDACTION_ENABLED"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REDACTION_ENABLED: %w", err)
+		}
+		config.Enabled = enabled
+	}
+
+	if v := os.Getenv("REDACTION_REDACT_PII"); v != "" {
+		redactPII, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REDACTION_REDACT_PII: %w", err)
+		}
+		config.RedactPII = redactPII
+	}
+
+	if v := os.Getenv("REDACTION_ENTROPY_THRESHOLD"); v != "" {
+		threshold, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REDACTION_ENTROPY_THRESHOLD: %w", err)
+		}
+		config.EntropyThreshold = threshold
+	}
+
+	if v := os.Getenv("REDACTION_CUSTOM_PATTERNS"); v != "" {
+		if err := json.Unmarshal([]byte(v), &config.CustomPatterns); err != nil {
+			return nil, fmt.Errorf("invalid REDACTION_CUSTOM_PATTERNS: %w", err)
+		}
+	}
+
+	return redaction.NewRedactorFromConfig(config)
+}
+
+// decorateChatModel wraps the chat model so that nothing sensitive is sent to the model provider
+func decorateChatModel(chatModel domain.ChatModel, redactor *redaction.Redactor) domain.ChatModel {
+	return redaction.NewChatModel(chatModel, redactor)
+}
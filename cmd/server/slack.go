@@ -3,19 +3,72 @@ package main
 import (
 	"os"
 
+	"go.uber.org/fx"
+
+	"docgent/internal/infrastructure/handler"
 	"docgent/internal/infrastructure/slack"
 )
 
+// Slack transports selected by SLACK_TRANSPORT
+const (
+	slackTransportHTTP       = "http"
+	slackTransportSocketMode = "socket_mode"
+	slackTransportBoth       = "both"
+)
+
+func slackTransport() string {
+	transport := os.Getenv("SLACK_TRANSPORT")
+	switch transport {
+	case "":
+		return slackTransportHTTP
+	case slackTransportHTTP, slackTransportSocketMode, slackTransportBoth:
+		return transport
+	}
+	panic("SLACK_TRANSPORT must be http, socket_mode or both")
+}
+
 func newSlackAPI() *slack.API {
 	token := os.Getenv("SLACK_BOT_TOKEN")
 	if token == "" {
 		panic("SLACK_BOT_TOKEN is not set")
 	}
 
+	transport := slackTransport()
+
+	// Socket Mode requests are not signed, so the signing secret is only for the HTTP endpoint
 	signingSecret := os.Getenv("SLACK_SIGNING_SECRET")
-	if signingSecret == "" {
+	if signingSecret == "" && transport != slackTransportSocketMode {
 		panic("SLACK_SIGNING_SECRET is not set")
 	}
 
-	return slack.NewAPI(token, signingSecret)
+	var options []slack.NewAPIOption
+	if transport != slackTransportHTTP {
+		appToken := os.Getenv("SLACK_APP_TOKEN")
+		if appToken == "" {
+			panic("SLACK_APP_TOKEN is not set")
+		}
+		options = append(options, slack.WithAppToken(appToken))
+	}
+
+	return slack.NewAPI(token, signingSecret, options...)
+}
+
+// slackTransportOptions registers the HTTP endpoints and/or the Socket Mode listener for Slack events, interactions and slash commands
+func slackTransportOptions() fx.Option {
+	options := []fx.Option{fx.Provide(handler.NewSlackInteractionConsumer, handler.NewSlackSlashCommandConsumer)}
+	transport := slackTransport()
+	if transport != slackTransportSocketMode {
+		options = append(options, fx.Provide(
+			asRoute(handler.NewSlackEventHandler),
+			asRoute(handler.NewSlackInteractionHandler),
+			asRoute(handler.NewSlackSlashCommandHandler),
+		))
+	}
+	if transport != slackTransportHTTP {
+		options = append(options,
+			fx.Provide(handler.NewSlackSocketModeListener),
+			fx.Invoke(func(*handler.SlackSocketModeListener) {}),
+		)
+	}
+	return fx.Options(options...)
 }
@@ -9,6 +9,7 @@ import (
 	"docgent/internal/infrastructure/google/vertexai/genai"
 	"docgent/internal/infrastructure/google/vertexai/rag"
 	raglib "docgent/internal/infrastructure/google/vertexai/rag/lib"
+	"docgent/internal/infrastructure/handler"
 
 	"golang.org/x/oauth2/google"
 )
@@ -53,5 +54,5 @@ func newRAGService() port.RAGService {
 		panic(fmt.Sprintf("Failed to find default credentials: %v", err))
 	}
 
-	return rag.NewService(raglib.NewClientWithCredentials(creds, projectID, location))
+	return withKeywordSearch(rag.NewService(raglib.NewClientWithCredentials(creds, projectID, location)), handler.RAGBackendVertexAI)
 }
@@ -0,0 +1,51 @@
+package chunking
+
+import (
+	"strings"
+)
+
+const (
+	// DefaultSize and DefaultOverlap are used when the upload has no chunking config. They are counted in characters
+	DefaultSize    = 1000
+	DefaultOverlap = 100
+)
+
+// Split splits the text into chunks of up to size characters that overlap by overlap characters.
+// A chunk ends at a line break in its latter half if there is one, so that paragraphs are kept together.
+func Split(text string, size, overlap int) []string {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	runes := []rune(text)
+	var chunks []string
+	for start := 0; start < len(runes); {
+		end := min(start+size, len(runes))
+		if end < len(runes) {
+			if i := lastLineBreak(runes[start:end]); i >= size/2 {
+				end = start + i + 1
+			}
+		}
+
+		if chunk := strings.TrimSpace(string(runes[start:end])); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		if end == len(runes) {
+			break
+		}
+		start = max(end-overlap, start+1)
+	}
+	return chunks
+}
+
+func lastLineBreak(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == '\n' {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,63 @@
+package chunking
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		size    int
+		overlap int
+		want    []string
+	}{
+		{
+			name: "サイズ以下の場合は1つのチャンク",
+			text: "# Guide\n\nHello\n",
+			size: 100,
+			want: []string{"# Guide\n\nHello"},
+		},
+		{
+			name:    "重なりを持たせて分割する",
+			text:    "abcdefghij",
+			size:    4,
+			overlap: 1,
+			want:    []string{"abcd", "defg", "ghij"},
+		},
+		{
+			name: "後半に改行があれば改行で区切る",
+			text: "line one\nline two\nline three",
+			size: 12,
+			want: []string{"line one", "line two", "line three"},
+		},
+		{
+			name: "文字数で数える",
+			text: "あいうえおかきくけこ",
+			size: 5,
+			want: []string{"あいうえお", "かきくけこ"},
+		},
+		{
+			name: "空白だけのチャンクは除く",
+			text: "  \n\n  ",
+			size: 10,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Split(tt.text, tt.size, tt.overlap))
+		})
+	}
+}
+
+func TestSplit_Default(t *testing.T) {
+	chunks := Split(strings.Repeat("a", DefaultSize+1), 0, 0)
+
+	assert.Len(t, chunks, 2)
+	assert.Len(t, chunks[0], DefaultSize)
+}
@@ -0,0 +1,286 @@
+package chunking
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MarkdownDocument is a Markdown document split into chunks by its sections
+type MarkdownDocument struct {
+	// Metadata is the top-level scalar fields of the frontmatter. Lists and nested fields are not included
+	Metadata map[string]string
+	// Title is the title in the frontmatter, or the first level 1 heading
+	Title  string
+	Chunks []MarkdownChunk
+}
+
+// MarkdownChunk is a chunk of a section of a Markdown document
+type MarkdownChunk struct {
+	// HeadingPath is the headings from the top level down to the section
+	HeadingPath []string
+	// Anchor links to the section in the way GitHub generates it from the heading. It is empty before the first heading
+	Anchor string
+	// Content is the text of the chunk prefixed with the title and the heading path, so that it makes sense on its own
+	Content string
+}
+
+var (
+	reHeading      = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+	reFence        = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	reMetadataLine = regexp.MustCompile(`^([A-Za-z0-9_-]+):[ \t]*(.*)$`)
+)
+
+// SplitMarkdown splits the Markdown content into chunks of up to size characters along its headings.
+// A chunk never spans two sections, and code blocks and tables are not split even if they are longer than size.
+func SplitMarkdown(content string, size int) MarkdownDocument {
+	if size <= 0 {
+		size = DefaultSize
+	}
+
+	frontmatter, body := splitFrontmatter(content)
+	document := MarkdownDocument{Metadata: parseMetadata(frontmatter)}
+	sections, firstH1 := splitSections(body)
+
+	document.Title = document.Metadata["title"]
+	if document.Title == "" {
+		document.Title = firstH1
+	}
+
+	for _, section := range sections {
+		prefix := chunkPrefix(document.Title, section.headingPath)
+		for _, text := range packBlocks(section.blocks, size-utf8.RuneCountInString(prefix)) {
+			document.Chunks = append(document.Chunks, MarkdownChunk{
+				HeadingPath: section.headingPath,
+				Anchor:      section.anchor,
+				Content:     prefix + text,
+			})
+		}
+	}
+	return document
+}
+
+// splitFrontmatter separates the YAML frontmatter between "---" lines at the beginning of the content
+func splitFrontmatter(content string) (frontmatter, body string) {
+	if !strings.HasPrefix(content, "---\n") {
+		return "", content
+	}
+	rest := content[len("---\n"):]
+	if strings.HasPrefix(rest, "---\n") {
+		return "", rest[len("---\n"):]
+	}
+	end := strings.Index(rest, "\n---\n")
+	if end < 0 {
+		if strings.HasSuffix(rest, "\n---") {
+			return rest[:len(rest)-len("\n---")], ""
+		}
+		return "", content
+	}
+	return rest[:end], rest[end+len("\n---\n"):]
+}
+
+// parseMetadata reads "key: value" lines at the top level of the frontmatter
+func parseMetadata(frontmatter string) map[string]string {
+	metadata := make(map[string]string)
+	for _, line := range strings.Split(frontmatter, "\n") {
+		matches := reMetadataLine.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		value := strings.TrimSpace(matches[2])
+		// 値のないキーはリストやネストしたフィールドなので除く
+		if value == "" || value == "|" || value == ">" {
+			continue
+		}
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		metadata[matches[1]] = value
+	}
+	return metadata
+}
+
+type markdownSection struct {
+	headingPath []string
+	anchor      string
+	// blocks are paragraphs, lists, code blocks and tables including the heading, which are not split
+	blocks []string
+}
+
+// splitSections splits the body into sections at headings outside code blocks.
+// It also returns the first level 1 heading, which is the title if the frontmatter has none
+func splitSections(body string) ([]markdownSection, string) {
+	var sections []markdownSection
+	var firstH1 string
+	current := markdownSection{}
+	var path []string
+	var levels []int
+	slugs := make(map[string]int)
+
+	var block []string
+	var fence string
+	flushBlock := func() {
+		if text := strings.TrimSpace(strings.Join(block, "\n")); text != "" {
+			current.blocks = append(current.blocks, text)
+		}
+		block = nil
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		if fence != "" {
+			block = append(block, line)
+			if strings.HasPrefix(strings.TrimSpace(line), fence) {
+				fence = ""
+				flushBlock()
+			}
+			continue
+		}
+
+		if matches := reFence.FindStringSubmatch(line); matches != nil {
+			flushBlock()
+			fence = matches[1]
+			block = append(block, line)
+			continue
+		}
+
+		if matches := reHeading.FindStringSubmatch(line); matches != nil {
+			flushBlock()
+			if len(current.blocks) > 0 {
+				sections = append(sections, current)
+			}
+
+			level := len(matches[1])
+			heading := strings.TrimSpace(matches[2])
+			if level == 1 && firstH1 == "" {
+				firstH1 = heading
+			}
+			for len(levels) > 0 && levels[len(levels)-1] >= level {
+				levels = levels[:len(levels)-1]
+				path = path[:len(path)-1]
+			}
+			levels = append(levels, level)
+			path = append(path, heading)
+
+			current = markdownSection{
+				headingPath: append([]string(nil), path...),
+				anchor:      uniqueSlug(slugs, heading),
+			}
+			// 見出しだけのセクションは除くので、見出しは本文が続いたときに加える
+			block = append(block, line)
+			continue
+		}
+
+		// 表は行が続く限り1つのブロックにし、空行で段落を区切る
+		isTableLine := strings.HasPrefix(strings.TrimSpace(line), "|")
+		if len(block) > 0 && isTableLine != strings.HasPrefix(strings.TrimSpace(block[len(block)-1]), "|") {
+			flushBlock()
+		}
+		if strings.TrimSpace(line) == "" {
+			flushBlock()
+			continue
+		}
+		block = append(block, line)
+	}
+	// 閉じられていないコードブロックは、チャンクを埋め込んだ先の後続の文章まで飲み込まないよう末尾で閉じる
+	if fence != "" {
+		for len(block) > 0 && strings.TrimSpace(block[len(block)-1]) == "" {
+			block = block[:len(block)-1]
+		}
+		block = append(block, fence)
+	}
+	flushBlock()
+	if len(current.blocks) > 0 {
+		sections = append(sections, current)
+	}
+
+	// 見出しの直後に次の見出しが来たセクションは見出しだけなので除く
+	var nonEmpty []markdownSection
+	for _, section := range sections {
+		if section.headingPath != nil && len(section.blocks) == 1 && reHeading.MatchString(section.blocks[0]) {
+			continue
+		}
+		nonEmpty = append(nonEmpty, section)
+	}
+	return nonEmpty, firstH1
+}
+
+// uniqueSlug returns the anchor of the heading. Duplicates get a number suffix like GitHub
+func uniqueSlug(slugs map[string]int, heading string) string {
+	slug := slugify(heading)
+	count := slugs[slug]
+	slugs[slug] = count + 1
+	if count > 0 {
+		return fmt.Sprintf("%s-%d", slug, count)
+	}
+	return slug
+}
+
+// slugify converts the heading into an anchor in the same way as GitHub:
+// lowercase, spaces replaced with hyphens, and punctuation removed
+func slugify(heading string) string {
+	var slug strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			slug.WriteRune(r)
+		case r == ' ':
+			slug.WriteRune('-')
+		}
+	}
+	return slug.String()
+}
+
+// chunkPrefix tells what the chunk is about when it is read without the rest of the document
+func chunkPrefix(title string, headingPath []string) string {
+	var prefix strings.Builder
+	if title != "" {
+		prefix.WriteString("Title: " + title + "\n")
+	}
+	if len(headingPath) > 0 && !(len(headingPath) == 1 && headingPath[0] == title) {
+		prefix.WriteString("Section: " + strings.Join(headingPath, " > ") + "\n")
+	}
+	if prefix.Len() > 0 {
+		prefix.WriteString("\n")
+	}
+	return prefix.String()
+}
+
+// packBlocks joins the blocks into texts of up to size characters.
+// A paragraph longer than size is split, while a code block or a table is kept as a whole.
+func packBlocks(blocks []string, size int) []string {
+	// 接頭辞が長すぎる場合でも本文を入れられるようにする
+	size = max(size, DefaultSize/4)
+
+	var texts []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			texts = append(texts, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, block := range blocks {
+		length := utf8.RuneCountInString(block)
+		if current.Len() > 0 && utf8.RuneCountInString(current.String())+len("\n\n")+length > size {
+			flush()
+		}
+		if length > size && !isAtomicBlock(block) {
+			flush()
+			texts = append(texts, Split(block, size, 0)...)
+			continue
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n\n")
+		}
+		current.WriteString(block)
+	}
+	flush()
+	return texts
+}
+
+func isAtomicBlock(block string) bool {
+	return reFence.MatchString(block) || strings.HasPrefix(block, "|")
+}
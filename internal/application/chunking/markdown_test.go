@@ -0,0 +1,140 @@
+package chunking
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitMarkdown(t *testing.T) {
+	content := `---
+title: "Deploy Guide"
+owners:
+  - octocat
+sources: []
+---
+Read this before deploying.
+
+# Deploy
+
+## Prerequisites
+
+You need access to the cluster.
+
+## Steps
+
+### Build
+
+` + "```bash\nmake build\n\n# not a heading\nmake test\n```" + `
+
+### Release
+
+| Env | Command |
+| --- | --- |
+| staging | make deploy-staging |
+
+## Steps
+Duplicated heading.
+`
+
+	got := SplitMarkdown(content, 1000)
+
+	assert.Equal(t, map[string]string{"title": "Deploy Guide", "sources": "[]"}, got.Metadata)
+	assert.Equal(t, "Deploy Guide", got.Title)
+	assert.Equal(t, []MarkdownChunk{
+		{
+			HeadingPath: nil,
+			Anchor:      "",
+			Content:     "Title: Deploy Guide\n\nRead this before deploying.",
+		},
+		{
+			HeadingPath: []string{"Deploy", "Prerequisites"},
+			Anchor:      "prerequisites",
+			Content:     "Title: Deploy Guide\nSection: Deploy > Prerequisites\n\n## Prerequisites\n\nYou need access to the cluster.",
+		},
+		{
+			HeadingPath: []string{"Deploy", "Steps", "Build"},
+			Anchor:      "build",
+			Content:     "Title: Deploy Guide\nSection: Deploy > Steps > Build\n\n### Build\n\n```bash\nmake build\n\n# not a heading\nmake test\n```",
+		},
+		{
+			HeadingPath: []string{"Deploy", "Steps", "Release"},
+			Anchor:      "release",
+			Content:     "Title: Deploy Guide\nSection: Deploy > Steps > Release\n\n### Release\n\n| Env | Command |\n| --- | --- |\n| staging | make deploy-staging |",
+		},
+		{
+			HeadingPath: []string{"Deploy", "Steps"},
+			Anchor:      "steps-1",
+			Content:     "Title: Deploy Guide\nSection: Deploy > Steps\n\n## Steps\nDuplicated heading.",
+		},
+	}, got.Chunks)
+}
+
+func TestSplitMarkdown_TitleFromHeading(t *testing.T) {
+	got := SplitMarkdown("# デプロイ手順\n\n本番環境へのデプロイ方法です。\n", 1000)
+
+	assert.Empty(t, got.Metadata)
+	assert.Equal(t, "デプロイ手順", got.Title)
+	assert.Equal(t, []MarkdownChunk{
+		{
+			HeadingPath: []string{"デプロイ手順"},
+			Anchor:      "デプロイ手順",
+			Content:     "Title: デプロイ手順\n\n# デプロイ手順\n\n本番環境へのデプロイ方法です。",
+		},
+	}, got.Chunks)
+}
+
+func TestSplitMarkdown_LongSection(t *testing.T) {
+	paragraph := strings.Repeat("a", 300)
+	code := "```\n" + strings.Repeat("b", 600) + "\n```"
+	content := "## Long\n\n" + paragraph + "\n\n" + paragraph + "\n\n" + code + "\n\n" + strings.Repeat("c", 700) + "\n"
+
+	got := SplitMarkdown(content, 500)
+
+	var contents []string
+	for _, chunk := range got.Chunks {
+		assert.Equal(t, "long", chunk.Anchor)
+		assert.True(t, strings.HasPrefix(chunk.Content, "Section: Long\n\n"))
+		contents = append(contents, strings.TrimPrefix(chunk.Content, "Section: Long\n\n"))
+	}
+	assert.Equal(t, []string{
+		"## Long\n\n" + paragraph,
+		paragraph,
+		// コードブロックはサイズを超えても分割しない
+		code,
+		strings.Repeat("c", 485),
+		strings.Repeat("c", 215),
+	}, contents)
+}
+
+func TestSplitMarkdown_UnclosedFence(t *testing.T) {
+	got := SplitMarkdown("## Setup\n\nRun the script.\n\n~~~~sh\n./setup.sh\n\n## Not a heading\n\n", 1000)
+
+	// 閉じられていないコードブロックは文書の末尾で閉じる
+	assert.Equal(t, []MarkdownChunk{
+		{
+			HeadingPath: []string{"Setup"},
+			Anchor:      "setup",
+			Content:     "Section: Setup\n\n## Setup\n\nRun the script.\n\n~~~~sh\n./setup.sh\n\n## Not a heading\n~~~~",
+		},
+	}, got.Chunks)
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{heading: "Getting Started", want: "getting-started"},
+		{heading: "What's new in v1.2?", want: "whats-new-in-v12"},
+		{heading: "config_key の設定", want: "config_key-の設定"},
+		{heading: "`make deploy`", want: "make-deploy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			assert.Equal(t, tt.want, slugify(tt.heading))
+		})
+	}
+}
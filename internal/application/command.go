@@ -0,0 +1,105 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandPrefix はコマンドの接頭辞です。
+const CommandPrefix = "/docgent"
+
+// CommandName はコマンドの種類です。
+type CommandName string
+
+const (
+	// CommandRefine はフィードバックに基づいて提案を修正します。
+	CommandRefine CommandName = "refine"
+	// CommandRetitle は提案のタイトルを付け直します。
+	CommandRetitle CommandName = "retitle"
+	// CommandSplit は提案をファイルごとの提案に分割します。
+	CommandSplit CommandName = "split"
+	// CommandSyncBase は提案をベースブランチの最新の状態に追従させます。
+	CommandSyncBase CommandName = "sync-base"
+	// CommandSources は提案の元になったソースを一覧します。
+	CommandSources CommandName = "sources"
+	// CommandIgnore はコメントを無視させます。人間同士のやりとりに使います。
+	CommandIgnore CommandName = "ignore"
+	// CommandHelp はコマンドの使い方を表示します。
+	CommandHelp CommandName = "help"
+)
+
+var commandDescriptions = []struct {
+	name        CommandName
+	usage       string
+	description string
+}{
+	{CommandRefine, "refine <feedback>", "Refine the proposal based on the feedback"},
+	{CommandRetitle, "retitle [title]", "Rename the proposal. Without a title, a new one is generated from the changes"},
+	{CommandSplit, "split", "Split the proposal into one proposal per changed file"},
+	{CommandSyncBase, "sync-base", "Bring the proposal up to date with the base branch"},
+	{CommandSources, "sources", "List the sources of the documents in the proposal"},
+	{CommandIgnore, "ignore", "Do nothing. Use it for comments meant for humans"},
+	{CommandHelp, "help", "Show this help"},
+}
+
+// Command はユーザーがDocgentに送ったコマンドです。
+type Command struct {
+	Name CommandName
+	// Args はコマンド名以降の文字列です。複数行にまたがることがあります。
+	Args string
+}
+
+// IsKnown はコマンドが定義されているかどうかを返します。
+func (c Command) IsKnown() bool {
+	for _, d := range commandDescriptions {
+		if d.name == c.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCommand はメッセージからコマンドを取り出します。
+// "/docgent <name> <args>" で始まる行があればそのコマンドを返します。
+// コマンドがなくても、mentions のいずれかでDocgentにメンションしていれば、メッセージ全体を refine コマンドとして扱います。
+// どちらでもない場合は false を返します。
+func ParseCommand(text string, mentions ...string) (Command, bool) {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		rest, ok := strings.CutPrefix(trimmed, CommandPrefix)
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return Command{Name: CommandHelp}, true
+		}
+
+		name := strings.ToLower(fields[0])
+		args := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest), fields[0]))
+		if following := strings.TrimSpace(strings.Join(lines[i+1:], "\n")); following != "" {
+			args = strings.TrimSpace(args + "\n" + following)
+		}
+		return Command{Name: CommandName(name), Args: args}, true
+	}
+
+	for _, mention := range mentions {
+		if mention != "" && strings.Contains(text, mention) {
+			return Command{Name: CommandRefine, Args: strings.TrimSpace(strings.ReplaceAll(text, mention, ""))}, true
+		}
+	}
+
+	return Command{}, false
+}
+
+// CommandHelpMessage はコマンドの使い方の説明を返します。
+func CommandHelpMessage() string {
+	var b strings.Builder
+	b.WriteString("Available commands:\n")
+	for _, d := range commandDescriptions {
+		b.WriteString(fmt.Sprintf("- `%s %s`: %s\n", CommandPrefix, d.usage, d.description))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
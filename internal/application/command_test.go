@@ -0,0 +1,95 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		mentions []string
+		expected Command
+		ok       bool
+	}{
+		{
+			name:     "コマンドもメンションもないコメントは無視される",
+			text:     "LGTM",
+			mentions: []string{"@docgent"},
+			ok:       false,
+		},
+		{
+			name:     "refineコマンドの引数を取り出す",
+			text:     "/docgent refine 手順を箇条書きにしてください",
+			expected: Command{Name: CommandRefine, Args: "手順を箇条書きにしてください"},
+			ok:       true,
+		},
+		{
+			name:     "コマンドの後の行も引数に含まれる",
+			text:     "前置き\n/docgent refine 以下を直してください\n- 見出し\n- 誤字",
+			expected: Command{Name: CommandRefine, Args: "以下を直してください\n- 見出し\n- 誤字"},
+			ok:       true,
+		},
+		{
+			name:     "引数のないコマンド",
+			text:     "/docgent sources",
+			expected: Command{Name: CommandSources},
+			ok:       true,
+		},
+		{
+			name:     "コマンド名は大文字小文字を区別しない",
+			text:     "/docgent Sync-Base",
+			expected: Command{Name: CommandSyncBase},
+			ok:       true,
+		},
+		{
+			name:     "コマンド名がなければhelpになる",
+			text:     "/docgent",
+			expected: Command{Name: CommandHelp},
+			ok:       true,
+		},
+		{
+			name: "接頭辞に続く文字列はコマンドではない",
+			text: "/docgentrefine foo",
+			ok:   false,
+		},
+		{
+			name:     "メンションはrefineとして扱われる",
+			text:     "@docgent 概要を短くしてください",
+			mentions: []string{"@docgent"},
+			expected: Command{Name: CommandRefine, Args: "概要を短くしてください"},
+			ok:       true,
+		},
+		{
+			name:     "メンションよりコマンドが優先される",
+			text:     "@docgent\n/docgent ignore",
+			mentions: []string{"@docgent"},
+			expected: Command{Name: CommandIgnore},
+			ok:       true,
+		},
+		{
+			name:     "空のメンションは無視される",
+			text:     "LGTM",
+			mentions: []string{""},
+			ok:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, ok := ParseCommand(tt.text, tt.mentions...)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.expected, command)
+				assert.True(t, command.IsKnown())
+			}
+		})
+	}
+}
+
+func TestCommand_IsKnown(t *testing.T) {
+	assert.True(t, Command{Name: CommandRetitle}.IsKnown())
+	assert.False(t, Command{Name: "unknown"}.IsKnown())
+}
@@ -17,6 +17,7 @@ type ConversationUsecase struct {
 	fileQueryService    port.FileQueryService
 	sourceRepositories  []port.SourceRepository
 	ragCorpus           port.RAGCorpus
+	sourcePolicy        *port.SourcePolicy
 	responseFormatter   port.ResponseFormatter
 	remainingStepCount  int
 }
@@ -31,6 +32,13 @@ func WithConversationRAGCorpus(ragCorpus port.RAGCorpus) NewConversationUsecaseO
 	}
 }
 
+// WithConversationSourcePolicy は利用できるソースを制限するポリシーを設定するオプションです。
+func WithConversationSourcePolicy(sourcePolicy *port.SourcePolicy) NewConversationUsecaseOption {
+	return func(u *ConversationUsecase) {
+		u.sourcePolicy = sourcePolicy
+	}
+}
+
 // NewConversationUsecase はConversationUsecaseを初期化します。
 func NewConversationUsecase(
 	chatModel domain.ChatModel,
@@ -68,7 +76,7 @@ func (u *ConversationUsecase) Execute(ctx context.Context) error {
 	}
 
 	// SourceRepositoryManagerの初期化
-	sourceRepositoryManager := port.NewSourceRepositoryManager(u.sourceRepositories)
+	sourceRepositoryManager := port.NewSourceRepositoryManager(u.sourceRepositories, port.WithSourcePolicy(u.sourcePolicy))
 
 	// ハンドラーの初期化
 	attemptCompleteHandler := tooluse.NewAttemptCompleteHandler(u.conversationService, u.responseFormatter)
@@ -0,0 +1,269 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+)
+
+var (
+	// reMarkdownLink は [text](target "title") と ![alt](target) のリンク先を取り出します
+	reMarkdownLink = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	reViolation    = regexp.MustCompile(`(?s)<violation line="(\d+)">(.*?)</violation>`)
+)
+
+// DocumentLintUsecase は、ドキュメントのリポジトリへの変更を検査するユースケースです。
+// Docgentが作成したものに限らず、人が直接編集した変更も対象にします。
+type DocumentLintUsecase struct {
+	chatModel          domain.ChatModel
+	fileQueryService   port.FileQueryService
+	fileRepository     data.FileRepository
+	sourceRepositories []port.SourceRepository
+	reporter           port.DocumentLintReporter
+}
+
+func NewDocumentLintUsecase(
+	chatModel domain.ChatModel,
+	fileQueryService port.FileQueryService,
+	fileRepository data.FileRepository,
+	sourceRepositories []port.SourceRepository,
+	reporter port.DocumentLintReporter,
+) *DocumentLintUsecase {
+	return &DocumentLintUsecase{
+		chatModel:          chatModel,
+		fileQueryService:   fileQueryService,
+		fileRepository:     fileRepository,
+		sourceRepositories: sourceRepositories,
+		reporter:           reporter,
+	}
+}
+
+// Execute は変更されたMarkdownのドキュメントを検査し、結果を報告します。
+// 一部のドキュメントの検査に失敗しても、他のドキュメントの結果は報告します。
+func (u *DocumentLintUsecase) Execute(ctx context.Context, paths []string) error {
+	if err := u.reporter.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start lint report: %w", err)
+	}
+
+	findings, err := u.lint(ctx, paths)
+	if reportErr := u.reporter.Complete(ctx, findings, err); reportErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to complete lint report: %w", reportErr))
+	}
+	return err
+}
+
+func (u *DocumentLintUsecase) lint(ctx context.Context, paths []string) ([]port.LintFinding, error) {
+	tree, err := u.fileQueryService.GetTree(ctx, port.WithGetTreeRecursive())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree metadata: %w", err)
+	}
+	existingPaths := make(map[string]bool, len(tree))
+	for _, metadata := range tree {
+		existingPaths[metadata.Path] = true
+	}
+
+	rules, err := getDocgentRulesFileIfExists(ctx, u.fileQueryService, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get .docgentrules: %w", err)
+	}
+
+	var findings []port.LintFinding
+	var errs []error
+	for _, filePath := range paths {
+		if !isMarkdown(filePath) {
+			continue
+		}
+		fileFindings, err := u.lintDocument(ctx, filePath, existingPaths, rules)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to lint %s: %w", filePath, err))
+		}
+		findings = append(findings, fileFindings...)
+	}
+	return findings, errors.Join(errs...)
+}
+
+func (u *DocumentLintUsecase) lintDocument(ctx context.Context, filePath string, existingPaths map[string]bool, rules *data.File) ([]port.LintFinding, error) {
+	raw, err := u.fileQueryService.FindFile(ctx, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find file: %w", err)
+	}
+
+	file, err := u.fileRepository.Get(ctx, filePath)
+	if errors.Is(err, data.ErrInvalidFrontmatter) {
+		// フロントマターが読めなければ知識源の検査はできないが、リンクとルールは検査する
+		file = &data.File{Path: filePath, Content: raw.Content}
+		findings := []port.LintFinding{{
+			Path:     filePath,
+			Line:     1,
+			Severity: port.LintSeverityFailure,
+			Title:    "Invalid frontmatter",
+			Message:  err.Error(),
+		}}
+		return u.lintBody(ctx, raw.Content, file, existingPaths, rules, findings)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	var findings []port.LintFinding
+	if len(file.SourceURIs) == 0 {
+		findings = append(findings, port.LintFinding{
+			Path:     filePath,
+			Line:     1,
+			Severity: port.LintSeverityWarning,
+			Title:    "Missing sources",
+			Message:  "The frontmatter has no sources. Add the URIs of the conversations or documents this document is based on to `sources`.",
+		})
+	}
+
+	sourceRepositoryManager := port.NewSourceRepositoryManager(u.sourceRepositories)
+	for _, uri := range file.SourceURIs {
+		_, err := sourceRepositoryManager.Find(ctx, uri)
+		if err == nil || errors.Is(err, port.ErrUnsupportedSource) {
+			continue
+		}
+		findings = append(findings, port.LintFinding{
+			Path:     filePath,
+			Line:     lineOf(raw.Content, uri.Value()),
+			Severity: port.LintSeverityWarning,
+			Title:    "Unreachable source",
+			Message:  fmt.Sprintf("%s could not be retrieved: %v", uri.Value(), err),
+		})
+	}
+
+	return u.lintBody(ctx, raw.Content, file, existingPaths, rules, findings)
+}
+
+// lintBody は本文のリンクと .docgentrules を検査します。行番号はフロントマターを含むファイルの行です。
+func (u *DocumentLintUsecase) lintBody(ctx context.Context, raw string, file *data.File, existingPaths map[string]bool, rules *data.File, findings []port.LintFinding) ([]port.LintFinding, error) {
+	offset := 0
+	if strings.HasSuffix(raw, file.Content) {
+		offset = strings.Count(raw[:len(raw)-len(file.Content)], "\n")
+	}
+
+	for _, link := range findRelativeLinks(file.Content) {
+		target := resolveLinkTarget(file.Path, link.target)
+		if target != "" && existingPaths[target] {
+			continue
+		}
+		findings = append(findings, port.LintFinding{
+			Path:     file.Path,
+			Line:     offset + link.line,
+			Severity: port.LintSeverityFailure,
+			Title:    "Broken link",
+			Message:  fmt.Sprintf("%s does not exist in the repository", link.target),
+		})
+	}
+
+	if rules == nil {
+		return findings, nil
+	}
+	violations, err := u.checkRules(ctx, rules.Content, raw)
+	if err != nil {
+		return findings, err
+	}
+	for _, violation := range violations {
+		violation.Path = file.Path
+		findings = append(findings, violation)
+	}
+	return findings, nil
+}
+
+// checkRules はモデルに .docgentrules への違反を判定させます
+func (u *DocumentLintUsecase) checkRules(ctx context.Context, rules, content string) ([]port.LintFinding, error) {
+	session := u.chatModel.StartChat(`You are a reviewer who checks that a document follows the writing rules of its repository.
+Report only clear violations of the rules, not general suggestions.
+For each violation, respond with <violation line="N">explanation</violation>, where N is the line number shown at the beginning of the line.
+Write the explanation in the same language as the document. If there are no violations, respond with <no_violations/>.`)
+
+	var numbered strings.Builder
+	for i, line := range strings.Split(content, "\n") {
+		fmt.Fprintf(&numbered, "%d: %s\n", i+1, line)
+	}
+
+	response, err := session.SendMessage(ctx, fmt.Sprintf("<rules>\n%s\n</rules>\n<document>\n%s</document>", rules, numbered.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rules: %w", err)
+	}
+
+	var violations []port.LintFinding
+	for _, matches := range reViolation.FindAllStringSubmatch(response, -1) {
+		line, _ := strconv.Atoi(matches[1])
+		violations = append(violations, port.LintFinding{
+			Line:     max(line, 1),
+			Severity: port.LintSeverityWarning,
+			Title:    "Violation of .docgentrules",
+			Message:  strings.TrimSpace(matches[2]),
+		})
+	}
+	return violations, nil
+}
+
+type markdownLink struct {
+	target string
+	// line is the 1-based line in the body
+	line int
+}
+
+// findRelativeLinks はコードブロックの外にあるリポジトリ内へのリンクを返します
+func findRelativeLinks(body string) []markdownLink {
+	var links []markdownLink
+	inCodeBlock := false
+	for i, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+		for _, matches := range reMarkdownLink.FindAllStringSubmatch(line, -1) {
+			target := matches[1]
+			if strings.HasPrefix(target, "#") || strings.Contains(target, ":") {
+				// ページ内のアンカーと、http: や mailto: などの外部リンク
+				continue
+			}
+			links = append(links, markdownLink{target: target, line: i + 1})
+		}
+	}
+	return links
+}
+
+// resolveLinkTarget はリンク先をリポジトリのルートからのパスにします。リポジトリの外を指す場合は空文字列を返します
+func resolveLinkTarget(documentPath, target string) string {
+	target, _, _ = strings.Cut(target, "#")
+	target, _, _ = strings.Cut(target, "?")
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+
+	var resolved string
+	if strings.HasPrefix(target, "/") {
+		resolved = path.Clean(strings.TrimPrefix(target, "/"))
+	} else {
+		resolved = path.Join(path.Dir(documentPath), target)
+	}
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return ""
+	}
+	return strings.TrimSuffix(resolved, "/")
+}
+
+// lineOf は文字列が最初に現れる行を返します。見つからない場合は1行目とします
+func lineOf(content, s string) int {
+	index := strings.Index(content, s)
+	if index < 0 {
+		return 1
+	}
+	return strings.Count(content[:index], "\n") + 1
+}
@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDocumentLintReporter struct {
+	mock.Mock
+}
+
+func (m *MockDocumentLintReporter) Start(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockDocumentLintReporter) Complete(ctx context.Context, findings []port.LintFinding, lintErr error) error {
+	args := m.Called(ctx, findings, lintErr)
+	return args.Error(0)
+}
+
+func TestDocumentLintUsecase_Execute(t *testing.T) {
+	slackURI := data.NewURIUnsafe("https://app.slack.com/client/T001/C001/1234567890.123456")
+	webURI := data.NewURIUnsafe("https://example.com/spec")
+	guide := "---\nsources:\n  - " + slackURI.Value() + "\n  - " + webURI.Value() + "\n---\n" +
+		"# Guide\n\nSee [other](other.md) and [missing](./missing.md#section).\n![image](../images/a.png)\n[external](https://example.com) [anchor](#top)\n```\n[in code](nowhere.md)\n```\n"
+	guideBody := strings.SplitN(guide, "---\n", 3)[2]
+	broken := "---\nsources: [\n---\n# Broken\n"
+	noSources := "# No sources\n"
+
+	chatModel := new(MockChatModel)
+	chatSession := new(MockChatSession)
+	fileQueryService := new(MockFileQueryService)
+	fileRepository := new(MockFileRepository)
+	sourceRepository := new(MockSourceRepository)
+	reporter := new(MockDocumentLintReporter)
+
+	fileQueryService.On("GetTree", mock.Anything, mock.Anything).Return([]port.TreeMetadata{
+		{Type: port.NodeTypeFile, Path: ".docgentrules"},
+		{Type: port.NodeTypeFile, Path: "docs/guide.md"},
+		{Type: port.NodeTypeFile, Path: "docs/other.md"},
+		{Type: port.NodeTypeFile, Path: "docs/broken.md"},
+		{Type: port.NodeTypeFile, Path: "docs/no-sources.md"},
+		{Type: port.NodeTypeDirectory, Path: "images"},
+		{Type: port.NodeTypeFile, Path: "images/a.png"},
+	}, nil)
+	fileQueryService.On("FindFile", mock.Anything, ".docgentrules").Return(data.File{Path: ".docgentrules", Content: "見出しは日本語で書く"}, nil)
+	fileQueryService.On("FindFile", mock.Anything, "docs/guide.md").Return(data.File{Path: "docs/guide.md", Content: guide}, nil)
+	fileQueryService.On("FindFile", mock.Anything, "docs/broken.md").Return(data.File{Path: "docs/broken.md", Content: broken}, nil)
+	fileQueryService.On("FindFile", mock.Anything, "docs/no-sources.md").Return(data.File{Path: "docs/no-sources.md", Content: noSources}, nil)
+	fileRepository.On("Get", mock.Anything, "docs/guide.md").Return(&data.File{Path: "docs/guide.md", Content: guideBody, SourceURIs: []*data.URI{slackURI, webURI}}, nil)
+	fileRepository.On("Get", mock.Anything, "docs/broken.md").Return((*data.File)(nil), fmt.Errorf("%w: yaml: line 1: did not find expected node content", data.ErrInvalidFrontmatter))
+	fileRepository.On("Get", mock.Anything, "docs/no-sources.md").Return(&data.File{Path: "docs/no-sources.md", Content: noSources}, nil)
+	sourceRepository.On("Match", slackURI).Return(true)
+	sourceRepository.On("Match", webURI).Return(false)
+	sourceRepository.On("Find", mock.Anything, slackURI).Return(nil, errors.New("channel_not_found"))
+
+	chatModel.On("StartChat", mock.Anything).Return(chatSession)
+	chatSession.On("SendMessage", mock.Anything, mock.MatchedBy(func(message string) bool {
+		return strings.Contains(message, "# Guide")
+	})).Return(`<violation line="6">見出しが英語です</violation>`, nil)
+	chatSession.On("SendMessage", mock.Anything, mock.Anything).Return("<no_violations/>", nil)
+
+	reporter.On("Start", mock.Anything).Return(nil)
+	reporter.On("Complete", mock.Anything, []port.LintFinding{
+		{Path: "docs/guide.md", Line: 3, Severity: port.LintSeverityWarning, Title: "Unreachable source", Message: slackURI.Value() + " could not be retrieved: channel_not_found"},
+		{Path: "docs/guide.md", Line: 8, Severity: port.LintSeverityFailure, Title: "Broken link", Message: "./missing.md#section does not exist in the repository"},
+		{Path: "docs/guide.md", Line: 6, Severity: port.LintSeverityWarning, Title: "Violation of .docgentrules", Message: "見出しが英語です"},
+		{Path: "docs/broken.md", Line: 1, Severity: port.LintSeverityFailure, Title: "Invalid frontmatter", Message: "invalid frontmatter format: yaml: line 1: did not find expected node content"},
+		{Path: "docs/no-sources.md", Line: 1, Severity: port.LintSeverityWarning, Title: "Missing sources", Message: "The frontmatter has no sources. Add the URIs of the conversations or documents this document is based on to `sources`."},
+	}, nil).Return(nil)
+
+	usecase := NewDocumentLintUsecase(chatModel, fileQueryService, fileRepository, []port.SourceRepository{sourceRepository}, reporter)
+	err := usecase.Execute(context.Background(), []string{"docs/guide.md", "docs/broken.md", "docs/no-sources.md", "mkdocs.yml"})
+
+	assert.NoError(t, err)
+	reporter.AssertExpectations(t)
+	fileQueryService.AssertExpectations(t)
+}
+
+func TestDocumentLintUsecase_Execute_LintError(t *testing.T) {
+	fileQueryService := new(MockFileQueryService)
+	reporter := new(MockDocumentLintReporter)
+
+	fileQueryService.On("GetTree", mock.Anything, mock.Anything).Return([]port.TreeMetadata(nil), errors.New("rate limited"))
+	reporter.On("Start", mock.Anything).Return(nil)
+	// 検査に失敗した場合は、問題がなかったことにせずエラーを報告する
+	reporter.On("Complete", mock.Anything, []port.LintFinding(nil), mock.MatchedBy(func(err error) bool {
+		return err != nil && strings.Contains(err.Error(), "rate limited")
+	})).Return(nil)
+
+	usecase := NewDocumentLintUsecase(new(MockChatModel), fileQueryService, new(MockFileRepository), nil, reporter)
+	err := usecase.Execute(context.Background(), []string{"docs/guide.md"})
+
+	assert.ErrorContains(t, err, "rate limited")
+	reporter.AssertExpectations(t)
+}
+
+func TestResolveLinkTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		target   string
+		expected string
+	}{
+		{name: "同じディレクトリ", document: "docs/a.md", target: "b.md", expected: "docs/b.md"},
+		{name: "親ディレクトリとアンカー", document: "docs/api/a.md", target: "../b.md#usage", expected: "docs/b.md"},
+		{name: "ルートからのパス", document: "docs/a.md", target: "/images/x.png", expected: "images/x.png"},
+		{name: "エスケープされた文字", document: "docs/a.md", target: "my%20doc.md", expected: "docs/my doc.md"},
+		{name: "リポジトリの外", document: "a.md", target: "../b.md", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolveLinkTarget(tt.document, tt.target))
+		})
+	}
+}
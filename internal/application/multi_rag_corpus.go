@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"docgent/internal/application/port"
+	"docgent/internal/application/rankfusion"
+	"docgent/internal/domain/data"
+)
+
+// ErrReadOnlyRAGCorpus is returned when writing to a corpus that only supports queries
+var ErrReadOnlyRAGCorpus = errors.New("rag corpus is read-only")
+
+// MultiRAGCorpus は複数のコーパスをまとめて検索するためのコーパスです。検索のみに対応します。
+type MultiRAGCorpus struct {
+	corpora []port.RAGCorpus
+}
+
+func NewMultiRAGCorpus(corpora ...port.RAGCorpus) *MultiRAGCorpus {
+	return &MultiRAGCorpus{corpora: corpora}
+}
+
+// Query はすべてのコーパスを検索し、関連度の高い順に最大 similarityTopK 件のドキュメントを返します。
+// スコアの意味はコーパスの種類によって異なり（Vertex AI RAG Engine は距離を返すなど）比較できないため、
+// 各コーパスでの順位を Reciprocal Rank Fusion でまとめます。
+func (c *MultiRAGCorpus) Query(ctx context.Context, query string, similarityTopK int32, vectorDistanceThreshold float64) ([]port.RAGDocument, error) {
+	rankings := make([][]port.RAGDocument, 0, len(c.corpora))
+	for _, corpus := range c.corpora {
+		result, err := corpus.Query(ctx, query, similarityTopK, vectorDistanceThreshold)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query corpus: %w", err)
+		}
+		rankings = append(rankings, result)
+	}
+
+	docs := rankfusion.Fuse(rankings...)
+	if len(docs) > int(similarityTopK) {
+		docs = docs[:similarityTopK]
+	}
+	return docs, nil
+}
+
+func (c *MultiRAGCorpus) UploadFile(ctx context.Context, file io.Reader, uri *data.URI, options ...port.RAGCorpusUploadFileOption) (port.RAGFile, error) {
+	return port.RAGFile{}, ErrReadOnlyRAGCorpus
+}
+
+func (c *MultiRAGCorpus) ListFiles(ctx context.Context) ([]port.RAGFile, error) {
+	return nil, ErrReadOnlyRAGCorpus
+}
+
+func (c *MultiRAGCorpus) DeleteFile(ctx context.Context, fileID int64) error {
+	return ErrReadOnlyRAGCorpus
+}
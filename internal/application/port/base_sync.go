@@ -0,0 +1,49 @@
+package port
+
+import (
+	"context"
+
+	"docgent/internal/domain"
+)
+
+// BaseSyncStatus is the outcome of merging the latest base into a proposal
+type BaseSyncStatus int
+
+const (
+	// BaseSyncUpToDate means the proposal already contains the latest base
+	BaseSyncUpToDate BaseSyncStatus = iota
+	// BaseSyncMerged means the latest base was merged into the proposal
+	BaseSyncMerged
+	// BaseSyncConflicted means the merge conflicted and the proposal was left as is
+	BaseSyncConflicted
+)
+
+// FileConflict is a file changed differently by the proposal and the latest base
+type FileConflict struct {
+	Path string
+	// Base is the content at the common ancestor. It is empty if the file did not exist
+	Base string
+	// Ours is the content in the proposal
+	Ours string
+	// Theirs is the content in the latest base
+	Theirs string
+	// Deleted is true if either side deleted the file
+	Deleted bool
+}
+
+// BaseSyncResult is the result of ProposalBaseSynchronizer.MergeBase
+type BaseSyncResult struct {
+	Status BaseSyncStatus
+	// Conflicts are set if Status is BaseSyncConflicted
+	Conflicts []FileConflict
+}
+
+// ProposalBaseSynchronizer brings the latest base into a proposal whose base has moved since it was created
+type ProposalBaseSynchronizer interface {
+	// MergeBase merges the latest base into the proposal.
+	// If the merge conflicts, the proposal is left as is and the conflicts are returned.
+	MergeBase(ctx context.Context, handle domain.ProposalHandle) (BaseSyncResult, error)
+	// MergeBaseWithResolutions merges the latest base into the proposal with the resolved contents of the conflicted files.
+	// resolutions maps the path of every conflicted file to its resolved content.
+	MergeBaseWithResolutions(ctx context.Context, handle domain.ProposalHandle, resolutions map[string]string) error
+}
@@ -13,6 +13,12 @@ type ConversationService interface {
 	RemoveEyes() error
 }
 
+// ConversationResolver restores the ConversationService of a conversation from its URI
+type ConversationResolver interface {
+	Match(uri *data.URI) bool
+	Resolve(uri *data.URI) (ConversationService, error)
+}
+
 type ConversationHistory struct {
 	URI      *data.URI
 	Messages []ConversationMessage
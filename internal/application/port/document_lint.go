@@ -0,0 +1,31 @@
+package port
+
+import "context"
+
+// LintSeverity is how serious a LintFinding is
+type LintSeverity string
+
+const (
+	LintSeverityFailure LintSeverity = "failure"
+	LintSeverityWarning LintSeverity = "warning"
+	LintSeverityNotice  LintSeverity = "notice"
+)
+
+// LintFinding is a problem found in a document
+type LintFinding struct {
+	Path string
+	// Line is the 1-based line of the problem in the file including the frontmatter
+	Line     int
+	Severity LintSeverity
+	Title    string
+	Message  string
+}
+
+// DocumentLintReporter shows the progress and the findings of a lint, e.g. as a GitHub check run
+type DocumentLintReporter interface {
+	// Start reports that the lint has started
+	Start(ctx context.Context) error
+	// Complete reports the findings. It is called once even if the lint failed halfway,
+	// in which case lintErr is the error and the findings may be incomplete, so the report must not conclude that there are no problems
+	Complete(ctx context.Context, findings []LintFinding, lintErr error) error
+}
@@ -3,6 +3,7 @@ package port
 import (
 	"context"
 	"errors"
+	"time"
 
 	"docgent/internal/domain/data"
 )
@@ -34,6 +35,12 @@ type FileQueryService interface {
 	GetFilePath(uri *data.URI) (string, error)
 }
 
+// FileHistoryService provides the change history of files.
+type FileHistoryService interface {
+	// GetLastModifiedAt returns the time of the last commit that touched the given path
+	GetLastModifiedAt(ctx context.Context, path string) (time.Time, error)
+}
+
 type GetTreeOption func(*GetTreeOptions)
 
 type GetTreeOptions struct {
@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+)
+
+var ErrProposalLinkNotFound = errors.New("proposal link not found")
+
+// ProposalLink connects a proposal to the conversation it was generated from
+type ProposalLink struct {
+	ProposalHandle  domain.ProposalHandle
+	ConversationURI *data.URI
+	// Open reports whether the proposal is still open, i.e. neither merged nor closed.
+	// It is only set by the finders and ignored by Save.
+	Open bool
+}
+
+// ProposalLinkRepository persists ProposalLinks
+type ProposalLinkRepository interface {
+	Save(ctx context.Context, link ProposalLink) error
+	// FindByProposal returns ErrProposalLinkNotFound if the proposal was not generated from a conversation
+	FindByProposal(ctx context.Context, handle domain.ProposalHandle) (ProposalLink, error)
+	// FindByConversation returns the latest proposal generated from the conversation,
+	// or ErrProposalLinkNotFound if no proposal was generated from it
+	FindByConversation(ctx context.Context, conversationURI *data.URI) (ProposalLink, error)
+}
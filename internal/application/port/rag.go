@@ -17,7 +17,8 @@ type RAGCorpus interface {
 	// It returns up to 10 documents in order of relevance to the query.
 	Query(ctx context.Context, query string, similarityTopK int32, vectorDistanceThreshold float64) ([]RAGDocument, error)
 
-	UploadFile(ctx context.Context, file io.Reader, uri *data.URI, options ...RAGCorpusUploadFileOption) error
+	// UploadFile uploads the file and returns it with the ID that the corpus assigned.
+	UploadFile(ctx context.Context, file io.Reader, uri *data.URI, options ...RAGCorpusUploadFileOption) (RAGFile, error)
 
 	ListFiles(ctx context.Context) ([]RAGFile, error)
 
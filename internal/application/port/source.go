@@ -15,13 +15,32 @@ type SourceRepository interface {
 
 type SourceRepositoryManager struct {
 	sourceRepositories []SourceRepository
+	sourcePolicy       *SourcePolicy
 }
 
-func NewSourceRepositoryManager(sourceRepositories []SourceRepository) *SourceRepositoryManager {
-	return &SourceRepositoryManager{sourceRepositories: sourceRepositories}
+type NewSourceRepositoryManagerOption func(*SourceRepositoryManager)
+
+// WithSourcePolicy makes the manager refuse sources that are not allowed by the policy
+func WithSourcePolicy(sourcePolicy *SourcePolicy) NewSourceRepositoryManagerOption {
+	return func(m *SourceRepositoryManager) {
+		m.sourcePolicy = sourcePolicy
+	}
+}
+
+func NewSourceRepositoryManager(sourceRepositories []SourceRepository, options ...NewSourceRepositoryManagerOption) *SourceRepositoryManager {
+	m := &SourceRepositoryManager{sourceRepositories: sourceRepositories}
+	for _, option := range options {
+		option(m)
+	}
+	return m
 }
 
 func (m *SourceRepositoryManager) Find(ctx context.Context, uri *data.URI) (*data.Source, error) {
+	if m.sourcePolicy != nil {
+		if err := m.sourcePolicy.Check(ctx, uri); err != nil {
+			return nil, err
+		}
+	}
 	for _, sourceRepository := range m.sourceRepositories {
 		if sourceRepository.Match(uri) {
 			return sourceRepository.Find(ctx, uri)
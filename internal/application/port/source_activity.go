@@ -0,0 +1,60 @@
+package port
+
+import (
+	"context"
+	"encoding/xml"
+	"time"
+
+	"docgent/internal/domain/data"
+)
+
+// SourceActivity describes what happened in a source after a point in time.
+type SourceActivity struct {
+	URI *data.URI
+	// Messages are the messages posted or edited after the point in time.
+	Messages []ConversationMessage
+	// Events are state changes of the source (e.g. "pull request was merged").
+	Events []string
+}
+
+// HasChanges reports whether there is any new activity in the source.
+func (a SourceActivity) HasChanges() bool {
+	return len(a.Messages) > 0 || len(a.Events) > 0
+}
+
+func (a SourceActivity) ToXML() string {
+	messages := make([]conversationMessage, len(a.Messages))
+	for i, message := range a.Messages {
+		messages[i] = conversationMessage{
+			Author:       message.Author,
+			Content:      message.Content,
+			YouMentioned: message.YouMentioned,
+			IsYou:        message.IsYou,
+		}
+	}
+	activity := sourceActivity{
+		URI:      a.URI.String(),
+		Events:   a.Events,
+		Messages: messages,
+	}
+
+	xmlData, err := xml.MarshalIndent(activity, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(xmlData)
+}
+
+type sourceActivity struct {
+	XMLName  xml.Name              `xml:"source_activity"`
+	URI      string                `xml:"uri,attr"`
+	Events   []string              `xml:"event"`
+	Messages []conversationMessage `xml:"message"`
+}
+
+// SourceActivityRepository finds new activity in sources such as chat threads or pull requests.
+type SourceActivityRepository interface {
+	Match(uri *data.URI) bool
+	// FindActivitySince returns the activity in the source that happened after the given time.
+	FindActivitySince(ctx context.Context, uri *data.URI, since time.Time) (SourceActivity, error)
+}
@@ -0,0 +1,112 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"docgent/internal/domain/data"
+)
+
+var ErrSourceNotAllowed = errors.New("source not allowed by policy")
+
+// SourceVisibility is who can read a source in the original tool
+type SourceVisibility string
+
+const (
+	SourceVisibilityPublic        SourceVisibility = "public"
+	SourceVisibilityPrivate       SourceVisibility = "private"
+	SourceVisibilityDirectMessage SourceVisibility = "direct_message"
+)
+
+// SourceLocation is where a source lives
+type SourceLocation struct {
+	// ContainerID identifies the container of the source (e.g. Slack channel ID, "owner/repo" on GitHub)
+	ContainerID string
+	Visibility  SourceVisibility
+}
+
+// SourceLocator resolves the location of sources it supports
+type SourceLocator interface {
+	Match(uri *data.URI) bool
+	Locate(ctx context.Context, uri *data.URI) (SourceLocation, error)
+}
+
+// SourcePolicyRule decides which sources may be documented in a repository
+type SourcePolicyRule struct {
+	// AllowedContainerIDs restricts sources to these containers if not empty
+	AllowedContainerIDs []string
+	// DeniedContainerIDs are never allowed, even if they are in AllowedContainerIDs
+	DeniedContainerIDs []string
+	// DenyPrivate refuses sources in private containers such as private channels
+	DenyPrivate bool
+	// DenyDirectMessages refuses sources in direct messages
+	DenyDirectMessages bool
+	// RepositoryPublic is true if the documentation repository is readable by everyone who can read public sources
+	RepositoryPublic bool
+	// AllowNonPublicSourcesInPublicRepository lets private sources and direct messages flow into a public repository
+	AllowNonPublicSourcesInPublicRepository bool
+}
+
+// Decide returns ErrSourceNotAllowed with the reason if the location is not allowed by the rule
+func (r SourcePolicyRule) Decide(location SourceLocation) error {
+	if slices.Contains(r.DeniedContainerIDs, location.ContainerID) {
+		return fmt.Errorf("%w: %s is denied", ErrSourceNotAllowed, location.ContainerID)
+	}
+	if len(r.AllowedContainerIDs) > 0 && !slices.Contains(r.AllowedContainerIDs, location.ContainerID) {
+		return fmt.Errorf("%w: %s is not in the allowlist", ErrSourceNotAllowed, location.ContainerID)
+	}
+
+	switch location.Visibility {
+	case SourceVisibilityPublic:
+		return nil
+	case SourceVisibilityPrivate:
+		if r.DenyPrivate {
+			return fmt.Errorf("%w: private sources are denied", ErrSourceNotAllowed)
+		}
+	case SourceVisibilityDirectMessage:
+		if r.DenyDirectMessages {
+			return fmt.Errorf("%w: direct messages are denied", ErrSourceNotAllowed)
+		}
+	default:
+		return fmt.Errorf("%w: unknown visibility %q", ErrSourceNotAllowed, location.Visibility)
+	}
+
+	if r.RepositoryPublic && !r.AllowNonPublicSourcesInPublicRepository {
+		return fmt.Errorf("%w: %s sources cannot be documented in a public repository", ErrSourceNotAllowed, location.Visibility)
+	}
+	return nil
+}
+
+// SourcePolicyEntry applies a rule to the sources that the locator matches
+type SourcePolicyEntry struct {
+	Locator SourceLocator
+	Rule    SourcePolicyRule
+}
+
+// SourcePolicy checks sources against the rule of the first matching entry.
+// Sources that no entry matches are allowed.
+type SourcePolicy struct {
+	entries []SourcePolicyEntry
+}
+
+func NewSourcePolicy(entries ...SourcePolicyEntry) *SourcePolicy {
+	return &SourcePolicy{entries: entries}
+}
+
+// Check returns ErrSourceNotAllowed if the source must not be used
+func (p *SourcePolicy) Check(ctx context.Context, uri *data.URI) error {
+	for _, entry := range p.entries {
+		if !entry.Locator.Match(uri) {
+			continue
+		}
+		location, err := entry.Locator.Locate(ctx, uri)
+		if err != nil {
+			// Fail closed: a source we cannot classify may be private
+			return fmt.Errorf("%w: failed to locate source: %w", ErrSourceNotAllowed, err)
+		}
+		return entry.Rule.Decide(location)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"docgent/internal/domain/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourcePolicyRule_Decide(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     SourcePolicyRule
+		location SourceLocation
+		allowed  bool
+	}{
+		{
+			name:     "公開チャンネルは許可される",
+			rule:     SourcePolicyRule{RepositoryPublic: true},
+			location: SourceLocation{ContainerID: "C001", Visibility: SourceVisibilityPublic},
+			allowed:  true,
+		},
+		{
+			name:     "拒否リストのチャンネルは拒否される",
+			rule:     SourcePolicyRule{DeniedContainerIDs: []string{"C001"}},
+			location: SourceLocation{ContainerID: "C001", Visibility: SourceVisibilityPublic},
+			allowed:  false,
+		},
+		{
+			name:     "許可リストにないチャンネルは拒否される",
+			rule:     SourcePolicyRule{AllowedContainerIDs: []string{"C002"}},
+			location: SourceLocation{ContainerID: "C001", Visibility: SourceVisibilityPublic},
+			allowed:  false,
+		},
+		{
+			name:     "拒否リストは許可リストより優先される",
+			rule:     SourcePolicyRule{AllowedContainerIDs: []string{"C001"}, DeniedContainerIDs: []string{"C001"}},
+			location: SourceLocation{ContainerID: "C001", Visibility: SourceVisibilityPublic},
+			allowed:  false,
+		},
+		{
+			name:     "プライベートリポジトリにはプライベートチャンネルを使える",
+			rule:     SourcePolicyRule{},
+			location: SourceLocation{ContainerID: "C001", Visibility: SourceVisibilityPrivate},
+			allowed:  true,
+		},
+		{
+			name:     "プライベートチャンネルの禁止が設定されていれば拒否される",
+			rule:     SourcePolicyRule{DenyPrivate: true},
+			location: SourceLocation{ContainerID: "C001", Visibility: SourceVisibilityPrivate},
+			allowed:  false,
+		},
+		{
+			name:     "DMの禁止が設定されていれば拒否される",
+			rule:     SourcePolicyRule{DenyDirectMessages: true},
+			location: SourceLocation{ContainerID: "D001", Visibility: SourceVisibilityDirectMessage},
+			allowed:  false,
+		},
+		{
+			name:     "公開リポジトリにはDMを使えない",
+			rule:     SourcePolicyRule{RepositoryPublic: true},
+			location: SourceLocation{ContainerID: "D001", Visibility: SourceVisibilityDirectMessage},
+			allowed:  false,
+		},
+		{
+			name:     "明示的に許可すれば公開リポジトリにプライベートチャンネルを使える",
+			rule:     SourcePolicyRule{RepositoryPublic: true, AllowNonPublicSourcesInPublicRepository: true},
+			location: SourceLocation{ContainerID: "C001", Visibility: SourceVisibilityPrivate},
+			allowed:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Decide(tt.location)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrSourceNotAllowed)
+			}
+		})
+	}
+}
+
+type stubSourceLocator struct {
+	host     string
+	location SourceLocation
+	err      error
+}
+
+func (l *stubSourceLocator) Match(uri *data.URI) bool {
+	return uri.Host() == l.host
+}
+
+func (l *stubSourceLocator) Locate(ctx context.Context, uri *data.URI) (SourceLocation, error) {
+	return l.location, l.err
+}
+
+func TestSourcePolicy_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		locator *stubSourceLocator
+		uri     string
+		allowed bool
+	}{
+		{
+			name:    "対応するロケーターがないソースは許可される",
+			locator: &stubSourceLocator{host: "app.slack.com", location: SourceLocation{Visibility: SourceVisibilityDirectMessage}},
+			uri:     "https://example.com/page",
+			allowed: true,
+		},
+		{
+			name:    "ルールに従って拒否される",
+			locator: &stubSourceLocator{host: "app.slack.com", location: SourceLocation{Visibility: SourceVisibilityDirectMessage}},
+			uri:     "https://app.slack.com/client/T001/D001/1234567890.123456",
+			allowed: false,
+		},
+		{
+			name:    "公開範囲を特定できないソースは拒否される",
+			locator: &stubSourceLocator{host: "app.slack.com", err: errors.New("channel_not_found")},
+			uri:     "https://app.slack.com/client/T001/C001/1234567890.123456",
+			allowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := NewSourcePolicy(SourcePolicyEntry{
+				Locator: tt.locator,
+				Rule:    SourcePolicyRule{DenyDirectMessages: true},
+			})
+
+			err := policy.Check(context.Background(), data.NewURIUnsafe(tt.uri))
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrSourceNotAllowed)
+			}
+		})
+	}
+}
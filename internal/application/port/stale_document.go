@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"docgent/internal/domain/data"
+)
+
+// StaleDocumentReport describes a document whose sources have changed after the document was last updated.
+type StaleDocumentReport struct {
+	// FilePath is the path of the document
+	FilePath string
+	// FileURI is the permalink of the document
+	FileURI *data.URI
+	// LastModifiedAt is the time when the document was last updated
+	LastModifiedAt time.Time
+	// CheckedAt is the time when the activities were checked. The next check reports only activities after it
+	CheckedAt time.Time
+	// Activities are the new activities in the sources of the document
+	Activities []SourceActivity
+	// Summary is a human-readable summary of what changed in the sources
+	Summary string
+}
+
+// StaleDocumentNotifier notifies maintainers that a document may be outdated.
+// Each platform (GitHub issues, etc.) implements its own way of notification.
+type StaleDocumentNotifier interface {
+	Notify(ctx context.Context, report StaleDocumentReport) error
+	// LastNotifiedAt returns the CheckedAt of the last notification of the document that maintainers have not resolved yet,
+	// or the zero time if there is none.
+	LastNotifiedAt(ctx context.Context, path string) (time.Time, error)
+}
@@ -0,0 +1,170 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+)
+
+var reTitle = regexp.MustCompile(`(?s)<title>(.*?)</title>`)
+
+// ProposalCommandUsecase は、提案に対するエージェントを使わないコマンドを実行するユースケースです。
+type ProposalCommandUsecase struct {
+	chatModel           domain.ChatModel
+	conversationService port.ConversationService
+	fileRepository      data.FileRepository
+	proposalRepository  domain.ProposalRepository
+}
+
+func NewProposalCommandUsecase(
+	chatModel domain.ChatModel,
+	conversationService port.ConversationService,
+	fileRepository data.FileRepository,
+	proposalRepository domain.ProposalRepository,
+) *ProposalCommandUsecase {
+	return &ProposalCommandUsecase{
+		chatModel:           chatModel,
+		conversationService: conversationService,
+		fileRepository:      fileRepository,
+		proposalRepository:  proposalRepository,
+	}
+}
+
+// Retitle は提案のタイトルを変更します。タイトルが空の場合は変更内容から新しいタイトルを生成します。
+func (u *ProposalCommandUsecase) Retitle(ctx context.Context, proposalHandle domain.ProposalHandle, title string) error {
+	proposal, err := u.proposalRepository.GetProposal(proposalHandle)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve proposal: %w", err)
+	}
+
+	if title == "" {
+		title, err = u.generateTitle(ctx, proposal)
+		if err != nil {
+			return err
+		}
+	}
+
+	content := domain.NewProposalContent(title, proposal.Body)
+	if err := u.proposalRepository.UpdateProposalContent(proposalHandle, content); err != nil {
+		return fmt.Errorf("failed to update proposal content: %w", err)
+	}
+
+	if err := u.conversationService.Reply(fmt.Sprintf("Renamed the proposal to %q", title), false); err != nil {
+		return fmt.Errorf("failed to reply: %w", err)
+	}
+	return nil
+}
+
+func (u *ProposalCommandUsecase) generateTitle(ctx context.Context, proposal domain.Proposal) (string, error) {
+	session := u.chatModel.StartChat(`You are a technical writer who names pull requests that change documents.
+Write a concise title (at most 72 characters) that describes the changes, in the same language as the documents.
+Respond only with the title wrapped in <title></title>.`)
+
+	message := fmt.Sprintf("<current_title>%s</current_title>\n<description>\n%s\n</description>\n%s", proposal.Title, proposal.Body, proposal.Diffs.ToXMLString())
+	response, err := session.SendMessage(ctx, message)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate title: %w", err)
+	}
+
+	matches := reTitle.FindStringSubmatch(response)
+	if matches == nil {
+		return "", fmt.Errorf("failed to parse generated title: %s", response)
+	}
+	title := strings.TrimSpace(matches[1])
+	if title == "" {
+		return "", fmt.Errorf("generated title is empty")
+	}
+	return title, nil
+}
+
+// ListSources は提案で変更されたドキュメントのソースを返信します。
+func (u *ProposalCommandUsecase) ListSources(ctx context.Context, proposalHandle domain.ProposalHandle) error {
+	proposal, err := u.proposalRepository.GetProposal(proposalHandle)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve proposal: %w", err)
+	}
+
+	var b strings.Builder
+	for _, diff := range proposal.Diffs {
+		if diff.NewName == "" {
+			continue
+		}
+		file, err := u.fileRepository.Get(ctx, diff.NewName)
+		if errors.Is(err, data.ErrFileNotFound) {
+			// 削除されたファイル
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get file %s: %w", diff.NewName, err)
+		}
+		b.WriteString(fmt.Sprintf("%s\n", file.Path))
+		if len(file.SourceURIs) == 0 {
+			b.WriteString("- (no sources)\n")
+		}
+		for _, uri := range file.SourceURIs {
+			b.WriteString(fmt.Sprintf("- %s\n", uri))
+		}
+	}
+
+	message := strings.TrimRight(b.String(), "\n")
+	if message == "" {
+		message = "The proposal has no documents."
+	}
+
+	if err := u.conversationService.Reply(message, false); err != nil {
+		return fmt.Errorf("failed to reply: %w", err)
+	}
+	return nil
+}
+
+// Split は提案を変更したファイルごとの提案に分割します。
+// newProposalRepository は分割後の i 番目の提案を、ベースブランチから作った新しいブランチに作成するリポジトリを返します。
+// もとの提案は閉じずに残すので、不要になったら人が閉じます。
+func (u *ProposalCommandUsecase) Split(ctx context.Context, proposalHandle domain.ProposalHandle, newProposalRepository func(ctx context.Context, i int) (domain.ProposalRepository, error)) error {
+	proposal, err := u.proposalRepository.GetProposal(proposalHandle)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve proposal: %w", err)
+	}
+
+	if len(proposal.Diffs) < 2 {
+		if err := u.conversationService.Reply("The proposal changes only one file, so there is nothing to split.", false); err != nil {
+			return fmt.Errorf("failed to reply: %w", err)
+		}
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Split the proposal into %d proposals:\n", len(proposal.Diffs)))
+	for i, diff := range proposal.Diffs {
+		path := diff.NewName
+		if path == "" {
+			path = diff.OldName
+		}
+
+		repository, err := newProposalRepository(ctx, i)
+		if err != nil {
+			return fmt.Errorf("failed to prepare proposal for %s: %w", path, err)
+		}
+		content := domain.NewProposalContent(
+			fmt.Sprintf("%s (%s)", proposal.Title, path),
+			fmt.Sprintf("Split from #%s.\n\n%s", proposalHandle.Value, proposal.Body),
+		)
+		handle, err := repository.CreateProposal(domain.Diffs{diff}, content)
+		if err != nil {
+			return fmt.Errorf("failed to create proposal for %s: %w", path, err)
+		}
+		b.WriteString(fmt.Sprintf("- #%s: %s\n", handle.Value, path))
+	}
+	b.WriteString("\nClose this proposal if it is no longer needed.")
+
+	if err := u.conversationService.Reply(b.String(), false); err != nil {
+		return fmt.Errorf("failed to reply: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProposalCommandUsecase_Retitle(t *testing.T) {
+	handle := domain.NewProposalHandle("github", "1")
+	proposal := domain.NewProposal(handle, domain.Diffs{}, domain.NewProposalContent("古いタイトル", "本文"), nil)
+
+	tests := []struct {
+		name          string
+		title         string
+		modelResponse string
+		expectedTitle string
+	}{
+		{
+			name:          "指定したタイトルに変更する",
+			title:         "新しいタイトル",
+			expectedTitle: "新しいタイトル",
+		},
+		{
+			name:          "タイトルがなければ生成する",
+			title:         "",
+			modelResponse: "<title>生成されたタイトル</title>",
+			expectedTitle: "生成されたタイトル",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatModel := new(MockChatModel)
+			chatSession := new(MockChatSession)
+			conversationService := new(MockConversationService)
+			fileRepository := new(MockFileRepository)
+			proposalRepository := new(MockProposalRepository)
+
+			if tt.modelResponse != "" {
+				chatModel.On("StartChat", mock.Anything).Return(chatSession)
+				chatSession.On("SendMessage", mock.Anything, mock.Anything).Return(tt.modelResponse, nil)
+			}
+			proposalRepository.On("GetProposal", handle).Return(proposal, nil)
+			proposalRepository.On("UpdateProposalContent", handle, domain.NewProposalContent(tt.expectedTitle, "本文")).Return(nil)
+			conversationService.On("Reply", mock.Anything, false).Return(nil)
+
+			usecase := NewProposalCommandUsecase(chatModel, conversationService, fileRepository, proposalRepository)
+			err := usecase.Retitle(context.Background(), handle, tt.title)
+
+			assert.NoError(t, err)
+			chatModel.AssertExpectations(t)
+			proposalRepository.AssertExpectations(t)
+			conversationService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProposalCommandUsecase_ListSources(t *testing.T) {
+	handle := domain.NewProposalHandle("github", "1")
+	diffs := domain.Diffs{
+		{OldName: "docs/a.md", NewName: "docs/a.md"},
+		{OldName: "docs/b.md", NewName: "docs/b.md"},
+		{OldName: "docs/removed.md", NewName: "docs/removed.md"},
+	}
+	proposal := domain.NewProposal(handle, diffs, domain.NewProposalContent("タイトル", "本文"), nil)
+
+	conversationService := new(MockConversationService)
+	fileRepository := new(MockFileRepository)
+	proposalRepository := new(MockProposalRepository)
+
+	proposalRepository.On("GetProposal", handle).Return(proposal, nil)
+	fileRepository.On("Get", mock.Anything, "docs/a.md").Return(&data.File{
+		Path:       "docs/a.md",
+		SourceURIs: []*data.URI{data.NewURIUnsafe("https://app.slack.com/client/T001/C001/1234567890.123456")},
+	}, nil)
+	fileRepository.On("Get", mock.Anything, "docs/b.md").Return(&data.File{Path: "docs/b.md"}, nil)
+	fileRepository.On("Get", mock.Anything, "docs/removed.md").Return((*data.File)(nil), data.ErrFileNotFound)
+	conversationService.On("Reply", "docs/a.md\n- https://app.slack.com/client/T001/C001/1234567890.123456\ndocs/b.md\n- (no sources)", false).Return(nil)
+
+	usecase := NewProposalCommandUsecase(new(MockChatModel), conversationService, fileRepository, proposalRepository)
+	err := usecase.ListSources(context.Background(), handle)
+
+	assert.NoError(t, err)
+	fileRepository.AssertExpectations(t)
+	conversationService.AssertExpectations(t)
+}
+
+func TestProposalCommandUsecase_Split(t *testing.T) {
+	handle := domain.NewProposalHandle("github", "1")
+	a := domain.Diff{OldName: "docs/a.md", NewName: "docs/a.md", Body: "@@ -1 +1 @@\n-a\n+A\n"}
+	b := domain.Diff{OldName: "", NewName: "docs/b.md", Body: "@@ -0,0 +1 @@\n+B\n", IsNewFile: true}
+
+	t.Run("ファイルごとに新しい提案を作成する", func(t *testing.T) {
+		proposal := domain.NewProposal(handle, domain.Diffs{a, b}, domain.NewProposalContent("タイトル", "本文"), nil)
+		conversationService := new(MockConversationService)
+		proposalRepository := new(MockProposalRepository)
+		splitRepositories := []*MockProposalRepository{new(MockProposalRepository), new(MockProposalRepository)}
+
+		proposalRepository.On("GetProposal", handle).Return(proposal, nil)
+		splitRepositories[0].On("CreateProposal", domain.Diffs{a}, domain.NewProposalContent("タイトル (docs/a.md)", "Split from #1.\n\n本文")).
+			Return(domain.NewProposalHandle("github", "2"), nil)
+		splitRepositories[1].On("CreateProposal", domain.Diffs{b}, domain.NewProposalContent("タイトル (docs/b.md)", "Split from #1.\n\n本文")).
+			Return(domain.NewProposalHandle("github", "3"), nil)
+		conversationService.On("Reply", "Split the proposal into 2 proposals:\n- #2: docs/a.md\n- #3: docs/b.md\n\nClose this proposal if it is no longer needed.", false).Return(nil)
+
+		usecase := NewProposalCommandUsecase(new(MockChatModel), conversationService, new(MockFileRepository), proposalRepository)
+		err := usecase.Split(context.Background(), handle, func(ctx context.Context, i int) (domain.ProposalRepository, error) {
+			return splitRepositories[i], nil
+		})
+
+		assert.NoError(t, err)
+		splitRepositories[0].AssertExpectations(t)
+		splitRepositories[1].AssertExpectations(t)
+		conversationService.AssertExpectations(t)
+	})
+
+	t.Run("ファイルが1つなら分割しない", func(t *testing.T) {
+		proposal := domain.NewProposal(handle, domain.Diffs{a}, domain.NewProposalContent("タイトル", "本文"), nil)
+		conversationService := new(MockConversationService)
+		proposalRepository := new(MockProposalRepository)
+
+		proposalRepository.On("GetProposal", handle).Return(proposal, nil)
+		conversationService.On("Reply", "The proposal changes only one file, so there is nothing to split.", false).Return(nil)
+
+		usecase := NewProposalCommandUsecase(new(MockChatModel), conversationService, new(MockFileRepository), proposalRepository)
+		err := usecase.Split(context.Background(), handle, func(ctx context.Context, i int) (domain.ProposalRepository, error) {
+			t.Fatal("no proposal should be created")
+			return nil, nil
+		})
+
+		assert.NoError(t, err)
+		conversationService.AssertExpectations(t)
+	})
+}
@@ -21,6 +21,7 @@ type ProposalGenerateUsecase struct {
 	sourceRepositories  []port.SourceRepository
 	proposalRepository  domain.ProposalRepository
 	ragCorpus           port.RAGCorpus
+	sourcePolicy        *port.SourcePolicy
 	responseFormatter   port.ResponseFormatter
 	remainingStepCount  int
 }
@@ -33,6 +34,13 @@ func WithProposalGenerateRAGCorpus(ragCorpus port.RAGCorpus) NewProposalGenerate
 	}
 }
 
+// WithProposalGenerateSourcePolicy は利用できるソースを制限するポリシーを設定するオプションです。
+func WithProposalGenerateSourcePolicy(sourcePolicy *port.SourcePolicy) NewProposalGenerateUsecaseOption {
+	return func(u *ProposalGenerateUsecase) {
+		u.sourcePolicy = sourcePolicy
+	}
+}
+
 func NewProposalGenerateUsecase(
 	chatModel domain.ChatModel,
 	conversationService port.ConversationService,
@@ -48,6 +56,7 @@ func NewProposalGenerateUsecase(
 		conversationService: conversationService,
 		fileQueryService:    fileQueryService,
 		fileRepository:      fileRepository,
+		sourceRepositories:  sourceRepositories,
 		proposalRepository:  proposalRepository,
 		responseFormatter:   responseFormatter,
 		remainingStepCount:  10,
@@ -79,7 +88,7 @@ func (w *ProposalGenerateUsecase) Execute(ctx context.Context) (domain.ProposalH
 		return domain.ProposalHandle{}, fmt.Errorf("failed to get docgent rules file: %w", err)
 	}
 
-	sourceRepositoryManager := port.NewSourceRepositoryManager(w.sourceRepositories)
+	sourceRepositoryManager := port.NewSourceRepositoryManager(w.sourceRepositories, port.WithSourcePolicy(w.sourcePolicy))
 
 	var proposalHandle domain.ProposalHandle
 	var fileChanged bool
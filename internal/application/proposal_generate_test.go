@@ -138,9 +138,9 @@ func (m *MockRAGCorpus) Query(ctx context.Context, query string, similarityTopK
 	return args.Get(0).([]port.RAGDocument), args.Error(1)
 }
 
-func (m *MockRAGCorpus) UploadFile(ctx context.Context, file io.Reader, uri *data.URI, options ...port.RAGCorpusUploadFileOption) error {
+func (m *MockRAGCorpus) UploadFile(ctx context.Context, file io.Reader, uri *data.URI, options ...port.RAGCorpusUploadFileOption) (port.RAGFile, error) {
 	args := m.Called(ctx, file, uri, options)
-	return args.Error(0)
+	return args.Get(0).(port.RAGFile), args.Error(1)
 }
 
 func (m *MockRAGCorpus) ListFiles(ctx context.Context) ([]port.RAGFile, error) {
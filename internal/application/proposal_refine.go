@@ -21,6 +21,7 @@ type ProposalRefineUsecase struct {
 	proposalRepository  domain.ProposalRepository
 	responseFormatter   port.ResponseFormatter
 	ragCorpus           port.RAGCorpus
+	sourcePolicy        *port.SourcePolicy
 	remainingStepCount  int
 }
 
@@ -32,6 +33,13 @@ func WithProposalRefineRAGCorpus(ragCorpus port.RAGCorpus) NewProposalRefineUsec
 	}
 }
 
+// WithProposalRefineSourcePolicy は利用できるソースを制限するポリシーを設定するオプションです。
+func WithProposalRefineSourcePolicy(sourcePolicy *port.SourcePolicy) NewProposalRefineUsecaseOption {
+	return func(u *ProposalRefineUsecase) {
+		u.sourcePolicy = sourcePolicy
+	}
+}
+
 func NewProposalRefineUsecase(
 	chatModel domain.ChatModel,
 	conversationService port.ConversationService,
@@ -66,7 +74,67 @@ func WithRemainingStepCount(remainingStepCount int) NewProposalRefineUsecaseOpti
 	}
 }
 
+// FeedbackAnchor は、フィードバックが付けられたファイル上の位置です。
+type FeedbackAnchor struct {
+	FilePath  string
+	StartLine int
+	EndLine   int
+	// DiffHunk は、フィードバックが付けられた行の周辺の差分です。
+	DiffHunk string
+}
+
+func (a FeedbackAnchor) toXML() string {
+	return fmt.Sprintf(`<feedback_anchor path=%q start_line="%d" end_line="%d">
+<diff_hunk>
+%s
+</diff_hunk>
+</feedback_anchor>`, a.FilePath, a.StartLine, a.EndLine, a.DiffHunk)
+}
+
 func (w *ProposalRefineUsecase) Refine(proposalHandle domain.ProposalHandle, userFeedback string) error {
+	return w.refine(proposalHandle, userFeedback, nil)
+}
+
+// RefineAt は、ファイルの特定の行に付けられたフィードバックに基づいて提案を修正します。
+func (w *ProposalRefineUsecase) RefineAt(proposalHandle domain.ProposalHandle, userFeedback string, anchor FeedbackAnchor) error {
+	return w.refine(proposalHandle, userFeedback, &anchor)
+}
+
+// RefineFromConversation は、提案の元になった会話に追加されたメッセージに基づいて提案を修正します。
+// Docgentの最後の発言より後のメッセージを新しいメッセージとみなします。Docgentが発言していない場合は会話全体を使います。
+// 新しいメッセージがなく提案を修正しなかった場合は false を返します。
+func (w *ProposalRefineUsecase) RefineFromConversation(proposalHandle domain.ProposalHandle) (bool, error) {
+	history, err := w.conversationService.GetHistory()
+	if err != nil {
+		return false, fmt.Errorf("failed to get conversation history: %w", err)
+	}
+
+	history.Messages = newMessagesSinceLastReply(history.Messages)
+	if len(history.Messages) == 0 {
+		if err := w.conversationService.Reply("There are no new messages to reflect in the proposal", false); err != nil {
+			return false, fmt.Errorf("failed to reply: %w", err)
+		}
+		return false, nil
+	}
+
+	feedback := fmt.Sprintf(`The conversation that the proposal was generated from has new messages. Update the proposal to reflect them.
+%s`, history.ToXML())
+	if err := w.refine(proposalHandle, feedback, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func newMessagesSinceLastReply(messages []port.ConversationMessage) []port.ConversationMessage {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].IsYou {
+			return messages[i+1:]
+		}
+	}
+	return messages
+}
+
+func (w *ProposalRefineUsecase) refine(proposalHandle domain.ProposalHandle, userFeedback string, anchor *FeedbackAnchor) error {
 	go w.conversationService.MarkEyes()
 	defer w.conversationService.RemoveEyes()
 
@@ -91,7 +159,7 @@ func (w *ProposalRefineUsecase) Refine(proposalHandle domain.ProposalHandle, use
 		return fmt.Errorf("failed to get docgent rules file: %w", err)
 	}
 
-	sourceRepositoryManager := port.NewSourceRepositoryManager(w.sourceRepositories)
+	sourceRepositoryManager := port.NewSourceRepositoryManager(w.sourceRepositories, port.WithSourcePolicy(w.sourcePolicy))
 
 	var fileChanged bool
 
@@ -126,6 +194,13 @@ Now, you are given a user feedback. Refine the proposal based on the user feedba
 <user_feedback uri=%q>
 %s
 </user_feedback>`, conversationURI.String(), userFeedback)
+	if anchor != nil {
+		task += fmt.Sprintf(`
+<note>
+The user feedback is left on specific lines of a file in the proposal. Focus on those lines unless the feedback says otherwise.
+</note>
+%s`, anchor.toXML())
+	}
 
 	err = agent.InitiateTaskLoop(ctx, task, w.remainingStepCount)
 	if err != nil {
@@ -167,3 +167,160 @@ func TestProposalRefineUsecase_Refine(t *testing.T) {
 		})
 	}
 }
+
+func TestProposalRefineUsecase_RefineAt(t *testing.T) {
+	chatModel := new(MockChatModel)
+	chatSession := new(MockChatSession)
+	conversationService := new(MockConversationService)
+	conversationService.markEyesWaitGroup = &sync.WaitGroup{}
+	conversationService.markEyesWaitGroup.Add(1)
+	fileQueryService := new(MockFileQueryService)
+	fileRepository := new(MockFileRepository)
+	proposalRepository := new(MockProposalRepository)
+	responseFormatter := new(MockResponseFormatter)
+
+	conversationService.On("MarkEyes").Return(nil).Once()
+	conversationService.On("RemoveEyes").Return(nil).Once()
+	conversationService.On("URI").Return(data.NewURIUnsafe("https://github.com/owner/repo/pull/123#discussion_r456")).Once()
+
+	proposal := domain.Proposal{
+		Handle: domain.NewProposalHandle("github", "123"),
+		Diffs:  domain.Diffs{{NewName: "docs/api.md"}},
+	}
+	proposalRepository.On("GetProposal", proposal.Handle).Return(proposal, nil)
+	fileQueryService.On("GetTree", mock.Anything, mock.AnythingOfType("[]port.GetTreeOption")).Return([]port.TreeMetadata{
+		{Path: "docs/api.md", Type: port.NodeTypeFile, Size: 100},
+	}, nil)
+
+	chatModel.On("StartChat", mock.Anything).Return(chatSession)
+	// 最初のメッセージに行の位置と差分が含まれること
+	chatSession.On("SendMessage", mock.Anything, mock.MatchedBy(func(message string) bool {
+		return strings.Contains(message, `<feedback_anchor path="docs/api.md" start_line="3" end_line="5">`) &&
+			strings.Contains(message, "@@ -1,3 +1,5 @@")
+	})).Return(`<attempt_complete><message>修正しました</message></attempt_complete>`, nil).Once()
+	responseFormatter.On("FormatResponse", mock.Anything).Return("修正しました", nil).Once()
+	conversationService.On("Reply", "修正しました", true).Return(nil)
+
+	workflow := NewProposalRefineUsecase(
+		chatModel,
+		conversationService,
+		fileQueryService,
+		fileRepository,
+		[]port.SourceRepository{},
+		proposalRepository,
+		responseFormatter,
+	)
+
+	err := workflow.RefineAt(proposal.Handle, "この行は古いです", FeedbackAnchor{
+		FilePath:  "docs/api.md",
+		StartLine: 3,
+		EndLine:   5,
+		DiffHunk:  "@@ -1,3 +1,5 @@\n # API\n+## Endpoints",
+	})
+
+	conversationService.markEyesWaitGroup.Wait()
+
+	assert.NoError(t, err)
+	chatModel.AssertExpectations(t)
+	chatSession.AssertExpectations(t)
+	conversationService.AssertExpectations(t)
+	proposalRepository.AssertExpectations(t)
+}
+
+func TestProposalRefineUsecase_RefineFromConversation(t *testing.T) {
+	chatModel := new(MockChatModel)
+	chatSession := new(MockChatSession)
+	conversationService := new(MockConversationService)
+	conversationService.markEyesWaitGroup = &sync.WaitGroup{}
+	conversationService.markEyesWaitGroup.Add(1)
+	fileQueryService := new(MockFileQueryService)
+	fileRepository := new(MockFileRepository)
+	proposalRepository := new(MockProposalRepository)
+	responseFormatter := new(MockResponseFormatter)
+
+	conversationURI := data.NewURIUnsafe("https://app.slack.com/client/T001/C001/1234567890.123456")
+	conversationService.On("GetHistory").Return(port.ConversationHistory{
+		URI: conversationURI,
+		Messages: []port.ConversationMessage{
+			{Author: "U001", Content: "APIの認証方式を変更しました"},
+			{Author: "docgent", Content: "PR: https://github.com/owner/repo/pull/123", IsYou: true},
+			{Author: "U001", Content: "トークンの有効期限は1時間です"},
+		},
+	}, nil).Once()
+	conversationService.On("MarkEyes").Return(nil).Once()
+	conversationService.On("RemoveEyes").Return(nil).Once()
+	conversationService.On("URI").Return(conversationURI).Once()
+
+	proposal := domain.Proposal{
+		Handle: domain.NewProposalHandle("github", "123"),
+		Diffs:  domain.Diffs{{NewName: "docs/api.md"}},
+	}
+	proposalRepository.On("GetProposal", proposal.Handle).Return(proposal, nil)
+	fileQueryService.On("GetTree", mock.Anything, mock.AnythingOfType("[]port.GetTreeOption")).Return([]port.TreeMetadata{
+		{Path: "docs/api.md", Type: port.NodeTypeFile, Size: 100},
+	}, nil)
+
+	chatModel.On("StartChat", mock.Anything).Return(chatSession)
+	// Docgentの返信より後のメッセージだけがフィードバックに含まれること
+	chatSession.On("SendMessage", mock.Anything, mock.MatchedBy(func(message string) bool {
+		return strings.Contains(message, "トークンの有効期限は1時間です") &&
+			!strings.Contains(message, "APIの認証方式を変更しました")
+	})).Return(`<attempt_complete><message>更新しました</message></attempt_complete>`, nil).Once()
+	responseFormatter.On("FormatResponse", mock.Anything).Return("更新しました", nil).Once()
+	conversationService.On("Reply", "更新しました", true).Return(nil)
+
+	workflow := NewProposalRefineUsecase(
+		chatModel,
+		conversationService,
+		fileQueryService,
+		fileRepository,
+		[]port.SourceRepository{},
+		proposalRepository,
+		responseFormatter,
+	)
+
+	refined, err := workflow.RefineFromConversation(proposal.Handle)
+
+	conversationService.markEyesWaitGroup.Wait()
+
+	assert.NoError(t, err)
+	assert.True(t, refined)
+	chatModel.AssertExpectations(t)
+	chatSession.AssertExpectations(t)
+	conversationService.AssertExpectations(t)
+	proposalRepository.AssertExpectations(t)
+}
+
+func TestProposalRefineUsecase_RefineFromConversation_NoNewMessages(t *testing.T) {
+	chatModel := new(MockChatModel)
+	conversationService := new(MockConversationService)
+	proposalRepository := new(MockProposalRepository)
+
+	conversationService.On("GetHistory").Return(port.ConversationHistory{
+		URI: data.NewURIUnsafe("https://app.slack.com/client/T001/C001/1234567890.123456"),
+		Messages: []port.ConversationMessage{
+			{Author: "U001", Content: "APIの認証方式を変更しました"},
+			{Author: "docgent", Content: "PR: https://github.com/owner/repo/pull/123", IsYou: true},
+		},
+	}, nil).Once()
+	conversationService.On("Reply", "There are no new messages to reflect in the proposal", false).Return(nil).Once()
+
+	workflow := NewProposalRefineUsecase(
+		chatModel,
+		conversationService,
+		new(MockFileQueryService),
+		new(MockFileRepository),
+		[]port.SourceRepository{},
+		proposalRepository,
+		new(MockResponseFormatter),
+	)
+
+	refined, err := workflow.RefineFromConversation(domain.NewProposalHandle("github", "123"))
+
+	assert.NoError(t, err)
+	assert.False(t, refined)
+	// 新しいメッセージがなければエージェントも提案も使わない
+	chatModel.AssertNotCalled(t, "StartChat", mock.Anything)
+	proposalRepository.AssertNotCalled(t, "GetProposal", mock.Anything)
+	conversationService.AssertExpectations(t)
+}
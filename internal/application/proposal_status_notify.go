@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+)
+
+// ProposalStatus は提案の状態の変化の種類です。
+type ProposalStatus string
+
+const (
+	ProposalStatusMerged           ProposalStatus = "merged"
+	ProposalStatusClosed           ProposalStatus = "closed"
+	ProposalStatusApproved         ProposalStatus = "approved"
+	ProposalStatusChangesRequested ProposalStatus = "changes_requested"
+	ProposalStatusCommented        ProposalStatus = "commented"
+)
+
+// ProposalStatusUpdate は提案の状態の変化です。
+type ProposalStatusUpdate struct {
+	Handle      domain.ProposalHandle
+	Status      ProposalStatus
+	ProposalURI *data.URI
+	// Actor は状態を変化させたユーザーです。
+	Actor string
+	// Comment はレビューの本文です。
+	Comment string
+	// DocumentURIs はマージされたドキュメントのパーマリンクです。
+	DocumentURIs []*data.URI
+}
+
+// ProposalStatusNotifyUsecase は、提案の状態の変化を提案の元になった会話に通知するユースケースです。
+type ProposalStatusNotifyUsecase struct {
+	proposalLinkRepository port.ProposalLinkRepository
+	conversationResolvers  []port.ConversationResolver
+}
+
+func NewProposalStatusNotifyUsecase(proposalLinkRepository port.ProposalLinkRepository, conversationResolvers []port.ConversationResolver) *ProposalStatusNotifyUsecase {
+	return &ProposalStatusNotifyUsecase{
+		proposalLinkRepository: proposalLinkRepository,
+		conversationResolvers:  conversationResolvers,
+	}
+}
+
+// Execute は状態の変化を通知します。会話から生成されていない提案の場合は何もしません。
+func (u *ProposalStatusNotifyUsecase) Execute(ctx context.Context, update ProposalStatusUpdate) error {
+	link, err := u.proposalLinkRepository.FindByProposal(ctx, update.Handle)
+	if errors.Is(err, port.ErrProposalLinkNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find proposal link: %w", err)
+	}
+
+	conversationService, err := u.resolveConversation(link.ConversationURI)
+	if err != nil {
+		return err
+	}
+
+	if err := conversationService.Reply(buildProposalStatusMessage(update), false); err != nil {
+		return fmt.Errorf("failed to reply status update: %w", err)
+	}
+
+	return nil
+}
+
+func (u *ProposalStatusNotifyUsecase) resolveConversation(uri *data.URI) (port.ConversationService, error) {
+	for _, resolver := range u.conversationResolvers {
+		if resolver.Match(uri) {
+			conversationService, err := resolver.Resolve(uri)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve conversation: %w", err)
+			}
+			return conversationService, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported conversation: %s", uri)
+}
+
+func buildProposalStatusMessage(update ProposalStatusUpdate) string {
+	var b strings.Builder
+
+	switch update.Status {
+	case ProposalStatusMerged:
+		b.WriteString(fmt.Sprintf("The proposal has been merged: %s", update.ProposalURI))
+		if len(update.DocumentURIs) > 0 {
+			b.WriteString("\nDocuments:")
+			for _, uri := range update.DocumentURIs {
+				b.WriteString(fmt.Sprintf("\n- %s", uri))
+			}
+		}
+	case ProposalStatusClosed:
+		b.WriteString(fmt.Sprintf("The proposal has been closed without merging: %s", update.ProposalURI))
+	case ProposalStatusApproved:
+		b.WriteString(fmt.Sprintf("%s approved the proposal: %s", update.Actor, update.ProposalURI))
+	case ProposalStatusChangesRequested:
+		b.WriteString(fmt.Sprintf("%s requested changes to the proposal: %s", update.Actor, update.ProposalURI))
+	default:
+		b.WriteString(fmt.Sprintf("%s reviewed the proposal: %s", update.Actor, update.ProposalURI))
+	}
+
+	if update.Comment != "" {
+		b.WriteString(fmt.Sprintf("\n> %s", strings.ReplaceAll(update.Comment, "\n", "\n> ")))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProposalLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockProposalLinkRepository) Save(ctx context.Context, link port.ProposalLink) error {
+	args := m.Called(ctx, link)
+	return args.Error(0)
+}
+
+func (m *MockProposalLinkRepository) FindByProposal(ctx context.Context, handle domain.ProposalHandle) (port.ProposalLink, error) {
+	args := m.Called(ctx, handle)
+	return args.Get(0).(port.ProposalLink), args.Error(1)
+}
+
+func (m *MockProposalLinkRepository) FindByConversation(ctx context.Context, conversationURI *data.URI) (port.ProposalLink, error) {
+	args := m.Called(ctx, conversationURI)
+	return args.Get(0).(port.ProposalLink), args.Error(1)
+}
+
+type MockConversationResolver struct {
+	mock.Mock
+}
+
+func (m *MockConversationResolver) Match(uri *data.URI) bool {
+	args := m.Called(uri)
+	return args.Bool(0)
+}
+
+func (m *MockConversationResolver) Resolve(uri *data.URI) (port.ConversationService, error) {
+	args := m.Called(uri)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(port.ConversationService), args.Error(1)
+}
+
+func TestProposalStatusNotifyUsecase_Execute(t *testing.T) {
+	handle := domain.NewProposalHandle("github-pull-request", "12")
+	conversationURI := data.NewURIUnsafe("https://app.slack.com/client/T123/C456/1234567890.123456")
+	proposalURI := data.NewURIUnsafe("https://github.com/owner/repo/pull/12")
+
+	tests := []struct {
+		name          string
+		update        ProposalStatusUpdate
+		setupMocks    func(*MockProposalLinkRepository, *MockConversationResolver, *MockConversationService)
+		expectedError bool
+	}{
+		{
+			name: "正常系：マージされたらドキュメントのリンクを通知する",
+			update: ProposalStatusUpdate{
+				Handle:       handle,
+				Status:       ProposalStatusMerged,
+				ProposalURI:  proposalURI,
+				DocumentURIs: []*data.URI{data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/api.md")},
+			},
+			setupMocks: func(linkRepository *MockProposalLinkRepository, resolver *MockConversationResolver, conversationService *MockConversationService) {
+				linkRepository.On("FindByProposal", mock.Anything, handle).Return(port.ProposalLink{ProposalHandle: handle, ConversationURI: conversationURI}, nil)
+				resolver.On("Match", conversationURI).Return(true)
+				resolver.On("Resolve", conversationURI).Return(conversationService, nil)
+				conversationService.On("Reply", "The proposal has been merged: https://github.com/owner/repo/pull/12\nDocuments:\n- https://github.com/owner/repo/blob/abc123/docs/api.md", false).Return(nil)
+			},
+		},
+		{
+			name: "正常系：レビューのコメントを引用して通知する",
+			update: ProposalStatusUpdate{
+				Handle:      handle,
+				Status:      ProposalStatusChangesRequested,
+				ProposalURI: proposalURI,
+				Actor:       "alice",
+				Comment:     "用語を統一してください",
+			},
+			setupMocks: func(linkRepository *MockProposalLinkRepository, resolver *MockConversationResolver, conversationService *MockConversationService) {
+				linkRepository.On("FindByProposal", mock.Anything, handle).Return(port.ProposalLink{ProposalHandle: handle, ConversationURI: conversationURI}, nil)
+				resolver.On("Match", conversationURI).Return(true)
+				resolver.On("Resolve", conversationURI).Return(conversationService, nil)
+				conversationService.On("Reply", "alice requested changes to the proposal: https://github.com/owner/repo/pull/12\n> 用語を統一してください", false).Return(nil)
+			},
+		},
+		{
+			name:   "正常系：会話から生成されていない提案は通知しない",
+			update: ProposalStatusUpdate{Handle: handle, Status: ProposalStatusClosed, ProposalURI: proposalURI},
+			setupMocks: func(linkRepository *MockProposalLinkRepository, resolver *MockConversationResolver, conversationService *MockConversationService) {
+				linkRepository.On("FindByProposal", mock.Anything, handle).Return(port.ProposalLink{}, port.ErrProposalLinkNotFound)
+			},
+		},
+		{
+			name:   "エラー系：紐付けの取得に失敗する",
+			update: ProposalStatusUpdate{Handle: handle, Status: ProposalStatusClosed, ProposalURI: proposalURI},
+			setupMocks: func(linkRepository *MockProposalLinkRepository, resolver *MockConversationResolver, conversationService *MockConversationService) {
+				linkRepository.On("FindByProposal", mock.Anything, handle).Return(port.ProposalLink{}, errors.New("rate limited"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linkRepository := new(MockProposalLinkRepository)
+			resolver := new(MockConversationResolver)
+			conversationService := new(MockConversationService)
+
+			tt.setupMocks(linkRepository, resolver, conversationService)
+
+			usecase := NewProposalStatusNotifyUsecase(linkRepository, []port.ConversationResolver{resolver})
+			err := usecase.Execute(context.Background(), tt.update)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			linkRepository.AssertExpectations(t)
+			resolver.AssertExpectations(t)
+			conversationService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+)
+
+// ProposalFileStatus is how a file is changed by a proposal
+type ProposalFileStatus string
+
+const (
+	ProposalFileAdded    ProposalFileStatus = "added"
+	ProposalFileModified ProposalFileStatus = "modified"
+	ProposalFileRenamed  ProposalFileStatus = "renamed"
+	ProposalFileDeleted  ProposalFileStatus = "deleted"
+)
+
+// ProposalFileSummary is a file changed by a proposal with the number of changed lines
+type ProposalFileSummary struct {
+	Path string
+	// OldPath is set if the file is renamed
+	OldPath   string
+	Status    ProposalFileStatus
+	Additions int
+	Deletions int
+}
+
+// ProposalSummary is an overview of a proposal to show in chats
+type ProposalSummary struct {
+	Title string
+	Files []ProposalFileSummary
+	// Sources are the sources of the changed documents without duplicates
+	Sources []*data.URI
+}
+
+// ProposalSummaryUsecase は、チャットに表示するための提案の概要を作成するユースケースです。
+type ProposalSummaryUsecase struct {
+	proposalRepository domain.ProposalRepository
+	fileRepository     data.FileRepository
+}
+
+// NewProposalSummaryUsecase creates the usecase. fileRepository must read the head branch of the proposal
+func NewProposalSummaryUsecase(proposalRepository domain.ProposalRepository, fileRepository data.FileRepository) *ProposalSummaryUsecase {
+	return &ProposalSummaryUsecase{
+		proposalRepository: proposalRepository,
+		fileRepository:     fileRepository,
+	}
+}
+
+func (u *ProposalSummaryUsecase) Execute(ctx context.Context, proposalHandle domain.ProposalHandle) (ProposalSummary, error) {
+	proposal, err := u.proposalRepository.GetProposal(proposalHandle)
+	if err != nil {
+		return ProposalSummary{}, fmt.Errorf("failed to retrieve proposal: %w", err)
+	}
+
+	summary := ProposalSummary{Title: proposal.Title}
+	seenSources := make(map[string]bool)
+	for _, diff := range proposal.Diffs {
+		if diff.NewName == "" {
+			continue
+		}
+		fileSummary := ProposalFileSummary{Path: diff.NewName, Status: ProposalFileModified}
+		fileSummary.Additions, fileSummary.Deletions = countChangedLines(diff.Body)
+		switch {
+		case diff.IsNewFile:
+			fileSummary.Status = ProposalFileAdded
+		case diff.IsDeletedFile:
+			// 削除されたファイルは本文を読めないのでソースは集めない
+			fileSummary.Status = ProposalFileDeleted
+			summary.Files = append(summary.Files, fileSummary)
+			continue
+		case diff.OldName != diff.NewName:
+			fileSummary.Status = ProposalFileRenamed
+			fileSummary.OldPath = diff.OldName
+		}
+
+		file, err := u.fileRepository.Get(ctx, diff.NewName)
+		switch {
+		case errors.Is(err, data.ErrFileNotFound):
+			// 削除されたファイル
+			fileSummary.Status = ProposalFileDeleted
+		case errors.Is(err, data.ErrInvalidFrontmatter):
+			// ソースが読めなくても概要は表示する
+		case err != nil:
+			return ProposalSummary{}, fmt.Errorf("failed to get file %s: %w", diff.NewName, err)
+		default:
+			for _, uri := range file.SourceURIs {
+				if !seenSources[uri.Value()] {
+					seenSources[uri.Value()] = true
+					summary.Sources = append(summary.Sources, uri)
+				}
+			}
+		}
+
+		summary.Files = append(summary.Files, fileSummary)
+	}
+
+	return summary, nil
+}
+
+// countChangedLines counts the added and deleted lines of a unified diff body
+func countChangedLines(body string) (additions, deletions int) {
+	for _, line := range strings.Split(body, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+)
+
+func TestProposalSummaryUsecase_Execute(t *testing.T) {
+	handle := domain.NewProposalHandle("github", "1")
+	source1 := data.NewURIUnsafe("https://app.slack.com/client/T1/C1/1.0")
+	source2 := data.NewURIUnsafe("https://github.com/org/repo/pull/2")
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*MockProposalRepository, *MockFileRepository)
+		want        ProposalSummary
+		expectError bool
+	}{
+		{
+			name: "ファイルごとの変更行数とソースをまとめる",
+			setupMocks: func(proposalRepository *MockProposalRepository, fileRepository *MockFileRepository) {
+				proposalRepository.On("GetProposal", handle).Return(domain.NewProposal(handle, domain.Diffs{
+					domain.NewCreateDiff("docs/new.md", "@@ -0,0 +1,2 @@\n+# New\n+body\n"),
+					domain.NewUpdateDiff("docs/a.md", "docs/a.md", "@@ -1,2 +1,2 @@\n-old\n+new\n context\n"),
+					domain.NewUpdateDiff("docs/old.md", "docs/renamed.md", ""),
+					domain.NewUpdateDiff("docs/deleted.md", "docs/deleted.md", "@@ -1 +0,0 @@\n-gone\n"),
+					domain.NewDeleteDiff("docs/removed.md", "@@ -1,2 +0,0 @@\n-a\n-b\n"),
+				}, domain.NewProposalContent("Add docs", "body"), nil), nil)
+				fileRepository.On("Get", mock.Anything, "docs/new.md").Return(&data.File{Path: "docs/new.md", SourceURIs: []*data.URI{source1}}, nil)
+				fileRepository.On("Get", mock.Anything, "docs/a.md").Return(&data.File{Path: "docs/a.md", SourceURIs: []*data.URI{source1, source2}}, nil)
+				fileRepository.On("Get", mock.Anything, "docs/renamed.md").Return(&data.File{Path: "docs/renamed.md"}, nil)
+				fileRepository.On("Get", mock.Anything, "docs/deleted.md").Return((*data.File)(nil), data.ErrFileNotFound)
+			},
+			want: ProposalSummary{
+				Title: "Add docs",
+				Files: []ProposalFileSummary{
+					{Path: "docs/new.md", Status: ProposalFileAdded, Additions: 2},
+					{Path: "docs/a.md", Status: ProposalFileModified, Additions: 1, Deletions: 1},
+					{Path: "docs/renamed.md", OldPath: "docs/old.md", Status: ProposalFileRenamed},
+					{Path: "docs/deleted.md", Status: ProposalFileDeleted, Deletions: 1},
+					{Path: "docs/removed.md", Status: ProposalFileDeleted, Deletions: 2},
+				},
+				Sources: []*data.URI{source1, source2},
+			},
+		},
+		{
+			name: "提案を取得できない場合はエラー",
+			setupMocks: func(proposalRepository *MockProposalRepository, fileRepository *MockFileRepository) {
+				proposalRepository.On("GetProposal", handle).Return(domain.Proposal{}, errors.New("not found"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proposalRepository := new(MockProposalRepository)
+			fileRepository := new(MockFileRepository)
+			tt.setupMocks(proposalRepository, fileRepository)
+
+			got, err := NewProposalSummaryUsecase(proposalRepository, fileRepository).Execute(context.Background(), handle)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			proposalRepository.AssertExpectations(t)
+			fileRepository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+)
+
+var (
+	reResolved = regexp.MustCompile(`(?s)<resolved>\n?(.*)</resolved>`)
+	reSummary  = regexp.MustCompile(`(?s)<summary>(.*?)</summary>`)
+)
+
+// ProposalSyncBaseUsecase は、作成後に移動したベースブランチの変更を提案に取り込むユースケースです。
+// Markdownのドキュメントの競合はエージェントが解消し、結果を会話に報告します。
+type ProposalSyncBaseUsecase struct {
+	chatModel           domain.ChatModel
+	conversationService port.ConversationService
+	synchronizer        port.ProposalBaseSynchronizer
+}
+
+func NewProposalSyncBaseUsecase(
+	chatModel domain.ChatModel,
+	conversationService port.ConversationService,
+	synchronizer port.ProposalBaseSynchronizer,
+) *ProposalSyncBaseUsecase {
+	return &ProposalSyncBaseUsecase{
+		chatModel:           chatModel,
+		conversationService: conversationService,
+		synchronizer:        synchronizer,
+	}
+}
+
+// Execute はベースブランチを提案にマージします。
+// エージェントが解消できない競合がある場合は、提案を変更せずにそのファイルを報告します。
+func (u *ProposalSyncBaseUsecase) Execute(ctx context.Context, proposalHandle domain.ProposalHandle) error {
+	result, err := u.synchronizer.MergeBase(ctx, proposalHandle)
+	if err != nil {
+		return fmt.Errorf("failed to merge base: %w", err)
+	}
+
+	switch result.Status {
+	case port.BaseSyncUpToDate:
+		return u.reply("The proposal is already up to date with the base branch.")
+	case port.BaseSyncMerged:
+		return u.reply("Merged the latest base branch into the proposal.")
+	}
+
+	var unresolvable []string
+	for _, conflict := range result.Conflicts {
+		if conflict.Deleted || !isMarkdown(conflict.Path) {
+			unresolvable = append(unresolvable, conflict.Path)
+		}
+	}
+	if len(unresolvable) > 0 {
+		return u.reply("Could not merge the latest base branch. Resolve the conflicts in the following files manually:\n- " + strings.Join(unresolvable, "\n- "))
+	}
+
+	resolutions := make(map[string]string, len(result.Conflicts))
+	var report strings.Builder
+	report.WriteString("Merged the latest base branch into the proposal and resolved the conflicts:")
+	for _, conflict := range result.Conflicts {
+		content, summary, err := u.resolve(ctx, conflict)
+		if err != nil {
+			return err
+		}
+		resolutions[conflict.Path] = content
+		fmt.Fprintf(&report, "\n- %s: %s", conflict.Path, summary)
+	}
+
+	err = u.synchronizer.MergeBaseWithResolutions(ctx, proposalHandle, resolutions)
+	if errors.Is(err, data.ErrSecretDetected) {
+		return u.reply(fmt.Sprintf("Could not merge the latest base branch because the resolved content contains secrets (%s). Resolve the conflicts manually.", err))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to merge base with resolutions: %w", err)
+	}
+	return u.reply(report.String())
+}
+
+// resolve はエージェントに競合を解消させ、解消後の内容とその要約を返します。
+func (u *ProposalSyncBaseUsecase) resolve(ctx context.Context, conflict port.FileConflict) (string, string, error) {
+	session := u.chatModel.StartChat(`You are a technical writer who resolves merge conflicts in Markdown documents.
+You are given three versions of a document: <base> is the common ancestor, <ours> is the proposal that adds or changes documentation, and <theirs> is the latest version in the base branch.
+Write a version that keeps the changes of both sides. When both sides changed the same part, prefer the newer facts in <theirs> and keep the intent of <ours>.
+If the document has a YAML frontmatter, keep the union of the sources and owners of both sides.
+Some values may be replaced with placeholders such as [REDACTED:password#1]. Copy them exactly as they are.
+Respond with the whole resolved document wrapped in <resolved></resolved>, followed by a one-sentence summary of how you resolved the conflict wrapped in <summary></summary>, in the same language as the document.`)
+
+	message := fmt.Sprintf("<path>%s</path>\n<base>\n%s\n</base>\n<ours>\n%s\n</ours>\n<theirs>\n%s\n</theirs>", conflict.Path, conflict.Base, conflict.Ours, conflict.Theirs)
+	response, err := session.SendMessage(ctx, message)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve conflict in %s: %w", conflict.Path, err)
+	}
+
+	matches := reResolved.FindStringSubmatch(response)
+	if matches == nil {
+		return "", "", fmt.Errorf("failed to parse resolved content of %s: %s", conflict.Path, response)
+	}
+	content := matches[1]
+
+	summary := "resolved"
+	if matches := reSummary.FindStringSubmatch(response); matches != nil {
+		summary = strings.TrimSpace(matches[1])
+	}
+	return content, summary, nil
+}
+
+func (u *ProposalSyncBaseUsecase) reply(message string) error {
+	if err := u.conversationService.Reply(message, false); err != nil {
+		return fmt.Errorf("failed to reply: %w", err)
+	}
+	return nil
+}
+
+func isMarkdown(path string) bool {
+	return strings.HasSuffix(path, ".md") || strings.HasSuffix(path, ".markdown")
+}
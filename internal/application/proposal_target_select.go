@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+)
+
+var reTarget = regexp.MustCompile(`(?s)<target>(.*?)</target>`)
+
+// ProposalTarget は提案の作成先の候補です。
+type ProposalTarget struct {
+	Name        string
+	Description string
+}
+
+// ProposalTargetSelectUsecase は、会話の内容から提案の作成先をモデルに選ばせるユースケースです。
+type ProposalTargetSelectUsecase struct {
+	chatModel           domain.ChatModel
+	conversationService port.ConversationService
+}
+
+func NewProposalTargetSelectUsecase(chatModel domain.ChatModel, conversationService port.ConversationService) *ProposalTargetSelectUsecase {
+	return &ProposalTargetSelectUsecase{
+		chatModel:           chatModel,
+		conversationService: conversationService,
+	}
+}
+
+// Execute は候補の中から提案の作成先を選びます。モデルが候補にない名前を返した場合はエラーを返します。
+func (u *ProposalTargetSelectUsecase) Execute(ctx context.Context, targets []ProposalTarget) (ProposalTarget, error) {
+	if len(targets) == 0 {
+		return ProposalTarget{}, fmt.Errorf("no targets to select")
+	}
+	if len(targets) == 1 {
+		return targets[0], nil
+	}
+
+	history, err := u.conversationService.GetHistory()
+	if err != nil {
+		return ProposalTarget{}, fmt.Errorf("failed to get conversation history: %w", err)
+	}
+
+	var candidates strings.Builder
+	for _, target := range targets {
+		candidates.WriteString(fmt.Sprintf("<target name=%q>%s</target>\n", target.Name, target.Description))
+	}
+
+	session := u.chatModel.StartChat(`You are a technical writer who files documents into the right repository.
+Given a conversation and candidate repositories, choose the repository where the documents about the conversation belong.
+Respond only with the name of the repository wrapped in <target></target>.`)
+
+	message := fmt.Sprintf("<candidates>\n%s</candidates>\n%s", candidates.String(), history.ToXML())
+	response, err := session.SendMessage(ctx, message)
+	if err != nil {
+		return ProposalTarget{}, fmt.Errorf("failed to select target: %w", err)
+	}
+
+	matches := reTarget.FindStringSubmatch(response)
+	if matches == nil {
+		return ProposalTarget{}, fmt.Errorf("failed to parse selected target: %s", response)
+	}
+	name := strings.TrimSpace(matches[1])
+	for _, target := range targets {
+		if target.Name == name {
+			return target, nil
+		}
+	}
+	return ProposalTarget{}, fmt.Errorf("unknown target selected: %s", name)
+}
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProposalTargetSelectUsecase_Execute(t *testing.T) {
+	targets := []ProposalTarget{
+		{Name: "platform", Description: "Infrastructure and deployment"},
+		{Name: "security", Description: "Security policies"},
+	}
+
+	tests := []struct {
+		name          string
+		modelResponse string
+		expected      string
+		wantErr       bool
+	}{
+		{
+			name:          "モデルが選んだ候補を返す",
+			modelResponse: "<target>security</target>",
+			expected:      "security",
+		},
+		{
+			name:          "候補にない名前はエラーになる",
+			modelResponse: "<target>product</target>",
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatModel := new(MockChatModel)
+			chatSession := new(MockChatSession)
+			conversationService := new(MockConversationService)
+
+			conversationService.On("GetHistory").Return(port.ConversationHistory{
+				URI:      data.NewURIUnsafe("https://app.slack.com/client/T001/C001/1234567890.123456"),
+				Messages: []port.ConversationMessage{{Author: "U001", Content: "脆弱性の報告手順を決めました"}},
+			}, nil)
+			chatModel.On("StartChat", mock.Anything).Return(chatSession)
+			chatSession.On("SendMessage", mock.Anything, mock.Anything).Return(tt.modelResponse, nil)
+
+			usecase := NewProposalTargetSelectUsecase(chatModel, conversationService)
+			target, err := usecase.Execute(context.Background(), targets)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, target.Name)
+		})
+	}
+}
+
+func TestProposalTargetSelectUsecase_Execute_SingleTarget(t *testing.T) {
+	// 候補が1つならモデルを呼ばない
+	usecase := NewProposalTargetSelectUsecase(new(MockChatModel), new(MockConversationService))
+	target, err := usecase.Execute(context.Background(), []ProposalTarget{{Name: "docs"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "docs", target.Name)
+}
+
+func TestMultiRAGCorpus_Query(t *testing.T) {
+	corpus1 := new(MockRAGCorpus)
+	corpus2 := new(MockRAGCorpus)
+	// Vertex AI RAG Engine のように距離をスコアにするコーパスと、類似度をスコアにするコーパス
+	corpus1.On("Query", mock.Anything, "deploy", int32(2), 0.7).Return([]port.RAGDocument{
+		{Source: "a", Score: 0.1},
+		{Source: "b", Score: 0.6},
+	}, nil)
+	corpus2.On("Query", mock.Anything, "deploy", int32(2), 0.7).Return([]port.RAGDocument{
+		{Source: "c", Score: 0.7},
+		{Source: "d", Score: 0.2},
+	}, nil)
+
+	docs, err := NewMultiRAGCorpus(corpus1, corpus2).Query(context.Background(), "deploy", 2, 0.7)
+
+	// スコアを比べずに、それぞれのコーパスでの上位を残す
+	assert.NoError(t, err)
+	assert.Equal(t, []port.RAGDocument{{Source: "a", Score: 0.5}, {Source: "c", Score: 0.5}}, docs)
+}
@@ -2,7 +2,11 @@ package application
 
 import (
 	"context"
+	"docgent/internal/application/chunking"
 	"docgent/internal/application/port"
+	"errors"
+	"path"
+	"sort"
 	"strings"
 )
 
@@ -26,13 +30,14 @@ func (u *RagFileSyncUsecase) Execute(newFiles, modifiedFiles, deletedFiles []str
 		return err
 	}
 
-	ragFileMap := make(map[string]int64)
+	// A Markdown file is uploaded as a RAG file per section, so a path can have several RAG files
+	ragFileMap := make(map[string][]int64)
 	for _, ragFile := range ragFiles {
-		path, err := u.fileQueryService.GetFilePath(ragFile.URI)
+		path, err := u.fileQueryService.GetFilePath(ragFile.URI.WithFragment(""))
 		if err != nil {
 			return err
 		}
-		ragFileMap[path] = ragFile.ID
+		ragFileMap[path] = append(ragFileMap[path], ragFile.ID)
 	}
 
 	for _, filePath := range newFiles {
@@ -43,48 +48,19 @@ func (u *RagFileSyncUsecase) Execute(newFiles, modifiedFiles, deletedFiles []str
 		}
 
 		// If the file does not exist, upload it
-		file, err := u.fileQueryService.FindFile(ctx, filePath)
-		if err != nil {
-			return err
-		}
-
-		// Get the URI (GitHub permalink) for the file
-		uri, err := u.fileQueryService.GetURI(ctx, filePath)
-		if err != nil {
-			return err
-		}
-
-		reader := strings.NewReader(file.Content)
-		// Use the URI as the displayName instead of the file path
-		err = u.ragCorpus.UploadFile(ctx, reader, uri)
-		if err != nil {
+		if err := u.uploadFile(ctx, filePath); err != nil {
 			return err
 		}
 	}
 
 	for _, filePath := range modifiedFiles {
-		file, err := u.fileQueryService.FindFile(ctx, filePath)
-		if err != nil {
-			return err
-		}
-
-		// Get the URI (GitHub permalink) for the file
-		uri, err := u.fileQueryService.GetURI(ctx, filePath)
-		if err != nil {
-			return err
-		}
-
-		reader := strings.NewReader(file.Content)
-		// Use the URI as the displayName instead of the file path
-		err = u.ragCorpus.UploadFile(ctx, reader, uri)
-		if err != nil {
+		if err := u.uploadFile(ctx, filePath); err != nil {
 			return err
 		}
 
 		// If the old file exists, delete it
-		_, exists := ragFileMap[filePath]
-		if exists {
-			err = u.ragCorpus.DeleteFile(ctx, ragFileMap[filePath])
+		for _, fileID := range ragFileMap[filePath] {
+			err = u.ragCorpus.DeleteFile(ctx, fileID)
 			if err != nil {
 				return err
 			}
@@ -93,9 +69,8 @@ func (u *RagFileSyncUsecase) Execute(newFiles, modifiedFiles, deletedFiles []str
 
 	for _, filePath := range deletedFiles {
 		// If the file exists, delete it
-		_, exists := ragFileMap[filePath]
-		if exists {
-			err := u.ragCorpus.DeleteFile(ctx, ragFileMap[filePath])
+		for _, fileID := range ragFileMap[filePath] {
+			err := u.ragCorpus.DeleteFile(ctx, fileID)
 			if err != nil {
 				return err
 			}
@@ -104,3 +79,73 @@ func (u *RagFileSyncUsecase) Execute(newFiles, modifiedFiles, deletedFiles []str
 
 	return nil
 }
+
+// uploadFile uploads the file at the latest commit.
+// A Markdown file is split along its headings, and each chunk is uploaded with the URI of its section (file#section),
+// so that chunks keep their headings and the documents retrieved link to the section.
+// The corpus returns the ID of each upload, so the corpus is listed only once per sync, and the sections already uploaded
+// are deleted by their IDs if a later section fails, so that a retry does not leave a half of the document twice.
+func (u *RagFileSyncUsecase) uploadFile(ctx context.Context, filePath string) error {
+	file, err := u.fileQueryService.FindFile(ctx, filePath)
+	if err != nil {
+		return err
+	}
+
+	// Get the URI (GitHub permalink) for the file
+	uri, err := u.fileQueryService.GetURI(ctx, filePath)
+	if err != nil {
+		return err
+	}
+
+	if !isMarkdownFile(filePath) {
+		// Use the URI as the displayName instead of the file path
+		_, err := u.ragCorpus.UploadFile(ctx, strings.NewReader(file.Content), uri)
+		return err
+	}
+
+	document := chunking.SplitMarkdown(file.Content, chunking.DefaultSize)
+	var uploadedIDs []int64
+	for _, chunk := range document.Chunks {
+		ragFile, err := u.ragCorpus.UploadFile(ctx, strings.NewReader(chunk.Content), uri.WithFragment(chunk.Anchor),
+			port.WithRagFileDescription(ragFileDescription(document, chunk)))
+		if err != nil {
+			for _, fileID := range uploadedIDs {
+				if deleteErr := u.ragCorpus.DeleteFile(ctx, fileID); deleteErr != nil {
+					err = errors.Join(err, deleteErr)
+				}
+			}
+			return err
+		}
+		uploadedIDs = append(uploadedIDs, ragFile.ID)
+	}
+	return nil
+}
+
+// ragFileDescription describes the section of the chunk by the heading path from the title,
+// followed by the other fields of the frontmatter such as the owner or the status of the document.
+func ragFileDescription(document chunking.MarkdownDocument, chunk chunking.MarkdownChunk) string {
+	description := strings.TrimPrefix(strings.Join(append([]string{document.Title}, chunk.HeadingPath...), " > "), " > ")
+
+	var fields []string
+	for key, value := range document.Metadata {
+		// Flow lists such as "sources: []" are not scalars, so they are left out like the other lists
+		if key == "title" || value == "" || strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") {
+			continue
+		}
+		fields = append(fields, key+": "+value)
+	}
+	if len(fields) == 0 {
+		return description
+	}
+	sort.Strings(fields)
+	return description + " (" + strings.Join(fields, ", ") + ")"
+}
+
+func isMarkdownFile(filePath string) bool {
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".md", ".markdown", ".mdx":
+		return true
+	default:
+		return false
+	}
+}
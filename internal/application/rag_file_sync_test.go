@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"docgent/internal/application/chunking"
 	"docgent/internal/application/port"
 	"docgent/internal/domain/data"
 
@@ -32,7 +33,7 @@ func TestRagFileSyncUsecase_Execute(t *testing.T) {
 					Content: "新規ファイルの内容",
 				}, nil)
 				fileQueryService.On("GetURI", mock.Anything, "docs/new.md").Return("https://github.com/owner/repo/blob/abc123/docs/new.md", nil)
-				ragCorpus.On("UploadFile", mock.Anything, mock.Anything, data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/new.md"), mock.Anything).Return(nil)
+				ragCorpus.On("UploadFile", mock.Anything, mock.Anything, data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/new.md"), mock.Anything).Return(port.RAGFile{URI: data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/new.md")}, nil)
 			},
 			expectedError: nil,
 		},
@@ -51,7 +52,7 @@ func TestRagFileSyncUsecase_Execute(t *testing.T) {
 				}, nil)
 				fileQueryService.On("GetFilePath", mock.Anything).Return("docs/modified.md", nil)
 				fileQueryService.On("GetURI", mock.Anything, "docs/modified.md").Return("https://github.com/owner/repo/blob/abc123/docs/modified.md", nil)
-				ragCorpus.On("UploadFile", mock.Anything, mock.Anything, data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/modified.md"), mock.Anything).Return(nil)
+				ragCorpus.On("UploadFile", mock.Anything, mock.Anything, data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/modified.md"), mock.Anything).Return(port.RAGFile{URI: data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/modified.md")}, nil)
 				ragCorpus.On("DeleteFile", mock.Anything, int64(1)).Return(nil)
 			},
 			expectedError: nil,
@@ -70,6 +71,56 @@ func TestRagFileSyncUsecase_Execute(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name:          "正常系：Markdownは見出しごとにセクションのURIでアップロード",
+			newFiles:      []string{"docs/deploy.md"},
+			modifiedFiles: []string{},
+			deletedFiles:  []string{},
+			setupMocks: func(ragCorpus *MockRAGCorpus, fileQueryService *MockFileQueryService) {
+				// セクションごとにアップロードしてもコーパスの一覧は同期ごとに1回だけ取得する
+				ragCorpus.On("ListFiles", mock.Anything).Return([]port.RAGFile{}, nil).Once()
+				fileQueryService.On("FindFile", mock.Anything, "docs/deploy.md").Return(data.File{
+					Path:    "docs/deploy.md",
+					Content: "# Deploy\n\n## Build\n\nmake build\n\n## Release\n\nmake release\n",
+				}, nil)
+				fileQueryService.On("GetURI", mock.Anything, "docs/deploy.md").Return("https://github.com/owner/repo/blob/abc123/docs/deploy.md", nil)
+				ragCorpus.On("UploadFile", mock.Anything, mock.Anything, data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/deploy.md#build"), mock.Anything).Return(port.RAGFile{URI: data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/deploy.md#build")}, nil).Once()
+				ragCorpus.On("UploadFile", mock.Anything, mock.Anything, data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/deploy.md#release"), mock.Anything).Return(port.RAGFile{URI: data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/deploy.md#release")}, nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "正常系：Markdown以外はそのままアップロード",
+			newFiles:      []string{"docs/openapi.yaml"},
+			modifiedFiles: []string{},
+			deletedFiles:  []string{},
+			setupMocks: func(ragCorpus *MockRAGCorpus, fileQueryService *MockFileQueryService) {
+				ragCorpus.On("ListFiles", mock.Anything).Return([]port.RAGFile{}, nil)
+				fileQueryService.On("FindFile", mock.Anything, "docs/openapi.yaml").Return(data.File{
+					Path:    "docs/openapi.yaml",
+					Content: "# comment\nopenapi: 3.0.0\n",
+				}, nil)
+				fileQueryService.On("GetURI", mock.Anything, "docs/openapi.yaml").Return("https://github.com/owner/repo/blob/abc123/docs/openapi.yaml", nil)
+				ragCorpus.On("UploadFile", mock.Anything, mock.Anything, data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/openapi.yaml"), mock.Anything).Return(port.RAGFile{URI: data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/openapi.yaml")}, nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "正常系：セクションごとのファイルをすべて削除",
+			newFiles:      []string{},
+			modifiedFiles: []string{},
+			deletedFiles:  []string{"docs/deploy.md"},
+			setupMocks: func(ragCorpus *MockRAGCorpus, fileQueryService *MockFileQueryService) {
+				ragCorpus.On("ListFiles", mock.Anything).Return([]port.RAGFile{
+					{ID: 1, URI: data.NewURIUnsafe("https://github.com/owner/repo/blob/xyz789/docs/deploy.md#build")},
+					{ID: 2, URI: data.NewURIUnsafe("https://github.com/owner/repo/blob/xyz789/docs/deploy.md#release")},
+				}, nil)
+				fileQueryService.On("GetFilePath", data.NewURIUnsafe("https://github.com/owner/repo/blob/xyz789/docs/deploy.md")).Return("docs/deploy.md", nil)
+				ragCorpus.On("DeleteFile", mock.Anything, int64(1)).Return(nil)
+				ragCorpus.On("DeleteFile", mock.Anything, int64(2)).Return(nil)
+			},
+			expectedError: nil,
+		},
 		{
 			name:          "エラー系：ListFilesに失敗",
 			newFiles:      []string{"docs/new.md"},
@@ -118,7 +169,25 @@ func TestRagFileSyncUsecase_Execute(t *testing.T) {
 					Content: "新規ファイルの内容",
 				}, nil)
 				fileQueryService.On("GetURI", mock.Anything, "docs/new.md").Return("https://github.com/owner/repo/blob/abc123/docs/new.md", nil)
-				ragCorpus.On("UploadFile", mock.Anything, mock.Anything, data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/new.md"), mock.Anything).Return(errors.New("failed to upload file"))
+				ragCorpus.On("UploadFile", mock.Anything, mock.Anything, data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/new.md"), mock.Anything).Return(port.RAGFile{}, errors.New("failed to upload file"))
+			},
+			expectedError: errors.New("failed to upload file"),
+		},
+		{
+			name:          "エラー系：Markdownのセクションのアップロードに失敗したらアップロード済みのセクションを削除",
+			newFiles:      []string{"docs/deploy.md"},
+			modifiedFiles: []string{},
+			deletedFiles:  []string{},
+			setupMocks: func(ragCorpus *MockRAGCorpus, fileQueryService *MockFileQueryService) {
+				ragCorpus.On("ListFiles", mock.Anything).Return([]port.RAGFile{}, nil).Once()
+				fileQueryService.On("FindFile", mock.Anything, "docs/deploy.md").Return(data.File{
+					Path:    "docs/deploy.md",
+					Content: "# Deploy\n\n## Build\n\nmake build\n\n## Release\n\nmake release\n",
+				}, nil)
+				fileQueryService.On("GetURI", mock.Anything, "docs/deploy.md").Return("https://github.com/owner/repo/blob/abc123/docs/deploy.md", nil)
+				ragCorpus.On("UploadFile", mock.Anything, mock.Anything, data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/deploy.md#build"), mock.Anything).Return(port.RAGFile{ID: 10, URI: data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/deploy.md#build")}, nil).Once()
+				ragCorpus.On("UploadFile", mock.Anything, mock.Anything, data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/deploy.md#release"), mock.Anything).Return(port.RAGFile{}, errors.New("failed to upload file")).Once()
+				ragCorpus.On("DeleteFile", mock.Anything, int64(10)).Return(nil).Once()
 			},
 			expectedError: errors.New("failed to upload file"),
 		},
@@ -166,3 +235,38 @@ func TestRagFileSyncUsecase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestRagFileDescription(t *testing.T) {
+	chunk := chunking.MarkdownChunk{HeadingPath: []string{"Deploy", "Build"}}
+
+	tests := []struct {
+		name     string
+		document chunking.MarkdownDocument
+		want     string
+	}{
+		{
+			name:     "タイトルと見出しの階層",
+			document: chunking.MarkdownDocument{Title: "Deploy Guide"},
+			want:     "Deploy Guide > Deploy > Build",
+		},
+		{
+			name: "タイトル以外のフロントマターを付ける",
+			document: chunking.MarkdownDocument{
+				Title:    "Deploy Guide",
+				Metadata: map[string]string{"title": "Deploy Guide", "status": "draft", "owner": "alice", "sources": "[]"},
+			},
+			want: "Deploy Guide > Deploy > Build (owner: alice, status: draft)",
+		},
+		{
+			name:     "タイトルがなければ見出しの階層のみ",
+			document: chunking.MarkdownDocument{Metadata: map[string]string{"owner": "alice"}},
+			want:     "Deploy > Build (owner: alice)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ragFileDescription(tt.document, chunk))
+		})
+	}
+}
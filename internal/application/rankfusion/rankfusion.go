@@ -0,0 +1,56 @@
+// Package rankfusion merges rankings of RAG documents from searches whose scores cannot be compared,
+// such as vector and keyword search, or corpora of different backends.
+package rankfusion
+
+import (
+	"sort"
+
+	"docgent/internal/application/port"
+)
+
+// rrfK dampens the weight of the top ranks in reciprocal rank fusion. 60 is the value of the original paper
+const rrfK = 60
+
+// Fuse merges the rankings with reciprocal rank fusion. A document gets 1/(rrfK+rank) from each ranking it is in,
+// so documents ranked high by several searches come first without comparing their scores.
+// Each ranking must be ordered from the most relevant. Documents are matched by their source (the file or its section),
+// since different searches chunk files differently and their contents rarely match. Only the best rank of a source in each ranking counts, and the first document found is kept.
+// Score is the fused score normalized so that a document ranked first in all rankings gets 1.0.
+func Fuse(rankings ...[]port.RAGDocument) []port.RAGDocument {
+	type fusedDocument struct {
+		document port.RAGDocument
+		score    float64
+	}
+
+	fused := make(map[string]*fusedDocument)
+	var order []string
+	for _, ranking := range rankings {
+		ranked := make(map[string]bool, len(ranking))
+		for rank, document := range ranking {
+			key := document.Source
+			if ranked[key] {
+				continue
+			}
+			ranked[key] = true
+
+			f, ok := fused[key]
+			if !ok {
+				f = &fusedDocument{document: document}
+				fused[key] = f
+				order = append(order, key)
+			}
+			f.score += 1 / float64(rrfK+rank+1)
+		}
+	}
+
+	maxScore := float64(len(rankings)) / float64(rrfK+1)
+	documents := make([]port.RAGDocument, len(order))
+	for i, key := range order {
+		documents[i] = fused[key].document
+		documents[i].Score = fused[key].score / maxScore
+	}
+	sort.SliceStable(documents, func(i, j int) bool {
+		return documents[i].Score > documents[j].Score
+	})
+	return documents
+}
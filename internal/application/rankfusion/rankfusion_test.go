@@ -0,0 +1,43 @@
+package rankfusion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+)
+
+func TestFuse(t *testing.T) {
+	a := port.RAGDocument{Source: "a.md", Content: "A", Score: 0.9}
+	b := port.RAGDocument{Source: "b.md", Content: "B", Score: 0.8}
+	c := port.RAGDocument{Source: "c.md", Content: "C", Score: 12.3}
+
+	got := Fuse([]port.RAGDocument{a, b}, []port.RAGDocument{c, b})
+
+	// 両方の検索で上位のドキュメントが最初に来る
+	assert.Equal(t, []string{"b.md", "a.md", "c.md"}, []string{got[0].Source, got[1].Source, got[2].Source})
+	assert.InDelta(t, (1.0/62+1.0/62)/(2.0/61), got[0].Score, 1e-9)
+	assert.InDelta(t, (1.0/61)/(2.0/61), got[1].Score, 1e-9)
+	// 順位が同じならもとの順序を保つ
+	assert.Equal(t, got[1].Score, got[2].Score)
+}
+
+func TestFuse_Top(t *testing.T) {
+	a := port.RAGDocument{Source: "a.md", Content: "A"}
+
+	got := Fuse([]port.RAGDocument{a}, []port.RAGDocument{a})
+
+	assert.Equal(t, []port.RAGDocument{{Source: "a.md", Content: "A", Score: 1}}, got)
+}
+
+func TestFuse_SameSource(t *testing.T) {
+	vector := port.RAGDocument{Source: "a.md#deploy", Content: "Run make deploy.", Score: 0.9}
+	keyword := port.RAGDocument{Source: "a.md#deploy", Content: "# Deploy\n\nRun make deploy.", Score: 3.2}
+	other := port.RAGDocument{Source: "a.md#deploy", Content: "Deploy takes 5 minutes.", Score: 2.1}
+
+	got := Fuse([]port.RAGDocument{vector}, []port.RAGDocument{keyword, other})
+
+	// チャンクの区切りが違っても同じソースなら1つにまとめ、同じランキング内の重複は数えない
+	assert.Equal(t, []port.RAGDocument{{Source: "a.md#deploy", Content: "Run make deploy.", Score: 1}}, got)
+}
@@ -0,0 +1,50 @@
+package redaction
+
+import (
+	"context"
+	"sort"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+)
+
+// BaseSynchronizer is a port.ProposalBaseSynchronizer decorator that refuses to commit resolved contents
+// containing secrets. Secrets that are already in either side of a conflict are not refused.
+type BaseSynchronizer struct {
+	synchronizer port.ProposalBaseSynchronizer
+	redactor     *Redactor
+	// conflicts は MergeBase で返した競合をパスごとに保持する
+	conflicts map[string]port.FileConflict
+}
+
+func NewBaseSynchronizer(synchronizer port.ProposalBaseSynchronizer, redactor *Redactor) port.ProposalBaseSynchronizer {
+	return &BaseSynchronizer{synchronizer: synchronizer, redactor: redactor, conflicts: make(map[string]port.FileConflict)}
+}
+
+func (s *BaseSynchronizer) MergeBase(ctx context.Context, handle domain.ProposalHandle) (port.BaseSyncResult, error) {
+	result, err := s.synchronizer.MergeBase(ctx, handle)
+	if err != nil {
+		return result, err
+	}
+	for _, conflict := range result.Conflicts {
+		s.conflicts[conflict.Path] = conflict
+	}
+	return result, nil
+}
+
+func (s *BaseSynchronizer) MergeBaseWithResolutions(ctx context.Context, handle domain.ProposalHandle, resolutions map[string]string) error {
+	paths := make([]string, 0, len(resolutions))
+	for path := range resolutions {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		conflict := s.conflicts[path]
+		secrets := s.redactor.DetectNewSecrets(resolutions[path], conflict.Base, conflict.Ours, conflict.Theirs)
+		if err := secretsError(path, secrets); err != nil {
+			return err
+		}
+	}
+	return s.synchronizer.MergeBaseWithResolutions(ctx, handle, resolutions)
+}
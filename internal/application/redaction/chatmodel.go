@@ -0,0 +1,79 @@
+package redaction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"docgent/internal/domain"
+)
+
+// ChatModel is a domain.ChatModel decorator that redacts the system instruction and every message
+// before they are sent to the underlying model. Conversation history, sources and file contents
+// all reach the model through these messages.
+//
+// Each redacted value gets a numbered placeholder such as "[REDACTED:password#1]", and the placeholders
+// are restored in the responses. This way, the model can still quote file contents it has to diff against,
+// while values that must not be committed are refused by FileRepository.
+type ChatModel struct {
+	chatModel domain.ChatModel
+	redactor  *Redactor
+}
+
+func NewChatModel(chatModel domain.ChatModel, redactor *Redactor) domain.ChatModel {
+	return &ChatModel{chatModel: chatModel, redactor: redactor}
+}
+
+func (m *ChatModel) StartChat(systemInstruction string) domain.ChatSession {
+	session := &ChatSession{
+		redactor:  m.redactor,
+		originals: make(map[string]string),
+		ids:       make(map[string]string),
+	}
+	session.session = m.chatModel.StartChat(session.redact(systemInstruction))
+	return session
+}
+
+type ChatSession struct {
+	session  domain.ChatSession
+	redactor *Redactor
+	// originals は placeholder から元の値へのマップ
+	originals map[string]string
+	// ids は元の値から placeholder へのマップ。同じ値には同じ placeholder を使う
+	ids map[string]string
+}
+
+func (s *ChatSession) SendMessage(ctx context.Context, message string) (string, error) {
+	response, err := s.session.SendMessage(ctx, s.redact(message))
+	if err != nil {
+		return "", err
+	}
+	return s.restore(response), nil
+}
+
+func (s *ChatSession) GetHistory() ([]domain.Message, error) {
+	return s.session.GetHistory()
+}
+
+func (s *ChatSession) redact(text string) string {
+	return s.redactor.replace(text, func(f Finding, original string) string {
+		if placeholder, ok := s.ids[original]; ok {
+			return placeholder
+		}
+		placeholder := fmt.Sprintf("[REDACTED:%s#%d]", f.Kind, len(s.ids)+1)
+		s.ids[original] = placeholder
+		s.originals[placeholder] = original
+		return placeholder
+	})
+}
+
+func (s *ChatSession) restore(text string) string {
+	if len(s.originals) == 0 || !strings.Contains(text, "[REDACTED:") {
+		return text
+	}
+	pairs := make([]string, 0, len(s.originals)*2)
+	for placeholder, original := range s.originals {
+		pairs = append(pairs, placeholder, original)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
@@ -0,0 +1,229 @@
+package redaction
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Finding is a sensitive part of a text found by a Detector.
+type Finding struct {
+	// Kind is the kind of the sensitive information (e.g. "aws_access_key", "email")
+	Kind string
+	// Secret is true if the information is a credential that must never be committed,
+	// and false if it is personal information that only needs to be hidden from the model.
+	Secret bool
+	// Start and End are the byte offsets of the finding in the text
+	Start int
+	End   int
+}
+
+// Detector finds sensitive information in a text.
+type Detector interface {
+	Detect(text string) []Finding
+}
+
+/**
+ * PatternDetector
+ */
+
+// PatternDetector finds sensitive information matching a regular expression.
+// If the expression has a capturing group, only the first group is reported.
+type PatternDetector struct {
+	kind    string
+	secret  bool
+	pattern *regexp.Regexp
+	// ignore skips reported values that are not sensitive even though they match the pattern
+	ignore func(value string) bool
+}
+
+func NewPatternDetector(kind string, secret bool, pattern *regexp.Regexp) *PatternDetector {
+	return &PatternDetector{kind: kind, secret: secret, pattern: pattern}
+}
+
+func (d *PatternDetector) Detect(text string) []Finding {
+	var findings []Finding
+	for _, loc := range d.pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		if len(loc) >= 4 && loc[2] >= 0 {
+			start, end = loc[2], loc[3]
+		}
+		if d.ignore != nil && d.ignore(text[start:end]) {
+			continue
+		}
+		findings = append(findings, Finding{Kind: d.kind, Secret: d.secret, Start: start, End: end})
+	}
+	return findings
+}
+
+/**
+ * EntropyDetector
+ */
+
+var (
+	reEntropyCandidate = regexp.MustCompile(`[A-Za-z0-9+/=_\-]{20,}`)
+	reURL              = regexp.MustCompile(`https?://[^\s<>"')\]]+`)
+)
+
+// EntropyDetector finds random-looking tokens such as API keys that no known pattern matches.
+// Tokens inside URLs are ignored so that links to sources are kept intact, and tokens that
+// do not mix upper case letters, lower case letters and digits throughout are ignored so that
+// identifiers such as "getConversationReplies2025x" and hex digests are not reported.
+type EntropyDetector struct {
+	threshold float64
+}
+
+// NewEntropyDetector creates an EntropyDetector that reports tokens whose Shannon entropy per character is at least threshold.
+func NewEntropyDetector(threshold float64) *EntropyDetector {
+	return &EntropyDetector{threshold: threshold}
+}
+
+func (d *EntropyDetector) Detect(text string) []Finding {
+	urls := reURL.FindAllStringIndex(text, -1)
+
+	var findings []Finding
+	for _, loc := range reEntropyCandidate.FindAllStringIndex(text, -1) {
+		token := text[loc[0]:loc[1]]
+		if !isMixedToken(token) {
+			continue
+		}
+		if overlapsAny(loc, urls) {
+			continue
+		}
+		if shannonEntropy(token) < d.threshold {
+			continue
+		}
+		findings = append(findings, Finding{Kind: "high_entropy_string", Secret: true, Start: loc[0], End: loc[1]})
+	}
+	return findings
+}
+
+// isMixedToken reports whether the token contains upper case letters, lower case letters and digits,
+// and switches between them often enough to look random rather than like words joined together.
+func isMixedToken(token string) bool {
+	var hasUpper, hasLower, hasDigit bool
+	switches := 0
+	prev := charClassOther
+	for i, r := range token {
+		class := charClassOf(r)
+		switch class {
+		case charClassUpper:
+			hasUpper = true
+		case charClassLower:
+			hasLower = true
+		case charClassDigit:
+			hasDigit = true
+		}
+		if i > 0 && class != prev {
+			switches++
+		}
+		prev = class
+	}
+	return hasUpper && hasLower && hasDigit && switches*4 >= len(token)
+}
+
+type charClass int
+
+const (
+	charClassOther charClass = iota
+	charClassUpper
+	charClassLower
+	charClassDigit
+)
+
+func charClassOf(r rune) charClass {
+	switch {
+	case r >= 'A' && r <= 'Z':
+		return charClassUpper
+	case r >= 'a' && r <= 'z':
+		return charClassLower
+	case r >= '0' && r <= '9':
+		return charClassDigit
+	default:
+		return charClassOther
+	}
+}
+
+func overlapsAny(loc []int, spans [][]int) bool {
+	for _, span := range spans {
+		if loc[0] < span[1] && span[0] < loc[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+/**
+ * Default detectors
+ */
+
+// SecretDetectors returns detectors for well-known credential formats.
+func SecretDetectors() []Detector {
+	return []Detector{
+		NewPatternDetector("private_key", true, regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)),
+		NewPatternDetector("aws_access_key", true, regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)),
+		NewPatternDetector("github_token", true, regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)),
+		NewPatternDetector("slack_token", true, regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`)),
+		NewPatternDetector("slack_webhook", true, regexp.MustCompile(`https://hooks\.slack\.com/services/[A-Za-z0-9/]+`)),
+		NewPatternDetector("google_api_key", true, regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)),
+		NewPatternDetector("jwt", true, regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]{10,}\.eyJ[A-Za-z0-9_\-]{10,}\.[A-Za-z0-9_\-]{10,}\b`)),
+		&PatternDetector{
+			kind:    "password",
+			secret:  true,
+			pattern: regexp.MustCompile(`(?i)\b(?:password|passwd|pwd|secret|api[_-]?key|access[_-]?token)\b\s*[:=]\s*["'\x60]?([^\s"'\x60]{4,})`),
+			ignore:  isPlaceholderValue,
+		},
+	}
+}
+
+// PIIDetectors returns detectors for personal information.
+func PIIDetectors() []Detector {
+	return []Detector{
+		NewPatternDetector("email", false, regexp.MustCompile(`\b[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}\b`)),
+		NewPatternDetector("phone_number", false, regexp.MustCompile(`(?:\+\d{1,3}[\s\-]?)?\(?\b\d{2,4}\)?[\s\-]\d{2,4}[\s\-]\d{3,4}\b`)),
+	}
+}
+
+var (
+	rePlaceholderValue = regexp.MustCompile(`^(?:<[^>]*>?|\$\{[^}]*\}?|\{\{[^}]*(?:\}\})?|\[[^\]]*\]?|%[^%]*%|\$[A-Za-z_][A-Za-z0-9_]*)[.,;:)]*$`)
+	reExampleValue     = regexp.MustCompile(`(?i)^(?:your|my|example|sample|dummy|placeholder)[_\-.]`)
+	rePathValue        = regexp.MustCompile(`^(?:~?/|\.{1,2}/|[A-Za-z]:\\)`)
+)
+
+// isPlaceholderValue reports whether the value of a credential assignment is obviously not a real credential,
+// such as a placeholder ("<your-api-key>", "YOUR_PASSWORD", "${TOKEN}"), a boolean or a file path.
+func isPlaceholderValue(value string) bool {
+	switch strings.ToLower(strings.TrimRight(value, ".,;:)")) {
+	case "true", "false", "yes", "no", "on", "off", "null", "nil", "none", "required", "optional", "changeme":
+		return true
+	}
+	if rePlaceholderValue.MatchString(value) || reExampleValue.MatchString(value) || rePathValue.MatchString(value) {
+		return true
+	}
+	// "xxxx" や "****" のような伏せ字
+	first, _ := utf8.DecodeRuneInString(value)
+	if strings.Trim(value, string(first)) == "" {
+		return true
+	}
+	// "PASSWORD" や "API_KEY" のように大文字の名前だけで書かれた値は環境変数名などのプレースホルダ
+	return strings.IndexFunc(value, func(r rune) bool {
+		return !unicode.IsUpper(r) && r != '_' && r != '-'
+	}) < 0
+}
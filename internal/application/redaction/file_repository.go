@@ -0,0 +1,74 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"docgent/internal/domain/data"
+)
+
+// FileRepository is a data.FileRepository decorator that refuses to write contents containing secrets.
+// Secrets that are already in the file are not refused, so that documents can still be edited
+// after a secret has been committed by someone else.
+type FileRepository struct {
+	fileRepository data.FileRepository
+	redactor       *Redactor
+}
+
+func NewFileRepository(fileRepository data.FileRepository, redactor *Redactor) data.FileRepository {
+	return &FileRepository{fileRepository: fileRepository, redactor: redactor}
+}
+
+func (r *FileRepository) Create(ctx context.Context, file *data.File) error {
+	if err := r.ensureNoNewSecrets(file, ""); err != nil {
+		return err
+	}
+	return r.fileRepository.Create(ctx, file)
+}
+
+func (r *FileRepository) Update(ctx context.Context, file *data.File) error {
+	current, err := r.fileRepository.Get(ctx, file.Path)
+	if err != nil && !errors.Is(err, data.ErrFileNotFound) {
+		return err
+	}
+	currentContent := ""
+	if current != nil {
+		currentContent = current.Content
+	}
+	if err := r.ensureNoNewSecrets(file, currentContent); err != nil {
+		return err
+	}
+	return r.fileRepository.Update(ctx, file)
+}
+
+func (r *FileRepository) Get(ctx context.Context, path string) (*data.File, error) {
+	return r.fileRepository.Get(ctx, path)
+}
+
+func (r *FileRepository) Delete(ctx context.Context, path string) error {
+	return r.fileRepository.Delete(ctx, path)
+}
+
+// ensureNoNewSecrets returns ErrSecretDetected if the file contains secrets that are not in currentContent.
+func (r *FileRepository) ensureNoNewSecrets(file *data.File, currentContent string) error {
+	return secretsError(file.Path, r.redactor.DetectNewSecrets(file.Content, currentContent))
+}
+
+// secretsError returns ErrSecretDetected with the kinds of the secrets found in the file at path, or nil if there are none.
+func secretsError(path string, secrets []Finding) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	var kinds []string
+	seen := make(map[string]bool)
+	for _, secret := range secrets {
+		if !seen[secret.Kind] {
+			seen[secret.Kind] = true
+			kinds = append(kinds, secret.Kind)
+		}
+	}
+	return fmt.Errorf("%w: %s (%s)", data.ErrSecretDetected, path, strings.Join(kinds, ", "))
+}
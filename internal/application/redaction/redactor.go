@@ -0,0 +1,146 @@
+package redaction
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Config is the configuration of the redaction layer.
+type Config struct {
+	// Enabled turns on redaction. If false, the Redactor does nothing.
+	Enabled bool
+	// RedactPII hides personal information such as emails and phone numbers from the model.
+	RedactPII bool
+	// EntropyThreshold is the minimum Shannon entropy per character of a token to be treated as a secret.
+	// Zero disables the entropy-based detection.
+	EntropyThreshold float64
+	// CustomPatterns are additional secret patterns keyed by their kind.
+	CustomPatterns map[string]string
+}
+
+// Redactor hides sensitive information in texts before they leave the application.
+type Redactor struct {
+	detectors []Detector
+}
+
+func NewRedactor(detectors ...Detector) *Redactor {
+	return &Redactor{detectors: detectors}
+}
+
+// NewRedactorFromConfig creates a Redactor with the detectors enabled by the config.
+func NewRedactorFromConfig(config Config) (*Redactor, error) {
+	if !config.Enabled {
+		return NewRedactor(), nil
+	}
+
+	detectors := SecretDetectors()
+	if config.RedactPII {
+		detectors = append(detectors, PIIDetectors()...)
+	}
+	if config.EntropyThreshold > 0 {
+		detectors = append(detectors, NewEntropyDetector(config.EntropyThreshold))
+	}
+
+	kinds := make([]string, 0, len(config.CustomPatterns))
+	for kind := range config.CustomPatterns {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		pattern, err := regexp.Compile(config.CustomPatterns[kind])
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom pattern %q: %w", kind, err)
+		}
+		detectors = append(detectors, NewPatternDetector(kind, true, pattern))
+	}
+
+	return NewRedactor(detectors...), nil
+}
+
+// Redact replaces every sensitive part of the text with a placeholder such as "[REDACTED:email]".
+func (r *Redactor) Redact(text string) string {
+	return r.replace(text, func(f Finding, _ string) string {
+		return fmt.Sprintf("[REDACTED:%s]", f.Kind)
+	})
+}
+
+// replace replaces every sensitive part of the text with the placeholder returned by placeholder.
+func (r *Redactor) replace(text string, placeholder func(f Finding, original string) string) string {
+	findings := r.detect(text)
+	if len(findings) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, f := range findings {
+		b.WriteString(text[last:f.Start])
+		b.WriteString(placeholder(f, text[f.Start:f.End]))
+		last = f.End
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// DetectSecrets returns the credentials found in the text. Personal information is not included.
+func (r *Redactor) DetectSecrets(text string) []Finding {
+	var secrets []Finding
+	for _, f := range r.detect(text) {
+		if f.Secret {
+			secrets = append(secrets, f)
+		}
+	}
+	return secrets
+}
+
+// DetectNewSecrets returns the credentials found in the text that do not appear in any of the existing texts.
+func (r *Redactor) DetectNewSecrets(text string, existing ...string) []Finding {
+	known := make(map[string]bool)
+	for _, e := range existing {
+		for _, secret := range r.DetectSecrets(e) {
+			known[e[secret.Start:secret.End]] = true
+		}
+	}
+
+	var secrets []Finding
+	for _, secret := range r.DetectSecrets(text) {
+		if !known[text[secret.Start:secret.End]] {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
+
+// detect returns the findings of all detectors sorted by position, with overlapping findings merged.
+func (r *Redactor) detect(text string) []Finding {
+	var findings []Finding
+	for _, detector := range r.detectors {
+		findings = append(findings, detector.Detect(text)...)
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Start != findings[j].Start {
+			return findings[i].Start < findings[j].Start
+		}
+		return findings[i].End > findings[j].End
+	})
+
+	merged := []Finding{findings[0]}
+	for _, f := range findings[1:] {
+		prev := &merged[len(merged)-1]
+		if f.Start < prev.End {
+			if f.End > prev.End {
+				prev.End = f.End
+			}
+			prev.Secret = prev.Secret || f.Secret
+			continue
+		}
+		merged = append(merged, f)
+	}
+	return merged
+}
@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+)
+
+// StaleDocumentDetectUsecase finds documents whose sources have new activity since the document was last updated,
+// and notifies maintainers with a summary of what changed. Activity that has already been notified is not notified again.
+type StaleDocumentDetectUsecase struct {
+	chatModel                  domain.ChatModel
+	fileQueryService           port.FileQueryService
+	fileRepository             data.FileRepository
+	fileHistoryService         port.FileHistoryService
+	sourceActivityRepositories []port.SourceActivityRepository
+	notifier                   port.StaleDocumentNotifier
+}
+
+func NewStaleDocumentDetectUsecase(
+	chatModel domain.ChatModel,
+	fileQueryService port.FileQueryService,
+	fileRepository data.FileRepository,
+	fileHistoryService port.FileHistoryService,
+	sourceActivityRepositories []port.SourceActivityRepository,
+	notifier port.StaleDocumentNotifier,
+) *StaleDocumentDetectUsecase {
+	return &StaleDocumentDetectUsecase{
+		chatModel:                  chatModel,
+		fileQueryService:           fileQueryService,
+		fileRepository:             fileRepository,
+		fileHistoryService:         fileHistoryService,
+		sourceActivityRepositories: sourceActivityRepositories,
+		notifier:                   notifier,
+	}
+}
+
+// Execute walks every document and notifies the stale ones.
+// A failure on one document does not stop the others; all failures are returned together.
+func (u *StaleDocumentDetectUsecase) Execute(ctx context.Context) ([]port.StaleDocumentReport, error) {
+	tree, err := u.fileQueryService.GetTree(ctx, port.WithGetTreeRecursive())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree metadata: %w", err)
+	}
+
+	var reports []port.StaleDocumentReport
+	var errs []error
+	for _, metadata := range tree {
+		if metadata.Type != port.NodeTypeFile || !strings.HasSuffix(metadata.Path, ".md") {
+			continue
+		}
+
+		report, stale, err := u.inspect(ctx, metadata.Path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to inspect %s: %w", metadata.Path, err))
+			continue
+		}
+		if !stale {
+			continue
+		}
+
+		if err := u.notifier.Notify(ctx, report); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify stale document %s: %w", metadata.Path, err))
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, errors.Join(errs...)
+}
+
+func (u *StaleDocumentDetectUsecase) inspect(ctx context.Context, path string) (port.StaleDocumentReport, bool, error) {
+	file, err := u.fileRepository.Get(ctx, path)
+	if err != nil {
+		return port.StaleDocumentReport{}, false, fmt.Errorf("failed to get file: %w", err)
+	}
+	if len(file.SourceURIs) == 0 {
+		return port.StaleDocumentReport{}, false, nil
+	}
+
+	lastModifiedAt, err := u.fileHistoryService.GetLastModifiedAt(ctx, path)
+	if err != nil {
+		return port.StaleDocumentReport{}, false, fmt.Errorf("failed to get last modified time: %w", err)
+	}
+
+	// 通知済みのアクティビティを次の実行で再び通知しないよう、前回の通知以降のアクティビティだけを探す
+	lastNotifiedAt, err := u.notifier.LastNotifiedAt(ctx, path)
+	if err != nil {
+		return port.StaleDocumentReport{}, false, fmt.Errorf("failed to get last notified time: %w", err)
+	}
+	since := lastModifiedAt
+	if lastNotifiedAt.After(since) {
+		since = lastNotifiedAt
+	}
+	checkedAt := time.Now()
+
+	var activities []port.SourceActivity
+	for _, uri := range file.SourceURIs {
+		repository := u.findSourceActivityRepository(uri)
+		if repository == nil {
+			continue
+		}
+		activity, err := repository.FindActivitySince(ctx, uri, since)
+		if err != nil {
+			return port.StaleDocumentReport{}, false, fmt.Errorf("failed to find activity of %s: %w", uri, err)
+		}
+		if activity.HasChanges() {
+			activities = append(activities, activity)
+		}
+	}
+	if len(activities) == 0 {
+		return port.StaleDocumentReport{}, false, nil
+	}
+
+	summary, err := u.summarize(ctx, file, activities)
+	if err != nil {
+		return port.StaleDocumentReport{}, false, fmt.Errorf("failed to summarize activities: %w", err)
+	}
+
+	fileURI, err := u.fileQueryService.GetURI(ctx, path)
+	if err != nil {
+		return port.StaleDocumentReport{}, false, fmt.Errorf("failed to get file URI: %w", err)
+	}
+
+	return port.StaleDocumentReport{
+		FilePath:       path,
+		FileURI:        fileURI,
+		LastModifiedAt: lastModifiedAt,
+		CheckedAt:      checkedAt,
+		Activities:     activities,
+		Summary:        summary,
+	}, true, nil
+}
+
+func (u *StaleDocumentDetectUsecase) findSourceActivityRepository(uri *data.URI) port.SourceActivityRepository {
+	for _, repository := range u.sourceActivityRepositories {
+		if repository.Match(uri) {
+			return repository
+		}
+	}
+	return nil
+}
+
+func (u *StaleDocumentDetectUsecase) summarize(ctx context.Context, file *data.File, activities []port.SourceActivity) (string, error) {
+	session := u.chatModel.StartChat(`You are a technical writer who maintains documents written from discussions.
+The discussions that a document is based on have new activity after the document was last updated.
+Summarize briefly what changed in the discussions, and point out which parts of the document may be outdated.
+Answer in the same language as the document.`)
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("<document path=%q>\n%s\n</document>\n", file.Path, file.Content))
+	for _, activity := range activities {
+		message.WriteString(activity.ToXML())
+		message.WriteString("\n")
+	}
+
+	return session.SendMessage(ctx, message.String())
+}
@@ -0,0 +1,238 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFileHistoryService struct {
+	mock.Mock
+}
+
+func (m *MockFileHistoryService) GetLastModifiedAt(ctx context.Context, path string) (time.Time, error) {
+	args := m.Called(ctx, path)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+type MockSourceActivityRepository struct {
+	mock.Mock
+}
+
+func (m *MockSourceActivityRepository) Match(uri *data.URI) bool {
+	args := m.Called(uri)
+	return args.Bool(0)
+}
+
+func (m *MockSourceActivityRepository) FindActivitySince(ctx context.Context, uri *data.URI, since time.Time) (port.SourceActivity, error) {
+	args := m.Called(ctx, uri, since)
+	return args.Get(0).(port.SourceActivity), args.Error(1)
+}
+
+type MockStaleDocumentNotifier struct {
+	mock.Mock
+}
+
+func (m *MockStaleDocumentNotifier) Notify(ctx context.Context, report port.StaleDocumentReport) error {
+	args := m.Called(ctx, report)
+	return args.Error(0)
+}
+
+func (m *MockStaleDocumentNotifier) LastNotifiedAt(ctx context.Context, path string) (time.Time, error) {
+	args := m.Called(ctx, path)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func TestStaleDocumentDetectUsecase_Execute(t *testing.T) {
+	lastModifiedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	lastNotifiedAt := time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)
+	sourceURI := data.NewURIUnsafe("https://app.slack.com/client/T123/C456/1234567890.123456")
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*MockChatModel, *MockChatSession, *MockFileQueryService, *MockFileRepository, *MockFileHistoryService, *MockSourceActivityRepository, *MockStaleDocumentNotifier)
+		wantPaths     []string
+		expectedError bool
+	}{
+		{
+			name: "success: notify a document whose source has new messages",
+			setupMocks: func(chatModel *MockChatModel, chatSession *MockChatSession, fileQueryService *MockFileQueryService, fileRepository *MockFileRepository, fileHistoryService *MockFileHistoryService, sourceActivityRepository *MockSourceActivityRepository, notifier *MockStaleDocumentNotifier) {
+				fileQueryService.On("GetTree", mock.Anything, mock.Anything).Return([]port.TreeMetadata{
+					{Type: port.NodeTypeDirectory, Path: "docs"},
+					{Type: port.NodeTypeFile, Path: "docs/api.md"},
+					{Type: port.NodeTypeFile, Path: ".docgentrules"},
+				}, nil)
+				fileRepository.On("Get", mock.Anything, "docs/api.md").Return(&data.File{
+					Path:       "docs/api.md",
+					Content:    "# API\n\nThe API uses token authentication.",
+					SourceURIs: []*data.URI{sourceURI},
+				}, nil)
+				fileHistoryService.On("GetLastModifiedAt", mock.Anything, "docs/api.md").Return(lastModifiedAt, nil)
+				notifier.On("LastNotifiedAt", mock.Anything, "docs/api.md").Return(time.Time{}, nil)
+				sourceActivityRepository.On("Match", sourceURI).Return(true)
+				sourceActivityRepository.On("FindActivitySince", mock.Anything, sourceURI, lastModifiedAt).Return(port.SourceActivity{
+					URI: sourceURI,
+					Messages: []port.ConversationMessage{
+						{Author: "U123", Content: "We switched to OAuth instead of tokens."},
+					},
+				}, nil)
+				chatModel.On("StartChat", mock.Anything).Return(chatSession)
+				chatSession.On("SendMessage", mock.Anything, mock.Anything).Return("Authentication changed to OAuth.", nil)
+				fileQueryService.On("GetURI", mock.Anything, "docs/api.md").Return("https://github.com/owner/repo/blob/abc123/docs/api.md", nil)
+				notifier.On("Notify", mock.Anything, mock.MatchedBy(func(report port.StaleDocumentReport) bool {
+					return report.FilePath == "docs/api.md" &&
+						report.Summary == "Authentication changed to OAuth." &&
+						report.FileURI.String() == "https://github.com/owner/repo/blob/abc123/docs/api.md" &&
+						!report.CheckedAt.Before(lastModifiedAt) &&
+						len(report.Activities) == 1
+				})).Return(nil)
+			},
+			wantPaths: []string{"docs/api.md"},
+		},
+		{
+			name: "success: skip documents without new activity",
+			setupMocks: func(chatModel *MockChatModel, chatSession *MockChatSession, fileQueryService *MockFileQueryService, fileRepository *MockFileRepository, fileHistoryService *MockFileHistoryService, sourceActivityRepository *MockSourceActivityRepository, notifier *MockStaleDocumentNotifier) {
+				fileQueryService.On("GetTree", mock.Anything, mock.Anything).Return([]port.TreeMetadata{
+					{Type: port.NodeTypeFile, Path: "docs/api.md"},
+				}, nil)
+				fileRepository.On("Get", mock.Anything, "docs/api.md").Return(&data.File{
+					Path:       "docs/api.md",
+					SourceURIs: []*data.URI{sourceURI},
+				}, nil)
+				fileHistoryService.On("GetLastModifiedAt", mock.Anything, "docs/api.md").Return(lastModifiedAt, nil)
+				notifier.On("LastNotifiedAt", mock.Anything, "docs/api.md").Return(time.Time{}, nil)
+				sourceActivityRepository.On("Match", sourceURI).Return(true)
+				sourceActivityRepository.On("FindActivitySince", mock.Anything, sourceURI, lastModifiedAt).Return(port.SourceActivity{URI: sourceURI}, nil)
+			},
+			wantPaths: nil,
+		},
+		{
+			name: "success: look for activity only after the last notification",
+			setupMocks: func(chatModel *MockChatModel, chatSession *MockChatSession, fileQueryService *MockFileQueryService, fileRepository *MockFileRepository, fileHistoryService *MockFileHistoryService, sourceActivityRepository *MockSourceActivityRepository, notifier *MockStaleDocumentNotifier) {
+				fileQueryService.On("GetTree", mock.Anything, mock.Anything).Return([]port.TreeMetadata{
+					{Type: port.NodeTypeFile, Path: "docs/api.md"},
+				}, nil)
+				fileRepository.On("Get", mock.Anything, "docs/api.md").Return(&data.File{
+					Path:       "docs/api.md",
+					SourceURIs: []*data.URI{sourceURI},
+				}, nil)
+				fileHistoryService.On("GetLastModifiedAt", mock.Anything, "docs/api.md").Return(lastModifiedAt, nil)
+				notifier.On("LastNotifiedAt", mock.Anything, "docs/api.md").Return(lastNotifiedAt, nil)
+				sourceActivityRepository.On("Match", sourceURI).Return(true)
+				sourceActivityRepository.On("FindActivitySince", mock.Anything, sourceURI, lastNotifiedAt).Return(port.SourceActivity{URI: sourceURI}, nil)
+			},
+			wantPaths: nil,
+		},
+		{
+			name: "success: a notification older than the document does not hide new activity",
+			setupMocks: func(chatModel *MockChatModel, chatSession *MockChatSession, fileQueryService *MockFileQueryService, fileRepository *MockFileRepository, fileHistoryService *MockFileHistoryService, sourceActivityRepository *MockSourceActivityRepository, notifier *MockStaleDocumentNotifier) {
+				fileQueryService.On("GetTree", mock.Anything, mock.Anything).Return([]port.TreeMetadata{
+					{Type: port.NodeTypeFile, Path: "docs/api.md"},
+				}, nil)
+				fileRepository.On("Get", mock.Anything, "docs/api.md").Return(&data.File{
+					Path:       "docs/api.md",
+					SourceURIs: []*data.URI{sourceURI},
+				}, nil)
+				fileHistoryService.On("GetLastModifiedAt", mock.Anything, "docs/api.md").Return(lastNotifiedAt, nil)
+				notifier.On("LastNotifiedAt", mock.Anything, "docs/api.md").Return(lastModifiedAt, nil)
+				sourceActivityRepository.On("Match", sourceURI).Return(true)
+				sourceActivityRepository.On("FindActivitySince", mock.Anything, sourceURI, lastNotifiedAt).Return(port.SourceActivity{URI: sourceURI}, nil)
+			},
+			wantPaths: nil,
+		},
+		{
+			name: "success: skip documents without sources",
+			setupMocks: func(chatModel *MockChatModel, chatSession *MockChatSession, fileQueryService *MockFileQueryService, fileRepository *MockFileRepository, fileHistoryService *MockFileHistoryService, sourceActivityRepository *MockSourceActivityRepository, notifier *MockStaleDocumentNotifier) {
+				fileQueryService.On("GetTree", mock.Anything, mock.Anything).Return([]port.TreeMetadata{
+					{Type: port.NodeTypeFile, Path: "README.md"},
+				}, nil)
+				fileRepository.On("Get", mock.Anything, "README.md").Return(&data.File{Path: "README.md"}, nil)
+			},
+			wantPaths: nil,
+		},
+		{
+			name: "error: continue with other documents when a source cannot be read",
+			setupMocks: func(chatModel *MockChatModel, chatSession *MockChatSession, fileQueryService *MockFileQueryService, fileRepository *MockFileRepository, fileHistoryService *MockFileHistoryService, sourceActivityRepository *MockSourceActivityRepository, notifier *MockStaleDocumentNotifier) {
+				brokenURI := data.NewURIUnsafe("https://app.slack.com/client/T123/C456/0000000000.000000")
+				fileQueryService.On("GetTree", mock.Anything, mock.Anything).Return([]port.TreeMetadata{
+					{Type: port.NodeTypeFile, Path: "docs/broken.md"},
+					{Type: port.NodeTypeFile, Path: "docs/api.md"},
+				}, nil)
+				fileRepository.On("Get", mock.Anything, "docs/broken.md").Return(&data.File{
+					Path:       "docs/broken.md",
+					SourceURIs: []*data.URI{brokenURI},
+				}, nil)
+				fileRepository.On("Get", mock.Anything, "docs/api.md").Return(&data.File{
+					Path:       "docs/api.md",
+					SourceURIs: []*data.URI{sourceURI},
+				}, nil)
+				fileHistoryService.On("GetLastModifiedAt", mock.Anything, mock.Anything).Return(lastModifiedAt, nil)
+				notifier.On("LastNotifiedAt", mock.Anything, mock.Anything).Return(time.Time{}, nil)
+				sourceActivityRepository.On("Match", mock.Anything).Return(true)
+				sourceActivityRepository.On("FindActivitySince", mock.Anything, brokenURI, lastModifiedAt).Return(port.SourceActivity{}, errors.New("channel_not_found"))
+				sourceActivityRepository.On("FindActivitySince", mock.Anything, sourceURI, lastModifiedAt).Return(port.SourceActivity{
+					URI:    sourceURI,
+					Events: []string{"closed"},
+				}, nil)
+				chatModel.On("StartChat", mock.Anything).Return(chatSession)
+				chatSession.On("SendMessage", mock.Anything, mock.Anything).Return("The discussion was closed.", nil)
+				fileQueryService.On("GetURI", mock.Anything, "docs/api.md").Return("https://github.com/owner/repo/blob/abc123/docs/api.md", nil)
+				notifier.On("Notify", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantPaths:     []string{"docs/api.md"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatModel := new(MockChatModel)
+			chatSession := new(MockChatSession)
+			fileQueryService := new(MockFileQueryService)
+			fileRepository := new(MockFileRepository)
+			fileHistoryService := new(MockFileHistoryService)
+			sourceActivityRepository := new(MockSourceActivityRepository)
+			notifier := new(MockStaleDocumentNotifier)
+
+			tt.setupMocks(chatModel, chatSession, fileQueryService, fileRepository, fileHistoryService, sourceActivityRepository, notifier)
+
+			usecase := NewStaleDocumentDetectUsecase(
+				chatModel,
+				fileQueryService,
+				fileRepository,
+				fileHistoryService,
+				[]port.SourceActivityRepository{sourceActivityRepository},
+				notifier,
+			)
+
+			reports, err := usecase.Execute(context.Background())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			var gotPaths []string
+			for _, report := range reports {
+				gotPaths = append(gotPaths, report.FilePath)
+			}
+			assert.Equal(t, tt.wantPaths, gotPaths)
+
+			chatModel.AssertExpectations(t)
+			chatSession.AssertExpectations(t)
+			fileQueryService.AssertExpectations(t)
+			fileRepository.AssertExpectations(t)
+			fileHistoryService.AssertExpectations(t)
+			sourceActivityRepository.AssertExpectations(t)
+			notifier.AssertExpectations(t)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package tooluse
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 
 	"docgent/internal/domain/data"
@@ -51,6 +53,9 @@ func (h *FileChangeHandler) handleCreateFile(c tooluse.CreateFile) (string, bool
 	}
 
 	err := h.fileRepository.Create(h.ctx, file)
+	if errors.Is(err, data.ErrSecretDetected) {
+		return secretDetectedMessage(err), false, nil
+	}
 	if err != nil {
 		return "", false, err
 	}
@@ -76,6 +81,9 @@ func (h *FileChangeHandler) handleModifyFile(c tooluse.ModifyFile) (string, bool
 
 	// ファイルを更新
 	err = h.fileRepository.Update(h.ctx, file)
+	if errors.Is(err, data.ErrSecretDetected) {
+		return secretDetectedMessage(err), false, nil
+	}
 	if err != nil {
 		return "", false, err
 	}
@@ -105,6 +113,9 @@ func (h *FileChangeHandler) handleRenameFile(c tooluse.RenameFile) (string, bool
 		SourceURIs: file.SourceURIs,
 	}
 	err = h.fileRepository.Create(h.ctx, newFile)
+	if errors.Is(err, data.ErrSecretDetected) {
+		return secretDetectedMessage(err), false, nil
+	}
 	if err != nil {
 		return "", false, err
 	}
@@ -130,3 +141,8 @@ func (h *FileChangeHandler) handleDeleteFile(c tooluse.DeleteFile) (string, bool
 
 	return "<success>File deleted</success>", false, nil
 }
+
+// secretDetectedMessage は秘密情報を含む変更を拒否したことをエージェントに伝えるメッセージを返します
+func secretDetectedMessage(err error) string {
+	return fmt.Sprintf("<error>%s. Remove the secret from the content and try again. Do not copy [REDACTED:...] values into the content; write a placeholder such as <your-api-key> instead.</error>", err)
+}
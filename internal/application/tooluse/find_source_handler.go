@@ -5,6 +5,7 @@ import (
 	"docgent/internal/application/port"
 	"docgent/internal/domain/data"
 	"docgent/internal/domain/tooluse"
+	"errors"
 	"fmt"
 )
 
@@ -27,6 +28,9 @@ func (h *FindSourceHandler) Handle(toolUse tooluse.FindSource) (string, bool, er
 	}
 
 	source, err := h.sourceRepositoryManager.Find(h.ctx, uri)
+	if errors.Is(err, port.ErrSourceNotAllowed) {
+		return fmt.Sprintf("<error>Source is not allowed by the workspace policy and must not be used: %s</error>", uri), false, nil
+	}
 	if err != nil {
 		return fmt.Sprintf("<error>Source not found: %s</error>", uri), false, nil
 	}
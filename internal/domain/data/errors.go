@@ -17,4 +17,7 @@ var (
 
 	// ErrFailedToAccessFile はファイルへのアクセスに失敗した場合のエラー
 	ErrFailedToAccessFile = errors.New("failed to access file")
+
+	// ErrSecretDetected はファイルの内容に秘密情報が含まれている場合のエラー
+	ErrSecretDetected = errors.New("secret detected in file content")
 )
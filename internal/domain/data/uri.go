@@ -2,6 +2,7 @@ package data
 
 import (
 	"net/url"
+	"strings"
 )
 
 type URI struct {
@@ -52,3 +53,18 @@ func (u *URI) Path() string {
 func (u *URI) Equal(other *URI) bool {
 	return u.Value() == other.Value()
 }
+
+// Fragment returns the part after "#", such as the anchor of a section
+func (u *URI) Fragment() string {
+	return u.parsed.Fragment
+}
+
+// WithFragment returns the URI pointing to the fragment. An empty fragment removes it.
+// The rest of the URI is kept as it is, so that it still equals the original without the fragment.
+func (u *URI) WithFragment(fragment string) *URI {
+	value, _, _ := strings.Cut(u.value, "#")
+	if fragment != "" {
+		value += "#" + (&url.URL{Fragment: fragment}).EscapedFragment()
+	}
+	return NewURIUnsafe(value)
+}
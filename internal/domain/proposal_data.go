@@ -57,10 +57,11 @@ func (ds Diffs) ToXMLString() string {
 }
 
 type Diff struct {
-	OldName   string
-	NewName   string
-	Body      string
-	IsNewFile bool
+	OldName       string
+	NewName       string
+	Body          string
+	IsNewFile     bool
+	IsDeletedFile bool
 }
 
 func NewUpdateDiff(oldName, newName, body string) Diff {
@@ -72,6 +73,15 @@ func NewUpdateDiff(oldName, newName, body string) Diff {
 	}
 }
 
+func NewDeleteDiff(oldName, body string) Diff {
+	return Diff{
+		OldName:       oldName,
+		NewName:       oldName,
+		Body:          body,
+		IsDeletedFile: true,
+	}
+}
+
 func NewCreateDiff(newName, body string) Diff {
 	return Diff{
 		OldName:   "",
@@ -0,0 +1,227 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// DefaultBaseURL is the URL of the Discord REST API
+const DefaultBaseURL = "https://discord.com/api/v10"
+
+// API is a minimal client of the Discord REST API authenticated by a bot token
+type API struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+
+	currentUserLock sync.Mutex
+	currentUser     *User
+}
+
+type NewAPIOption func(*API)
+
+// WithBaseURL replaces DefaultBaseURL, e.g. with a fake server in tests
+func WithBaseURL(baseURL string) NewAPIOption {
+	return func(a *API) {
+		a.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient replaces http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) NewAPIOption {
+	return func(a *API) {
+		a.httpClient = httpClient
+	}
+}
+
+// NewAPI creates an API authenticated as the bot of the token
+func NewAPI(token string, options ...NewAPIOption) *API {
+	a := &API{
+		baseURL:    DefaultBaseURL,
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+	for _, option := range options {
+		option(a)
+	}
+	return a
+}
+
+// Enabled reports whether a bot token is configured. Discord is optional
+func (a *API) Enabled() bool {
+	return a.token != ""
+}
+
+// CurrentUser returns the bot user, which is mentioned to call Docgent
+func (a *API) CurrentUser(ctx context.Context) (*User, error) {
+	a.currentUserLock.Lock()
+	defer a.currentUserLock.Unlock()
+	if a.currentUser != nil {
+		return a.currentUser, nil
+	}
+
+	var user User
+	if err := a.do(ctx, http.MethodGet, "/users/@me", nil, nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	a.currentUser = &user
+	return a.currentUser, nil
+}
+
+// GetChannel returns the channel or thread
+func (a *API) GetChannel(ctx context.Context, channelID string) (*Channel, error) {
+	var channel Channel
+	if err := a.do(ctx, http.MethodGet, "/channels/"+channelID, nil, nil, &channel); err != nil {
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+	return &channel, nil
+}
+
+// GetMessage returns the message in the channel
+func (a *API) GetMessage(ctx context.Context, channelID, messageID string) (*Message, error) {
+	var message Message
+	if err := a.do(ctx, http.MethodGet, "/channels/"+channelID+"/messages/"+messageID, nil, nil, &message); err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	return &message, nil
+}
+
+// ListMessagesParams selects the messages of ListMessages. Only one of Before and After may be set
+type ListMessagesParams struct {
+	Before string
+	After  string
+	// Limit is between 1 and 100. The API defaults to 50
+	Limit int
+}
+
+// ListMessages returns the messages of the channel, newest first
+func (a *API) ListMessages(ctx context.Context, channelID string, params ListMessagesParams) ([]Message, error) {
+	query := url.Values{}
+	if params.Before != "" {
+		query.Set("before", params.Before)
+	}
+	if params.After != "" {
+		query.Set("after", params.After)
+	}
+	if params.Limit > 0 {
+		query.Set("limit", fmt.Sprint(params.Limit))
+	}
+
+	var messages []Message
+	if err := a.do(ctx, http.MethodGet, "/channels/"+channelID+"/messages", query, nil, &messages); err != nil {
+		return nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+	return messages, nil
+}
+
+// CreateMessageParams is the body of CreateMessage
+type CreateMessageParams struct {
+	Content          string            `json:"content"`
+	MessageReference *MessageReference `json:"message_reference,omitempty"`
+	AllowedMentions  *AllowedMentions  `json:"allowed_mentions,omitempty"`
+}
+
+// MessageReference makes a message a reply to another message
+type MessageReference struct {
+	MessageID string `json:"message_id"`
+	// FailIfNotExists is false so that the reply is posted even if the message was deleted
+	FailIfNotExists bool `json:"fail_if_not_exists"`
+}
+
+// AllowedMentions restricts who is notified by the mentions in the content
+type AllowedMentions struct {
+	Parse       []string `json:"parse"`
+	Users       []string `json:"users,omitempty"`
+	RepliedUser bool     `json:"replied_user"`
+}
+
+// CreateMessage posts a message to the channel
+func (a *API) CreateMessage(ctx context.Context, channelID string, params CreateMessageParams) (*Message, error) {
+	var message Message
+	if err := a.do(ctx, http.MethodPost, "/channels/"+channelID+"/messages", nil, params, &message); err != nil {
+		return nil, fmt.Errorf("failed to create message: %w", err)
+	}
+	return &message, nil
+}
+
+// CreateReaction adds the reaction of the bot to the message. emoji is a Unicode emoji or "name:id" of a custom emoji
+func (a *API) CreateReaction(ctx context.Context, channelID, messageID, emoji string) error {
+	if err := a.do(ctx, http.MethodPut, reactionPath(channelID, messageID, emoji), nil, nil, nil); err != nil {
+		return fmt.Errorf("failed to create reaction: %w", err)
+	}
+	return nil
+}
+
+// DeleteOwnReaction removes the reaction of the bot from the message
+func (a *API) DeleteOwnReaction(ctx context.Context, channelID, messageID, emoji string) error {
+	if err := a.do(ctx, http.MethodDelete, reactionPath(channelID, messageID, emoji), nil, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete reaction: %w", err)
+	}
+	return nil
+}
+
+func reactionPath(channelID, messageID, emoji string) string {
+	return "/channels/" + channelID + "/messages/" + messageID + "/reactions/" + url.PathEscape(emoji) + "/@me"
+}
+
+// GetGatewayURL returns the URL to connect to the Gateway as the bot
+func (a *API) GetGatewayURL(ctx context.Context) (string, error) {
+	var gateway struct {
+		URL string `json:"url"`
+	}
+	if err := a.do(ctx, http.MethodGet, "/gateway/bot", nil, nil, &gateway); err != nil {
+		return "", fmt.Errorf("failed to get gateway: %w", err)
+	}
+	return gateway.URL, nil
+}
+
+// do sends a request to the path under the base URL. body and out are encoded and decoded as JSON if not nil.
+// Path segments such as emojis must already be escaped.
+func (a *API) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	rawURL := a.baseURL + path
+	if len(query) > 0 {
+		rawURL += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bot "+a.token)
+	req.Header.Set("User-Agent", "DiscordBot (docgent, 1.0)")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return newErrorResponse(resp)
+	}
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
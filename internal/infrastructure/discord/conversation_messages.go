@@ -0,0 +1,91 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Message types that users post. Other types are system messages such as "started a thread"
+const (
+	messageTypeDefault = 0
+	messageTypeReply   = 19
+)
+
+// maxThreadMessages is the number of the latest messages of a thread that are read
+const maxThreadMessages = 300
+
+// listMessagesPageSize is the maximum limit of ListMessages
+const listMessagesPageSize = 100
+
+// listConversationMessages returns the messages of the conversation, oldest first.
+// A thread is read as a whole, including the message that started it in the parent channel.
+// A message outside threads is read together with the thread started from it, if any.
+func listConversationMessages(ctx context.Context, api *API, ref *ConversationRef) ([]Message, error) {
+	channel, err := api.GetChannel(ctx, ref.ChannelID())
+	if err != nil {
+		return nil, err
+	}
+	if channel.IsThread() {
+		return listThreadMessages(ctx, api, channel)
+	}
+
+	if ref.MessageID() == "" {
+		return nil, errors.New("message ID is required for conversations outside threads")
+	}
+	message, err := api.GetMessage(ctx, ref.ChannelID(), ref.MessageID())
+	if err != nil {
+		return nil, err
+	}
+	if message.Thread == nil {
+		return []Message{*message}, nil
+	}
+	// スレッドの起点のメッセージは listThreadMessages が親チャンネルから取得する
+	return listThreadMessages(ctx, api, message.Thread)
+}
+
+func listThreadMessages(ctx context.Context, api *API, thread *Channel) ([]Message, error) {
+	var messages []Message
+	params := ListMessagesParams{Limit: listMessagesPageSize}
+	for len(messages) < maxThreadMessages {
+		page, err := api.ListMessages(ctx, thread.ID, params)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, page...)
+		if len(page) < listMessagesPageSize {
+			break
+		}
+		params.Before = page[len(page)-1].ID
+	}
+
+	// A thread started from a message has the ID of the message, which is posted in the parent channel.
+	// Threads in forums and threads started without a message have no such message.
+	if thread.ParentID != "" {
+		starter, err := api.GetMessage(ctx, thread.ParentID, thread.ID)
+		var errorResponse *ErrorResponse
+		switch {
+		case err == nil:
+			messages = append(messages, *starter)
+		case errors.As(err, &errorResponse) && errorResponse.StatusCode == http.StatusNotFound:
+		default:
+			return nil, err
+		}
+	}
+
+	messages = slices.DeleteFunc(messages, func(m Message) bool {
+		return (m.Type != messageTypeDefault && m.Type != messageTypeReply) || strings.TrimSpace(m.Content) == ""
+	})
+	slices.SortFunc(messages, func(a, b Message) int { return compareSnowflakes(a.ID, b.ID) })
+	return slices.CompactFunc(messages, func(a, b Message) bool { return a.ID == b.ID }), nil
+}
+
+// compareSnowflakes orders IDs by the time they were created. Snowflakes are decimal numbers of up to 64 bits
+func compareSnowflakes(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,69 @@
+package discord
+
+import (
+	"fmt"
+	"regexp"
+
+	"docgent/internal/domain/data"
+)
+
+// directMessageGuildID is the guild segment of the links to direct messages
+const directMessageGuildID = "@me"
+
+// ConversationRef points to a conversation on Discord.
+// A conversation is a thread, or a message outside threads together with the thread started from it.
+type ConversationRef struct {
+	guildID   string
+	channelID string
+	// messageID is the message that the conversation was started from. It is empty for a whole thread
+	messageID string
+}
+
+func NewConversationRef(guildID, channelID, messageID string) *ConversationRef {
+	if guildID == "" {
+		guildID = directMessageGuildID
+	}
+	return &ConversationRef{guildID: guildID, channelID: channelID, messageID: messageID}
+}
+
+func (r *ConversationRef) GuildID() string {
+	return r.guildID
+}
+
+// ChannelID returns the ID of the channel or the thread
+func (r *ConversationRef) ChannelID() string {
+	return r.channelID
+}
+
+func (r *ConversationRef) MessageID() string {
+	return r.messageID
+}
+
+// ToURI returns the link to the message or the thread, which opens it in the Discord client
+func (r *ConversationRef) ToURI() *data.URI {
+	if r.messageID == "" {
+		return data.NewURIUnsafe(fmt.Sprintf("https://discord.com/channels/%s/%s", r.guildID, r.channelID))
+	}
+	return data.NewURIUnsafe(fmt.Sprintf("https://discord.com/channels/%s/%s/%s", r.guildID, r.channelID, r.messageID))
+}
+
+// https://discord.com/channels/{guild_id}/{channel_id}
+// or https://discord.com/channels/{guild_id}/{channel_id}/{message_id}. guild_id is "@me" for direct messages
+var reConversationURI = regexp.MustCompile(`^https://(?:(?:ptb|canary)\.)?discord(?:app)?\.com/channels/(@me|\d+)/(\d+)(?:/(\d+))?/?$`)
+
+func ParseConversationRef(uri *data.URI) (*ConversationRef, error) {
+	matches := reConversationURI.FindStringSubmatch(uri.Value())
+	if matches == nil {
+		return nil, fmt.Errorf("invalid URI: %s", uri)
+	}
+	return NewConversationRef(matches[1], matches[2], matches[3]), nil
+}
+
+// isDiscordHost reports whether the host serves links to Discord messages
+func isDiscordHost(host string) bool {
+	switch host {
+	case "discord.com", "ptb.discord.com", "canary.discord.com", "discordapp.com":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/domain/data"
+)
+
+func TestParseConversationRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		want    *ConversationRef
+		wantErr bool
+	}{
+		{
+			name: "メッセージのリンク",
+			uri:  "https://discord.com/channels/100/200/300",
+			want: NewConversationRef("100", "200", "300"),
+		},
+		{
+			name: "スレッドのリンク",
+			uri:  "https://discord.com/channels/100/200",
+			want: NewConversationRef("100", "200", ""),
+		},
+		{
+			name: "DMのリンク",
+			uri:  "https://discord.com/channels/@me/200/300",
+			want: NewConversationRef("", "200", "300"),
+		},
+		{
+			name: "PTBクライアントのリンク",
+			uri:  "https://ptb.discord.com/channels/100/200/300",
+			want: NewConversationRef("100", "200", "300"),
+		},
+		{
+			name:    "チャンネル以外のリンク",
+			uri:     "https://discord.com/invite/abc",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseConversationRef(data.NewURIUnsafe(tt.uri))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConversationRef_ToURI(t *testing.T) {
+	assert.Equal(t, "https://discord.com/channels/100/200/300", NewConversationRef("100", "200", "300").ToURI().Value())
+	assert.Equal(t, "https://discord.com/channels/100/200", NewConversationRef("100", "200", "").ToURI().Value())
+	assert.Equal(t, "https://discord.com/channels/@me/200/300", NewConversationRef("", "200", "300").ToURI().Value())
+}
@@ -0,0 +1,30 @@
+package discord
+
+import (
+	"fmt"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// ConversationResolver restores the ConversationService of a Discord conversation from its URI
+type ConversationResolver struct {
+	api *API
+}
+
+func NewConversationResolver(api *API) *ConversationResolver {
+	return &ConversationResolver{api: api}
+}
+
+func (r *ConversationResolver) Match(uri *data.URI) bool {
+	return isDiscordHost(uri.Host())
+}
+
+func (r *ConversationResolver) Resolve(uri *data.URI) (port.ConversationService, error) {
+	ref, err := ParseConversationRef(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse conversation ref: %w", err)
+	}
+	// Nobody to mention since the reply is not triggered by a user in the conversation
+	return NewConversationService(r.api, ref, ""), nil
+}
@@ -0,0 +1,108 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// eyesEmoji is the reaction that shows Docgent is working on a message
+const eyesEmoji = "👀"
+
+type ConversationService struct {
+	api        *API
+	ref        *ConversationRef
+	fromUserID string
+}
+
+func NewConversationService(api *API, ref *ConversationRef, fromUserID string) port.ConversationService {
+	return &ConversationService{
+		api:        api,
+		ref:        ref,
+		fromUserID: fromUserID,
+	}
+}
+
+// Reply posts the input to the channel or the thread of the conversation.
+// The first message replies to the message that started the conversation, if any.
+func (s *ConversationService) Reply(input string, withMention bool) error {
+	ctx := context.Background()
+
+	message := emojiShortcodes.Replace(input)
+	// モデルの出力で @everyone などが通知されないように、メンションは明示したユーザーだけに限る
+	allowedMentions := &AllowedMentions{Parse: []string{}}
+	if withMention && s.fromUserID != "" {
+		message = fmt.Sprintf("<@%s>\n%s", s.fromUserID, message)
+		allowedMentions.Users = []string{s.fromUserID}
+	}
+
+	// 長い返信は複数のメッセージに分けて投稿する
+	for i, chunk := range splitMessage(message, maxMessageLength) {
+		params := CreateMessageParams{Content: chunk, AllowedMentions: allowedMentions}
+		if i == 0 && s.ref.MessageID() != "" {
+			params.MessageReference = &MessageReference{MessageID: s.ref.MessageID()}
+		}
+		if _, err := s.api.CreateMessage(ctx, s.ref.ChannelID(), params); err != nil {
+			return fmt.Errorf("failed to post message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *ConversationService) URI() *data.URI {
+	return s.ref.ToURI()
+}
+
+func (s *ConversationService) GetHistory() (port.ConversationHistory, error) {
+	ctx := context.Background()
+
+	messages, err := listConversationMessages(ctx, s.api, s.ref)
+	if err != nil {
+		return port.ConversationHistory{}, fmt.Errorf("failed to get conversation messages: %w", err)
+	}
+
+	currentUser, err := s.api.CurrentUser(ctx)
+	if err != nil {
+		return port.ConversationHistory{}, err
+	}
+
+	conversationMessages := make([]port.ConversationMessage, 0, len(messages))
+	for _, message := range messages {
+		conversationMessages = append(conversationMessages, port.ConversationMessage{
+			Author:       message.Author.ID,
+			Content:      message.Content,
+			YouMentioned: message.MentionsUser(currentUser.ID),
+			IsYou:        message.Author.ID == currentUser.ID,
+		})
+	}
+
+	return port.ConversationHistory{
+		URI:      s.ref.ToURI(),
+		Messages: conversationMessages,
+	}, nil
+}
+
+// MarkEyes adds the eyes reaction to the message that started the conversation.
+// A whole thread has no such message, so nothing is marked.
+func (s *ConversationService) MarkEyes() error {
+	if s.ref.MessageID() == "" {
+		return nil
+	}
+	if err := s.api.CreateReaction(context.Background(), s.ref.ChannelID(), s.ref.MessageID(), eyesEmoji); err != nil {
+		return fmt.Errorf("failed to add eyes reaction: %w", err)
+	}
+	return nil
+}
+
+func (s *ConversationService) RemoveEyes() error {
+	if s.ref.MessageID() == "" {
+		return nil
+	}
+	if err := s.api.DeleteOwnReaction(context.Background(), s.ref.ChannelID(), s.ref.MessageID(), eyesEmoji); err != nil {
+		return fmt.Errorf("failed to remove eyes reaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package discord
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+)
+
+func TestConversationService_GetHistory(t *testing.T) {
+	tests := []struct {
+		name      string
+		ref       *ConversationRef
+		responses map[string]fakeResponse
+		want      []port.ConversationMessage
+	}{
+		{
+			name: "スレッドは起点のメッセージから全体を読む",
+			ref:  NewConversationRef("100", "300", "302"),
+			responses: map[string]fakeResponse{
+				"GET /channels/300": {Body: map[string]any{"id": "300", "type": ChannelTypePublicThread, "guild_id": "100", "parent_id": "200"}},
+				"GET /channels/300/messages?limit=100": {Body: []any{
+					message("303", "bot", "Here is the answer"),
+					withMentions(message("302", "alice", "<@bot> how do we deploy?"), "bot"),
+					// スレッドの開始を示すシステムメッセージ
+					map[string]any{"id": "301", "type": 21, "author": map[string]any{"id": "bob"}, "content": ""},
+				}},
+				"GET /channels/200/messages/300": {Body: message("300", "bob", "Deploy failed")},
+			},
+			want: []port.ConversationMessage{
+				{Author: "bob", Content: "Deploy failed"},
+				{Author: "alice", Content: "<@bot> how do we deploy?", YouMentioned: true},
+				{Author: "bot", Content: "Here is the answer", IsYou: true},
+			},
+		},
+		{
+			name: "フォーラムのスレッドは親チャンネルに起点のメッセージがない",
+			ref:  NewConversationRef("100", "300", ""),
+			responses: map[string]fakeResponse{
+				"GET /channels/300":                    {Body: map[string]any{"id": "300", "type": ChannelTypePublicThread, "guild_id": "100", "parent_id": "200"}},
+				"GET /channels/300/messages?limit=100": {Body: []any{message("300", "alice", "Question")}},
+			},
+			want: []port.ConversationMessage{
+				{Author: "alice", Content: "Question"},
+			},
+		},
+		{
+			name: "スレッドの外のメッセージはそのメッセージだけを読む",
+			ref:  NewConversationRef("100", "200", "201"),
+			responses: map[string]fakeResponse{
+				"GET /channels/200":              {Body: map[string]any{"id": "200", "type": ChannelTypeGuildText, "guild_id": "100"}},
+				"GET /channels/200/messages/201": {Body: withMentions(message("201", "alice", "<@bot> hello"), "bot")},
+			},
+			want: []port.ConversationMessage{
+				{Author: "alice", Content: "<@bot> hello", YouMentioned: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.responses["GET /users/@me"] = fakeResponse{Body: map[string]any{"id": "bot", "username": "docgent", "bot": true}}
+			server := newFakeServer(t, tt.responses)
+			service := NewConversationService(server.newAPI(), tt.ref, "alice")
+
+			got, err := service.GetHistory()
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.ref.ToURI(), got.URI)
+			assert.Equal(t, tt.want, got.Messages)
+		})
+	}
+}
+
+func TestConversationService_Reply(t *testing.T) {
+	t.Run("メンションを付けて起点のメッセージに返信する", func(t *testing.T) {
+		server := newFakeServer(t, map[string]fakeResponse{
+			"POST /channels/200/messages": {Body: message("202", "bot", "")},
+		})
+		service := NewConversationService(server.newAPI(), NewConversationRef("100", "200", "201"), "alice")
+
+		err := service.Reply(":warning: エラー: 失敗しました", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"content":           "<@alice>\n⚠️ エラー: 失敗しました",
+			"message_reference": map[string]any{"message_id": "201", "fail_if_not_exists": false},
+			"allowed_mentions":  map[string]any{"parse": []any{}, "users": []any{"alice"}, "replied_user": false},
+		}, server.FindRequest(t, http.MethodPost, "/channels/200/messages").Body)
+	})
+
+	t.Run("長い返信は2000文字以内に分けて投稿する", func(t *testing.T) {
+		server := newFakeServer(t, map[string]fakeResponse{
+			"POST /channels/300/messages": {Body: message("302", "bot", "")},
+		})
+		service := NewConversationService(server.newAPI(), NewConversationRef("100", "300", ""), "alice")
+
+		line := strings.Repeat("a", 990)
+		err := service.Reply(line+"\n"+line+"\n"+line, false)
+
+		assert.NoError(t, err)
+		requests := server.FindRequests(http.MethodPost, "/channels/300/messages")
+		if assert.Len(t, requests, 2) {
+			assert.Equal(t, line+"\n"+line, requests[0].Body.(map[string]any)["content"])
+			assert.Equal(t, line, requests[1].Body.(map[string]any)["content"])
+			// スレッド全体への返信は、特定のメッセージを参照しない
+			assert.NotContains(t, requests[0].Body, "message_reference")
+		}
+	})
+}
+
+func TestConversationService_MarkEyes(t *testing.T) {
+	server := newFakeServer(t, map[string]fakeResponse{
+		"PUT /channels/200/messages/201/reactions/%F0%9F%91%80/@me":    {StatusCode: http.StatusNoContent},
+		"DELETE /channels/200/messages/201/reactions/%F0%9F%91%80/@me": {StatusCode: http.StatusNoContent},
+	})
+	service := NewConversationService(server.newAPI(), NewConversationRef("100", "200", "201"), "alice")
+
+	assert.NoError(t, service.MarkEyes())
+	assert.NoError(t, service.RemoveEyes())
+	assert.Len(t, server.FindRequests(http.MethodPut, "/channels/200/messages/201/reactions/%F0%9F%91%80/@me"), 1)
+	assert.Len(t, server.FindRequests(http.MethodDelete, "/channels/200/messages/201/reactions/%F0%9F%91%80/@me"), 1)
+}
@@ -0,0 +1,39 @@
+package discord
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrorResponse is an error returned by the Discord API
+type ErrorResponse struct {
+	StatusCode int
+	// Code is the JSON error code of Discord, e.g. 10008 for an unknown message
+	Code    int
+	Message string
+}
+
+func newErrorResponse(resp *http.Response) *ErrorResponse {
+	e := &ErrorResponse{StatusCode: resp.StatusCode}
+	body, _ := io.ReadAll(resp.Body)
+	var payload struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Message != "" {
+		e.Code = payload.Code
+		e.Message = payload.Message
+	} else {
+		e.Message = string(body)
+	}
+	return e
+}
+
+func (e *ErrorResponse) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("discord: %d %s (code %d)", e.StatusCode, e.Message, e.Code)
+	}
+	return fmt.Sprintf("discord: %d %s", e.StatusCode, e.Message)
+}
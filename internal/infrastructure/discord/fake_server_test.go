@@ -0,0 +1,46 @@
+package discord
+
+import (
+	"testing"
+
+	"docgent/internal/testutil/fakeapi"
+)
+
+// fakeServer is an httptest fake of the Discord REST API.
+// Responses are keyed by "METHOD escaped-path", with the query appended if any.
+type fakeServer struct {
+	*fakeapi.Server
+}
+
+type fakeResponse = fakeapi.Response
+
+func newFakeServer(t *testing.T, responses map[string]fakeResponse) *fakeServer {
+	return &fakeServer{fakeapi.NewServer(t, fakeapi.Config{
+		AuthHeader:       "Authorization",
+		AuthValue:        "Bot token",
+		UnauthorizedBody: `{"message":"401: Unauthorized","code":0}`,
+		NotFoundBody:     `{"message":"Unknown Message","code":10008}`,
+		KeyQuery:         fakeapi.RawQuery,
+		NoContent:        true,
+	}, responses)}
+}
+
+// newAPI returns an API connected to the fake server
+func (f *fakeServer) newAPI() *API {
+	return NewAPI("token", WithBaseURL(f.URL))
+}
+
+// message returns the JSON of a message posted by the user
+func message(id, authorID, content string) map[string]any {
+	return map[string]any{"id": id, "type": 0, "author": map[string]any{"id": authorID}, "content": content}
+}
+
+// withMentions sets the users mentioned in the message
+func withMentions(message map[string]any, userIDs ...string) map[string]any {
+	mentions := make([]any, len(userIDs))
+	for i, userID := range userIDs {
+		mentions[i] = map[string]any{"id": userID}
+	}
+	message["mentions"] = mentions
+	return message
+}
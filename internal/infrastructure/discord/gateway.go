@@ -0,0 +1,242 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// Event types dispatched by the Gateway that Docgent handles
+const (
+	EventTypeMessageCreate      = "MESSAGE_CREATE"
+	EventTypeMessageReactionAdd = "MESSAGE_REACTION_ADD"
+)
+
+// Gateway opcodes
+const (
+	opDispatch       = 0
+	opHeartbeat      = 1
+	opIdentify       = 2
+	opReconnect      = 7
+	opInvalidSession = 9
+	opHello          = 10
+	opHeartbeatACK   = 11
+)
+
+// Gateway intents. MESSAGE_CONTENT is a privileged intent that must be enabled in the Developer Portal
+const (
+	intentGuilds                = 1 << 0
+	intentGuildMessages         = 1 << 9
+	intentGuildMessageReactions = 1 << 10
+	intentMessageContent        = 1 << 15
+
+	gatewayIntents = intentGuilds | intentGuildMessages | intentGuildMessageReactions | intentMessageContent
+)
+
+// reconnectDelay is the wait before connecting again after the connection is closed
+const reconnectDelay = 5 * time.Second
+
+// Event is an event of a guild received from the Gateway. Either Message or Reaction is set according to Type
+type Event struct {
+	Type     string
+	GuildID  string
+	Message  *Message
+	Reaction *MessageReactionAdd
+}
+
+type gatewayPayload struct {
+	Op       int             `json:"op"`
+	Data     json.RawMessage `json:"d,omitempty"`
+	Sequence *int64          `json:"s,omitempty"`
+	Type     string          `json:"t,omitempty"`
+}
+
+// GatewayClient receives the events of the guilds that the bot is in over the Gateway WebSocket.
+// Discord has no HTTP endpoint for message and reaction events, so the bot keeps this connection open.
+type GatewayClient struct {
+	api    *API
+	dialer *websocket.Dialer
+}
+
+// NewGatewayClient creates a client of the Gateway of the bot
+func (a *API) NewGatewayClient() *GatewayClient {
+	return &GatewayClient{api: a, dialer: websocket.DefaultDialer}
+}
+
+// Run connects to the Gateway and passes the events to handle until ctx is canceled.
+// The connection is opened again when Discord closes it or asks to reconnect.
+// Events sent while reconnecting are not replayed, as sessions are not resumed.
+func (c *GatewayClient) Run(ctx context.Context, logger *zap.Logger, handle func(Event)) error {
+	for {
+		err := c.connect(ctx, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		logger.Warn("Discord Gateway connection closed, reconnecting", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// connect runs a session of the Gateway until the connection is closed
+func (c *GatewayClient) connect(ctx context.Context, handle func(Event)) error {
+	gatewayURL, err := c.api.GetGatewayURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	conn, _, err := c.dialer.DialContext(ctx, gatewayURL+"?v=10&encoding=json", nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to gateway: %w", err)
+	}
+	defer conn.Close()
+
+	// ctx がキャンセルされたら読み込みを止める
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	var hello gatewayPayload
+	if err := conn.ReadJSON(&hello); err != nil {
+		return fmt.Errorf("failed to read hello: %w", err)
+	}
+	if hello.Op != opHello {
+		return fmt.Errorf("unexpected opcode %d instead of hello", hello.Op)
+	}
+	var helloData struct {
+		HeartbeatInterval int64 `json:"heartbeat_interval"`
+	}
+	if err := json.Unmarshal(hello.Data, &helloData); err != nil {
+		return fmt.Errorf("failed to parse hello: %w", err)
+	}
+
+	session := &gatewaySession{conn: conn}
+	if err := session.identify(c.api.token); err != nil {
+		return err
+	}
+
+	heartbeatCtx, cancelHeartbeat := context.WithCancel(ctx)
+	defer cancelHeartbeat()
+	go session.heartbeat(heartbeatCtx, time.Duration(helloData.HeartbeatInterval)*time.Millisecond)
+
+	for {
+		var payload gatewayPayload
+		if err := conn.ReadJSON(&payload); err != nil {
+			return fmt.Errorf("failed to read gateway payload: %w", err)
+		}
+		if payload.Sequence != nil {
+			session.setSequence(*payload.Sequence)
+		}
+
+		switch payload.Op {
+		case opDispatch:
+			if event, ok := parseEvent(payload); ok {
+				handle(event)
+			}
+		case opHeartbeat:
+			if err := session.sendHeartbeat(); err != nil {
+				return err
+			}
+		case opReconnect:
+			return errors.New("gateway requested reconnect")
+		case opInvalidSession:
+			return errors.New("gateway session is invalid")
+		case opHeartbeatACK:
+		}
+	}
+}
+
+// parseEvent returns the events that Docgent handles. Other events are ignored
+func parseEvent(payload gatewayPayload) (Event, bool) {
+	switch payload.Type {
+	case EventTypeMessageCreate:
+		var message Message
+		if err := json.Unmarshal(payload.Data, &message); err != nil {
+			return Event{}, false
+		}
+		return Event{Type: payload.Type, GuildID: message.GuildID, Message: &message}, true
+	case EventTypeMessageReactionAdd:
+		var reaction MessageReactionAdd
+		if err := json.Unmarshal(payload.Data, &reaction); err != nil {
+			return Event{}, false
+		}
+		return Event{Type: payload.Type, GuildID: reaction.GuildID, Reaction: &reaction}, true
+	}
+	return Event{}, false
+}
+
+// gatewaySession serializes the writes to the connection, which are sent from the heartbeat goroutine too
+type gatewaySession struct {
+	conn *websocket.Conn
+
+	lock     sync.Mutex
+	sequence *int64
+}
+
+func (s *gatewaySession) setSequence(sequence int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sequence = &sequence
+}
+
+func (s *gatewaySession) write(payload any) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.conn.WriteJSON(payload)
+}
+
+func (s *gatewaySession) identify(token string) error {
+	data, _ := json.Marshal(map[string]any{
+		"token":   token,
+		"intents": gatewayIntents,
+		"properties": map[string]string{
+			"os":      runtime.GOOS,
+			"browser": "docgent",
+			"device":  "docgent",
+		},
+	})
+	if err := s.write(gatewayPayload{Op: opIdentify, Data: data}); err != nil {
+		return fmt.Errorf("failed to identify: %w", err)
+	}
+	return nil
+}
+
+func (s *gatewaySession) sendHeartbeat() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	// 最後に受け取ったシーケンス番号を送る。まだ受け取っていなければ null
+	data, _ := json.Marshal(s.sequence)
+	if err := s.conn.WriteJSON(gatewayPayload{Op: opHeartbeat, Data: data}); err != nil {
+		return fmt.Errorf("failed to send heartbeat: %w", err)
+	}
+	return nil
+}
+
+func (s *gatewaySession) heartbeat(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.sendHeartbeat(); err != nil {
+				// 書き込めない接続は読み込みも失敗するので、再接続は connect に任せる
+				return
+			}
+		}
+	}
+}
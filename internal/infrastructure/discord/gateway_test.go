@@ -0,0 +1,94 @@
+package discord
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestGatewayClient_Run(t *testing.T) {
+	identified := make(chan map[string]any, 1)
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.WriteJSON(map[string]any{"op": opHello, "d": map[string]any{"heartbeat_interval": 60000}})
+
+		var identify struct {
+			Op   int            `json:"op"`
+			Data map[string]any `json:"d"`
+		}
+		if err := conn.ReadJSON(&identify); err != nil || identify.Op != opIdentify {
+			return
+		}
+		identified <- identify.Data
+
+		conn.WriteJSON(map[string]any{"op": opDispatch, "s": 1, "t": "READY", "d": map[string]any{}})
+		conn.WriteJSON(map[string]any{"op": opDispatch, "s": 2, "t": EventTypeMessageCreate, "d": message("201", "alice", "<@bot> hello")})
+		conn.WriteJSON(map[string]any{"op": opDispatch, "s": 3, "t": EventTypeMessageReactionAdd, "d": map[string]any{
+			"user_id": "alice", "channel_id": "200", "message_id": "201", "guild_id": "100", "emoji": map[string]any{"name": "📝"},
+		}})
+		// クライアントが切断するまで待つ
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(gateway.Close)
+
+	server := newFakeServer(t, map[string]fakeResponse{
+		"GET /gateway/bot": {Body: map[string]any{"url": "ws" + strings.TrimPrefix(gateway.URL, "http")}},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan Event, 2)
+	done := make(chan error)
+	go func() {
+		done <- server.newAPI().NewGatewayClient().Run(ctx, zap.NewNop(), func(event Event) { events <- event })
+	}()
+
+	select {
+	case data := <-identified:
+		assert.Equal(t, "token", data["token"])
+		assert.EqualValues(t, gatewayIntents, data["intents"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("identify was not sent")
+	}
+
+	var got []Event
+	for len(got) < 2 {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case <-time.After(5 * time.Second):
+			t.Fatal("events were not dispatched")
+		}
+	}
+	if assert.Equal(t, EventTypeMessageCreate, got[0].Type) {
+		assert.Equal(t, "<@bot> hello", got[0].Message.Content)
+	}
+	assert.Equal(t, Event{
+		Type:     EventTypeMessageReactionAdd,
+		GuildID:  "100",
+		Reaction: &MessageReactionAdd{UserID: "alice", ChannelID: "200", MessageID: "201", GuildID: "100", Emoji: Emoji{Name: "📝"}},
+	}, got[1])
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
@@ -0,0 +1,83 @@
+package discord
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// maxMessageLength is the limit of the content of a message
+const maxMessageLength = 2000
+
+// emojiShortcodes are the Slack style shortcodes used in the replies of the handlers.
+// Discord only converts shortcodes typed in the client, so bots must send the emojis themselves.
+var emojiShortcodes = strings.NewReplacer(
+	":warning:", "⚠️",
+	":white_check_mark:", "✅",
+	":x:", "❌",
+	":eyes:", "👀",
+	":no_entry:", "⛔",
+)
+
+// splitMessage splits text into chunks of at most limit characters at line breaks.
+// A code block split across chunks is closed and reopened so that each chunk renders on its own.
+func splitMessage(text string, limit int) []string {
+	const fence = "```"
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	var chunks []string
+	var current strings.Builder
+	currentLength := 0
+	inCode := false
+
+	flush := func() {
+		chunk := strings.TrimRight(current.String(), "\n")
+		if inCode {
+			chunk += "\n" + fence
+		}
+		if strings.TrimSpace(chunk) != "" {
+			chunks = append(chunks, chunk)
+		}
+		current.Reset()
+		currentLength = 0
+		if inCode {
+			current.WriteString(fence + "\n")
+			currentLength = len(fence) + 1
+		}
+	}
+
+	// 閉じるためのフェンスの分を空けておく
+	lineLimit := limit - len(fence) - 1
+	for _, line := range strings.Split(text, "\n") {
+		for utf8.RuneCountInString(line) > lineLimit-currentLength {
+			if currentLength > len(fence)+1 {
+				flush()
+				continue
+			}
+			// 1行が長すぎる場合は途中で分割する
+			head := string([]rune(line)[:lineLimit-currentLength])
+			current.WriteString(head)
+			line = strings.TrimPrefix(line, head)
+			flush()
+		}
+		toggles := strings.Count(line, fence)%2 == 1
+		if toggles && inCode && currentLength == len(fence)+1 {
+			// 開き直しただけのコードブロックは閉じずに取り除く
+			current.Reset()
+			currentLength = 0
+			inCode = false
+			continue
+		}
+		current.WriteString(line + "\n")
+		currentLength += utf8.RuneCountInString(line) + 1
+		if toggles {
+			inCode = !inCode
+		}
+	}
+	inCode = false
+	flush()
+
+	return chunks
+}
@@ -0,0 +1,120 @@
+package discord
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/tooluse"
+)
+
+var (
+	reFence              = regexp.MustCompile("^\\s*(```|~~~)")
+	reFootnoteDefinition = regexp.MustCompile(`^\[\^([^\]]+)\]:\s*(.*)$`)
+	reFootnoteRef        = regexp.MustCompile(`\[\^([^\]]+)\]`)
+	reDeepHeading        = regexp.MustCompile(`^\s{0,3}#{4,6}\s+`)
+	reRule               = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	reTableSeparator     = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+)
+
+// ResponseFormatter implements the port.ResponseFormatter interface for Discord
+type ResponseFormatter struct{}
+
+func NewResponseFormatter() port.ResponseFormatter {
+	return &ResponseFormatter{}
+}
+
+// FormatResponse rewrites the Markdown that Discord cannot render: footnotes, tables, deep headings and rules.
+// Sources are numbered in order and listed at the end in small text, without link previews.
+func (f *ResponseFormatter) FormatResponse(toolUse tooluse.AttemptComplete) (string, error) {
+	footnoteNumbers := make(map[string]int, len(toolUse.Sources))
+	for i, s := range toolUse.Sources {
+		footnoteNumbers[s.ID] = i + 1
+	}
+	footnoteNumber := func(id string) int {
+		if number, ok := footnoteNumbers[id]; ok {
+			return number
+		}
+		number := len(footnoteNumbers) + 1
+		footnoteNumbers[id] = number
+		return number
+	}
+
+	var markdown strings.Builder
+	for _, m := range toolUse.Messages {
+		markdown.WriteString(strings.TrimRight(m.Text, "\n"))
+		for _, sourceID := range m.GetSourceIDs() {
+			markdown.WriteString(fmt.Sprintf("[^%s]", strings.TrimSpace(sourceID)))
+		}
+		markdown.WriteString("\n")
+	}
+
+	var out, footnotes []string
+	lines := strings.Split(markdown.String(), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := reFence.FindStringSubmatch(line); m != nil {
+			// コードブロックの中身は変換しない
+			out = append(out, line)
+			for i++; i < len(lines); i++ {
+				out = append(out, lines[i])
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]) {
+					break
+				}
+			}
+			continue
+		}
+
+		if m := reFootnoteDefinition.FindStringSubmatch(line); m != nil {
+			footnotes = append(footnotes, fmt.Sprintf("-# [%d] %s", footnoteNumber(m[1]), m[2]))
+			continue
+		}
+
+		// Discordには表がないので、等幅で表示する
+		if strings.HasPrefix(strings.TrimSpace(line), "|") && i+1 < len(lines) && reTableSeparator.MatchString(lines[i+1]) {
+			table := []string{"```", line}
+			for i++; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				table = append(table, lines[i])
+			}
+			i--
+			out = append(out, strings.Join(append(table, "```"), "\n"))
+			continue
+		}
+
+		if reRule.MatchString(line) {
+			out = append(out, "──────────")
+			continue
+		}
+
+		// 見出しは3段階まで
+		line = reDeepHeading.ReplaceAllString(line, "### ")
+		line = reFootnoteRef.ReplaceAllStringFunc(line, func(ref string) string {
+			return fmt.Sprintf("[%d]", footnoteNumber(reFootnoteRef.FindStringSubmatch(ref)[1]))
+		})
+		out = append(out, line)
+	}
+
+	text := strings.TrimSpace(strings.Join(out, "\n"))
+
+	var citations []string
+	for i, s := range toolUse.Sources {
+		citations = append(citations, fmt.Sprintf("-# [%d] %s", i+1, markdownLink(s.URI, s.Name)))
+	}
+	citations = append(citations, footnotes...)
+	if len(citations) > 0 {
+		text += "\n\n" + strings.Join(citations, "\n")
+	}
+
+	return strings.TrimSpace(text), nil
+}
+
+// markdownLink returns a masked link whose URL is wrapped in angle brackets, which suppresses the preview
+func markdownLink(url, text string) string {
+	if text == "" || text == url {
+		return "<" + url + ">"
+	}
+	text = strings.NewReplacer("[", "(", "]", ")").Replace(text)
+	return "[" + text + "](<" + url + ">)"
+}
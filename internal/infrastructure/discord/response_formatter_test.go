@@ -0,0 +1,58 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/domain/tooluse"
+)
+
+func TestResponseFormatter_FormatResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		toolUse tooluse.AttemptComplete
+		want    string
+	}{
+		{
+			name: "DiscordのMarkdownはそのまま",
+			toolUse: tooluse.NewAttemptComplete([]tooluse.Message{
+				tooluse.NewMessage("## Answer\nSee **the guide**.\n- item"),
+			}, nil),
+			want: "## Answer\nSee **the guide**.\n- item",
+		},
+		{
+			name: "ソースに番号を振って末尾に小さく並べる",
+			toolUse: tooluse.NewAttemptComplete([]tooluse.Message{
+				tooluse.NewMessage("Here is the answer:"),
+				{SourceID: "b,a", Text: "- Docgent writes docs"},
+			}, []tooluse.Source{
+				{ID: "a", URI: "https://example.com/a", Name: "A"},
+				{ID: "b", URI: "https://example.com/b", Name: "Guide [draft]"},
+			}),
+			want: "Here is the answer:\n- Docgent writes docs[2][1]\n\n-# [1] [A](<https://example.com/a>)\n-# [2] [Guide (draft)](<https://example.com/b>)",
+		},
+		{
+			name: "本文中の脚注を番号に置き換える",
+			toolUse: tooluse.NewAttemptComplete([]tooluse.Message{
+				tooluse.NewMessage("Deploys run nightly[^ops].\n\n[^ops]: Ops handbook"),
+			}, nil),
+			want: "Deploys run nightly[1].\n\n-# [1] Ops handbook",
+		},
+		{
+			name: "表はコードブロックで表示し、深い見出しは3段階にする",
+			toolUse: tooluse.NewAttemptComplete([]tooluse.Message{
+				tooluse.NewMessage("#### Options\n| Name | Default |\n| --- | --- |\n| draft | false |\n\n---\n```\n[^a] in code\n```"),
+			}, nil),
+			want: "### Options\n```\n| Name | Default |\n| --- | --- |\n| draft | false |\n```\n\n──────────\n```\n[^a] in code\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewResponseFormatter().FormatResponse(tt.toolUse)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
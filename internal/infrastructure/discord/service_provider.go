@@ -0,0 +1,62 @@
+package discord
+
+import (
+	"context"
+
+	"docgent/internal/application/port"
+)
+
+// ServiceProvider creates Discord services that share the API
+type ServiceProvider struct {
+	api *API
+}
+
+func NewServiceProvider(api *API) *ServiceProvider {
+	return &ServiceProvider{api: api}
+}
+
+// CurrentUser returns the bot user, which is mentioned to call Docgent
+func (p *ServiceProvider) CurrentUser(ctx context.Context) (*User, error) {
+	return p.api.CurrentUser(ctx)
+}
+
+func (p *ServiceProvider) NewConversationService(ref *ConversationRef, fromUserID string) port.ConversationService {
+	return NewConversationService(p.api, ref, fromUserID)
+}
+
+// ResolveConversationRef returns the ref of the conversation that the message belongs to.
+// A message in a thread or a message that started a thread belongs to the whole thread.
+func (p *ServiceProvider) ResolveConversationRef(ctx context.Context, guildID, channelID, messageID string) (*ConversationRef, error) {
+	channel, err := p.api.GetChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if channel.IsThread() {
+		return NewConversationRef(guildID, channel.ID, ""), nil
+	}
+
+	message, err := p.api.GetMessage(ctx, channelID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.Thread != nil {
+		return NewConversationRef(guildID, message.Thread.ID, ""), nil
+	}
+	return NewConversationRef(guildID, channelID, messageID), nil
+}
+
+func (p *ServiceProvider) NewConversationResolver() port.ConversationResolver {
+	return NewConversationResolver(p.api)
+}
+
+func (p *ServiceProvider) NewSourceRepository() *SourceRepository {
+	return NewSourceRepository(p.api)
+}
+
+func (p *ServiceProvider) NewSourceLocator() *SourceLocator {
+	return NewSourceLocator(p.api)
+}
+
+func (p *ServiceProvider) NewResponseFormatter() port.ResponseFormatter {
+	return NewResponseFormatter()
+}
@@ -0,0 +1,55 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// SourceLocator resolves the channel and visibility of Discord conversations
+type SourceLocator struct {
+	api *API
+}
+
+func NewSourceLocator(api *API) *SourceLocator {
+	return &SourceLocator{api: api}
+}
+
+func (l *SourceLocator) Match(uri *data.URI) bool {
+	return isDiscordHost(uri.Host())
+}
+
+// Locate returns the channel of the conversation. Threads are located in their parent channel,
+// so that the channel lists of the source policy apply to the threads in the channels.
+func (l *SourceLocator) Locate(ctx context.Context, uri *data.URI) (port.SourceLocation, error) {
+	ref, err := ParseConversationRef(uri)
+	if err != nil {
+		return port.SourceLocation{}, fmt.Errorf("failed to parse conversation ref: %w", err)
+	}
+
+	channel, err := l.api.GetChannel(ctx, ref.ChannelID())
+	if err != nil {
+		return port.SourceLocation{}, err
+	}
+
+	switch {
+	case channel.IsDirectMessage():
+		return port.SourceLocation{ContainerID: channel.ID, Visibility: port.SourceVisibilityDirectMessage}, nil
+	case channel.Type == ChannelTypePrivateThread:
+		return port.SourceLocation{ContainerID: channel.ParentID, Visibility: port.SourceVisibilityPrivate}, nil
+	case channel.IsThread():
+		channel, err = l.api.GetChannel(ctx, channel.ParentID)
+		if err != nil {
+			return port.SourceLocation{}, err
+		}
+	}
+
+	// @everyone が閲覧できないチャンネルは、特定のロールやメンバーだけのプライベートなチャンネル
+	visibility := port.SourceVisibilityPublic
+	if channel.deniesEveryone() {
+		visibility = port.SourceVisibilityPrivate
+	}
+	return port.SourceLocation{ContainerID: channel.ID, Visibility: visibility}, nil
+}
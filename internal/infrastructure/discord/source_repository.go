@@ -0,0 +1,50 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"docgent/internal/domain/data"
+)
+
+// SourceRepository reads Discord threads and messages as sources
+type SourceRepository struct {
+	api *API
+}
+
+func NewSourceRepository(api *API) *SourceRepository {
+	return &SourceRepository{api: api}
+}
+
+func (r *SourceRepository) Match(uri *data.URI) bool {
+	return isDiscordHost(uri.Host())
+}
+
+func (r *SourceRepository) Find(ctx context.Context, uri *data.URI) (*data.Source, error) {
+	ref, err := ParseConversationRef(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse conversation ref: %w", err)
+	}
+
+	messages, err := listConversationMessages(ctx, r.api, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation messages: %w", err)
+	}
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("<conversation uri=%q>\n", uri))
+
+	for _, message := range messages {
+		// スレッド内の特定のメッセージが指定されている場合、そのメッセージにマークを付ける
+		if message.ID == ref.MessageID() && len(messages) > 1 {
+			content.WriteString(fmt.Sprintf("<message user=%q highlighted=\"true\">\n%s\n</message>\n", message.Author.ID, message.Content))
+		} else {
+			content.WriteString(fmt.Sprintf("<message user=%q>\n%s\n</message>\n", message.Author.ID, message.Content))
+		}
+	}
+
+	content.WriteString("</conversation>")
+
+	return data.NewSource(uri, content.String()), nil
+}
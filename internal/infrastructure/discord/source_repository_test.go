@@ -0,0 +1,101 @@
+package discord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+func TestSourceRepository_Find(t *testing.T) {
+	server := newFakeServer(t, map[string]fakeResponse{
+		"GET /channels/200":              {Body: map[string]any{"id": "200", "type": ChannelTypeGuildText, "guild_id": "100"}},
+		"GET /channels/200/messages/201": {Body: map[string]any{"id": "201", "type": 0, "author": map[string]any{"id": "alice"}, "content": "Deploy failed", "thread": map[string]any{"id": "201", "type": ChannelTypePublicThread, "parent_id": "200"}}},
+		"GET /channels/201/messages?limit=100": {Body: []any{
+			message("203", "bob", "Fixed by rollback"),
+			message("202", "alice", "Retrying"),
+		}},
+	})
+	uri := data.NewURIUnsafe("https://discord.com/channels/100/200/201")
+
+	got, err := NewSourceRepository(server.newAPI()).Find(context.Background(), uri)
+
+	assert.NoError(t, err)
+	assert.Equal(t, data.NewSource(uri, `<conversation uri="https://discord.com/channels/100/200/201">
+<message user="alice" highlighted="true">
+Deploy failed
+</message>
+<message user="alice">
+Retrying
+</message>
+<message user="bob">
+Fixed by rollback
+</message>
+</conversation>`), got)
+}
+
+func TestSourceLocator_Locate(t *testing.T) {
+	denyEveryone := []any{map[string]any{"id": "100", "type": 0, "allow": "0", "deny": "1024"}}
+	tests := []struct {
+		name      string
+		uri       string
+		responses map[string]fakeResponse
+		want      port.SourceLocation
+	}{
+		{
+			name: "公開チャンネル",
+			uri:  "https://discord.com/channels/100/200/201",
+			responses: map[string]fakeResponse{
+				"GET /channels/200": {Body: map[string]any{"id": "200", "type": ChannelTypeGuildText, "guild_id": "100", "permission_overwrites": []any{map[string]any{"id": "999", "type": 0, "allow": "0", "deny": "1024"}}}},
+			},
+			want: port.SourceLocation{ContainerID: "200", Visibility: port.SourceVisibilityPublic},
+		},
+		{
+			name: "@everyoneが閲覧できないチャンネル",
+			uri:  "https://discord.com/channels/100/200/201",
+			responses: map[string]fakeResponse{
+				"GET /channels/200": {Body: map[string]any{"id": "200", "type": ChannelTypeGuildText, "guild_id": "100", "permission_overwrites": denyEveryone}},
+			},
+			want: port.SourceLocation{ContainerID: "200", Visibility: port.SourceVisibilityPrivate},
+		},
+		{
+			name: "公開スレッドは親チャンネルの公開範囲に従う",
+			uri:  "https://discord.com/channels/100/300",
+			responses: map[string]fakeResponse{
+				"GET /channels/300": {Body: map[string]any{"id": "300", "type": ChannelTypePublicThread, "guild_id": "100", "parent_id": "200"}},
+				"GET /channels/200": {Body: map[string]any{"id": "200", "type": ChannelTypeGuildText, "guild_id": "100", "permission_overwrites": denyEveryone}},
+			},
+			want: port.SourceLocation{ContainerID: "200", Visibility: port.SourceVisibilityPrivate},
+		},
+		{
+			name: "プライベートスレッド",
+			uri:  "https://discord.com/channels/100/300",
+			responses: map[string]fakeResponse{
+				"GET /channels/300": {Body: map[string]any{"id": "300", "type": ChannelTypePrivateThread, "guild_id": "100", "parent_id": "200"}},
+			},
+			want: port.SourceLocation{ContainerID: "200", Visibility: port.SourceVisibilityPrivate},
+		},
+		{
+			name: "DM",
+			uri:  "https://discord.com/channels/@me/400/401",
+			responses: map[string]fakeResponse{
+				"GET /channels/400": {Body: map[string]any{"id": "400", "type": ChannelTypeDM}},
+			},
+			want: port.SourceLocation{ContainerID: "400", Visibility: port.SourceVisibilityDirectMessage},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t, tt.responses)
+
+			got, err := NewSourceLocator(server.newAPI()).Locate(context.Background(), data.NewURIUnsafe(tt.uri))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package discord
+
+import (
+	"slices"
+	"strconv"
+)
+
+// Channel types of Discord
+const (
+	ChannelTypeGuildText          = 0
+	ChannelTypeDM                 = 1
+	ChannelTypeGroupDM            = 3
+	ChannelTypeGuildAnnouncement  = 5
+	ChannelTypeAnnouncementThread = 10
+	ChannelTypePublicThread       = 11
+	ChannelTypePrivateThread      = 12
+	ChannelTypeGuildForum         = 15
+)
+
+// permissionViewChannel is the VIEW_CHANNEL permission bit
+const permissionViewChannel = 1 << 10
+
+// overwriteTypeRole is the type of permission overwrites for roles. @everyone is the role with the ID of the guild
+const overwriteTypeRole = 0
+
+// User is a Discord user or bot
+type User struct {
+	ID         string `json:"id"`
+	Username   string `json:"username"`
+	GlobalName string `json:"global_name"`
+	Bot        bool   `json:"bot"`
+}
+
+// Message is a message in a channel or thread
+type Message struct {
+	ID        string `json:"id"`
+	Type      int    `json:"type"`
+	ChannelID string `json:"channel_id"`
+	// GuildID is only set in Gateway events
+	GuildID   string `json:"guild_id"`
+	Author    User   `json:"author"`
+	Content   string `json:"content"`
+	Timestamp string `json:"timestamp"`
+	Mentions  []User `json:"mentions"`
+	// Thread is the thread started from the message, if any
+	Thread *Channel `json:"thread"`
+}
+
+// MentionsUser reports whether the message mentions the user
+func (m Message) MentionsUser(userID string) bool {
+	return slices.ContainsFunc(m.Mentions, func(user User) bool { return user.ID == userID })
+}
+
+// Channel is a channel or thread of a guild, or a direct message
+type Channel struct {
+	ID      string `json:"id"`
+	Type    int    `json:"type"`
+	GuildID string `json:"guild_id"`
+	Name    string `json:"name"`
+	// ParentID is the channel of a thread, or the category of a channel
+	ParentID             string                `json:"parent_id"`
+	PermissionOverwrites []PermissionOverwrite `json:"permission_overwrites"`
+}
+
+// IsThread reports whether the channel is a thread
+func (c Channel) IsThread() bool {
+	return c.Type == ChannelTypeAnnouncementThread || c.Type == ChannelTypePublicThread || c.Type == ChannelTypePrivateThread
+}
+
+// IsDirectMessage reports whether the channel is a DM or a group DM
+func (c Channel) IsDirectMessage() bool {
+	return c.Type == ChannelTypeDM || c.Type == ChannelTypeGroupDM
+}
+
+// deniesEveryone reports whether @everyone cannot view the channel
+func (c Channel) deniesEveryone() bool {
+	for _, overwrite := range c.PermissionOverwrites {
+		if overwrite.Type == overwriteTypeRole && overwrite.ID == c.GuildID {
+			// Permissions are serialized as strings because they may exceed 53 bits
+			deny, err := strconv.ParseUint(overwrite.Deny, 10, 64)
+			if err == nil && deny&permissionViewChannel != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PermissionOverwrite is an explicit permission of a role or a member on a channel
+type PermissionOverwrite struct {
+	ID    string `json:"id"`
+	Type  int    `json:"type"`
+	Allow string `json:"allow"`
+	Deny  string `json:"deny"`
+}
+
+// Emoji is the emoji of a reaction. ID is empty for Unicode emojis
+type Emoji struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// MessageReactionAdd is the MESSAGE_REACTION_ADD event of the Gateway
+type MessageReactionAdd struct {
+	UserID    string `json:"user_id"`
+	ChannelID string `json:"channel_id"`
+	MessageID string `json:"message_id"`
+	GuildID   string `json:"guild_id"`
+	Emoji     Emoji  `json:"emoji"`
+}
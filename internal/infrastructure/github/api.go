@@ -3,6 +3,8 @@ package github
 import (
 	"log"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v68/github"
@@ -11,10 +13,46 @@ import (
 type API struct {
 	appID      int64
 	privateKey []byte
+	appSlug    string
+	baseURL    string
+	uploadURL  string
 }
 
-func NewAPI(appID int64, privateKey []byte) *API {
-	return &API{appID: appID, privateKey: privateKey}
+type NewAPIOption func(*API)
+
+// WithAppSlug sets the slug of the GitHub App, which users mention as "@<slug>"
+func WithAppSlug(appSlug string) NewAPIOption {
+	return func(a *API) {
+		a.appSlug = appSlug
+	}
+}
+
+// WithEnterpriseURLs sets the API and upload URLs of GitHub Enterprise Server, e.g. "https://ghes.example.com/api/v3/".
+// If uploadURL is empty, baseURL is used for uploads as well.
+func WithEnterpriseURLs(baseURL, uploadURL string) NewAPIOption {
+	return func(a *API) {
+		if uploadURL == "" {
+			uploadURL = baseURL
+		}
+		a.baseURL = baseURL
+		a.uploadURL = uploadURL
+	}
+}
+
+func NewAPI(appID int64, privateKey []byte, options ...NewAPIOption) *API {
+	a := &API{appID: appID, privateKey: privateKey}
+	for _, option := range options {
+		option(a)
+	}
+	return a
+}
+
+// Mention returns the mention of the app in comments, or an empty string if the slug is not configured
+func (a *API) Mention() string {
+	if a.appSlug == "" {
+		return ""
+	}
+	return "@" + a.appSlug
 }
 
 func (a *API) NewClient(installationID int64) *github.Client {
@@ -30,5 +68,26 @@ func (a *API) NewClient(installationID int64) *github.Client {
 	// Use installation transport with github.com/google/go-github
 	client := github.NewClient(&http.Client{Transport: itr})
 
+	if a.baseURL != "" {
+		client, err = client.WithEnterpriseURLs(a.baseURL, a.uploadURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// Installation tokens must be issued by the same GitHub Enterprise Server
+		itr.BaseURL = strings.TrimSuffix(client.BaseURL.String(), "/")
+	}
+
 	return client
 }
+
+// WebURL returns the web URL of the GitHub instance, e.g. "https://github.com"
+func (a *API) WebURL() string {
+	if a.baseURL == "" {
+		return DefaultWebURL
+	}
+	baseURL, err := url.Parse(a.baseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return webURLFromAPIURL(baseURL)
+}
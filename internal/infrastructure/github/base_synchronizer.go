@@ -0,0 +1,271 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v68/github"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+)
+
+// BaseSynchronizer merges the latest base branch into the head branch of a pull request
+type BaseSynchronizer struct {
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+var _ port.ProposalBaseSynchronizer = (*BaseSynchronizer)(nil)
+
+func NewBaseSynchronizer(client *github.Client, owner, repo string) *BaseSynchronizer {
+	return &BaseSynchronizer{
+		client: client,
+		owner:  owner,
+		repo:   repo,
+	}
+}
+
+// baseMerge is the state of a pull request needed to merge its base branch by hand
+type baseMerge struct {
+	headBranch   string
+	baseBranch   string
+	headSHA      string
+	baseSHA      string
+	mergeBaseSHA string
+	// headFiles are the files changed by the pull request since the merge base
+	headFiles []*github.CommitFile
+	conflicts []port.FileConflict
+}
+
+func mergeCommitMessage(baseBranch, headBranch string) string {
+	return fmt.Sprintf("Merge branch '%s' into %s", baseBranch, headBranch)
+}
+
+// MergeBase merges the base branch with the merges API, which fails without changing the branch if it conflicts
+func (s *BaseSynchronizer) MergeBase(ctx context.Context, handle domain.ProposalHandle) (port.BaseSyncResult, error) {
+	number, err := strconv.Atoi(handle.Value)
+	if err != nil {
+		return port.BaseSyncResult{}, fmt.Errorf("failed to parse proposal handle to pull request number: %w", err)
+	}
+
+	pr, _, err := s.client.PullRequests.Get(ctx, s.owner, s.repo, number)
+	if err != nil {
+		return port.BaseSyncResult{}, fmt.Errorf("failed to get pull request: %w", err)
+	}
+	headBranch := pr.GetHead().GetRef()
+	baseBranch := pr.GetBase().GetRef()
+
+	_, resp, err := s.client.Repositories.Merge(ctx, s.owner, s.repo, &github.RepositoryMergeRequest{
+		Base:          github.Ptr(headBranch),
+		Head:          github.Ptr(baseBranch),
+		CommitMessage: github.Ptr(mergeCommitMessage(baseBranch, headBranch)),
+	})
+	if resp != nil && resp.StatusCode == http.StatusConflict {
+		merge, err := s.inspect(ctx, headBranch, baseBranch)
+		if err != nil {
+			return port.BaseSyncResult{}, err
+		}
+		return port.BaseSyncResult{Status: port.BaseSyncConflicted, Conflicts: merge.conflicts}, nil
+	}
+	if err != nil {
+		return port.BaseSyncResult{}, fmt.Errorf("failed to merge %s into %s: %w", baseBranch, headBranch, err)
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return port.BaseSyncResult{Status: port.BaseSyncUpToDate}, nil
+	}
+	return port.BaseSyncResult{Status: port.BaseSyncMerged}, nil
+}
+
+// MergeBaseWithResolutions creates a merge commit whose tree is the latest base
+// with the changes of the pull request and the resolved contents of the conflicted files
+func (s *BaseSynchronizer) MergeBaseWithResolutions(ctx context.Context, handle domain.ProposalHandle, resolutions map[string]string) error {
+	number, err := strconv.Atoi(handle.Value)
+	if err != nil {
+		return fmt.Errorf("failed to parse proposal handle to pull request number: %w", err)
+	}
+
+	pr, _, err := s.client.PullRequests.Get(ctx, s.owner, s.repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	merge, err := s.inspect(ctx, pr.GetHead().GetRef(), pr.GetBase().GetRef())
+	if err != nil {
+		return err
+	}
+	// ベースブランチが競合の解消中にさらに進んだ場合は、新しい競合が解消されていないことがある
+	for _, conflict := range merge.conflicts {
+		if _, ok := resolutions[conflict.Path]; !ok {
+			return fmt.Errorf("conflict in %s is not resolved", conflict.Path)
+		}
+	}
+
+	var entries []*github.TreeEntry
+	for _, file := range merge.headFiles {
+		if file.GetStatus() == "renamed" {
+			entries = append(entries, deletedTreeEntry(file.GetPreviousFilename()))
+		}
+		if content, ok := resolutions[file.GetFilename()]; ok {
+			entries = append(entries, &github.TreeEntry{
+				Path:    github.Ptr(file.GetFilename()),
+				Mode:    github.Ptr("100644"),
+				Type:    github.Ptr("blob"),
+				Content: github.Ptr(content),
+			})
+			continue
+		}
+		if file.GetStatus() == "removed" {
+			entries = append(entries, deletedTreeEntry(file.GetFilename()))
+			continue
+		}
+		entries = append(entries, &github.TreeEntry{
+			Path: github.Ptr(file.GetFilename()),
+			Mode: github.Ptr("100644"),
+			Type: github.Ptr("blob"),
+			SHA:  github.Ptr(file.GetSHA()),
+		})
+	}
+
+	baseCommit, _, err := s.client.Git.GetCommit(ctx, s.owner, s.repo, merge.baseSHA)
+	if err != nil {
+		return fmt.Errorf("failed to get base commit: %w", err)
+	}
+
+	tree, _, err := s.client.Git.CreateTree(ctx, s.owner, s.repo, baseCommit.GetTree().GetSHA(), entries)
+	if err != nil {
+		return fmt.Errorf("failed to create tree: %w", err)
+	}
+
+	commit, _, err := s.client.Git.CreateCommit(ctx, s.owner, s.repo, &github.Commit{
+		Message: github.Ptr(mergeCommitMessage(merge.baseBranch, merge.headBranch)),
+		Tree:    &github.Tree{SHA: tree.SHA},
+		Parents: []*github.Commit{{SHA: github.Ptr(merge.headSHA)}, {SHA: github.Ptr(merge.baseSHA)}},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create merge commit: %w", err)
+	}
+
+	// 作成したコミットはheadの子孫なので強制する必要はなく、その間にheadが進んでいれば失敗する
+	_, _, err = s.client.Git.UpdateRef(ctx, s.owner, s.repo, &github.Reference{
+		Ref:    github.Ptr(fmt.Sprintf("refs/heads/%s", merge.headBranch)),
+		Object: &github.GitObject{SHA: commit.SHA},
+	}, false)
+	if err != nil {
+		return fmt.Errorf("failed to update head branch: %w", err)
+	}
+	return nil
+}
+
+// inspect finds the files changed differently by the head and base branches since their merge base
+func (s *BaseSynchronizer) inspect(ctx context.Context, headBranch, baseBranch string) (*baseMerge, error) {
+	merge := &baseMerge{headBranch: headBranch, baseBranch: baseBranch}
+
+	var err error
+	if merge.headSHA, err = s.getBranchSHA(ctx, headBranch); err != nil {
+		return nil, err
+	}
+	if merge.baseSHA, err = s.getBranchSHA(ctx, baseBranch); err != nil {
+		return nil, err
+	}
+
+	headComparison, _, err := s.client.Repositories.CompareCommits(ctx, s.owner, s.repo, merge.baseSHA, merge.headSHA, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s with %s: %w", headBranch, baseBranch, err)
+	}
+	merge.mergeBaseSHA = headComparison.GetMergeBaseCommit().GetSHA()
+	merge.headFiles = headComparison.Files
+
+	baseComparison, _, err := s.client.Repositories.CompareCommits(ctx, s.owner, s.repo, merge.mergeBaseSHA, merge.baseSHA, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s with the merge base: %w", baseBranch, err)
+	}
+	changedInBase := make(map[string]bool)
+	for _, file := range baseComparison.Files {
+		changedInBase[file.GetFilename()] = true
+		if file.GetPreviousFilename() != "" {
+			changedInBase[file.GetPreviousFilename()] = true
+		}
+	}
+
+	for _, file := range merge.headFiles {
+		path := file.GetFilename()
+		if !changedInBase[path] {
+			continue
+		}
+		conflict, ok, err := s.inspectFile(ctx, merge, path)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			merge.conflicts = append(merge.conflicts, conflict)
+		}
+	}
+	return merge, nil
+}
+
+// inspectFile returns false if both branches have the same content
+func (s *BaseSynchronizer) inspectFile(ctx context.Context, merge *baseMerge, path string) (port.FileConflict, bool, error) {
+	base, _, err := s.getContentAt(ctx, path, merge.mergeBaseSHA)
+	if err != nil {
+		return port.FileConflict{}, false, err
+	}
+	ours, oursExists, err := s.getContentAt(ctx, path, merge.headSHA)
+	if err != nil {
+		return port.FileConflict{}, false, err
+	}
+	theirs, theirsExists, err := s.getContentAt(ctx, path, merge.baseSHA)
+	if err != nil {
+		return port.FileConflict{}, false, err
+	}
+	if oursExists == theirsExists && ours == theirs {
+		return port.FileConflict{}, false, nil
+	}
+	return port.FileConflict{
+		Path:    path,
+		Base:    base,
+		Ours:    ours,
+		Theirs:  theirs,
+		Deleted: !oursExists || !theirsExists,
+	}, true, nil
+}
+
+func (s *BaseSynchronizer) getBranchSHA(ctx context.Context, branch string) (string, error) {
+	ref, _, err := s.client.Git.GetRef(ctx, s.owner, s.repo, fmt.Sprintf("refs/heads/%s", branch))
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s branch reference: %w", branch, err)
+	}
+	return ref.GetObject().GetSHA(), nil
+}
+
+// getContentAt returns false if the file does not exist at the commit
+func (s *BaseSynchronizer) getContentAt(ctx context.Context, path, sha string) (string, bool, error) {
+	file, _, resp, err := s.client.Repositories.GetContents(ctx, s.owner, s.repo, path, &github.RepositoryContentGetOptions{Ref: sha})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get %s at %s: %w", path, sha, err)
+	}
+	if file == nil {
+		return "", false, errors.New(path + " is a directory")
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return content, true, nil
+}
+
+// deletedTreeEntry removes the path from the base tree
+func deletedTreeEntry(path string) *github.TreeEntry {
+	return &github.TreeEntry{
+		Path: github.Ptr(path),
+		Mode: github.Ptr("100644"),
+		Type: github.Ptr("blob"),
+	}
+}
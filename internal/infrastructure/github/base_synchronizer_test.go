@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+)
+
+func TestBaseSynchronizer_MergeBase(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		expected   port.BaseSyncStatus
+	}{
+		{
+			name:       "マージするコミットがなければ最新",
+			statusCode: http.StatusNoContent,
+			expected:   port.BaseSyncUpToDate,
+		},
+		{
+			name:       "マージコミットが作成された",
+			statusCode: http.StatusCreated,
+			expected:   port.BaseSyncMerged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := &mockTransport{
+				responses: map[string]mockResponse{
+					"GET /repos/owner/repo/pulls/12": {
+						statusCode: http.StatusOK,
+						body: github.PullRequest{
+							Number: github.Ptr(12),
+							Head:   &github.PullRequestBranch{Ref: github.Ptr("docgent/1")},
+							Base:   &github.PullRequestBranch{Ref: github.Ptr("main")},
+						},
+					},
+					"POST /repos/owner/repo/merges": {statusCode: tt.statusCode, body: github.RepositoryCommit{SHA: github.Ptr("merge")}},
+				},
+				expectedReqs: []mockRequest{
+					{method: "GET", path: "/repos/owner/repo/pulls/12"},
+					{method: "POST", path: "/repos/owner/repo/merges", body: map[string]interface{}{
+						"base":           "docgent/1",
+						"head":           "main",
+						"commit_message": "Merge branch 'main' into docgent/1",
+					}},
+				},
+			}
+			synchronizer := NewBaseSynchronizer(github.NewClient(&http.Client{Transport: mt}), "owner", "repo")
+
+			result, err := synchronizer.MergeBase(context.Background(), domain.NewProposalHandle("github-pull-request", "12"))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Status)
+			mt.verify(t)
+		})
+	}
+}
+
+func TestBaseSynchronizer_MergeBaseWithResolutions(t *testing.T) {
+	mt := &mockTransport{
+		responses: map[string]mockResponse{
+			"GET /repos/owner/repo/pulls/12": {
+				statusCode: http.StatusOK,
+				body: github.PullRequest{
+					Number: github.Ptr(12),
+					Head:   &github.PullRequestBranch{Ref: github.Ptr("docgent/1")},
+					Base:   &github.PullRequestBranch{Ref: github.Ptr("main")},
+				},
+			},
+			"GET /repos/owner/repo/git/ref/heads/docgent/1": {
+				statusCode: http.StatusOK,
+				body:       github.Reference{Object: &github.GitObject{SHA: github.Ptr("head")}},
+			},
+			"GET /repos/owner/repo/git/ref/heads/main": {
+				statusCode: http.StatusOK,
+				body:       github.Reference{Object: &github.GitObject{SHA: github.Ptr("base")}},
+			},
+			"GET /repos/owner/repo/compare/base...head": {
+				statusCode: http.StatusOK,
+				body: github.CommitsComparison{
+					MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("ancestor")},
+					Files: []*github.CommitFile{
+						{Filename: github.Ptr("docs/setup.md"), Status: github.Ptr("modified"), SHA: github.Ptr("setup-ours")},
+						{Filename: github.Ptr("docs/new.md"), Status: github.Ptr("added"), SHA: github.Ptr("new")},
+						{Filename: github.Ptr("docs/old.md"), Status: github.Ptr("removed")},
+					},
+				},
+			},
+			"GET /repos/owner/repo/compare/ancestor...base": {
+				statusCode: http.StatusOK,
+				body: github.CommitsComparison{
+					Files: []*github.CommitFile{{Filename: github.Ptr("docs/setup.md"), Status: github.Ptr("modified")}},
+				},
+			},
+			"GET /repos/owner/repo/contents/docs/setup.md": {
+				statusCode: http.StatusOK,
+				body:       github.RepositoryContent{Type: github.Ptr("file"), Content: github.Ptr("# Setup\n")},
+			},
+			"GET /repos/owner/repo/git/commits/base": {
+				statusCode: http.StatusOK,
+				body:       github.Commit{SHA: github.Ptr("base"), Tree: &github.Tree{SHA: github.Ptr("base-tree")}},
+			},
+			"POST /repos/owner/repo/git/trees": {
+				statusCode: http.StatusCreated,
+				body:       github.Tree{SHA: github.Ptr("merged-tree")},
+			},
+			"POST /repos/owner/repo/git/commits": {
+				statusCode: http.StatusCreated,
+				body:       github.Commit{SHA: github.Ptr("merge")},
+			},
+			"PATCH /repos/owner/repo/git/refs/heads/docgent/1": {
+				statusCode: http.StatusOK,
+				body:       github.Reference{Object: &github.GitObject{SHA: github.Ptr("merge")}},
+			},
+		},
+		expectedReqs: []mockRequest{
+			{method: "GET", path: "/repos/owner/repo/pulls/12"},
+			{method: "GET", path: "/repos/owner/repo/git/ref/heads/docgent/1"},
+			{method: "GET", path: "/repos/owner/repo/git/ref/heads/main"},
+			{method: "GET", path: "/repos/owner/repo/compare/base...head"},
+			{method: "GET", path: "/repos/owner/repo/compare/ancestor...base"},
+			{method: "GET", path: "/repos/owner/repo/contents/docs/setup.md"},
+			{method: "GET", path: "/repos/owner/repo/contents/docs/setup.md"},
+			{method: "GET", path: "/repos/owner/repo/contents/docs/setup.md"},
+			{method: "GET", path: "/repos/owner/repo/git/commits/base"},
+			{method: "POST", path: "/repos/owner/repo/git/trees", body: map[string]interface{}{
+				"base_tree": "base-tree",
+				"tree": []interface{}{
+					map[string]interface{}{"path": "docs/setup.md", "mode": "100644", "type": "blob", "content": "# Setup\n\nresolved\n"},
+					map[string]interface{}{"path": "docs/new.md", "mode": "100644", "type": "blob", "sha": "new"},
+					map[string]interface{}{"path": "docs/old.md", "mode": "100644", "type": "blob", "sha": nil},
+				},
+			}},
+			{method: "POST", path: "/repos/owner/repo/git/commits", body: map[string]interface{}{
+				"message": "Merge branch 'main' into docgent/1",
+				"tree":    "merged-tree",
+				"parents": []interface{}{"head", "base"},
+			}},
+			{method: "PATCH", path: "/repos/owner/repo/git/refs/heads/docgent/1", body: map[string]interface{}{
+				"sha":   "merge",
+				"force": false,
+			}},
+		},
+	}
+	synchronizer := NewBaseSynchronizer(github.NewClient(&http.Client{Transport: mt}), "owner", "repo")
+
+	err := synchronizer.MergeBaseWithResolutions(context.Background(), domain.NewProposalHandle("github-pull-request", "12"), map[string]string{
+		"docs/setup.md": "# Setup\n\nresolved\n",
+	})
+
+	assert.NoError(t, err)
+	mt.verify(t)
+}
@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v68/github"
+
+	"docgent/internal/application/port"
+)
+
+const (
+	checkRunName = "Docgent"
+	// maxAnnotationsPerRequest is the limit of annotations in one request to the checks API
+	maxAnnotationsPerRequest = 50
+)
+
+// CheckRunReporter reports the findings of a document lint as a check run of a commit
+type CheckRunReporter struct {
+	client     *github.Client
+	owner      string
+	repo       string
+	headSHA    string
+	checkRunID int64
+}
+
+var _ port.DocumentLintReporter = (*CheckRunReporter)(nil)
+
+func NewCheckRunReporter(client *github.Client, owner, repo, headSHA string) *CheckRunReporter {
+	return &CheckRunReporter{
+		client:  client,
+		owner:   owner,
+		repo:    repo,
+		headSHA: headSHA,
+	}
+}
+
+func (r *CheckRunReporter) Start(ctx context.Context) error {
+	checkRun, _, err := r.client.Checks.CreateCheckRun(ctx, r.owner, r.repo, github.CreateCheckRunOptions{
+		Name:    checkRunName,
+		HeadSHA: r.headSHA,
+		Status:  github.Ptr("in_progress"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create check run: %w", err)
+	}
+	r.checkRunID = checkRun.GetID()
+	return nil
+}
+
+// Complete sends the annotations in batches because the checks API accepts a limited number of them per request.
+// The conclusion is failure if any finding is a failure, neutral if there are only warnings or notices or the lint failed halfway,
+// and success otherwise.
+func (r *CheckRunReporter) Complete(ctx context.Context, findings []port.LintFinding, lintErr error) error {
+	if r.checkRunID == 0 {
+		return errors.New("check run has not been started")
+	}
+
+	title, summary, conclusion := summarizeFindings(findings, lintErr)
+	annotations := make([]*github.CheckRunAnnotation, len(findings))
+	for i, finding := range findings {
+		annotations[i] = &github.CheckRunAnnotation{
+			Path:            github.Ptr(finding.Path),
+			StartLine:       github.Ptr(finding.Line),
+			EndLine:         github.Ptr(finding.Line),
+			AnnotationLevel: github.Ptr(string(finding.Severity)),
+			Title:           github.Ptr(finding.Title),
+			Message:         github.Ptr(finding.Message),
+		}
+	}
+
+	for {
+		batch := annotations[:min(len(annotations), maxAnnotationsPerRequest)]
+		annotations = annotations[len(batch):]
+
+		opts := github.UpdateCheckRunOptions{
+			Name: checkRunName,
+			Output: &github.CheckRunOutput{
+				Title:       github.Ptr(title),
+				Summary:     github.Ptr(summary),
+				Annotations: batch,
+			},
+		}
+		// 最後のリクエストで完了にする
+		if len(annotations) == 0 {
+			opts.Status = github.Ptr("completed")
+			opts.Conclusion = github.Ptr(conclusion)
+		}
+		if _, _, err := r.client.Checks.UpdateCheckRun(ctx, r.owner, r.repo, r.checkRunID, opts); err != nil {
+			return fmt.Errorf("failed to update check run: %w", err)
+		}
+		if len(annotations) == 0 {
+			return nil
+		}
+	}
+}
+
+func summarizeFindings(findings []port.LintFinding, lintErr error) (title, summary, conclusion string) {
+	var failures, warnings int
+	for _, finding := range findings {
+		switch finding.Severity {
+		case port.LintSeverityFailure:
+			failures++
+		case port.LintSeverityWarning:
+			warnings++
+		}
+	}
+
+	switch {
+	case failures > 0:
+		conclusion = "failure"
+	case len(findings) > 0 || lintErr != nil:
+		conclusion = "neutral"
+	default:
+		return "No problems found", "The changed documents passed all checks.", "success"
+	}
+	title = fmt.Sprintf("%d failure(s), %d warning(s)", failures, warnings)
+	summary = fmt.Sprintf("Found %d problem(s) in the changed documents. See the annotations for details.", len(findings))
+	// 検査が途中で失敗した場合は、見つかった問題がすべてではないことを伝える
+	if lintErr != nil {
+		title = "Lint did not complete: " + title
+		summary = fmt.Sprintf("Some documents could not be checked, so there may be more problems.\n\n```\n%s\n```", lintErr)
+		if len(findings) > 0 {
+			summary += fmt.Sprintf("\n\nFound %d problem(s) in the documents that could be checked. See the annotations for details.", len(findings))
+		}
+	}
+	return title, summary, conclusion
+}
@@ -0,0 +1,101 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+)
+
+func TestCheckRunReporter(t *testing.T) {
+	manyFindings := make([]port.LintFinding, 51)
+	for i := range manyFindings {
+		manyFindings[i] = port.LintFinding{Path: "docs/a.md", Line: i + 1, Severity: port.LintSeverityWarning, Title: "Missing sources", Message: fmt.Sprint(i)}
+	}
+
+	tests := []struct {
+		name               string
+		findings           []port.LintFinding
+		lintErr            error
+		expectedUpdates    int
+		expectedConclusion string
+		expectedTitle      string
+	}{
+		{
+			name:               "問題がなければ成功",
+			findings:           nil,
+			expectedUpdates:    1,
+			expectedConclusion: "success",
+			expectedTitle:      "No problems found",
+		},
+		{
+			name:               "検査が途中で失敗したら問題がなくても成功にしない",
+			findings:           nil,
+			lintErr:            errors.New("failed to get .docgentrules: 502 Bad Gateway"),
+			expectedUpdates:    1,
+			expectedConclusion: "neutral",
+			expectedTitle:      "Lint did not complete: 0 failure(s), 0 warning(s)",
+		},
+		{
+			name: "失敗があれば失敗",
+			findings: []port.LintFinding{
+				{Path: "docs/a.md", Line: 3, Severity: port.LintSeverityFailure, Title: "Broken link", Message: "b.md does not exist in the repository"},
+				{Path: "docs/a.md", Line: 1, Severity: port.LintSeverityWarning, Title: "Missing sources", Message: "no sources"},
+			},
+			expectedUpdates:    1,
+			expectedConclusion: "failure",
+		},
+		{
+			name:               "警告だけなら中立で、注釈は50件ずつ送る",
+			findings:           manyFindings,
+			expectedUpdates:    2,
+			expectedConclusion: "neutral",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := &mockTransport{
+				responses: map[string]mockResponse{
+					"POST /repos/owner/repo/check-runs":    {statusCode: http.StatusCreated, body: github.CheckRun{ID: github.Ptr(int64(7))}},
+					"PATCH /repos/owner/repo/check-runs/7": {statusCode: http.StatusOK, body: github.CheckRun{ID: github.Ptr(int64(7))}},
+				},
+			}
+			reporter := NewCheckRunReporter(github.NewClient(&http.Client{Transport: mt}), "owner", "repo", "abc123")
+
+			assert.NoError(t, reporter.Start(context.Background()))
+			assert.NoError(t, reporter.Complete(context.Background(), tt.findings, tt.lintErr))
+
+			assert.Len(t, mt.requests, 1+tt.expectedUpdates)
+			assert.Equal(t, map[string]interface{}{"name": "Docgent", "head_sha": "abc123", "status": "in_progress"}, mt.requests[0].body)
+
+			var annotations int
+			for i, request := range mt.requests[1:] {
+				body := request.body.(map[string]interface{})
+				output := body["output"].(map[string]interface{})
+				if list, ok := output["annotations"].([]interface{}); ok {
+					annotations += len(list)
+				}
+				if tt.expectedTitle != "" {
+					assert.Equal(t, tt.expectedTitle, output["title"])
+				}
+				if tt.lintErr != nil {
+					assert.Contains(t, output["summary"], tt.lintErr.Error())
+				}
+				if i == tt.expectedUpdates-1 {
+					assert.Equal(t, "completed", body["status"])
+					assert.Equal(t, tt.expectedConclusion, body["conclusion"])
+				} else {
+					assert.NotContains(t, body, "conclusion")
+				}
+			}
+			assert.Equal(t, len(tt.findings), annotations)
+		})
+	}
+}
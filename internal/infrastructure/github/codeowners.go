@@ -0,0 +1,89 @@
+package github
+
+import (
+	"regexp"
+	"strings"
+)
+
+// codeownersPaths are the locations GitHub looks up CODEOWNERS in, in order of precedence
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+type codeownersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// Codeowners is a parsed CODEOWNERS file
+type Codeowners struct {
+	rules []codeownersRule
+}
+
+// ParseCodeowners parses a CODEOWNERS file. Lines with invalid patterns are skipped as GitHub does.
+func ParseCodeowners(content string) *Codeowners {
+	c := &Codeowners{}
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		pattern, err := compileCodeownersPattern(fields[0])
+		if err != nil {
+			continue
+		}
+		c.rules = append(c.rules, codeownersRule{pattern: pattern, owners: fields[1:]})
+	}
+	return c
+}
+
+// OwnersOf returns the owners of the path. The last matching rule takes precedence.
+func (c *Codeowners) OwnersOf(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].pattern.MatchString(path) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// compileCodeownersPattern converts a gitignore style pattern to a regular expression
+func compileCodeownersPattern(pattern string) (*regexp.Regexp, error) {
+	// Patterns without a slash except a trailing one match at any depth
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	directory := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+	if directory {
+		b.WriteString("/.*")
+	} else {
+		// A pattern also matches everything under the directory of the same name
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,34 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeowners_OwnersOf(t *testing.T) {
+	codeowners := ParseCodeowners(`# Default owners
+*                @org/docs
+docs/security/   @org/security @alice
+/docs/api/*.md   @bob # API docs
+**/runbook.md    @carol
+`)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{name: "デフォルトの管理者", path: "README.md", expected: []string{"@org/docs"}},
+		{name: "ディレクトリ配下", path: "docs/security/policy/incident.md", expected: []string{"@org/security", "@alice"}},
+		{name: "ワイルドカード", path: "docs/api/users.md", expected: []string{"@bob"}},
+		{name: "ワイルドカードはディレクトリをまたがない", path: "docs/api/v1/users.md", expected: []string{"@org/docs"}},
+		{name: "任意の深さ", path: "docs/platform/runbook.md", expected: []string{"@carol"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, codeowners.OwnersOf(tt.path))
+		})
+	}
+}
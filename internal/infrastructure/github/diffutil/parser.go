@@ -32,6 +32,8 @@ func (p *Parser) Execute(diff string) []domain.Diff {
 		} else if strings.HasPrefix(line, "new file mode") {
 			currentChange.IsNewFile = true
 			currentChange.OldName = ""
+		} else if strings.HasPrefix(line, "deleted file mode") {
+			currentChange.IsDeletedFile = true
 		} else if strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---") {
 			continue
 		} else if strings.HasPrefix(line, "@@") || strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") || strings.HasPrefix(line, " ") {
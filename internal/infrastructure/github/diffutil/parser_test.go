@@ -40,6 +40,14 @@ index 0000000..e69de29
 -Foo
 +Bar
  Baz
+diff --git a/oldfile.txt b/oldfile.txt
+deleted file mode 100644
+index e69de29..0000000
+--- a/oldfile.txt
++++ /dev/null
+@@ -1,2 +0,0 @@
+-This file is removed.
+-Goodbye.
 `
 
 	expected := []domain.Diff{
@@ -83,6 +91,15 @@ index 0000000..e69de29
 `,
 			IsNewFile: false,
 		},
+		{
+			OldName: "oldfile.txt",
+			NewName: "oldfile.txt",
+			Body: `@@ -1,2 +0,0 @@
+-This file is removed.
+-Goodbye.
+`,
+			IsDeletedFile: true,
+		},
 	}
 
 	parser := NewParser()
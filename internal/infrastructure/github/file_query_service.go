@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"sync"
+	"time"
 
 	"docgent/internal/application/port"
 	"docgent/internal/domain/data"
@@ -92,6 +93,23 @@ func (s *FileQueryService) GetTree(ctx context.Context, options ...port.GetTreeO
 	return treeMetadata, nil
 }
 
+// GetLastModifiedAt returns the committer date of the last commit on the branch that touched the given path
+func (s *FileQueryService) GetLastModifiedAt(ctx context.Context, path string) (time.Time, error) {
+	commits, _, err := s.client.Repositories.ListCommits(ctx, s.owner, s.repo, &github.CommitsListOptions{
+		SHA:         s.branch,
+		Path:        path,
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to list commits: %w", err)
+	}
+	if len(commits) == 0 {
+		return time.Time{}, port.ErrFileNotFound
+	}
+
+	return commits[0].GetCommit().GetCommitter().GetDate().Time, nil
+}
+
 // GetURI returns a GitHub permalink for the given file path
 func (s *FileQueryService) GetURI(ctx context.Context, path string) (*data.URI, error) {
 	// Get the commit SHA from cache or API
@@ -101,9 +119,9 @@ func (s *FileQueryService) GetURI(ctx context.Context, path string) (*data.URI,
 	}
 
 	// Construct the GitHub permalink
-	// Format: https://github.com/{owner}/{repo}/blob/{commitSHA}/{path}
-	rawURI := fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s",
-		s.owner, s.repo, commitSHA, path)
+	// Format: {webURL}/{owner}/{repo}/blob/{commitSHA}/{path}
+	rawURI := fmt.Sprintf("%s/%s/%s/blob/%s/%s",
+		webURLOf(s.client), s.owner, s.repo, commitSHA, path)
 
 	uri, err := data.NewURI(rawURI)
 	if err != nil {
@@ -140,13 +158,13 @@ func (s *FileQueryService) getCommitSHA(ctx context.Context) (string, error) {
 	return commitSHA, nil
 }
 
-var reFileURI = regexp.MustCompile(`^https://github\.com/(?P<owner>[^/]+)/(?P<repo>[^/]+)/blob/(?P<commitSHA>[^/]+)/(?P<path>[^?]+)`)
+var reFileURI = regexp.MustCompile(`^https?://[^/]+/(?P<owner>[^/]+)/(?P<repo>[^/]+)/blob/(?P<commitSHA>[^/]+)/(?P<path>[^?]+)`)
 var reFileURISubNames = reFileURI.SubexpNames()
 
 func (s *FileQueryService) GetFilePath(uri *data.URI) (string, error) {
 	// Extract owner, repo, commitSHA, and path from the URI
 	matches := reFileURI.FindStringSubmatch(uri.String())
-	if matches == nil {
+	if matches == nil || uri.Host() != webHostOf(s.client) {
 		return "", fmt.Errorf("invalid GitHub URI: %s", uri)
 	}
 
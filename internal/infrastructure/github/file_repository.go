@@ -64,12 +64,6 @@ func (r *FileRepository) Create(ctx context.Context, file *data.File) error {
 }
 
 func (r *FileRepository) Update(ctx context.Context, file *data.File) error {
-	// YAMLフロントマターを生成
-	frontmatter, err := yaml.GenerateFrontmatter(file.SourceURIs)
-	if err != nil {
-		return fmt.Errorf("%w: %s", data.ErrInvalidKnowledgeSource, err.Error())
-	}
-
 	// 現在のファイルを取得
 	fileContent, _, _, err := r.client.Repositories.GetContents(
 		ctx,
@@ -87,6 +81,19 @@ func (r *FileRepository) Update(ctx context.Context, file *data.File) error {
 		return fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
 	}
 
+	// 人間が設定した管理者は引き継ぐ
+	var owners []string
+	if currentContent, err := fileContent.GetContent(); err == nil {
+		currentFrontmatter, _ := yaml.SplitContentAndFrontmatter(currentContent)
+		owners, _ = yaml.ParseOwners(currentFrontmatter)
+	}
+
+	// YAMLフロントマターを生成
+	frontmatter, err := yaml.GenerateFrontmatter(file.SourceURIs, owners...)
+	if err != nil {
+		return fmt.Errorf("%w: %s", data.ErrInvalidKnowledgeSource, err.Error())
+	}
+
 	// フロントマターとコンテンツを結合
 	content := yaml.CombineContentAndFrontmatter(frontmatter, file.Content)
 
@@ -1,8 +1,11 @@
 package github
 
 import (
+	"net/http"
 	"testing"
 
+	"docgent/internal/domain/data"
+
 	"github.com/google/go-github/v68/github"
 	"github.com/stretchr/testify/assert"
 )
@@ -11,7 +14,7 @@ func TestIssueCommentConversationService_URI(t *testing.T) {
 	// テストケースの準備
 	service := &IssueCommentConversationService{
 		client: github.NewClient(nil),
-		ref:    NewIssueCommentRef("kecbigmt", "docgent", 123, 456789),
+		ref:    NewIssueCommentRef(DefaultWebURL, "kecbigmt", "docgent", 123, 456789),
 	}
 
 	// テストの実行
@@ -21,3 +24,52 @@ func TestIssueCommentConversationService_URI(t *testing.T) {
 	want := "https://github.com/kecbigmt/docgent/pull/123#issuecomment-456789"
 	assert.Equal(t, want, got.String())
 }
+
+func TestIssueConversationService_GetHistory(t *testing.T) {
+	mt := &mockTransport{
+		responses: map[string]mockResponse{
+			"GET /repos/owner/repo/issues/7": {
+				statusCode: http.StatusOK,
+				body: github.Issue{
+					Number: github.Ptr(7),
+					Title:  github.Ptr("認証方式の変更"),
+					Body:   github.Ptr("OAuthに移行します"),
+					User:   &github.User{Login: github.Ptr("alice")},
+				},
+			},
+			"GET /repos/owner/repo/issues/7/comments": {
+				statusCode: http.StatusOK,
+				body: []github.IssueComment{
+					{Body: github.Ptr("いつからですか？"), User: &github.User{Login: github.Ptr("bob")}},
+				},
+			},
+			"GET /user": {
+				statusCode: http.StatusOK,
+				body:       github.User{Login: github.Ptr("docgent")},
+			},
+		},
+	}
+	service := NewIssueConversationService(github.NewClient(&http.Client{Transport: mt}), "owner", "repo", 7, "alice")
+
+	history, err := service.GetHistory()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/owner/repo/issues/7", history.URI.String())
+	if assert.Len(t, history.Messages, 2) {
+		assert.Equal(t, "alice", history.Messages[0].Author)
+		assert.Equal(t, "認証方式の変更\n\nOAuthに移行します", history.Messages[0].Content)
+		assert.Equal(t, "いつからですか？", history.Messages[1].Content)
+	}
+}
+
+func TestIssueConversationResolver_Resolve(t *testing.T) {
+	resolver := NewIssueConversationResolver(github.NewClient(nil))
+
+	uri := data.NewURIUnsafe("https://github.com/owner/repo/issues/7")
+	assert.True(t, resolver.Match(uri))
+	assert.False(t, resolver.Match(data.NewURIUnsafe("https://github.com/owner/repo/pull/7")))
+
+	service, err := resolver.Resolve(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, uri.String(), service.URI().String())
+}
@@ -8,18 +8,19 @@ import (
 )
 
 type IssueCommentRef struct {
+	webURL          string
 	owner           string
 	repo            string
 	prNumber        int
 	sourceCommentID int64
 }
 
-func NewIssueCommentRef(owner, repo string, prNumber int, sourceCommentID int64) *IssueCommentRef {
-	return &IssueCommentRef{owner: owner, repo: repo, prNumber: prNumber, sourceCommentID: sourceCommentID}
+func NewIssueCommentRef(webURL, owner, repo string, prNumber int, sourceCommentID int64) *IssueCommentRef {
+	return &IssueCommentRef{webURL: webURL, owner: owner, repo: repo, prNumber: prNumber, sourceCommentID: sourceCommentID}
 }
 
 func (u *IssueCommentRef) ToURI() *data.URI {
-	rawURI := fmt.Sprintf("https://github.com/%s/%s/pull/%d#issuecomment-%d", u.owner, u.repo, u.prNumber, u.sourceCommentID)
+	rawURI := fmt.Sprintf("%s/%s/%s/pull/%d#issuecomment-%d", u.webURL, u.owner, u.repo, u.prNumber, u.sourceCommentID)
 	return data.NewURIUnsafe(rawURI)
 }
 
@@ -39,22 +40,23 @@ func (u *IssueCommentRef) SourceCommentID() int64 {
 	return u.sourceCommentID
 }
 
-// https://github.com/{owner}/{repo}/pull/{prNumber}#issuecomment-{sourceCommentID}
-var re = regexp.MustCompile(`^https://github\.com/([^/]+)/([^/]+)/pull/([^/]+)#issuecomment-([^/]+)$`)
+// {webURL}/{owner}/{repo}/pull/{prNumber}#issuecomment-{sourceCommentID}
+// e.g. https://github.com/{owner}/{repo}/pull/{prNumber}#issuecomment-{sourceCommentID}
+var re = regexp.MustCompile(`^(https?://[^/]+)/([^/]+)/([^/]+)/pull/([^/]+)#issuecomment-([^/]+)$`)
 
 func ParseIssueCommentRef(uri *data.URI) (*IssueCommentRef, error) {
 	rawURI := uri.String()
 	matches := re.FindStringSubmatch(rawURI)
-	if len(matches) == 5 {
-		prNumber, err := strconv.Atoi(matches[3])
+	if len(matches) == 6 {
+		prNumber, err := strconv.Atoi(matches[4])
 		if err != nil {
-			return nil, fmt.Errorf("invalid PR number: %s", matches[3])
+			return nil, fmt.Errorf("invalid PR number: %s", matches[4])
 		}
-		sourceCommentID, err := strconv.ParseInt(matches[4], 10, 64)
+		sourceCommentID, err := strconv.ParseInt(matches[5], 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("invalid source comment ID: %s", matches[4])
+			return nil, fmt.Errorf("invalid source comment ID: %s", matches[5])
 		}
-		return NewIssueCommentRef(matches[1], matches[2], prNumber, sourceCommentID), nil
+		return NewIssueCommentRef(matches[1], matches[2], matches[3], prNumber, sourceCommentID), nil
 	}
 	return nil, fmt.Errorf("invalid URI: %s", uri)
 }
@@ -0,0 +1,162 @@
+package github
+
+import (
+	"context"
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// IssueConversationService treats an issue and its comments as a conversation
+type IssueConversationService struct {
+	client         *github.Client
+	owner          string
+	repo           string
+	number         int
+	eyesReactionID int64
+	fromUserID     string // 会話を始めたユーザーのID
+}
+
+func NewIssueConversationService(client *github.Client, owner, repo string, number int, fromUserID string) port.ConversationService {
+	return &IssueConversationService{
+		client:     client,
+		owner:      owner,
+		repo:       repo,
+		number:     number,
+		fromUserID: fromUserID,
+	}
+}
+
+func (s *IssueConversationService) GetHistory() (port.ConversationHistory, error) {
+	ctx := context.Background()
+
+	issue, _, err := s.client.Issues.Get(ctx, s.owner, s.repo, s.number)
+	if err != nil {
+		return port.ConversationHistory{}, fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	user, _, err := s.client.Users.Get(ctx, "")
+	if err != nil {
+		return port.ConversationHistory{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	currentUserID := user.GetLogin()
+
+	newMessage := func(author, body string) port.ConversationMessage {
+		return port.ConversationMessage{
+			Author:       author,
+			Content:      body,
+			YouMentioned: strings.Contains(body, fmt.Sprintf("@%s", currentUserID)),
+			IsYou:        author == currentUserID,
+		}
+	}
+
+	// Issueの本文を最初のメッセージとして扱う
+	content := issue.GetTitle()
+	if issue.GetBody() != "" {
+		content = fmt.Sprintf("%s\n\n%s", issue.GetTitle(), issue.GetBody())
+	}
+	messages := []port.ConversationMessage{newMessage(issue.GetUser().GetLogin(), content)}
+
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := s.client.Issues.ListComments(ctx, s.owner, s.repo, s.number, opts)
+		if err != nil {
+			return port.ConversationHistory{}, fmt.Errorf("failed to list issue comments: %w", err)
+		}
+		for _, comment := range comments {
+			messages = append(messages, newMessage(comment.GetUser().GetLogin(), comment.GetBody()))
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return port.ConversationHistory{
+		URI:      s.URI(),
+		Messages: messages,
+	}, nil
+}
+
+func (s *IssueConversationService) URI() *data.URI {
+	return data.NewURIUnsafe(fmt.Sprintf("%s/%s/%s/issues/%d", webURLOf(s.client), s.owner, s.repo, s.number))
+}
+
+func (s *IssueConversationService) Reply(input string, withMention bool) error {
+	ctx := context.Background()
+
+	message := input
+	if withMention && s.fromUserID != "" {
+		message = fmt.Sprintf("@%s\n%s", s.fromUserID, input)
+	}
+
+	_, _, err := s.client.Issues.CreateComment(ctx, s.owner, s.repo, s.number, &github.IssueComment{Body: github.Ptr(message)})
+	if err != nil {
+		return fmt.Errorf("failed to create issue comment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *IssueConversationService) MarkEyes() error {
+	ctx := context.Background()
+	reaction, _, err := s.client.Reactions.CreateIssueReaction(ctx, s.owner, s.repo, s.number, "eyes")
+	if err != nil {
+		return fmt.Errorf("failed to add eyes reaction to issue: %w", err)
+	}
+
+	s.eyesReactionID = reaction.GetID()
+
+	return nil
+}
+
+func (s *IssueConversationService) RemoveEyes() error {
+	if s.eyesReactionID == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	_, err := s.client.Reactions.DeleteIssueReaction(ctx, s.owner, s.repo, s.number, s.eyesReactionID)
+	if err != nil {
+		return fmt.Errorf("failed to remove eyes reaction from issue: %w", err)
+	}
+
+	return nil
+}
+
+/**
+ * IssueConversationResolver
+ */
+
+// {webURL}/{owner}/{repo}/issues/{number}
+var reIssueConversationURI = regexp.MustCompile(`^https?://[^/]+/([^/]+)/([^/]+)/issues/(\d+)$`)
+
+// IssueConversationResolver restores the ConversationService of an issue from its URI
+type IssueConversationResolver struct {
+	client *github.Client
+}
+
+func NewIssueConversationResolver(client *github.Client) *IssueConversationResolver {
+	return &IssueConversationResolver{client: client}
+}
+
+func (r *IssueConversationResolver) Match(uri *data.URI) bool {
+	return uri.Host() == webHostOf(r.client) && reIssueConversationURI.MatchString(uri.String())
+}
+
+func (r *IssueConversationResolver) Resolve(uri *data.URI) (port.ConversationService, error) {
+	matches := reIssueConversationURI.FindStringSubmatch(uri.String())
+	if matches == nil {
+		return nil, fmt.Errorf("invalid issue URI: %s", uri)
+	}
+	number, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue number: %s", matches[3])
+	}
+	return NewIssueConversationService(r.client, matches[1], matches[2], number, ""), nil
+}
@@ -20,6 +20,8 @@ type mockTransport struct {
 type mockResponse struct {
 	statusCode int
 	body       interface{}
+	// nextPage is returned in the Link header if not 0
+	nextPage int
 }
 
 type mockRequest struct {
@@ -30,6 +32,10 @@ type mockRequest struct {
 
 func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	key := req.Method + " " + req.URL.Path
+	// 2ページ目以降はページ番号付きのキーで返す
+	if page := req.URL.Query().Get("page"); page != "" && page != "1" {
+		key += "?page=" + page
+	}
 
 	// リクエストボディの読み取り
 	var reqBody interface{}
@@ -63,8 +69,17 @@ func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		if err != nil {
 			return nil, err
 		}
+		header := http.Header{}
+		if resp.nextPage != 0 {
+			nextURL := *req.URL
+			query := nextURL.Query()
+			query.Set("page", fmt.Sprint(resp.nextPage))
+			nextURL.RawQuery = query.Encode()
+			header.Set("Link", fmt.Sprintf(`<%s>; rel="next"`, nextURL.String()))
+		}
 		return &http.Response{
 			StatusCode: resp.statusCode,
+			Header:     header,
 			Body:       io.NopCloser(bytes.NewReader(body)),
 		}, nil
 	}
@@ -0,0 +1,194 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+)
+
+// recentPullRequestsPerPage is the number of recently updated pull requests that FindByConversation scans
+// in addition to the search results
+const recentPullRequestsPerPage = 30
+
+var reProposalLinkMarker = regexp.MustCompile(`<!-- docgent:conversation-uri (\S+) -->`)
+
+func proposalLinkMarker(conversationURI *data.URI) string {
+	return fmt.Sprintf("<!-- docgent:conversation-uri %s -->", conversationURI)
+}
+
+// ProposalLinkRepository stores the originating conversation of a proposal as a hidden marker in the pull request body
+type ProposalLinkRepository struct {
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+func NewProposalLinkRepository(client *github.Client, owner, repo string) *ProposalLinkRepository {
+	return &ProposalLinkRepository{client: client, owner: owner, repo: repo}
+}
+
+func (r *ProposalLinkRepository) Save(ctx context.Context, link port.ProposalLink) error {
+	number, err := strconv.Atoi(link.ProposalHandle.Value)
+	if err != nil {
+		return fmt.Errorf("failed to parse proposal handle to pull request number: %w", err)
+	}
+
+	pr, _, err := r.client.PullRequests.Get(ctx, r.owner, r.repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	marker := proposalLinkMarker(link.ConversationURI)
+	body := pr.GetBody()
+	if reProposalLinkMarker.MatchString(body) {
+		body = reProposalLinkMarker.ReplaceAllLiteralString(body, marker)
+	} else {
+		body = strings.TrimRight(body, "\n") + "\n\n" + marker
+	}
+
+	_, _, err = r.client.PullRequests.Edit(ctx, r.owner, r.repo, number, &github.PullRequest{Body: github.Ptr(body)})
+	if err != nil {
+		return fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProposalLinkRepository) FindByProposal(ctx context.Context, handle domain.ProposalHandle) (port.ProposalLink, error) {
+	number, err := strconv.Atoi(handle.Value)
+	if err != nil {
+		return port.ProposalLink{}, fmt.Errorf("failed to parse proposal handle to pull request number: %w", err)
+	}
+
+	pr, _, err := r.client.PullRequests.Get(ctx, r.owner, r.repo, number)
+	if err != nil {
+		return port.ProposalLink{}, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	conversationURI, ok := parseProposalLinkMarker(pr.GetBody())
+	if !ok {
+		return port.ProposalLink{}, port.ErrProposalLinkNotFound
+	}
+
+	return port.ProposalLink{ProposalHandle: handle, ConversationURI: conversationURI, Open: pr.GetState() == "open"}, nil
+}
+
+// FindByConversation returns the most recently updated pull request generated from the conversation.
+// Pull requests are found with the search API, so that old proposals are found as well.
+// The most recently updated pull requests are also scanned, since new pull requests take a while to be searchable.
+func (r *ProposalLinkRepository) FindByConversation(ctx context.Context, conversationURI *data.URI) (port.ProposalLink, error) {
+	var found *port.ProposalLink
+	err := r.searchLinkedPullRequests(ctx, fmt.Sprintf("%q", conversationURI.String()), func(issue *github.Issue, uri *data.URI) bool {
+		if uri.String() != conversationURI.String() {
+			return true
+		}
+		handle := domain.NewProposalHandle("github-pull-request", strconv.Itoa(issue.GetNumber()))
+		found = &port.ProposalLink{ProposalHandle: handle, ConversationURI: uri, Open: issue.GetState() == "open"}
+		return false
+	})
+	if err != nil {
+		return port.ProposalLink{}, err
+	}
+	if found != nil {
+		return *found, nil
+	}
+
+	// 作成直後のPRは検索のインデックスに反映されていないことがある
+	prs, _, err := r.client.PullRequests.List(ctx, r.owner, r.repo, &github.PullRequestListOptions{
+		State:       "all",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: recentPullRequestsPerPage},
+	})
+	if err != nil {
+		return port.ProposalLink{}, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	for _, pr := range prs {
+		uri, ok := parseProposalLinkMarker(pr.GetBody())
+		if ok && uri.String() == conversationURI.String() {
+			handle := domain.NewProposalHandle("github-pull-request", strconv.Itoa(pr.GetNumber()))
+			return port.ProposalLink{ProposalHandle: handle, ConversationURI: uri, Open: pr.GetState() == "open"}, nil
+		}
+	}
+
+	return port.ProposalLink{}, port.ErrProposalLinkNotFound
+}
+
+// OpenProposal is an open pull request generated from a conversation
+type OpenProposal struct {
+	port.ProposalLink
+	Title string
+	URL   string
+}
+
+// ListOpen returns the open pull requests generated from conversations, most recently updated first
+func (r *ProposalLinkRepository) ListOpen(ctx context.Context) ([]OpenProposal, error) {
+	var proposals []OpenProposal
+	err := r.searchLinkedPullRequests(ctx, "is:open", func(issue *github.Issue, uri *data.URI) bool {
+		proposals = append(proposals, OpenProposal{
+			ProposalLink: port.ProposalLink{
+				ProposalHandle:  domain.NewProposalHandle("github-pull-request", strconv.Itoa(issue.GetNumber())),
+				ConversationURI: uri,
+				Open:            true,
+			},
+			Title: issue.GetTitle(),
+			URL:   issue.GetHTMLURL(),
+		})
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return proposals, nil
+}
+
+// searchLinkedPullRequests searches the pull requests of the repository whose body has the marker and matches the qualifiers,
+// most recently updated first, and calls yield with each pull request and its conversation URI until yield returns false.
+func (r *ProposalLinkRepository) searchLinkedPullRequests(ctx context.Context, qualifiers string, yield func(issue *github.Issue, uri *data.URI) bool) error {
+	query := fmt.Sprintf(`repo:%s/%s is:pr in:body "docgent:conversation-uri" %s`, r.owner, r.repo, qualifiers)
+	opts := &github.SearchOptions{
+		Sort:        "updated",
+		Order:       "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		result, resp, err := r.client.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return fmt.Errorf("failed to search pull requests: %w", err)
+		}
+		for _, issue := range result.Issues {
+			// 検索は語句の一致なので、マーカーを解析して確かめる
+			uri, ok := parseProposalLinkMarker(issue.GetBody())
+			if !ok {
+				continue
+			}
+			if !yield(issue, uri) {
+				return nil
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func parseProposalLinkMarker(body string) (*data.URI, bool) {
+	matches := reProposalLinkMarker.FindStringSubmatch(body)
+	if matches == nil {
+		return nil, false
+	}
+	uri, err := data.NewURI(matches[1])
+	if err != nil {
+		return nil, false
+	}
+	return uri, true
+}
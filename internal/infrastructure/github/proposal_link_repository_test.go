@@ -0,0 +1,180 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+)
+
+func TestProposalLinkRepository_Save(t *testing.T) {
+	mt := &mockTransport{
+		responses: map[string]mockResponse{
+			"GET /repos/owner/repo/pulls/12": {
+				statusCode: http.StatusOK,
+				body:       github.PullRequest{Number: github.Ptr(12), Body: github.Ptr("ドキュメントを追加しました\n")},
+			},
+			"PATCH /repos/owner/repo/pulls/12": {
+				statusCode: http.StatusOK,
+				body:       github.PullRequest{Number: github.Ptr(12)},
+			},
+		},
+		expectedReqs: []mockRequest{
+			{method: "GET", path: "/repos/owner/repo/pulls/12"},
+			{
+				method: "PATCH",
+				path:   "/repos/owner/repo/pulls/12",
+				body: map[string]interface{}{
+					"body": "ドキュメントを追加しました\n\n<!-- docgent:conversation-uri https://app.slack.com/client/T123/C456/1234567890.123456 -->",
+				},
+			},
+		},
+	}
+	repo := NewProposalLinkRepository(github.NewClient(&http.Client{Transport: mt}), "owner", "repo")
+
+	err := repo.Save(context.Background(), port.ProposalLink{
+		ProposalHandle:  domain.NewProposalHandle("github-pull-request", "12"),
+		ConversationURI: data.NewURIUnsafe("https://app.slack.com/client/T123/C456/1234567890.123456"),
+	})
+
+	assert.NoError(t, err)
+	mt.verify(t)
+}
+
+func TestProposalLinkRepository_FindByProposal(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantURI string
+		wantErr error
+	}{
+		{
+			name:    "マーカーから会話のURIを取得できる",
+			body:    "本文\n\n<!-- docgent:conversation-uri https://app.slack.com/client/T123/C456/1234567890.123456 -->",
+			wantURI: "https://app.slack.com/client/T123/C456/1234567890.123456",
+		},
+		{
+			name:    "マーカーがない場合はErrProposalLinkNotFoundを返す",
+			body:    "本文",
+			wantErr: port.ErrProposalLinkNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := &mockTransport{
+				responses: map[string]mockResponse{
+					"GET /repos/owner/repo/pulls/12": {
+						statusCode: http.StatusOK,
+						body:       github.PullRequest{Number: github.Ptr(12), Body: github.Ptr(tt.body)},
+					},
+				},
+			}
+			repo := NewProposalLinkRepository(github.NewClient(&http.Client{Transport: mt}), "owner", "repo")
+
+			link, err := repo.FindByProposal(context.Background(), domain.NewProposalHandle("github-pull-request", "12"))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantURI, link.ConversationURI.String())
+		})
+	}
+}
+
+func TestProposalLinkRepository_FindByConversation(t *testing.T) {
+	conversationURI := "https://app.slack.com/client/T123/C456/1234567890.123456"
+	tests := []struct {
+		name       string
+		responses  map[string]mockResponse
+		wantNumber string
+		wantOpen   bool
+		wantErr    error
+	}{
+		{
+			name: "検索結果からマーカーが一致するPRを見つける",
+			responses: map[string]mockResponse{
+				"GET /search/issues": {
+					statusCode: http.StatusOK,
+					body: github.IssuesSearchResult{Issues: []*github.Issue{
+						{Number: github.Ptr(13), Body: github.Ptr("<!-- docgent:conversation-uri https://app.slack.com/client/T123/C456/9999999999.999999 -->")},
+						{Number: github.Ptr(12), State: github.Ptr("open"), Body: github.Ptr("<!-- docgent:conversation-uri " + conversationURI + " -->")},
+					}},
+				},
+			},
+			wantNumber: "12",
+			wantOpen:   true,
+		},
+		{
+			name: "検索に反映されていない最近のPRも見つける",
+			responses: map[string]mockResponse{
+				"GET /search/issues": {statusCode: http.StatusOK, body: github.IssuesSearchResult{}},
+				"GET /repos/owner/repo/pulls": {
+					statusCode: http.StatusOK,
+					body: []github.PullRequest{
+						{Number: github.Ptr(14), State: github.Ptr("closed"), Body: github.Ptr("<!-- docgent:conversation-uri " + conversationURI + " -->")},
+					},
+				},
+			},
+			wantNumber: "14",
+			wantOpen:   false,
+		},
+		{
+			name: "見つからない場合はErrProposalLinkNotFoundを返す",
+			responses: map[string]mockResponse{
+				"GET /search/issues":          {statusCode: http.StatusOK, body: github.IssuesSearchResult{}},
+				"GET /repos/owner/repo/pulls": {statusCode: http.StatusOK, body: []github.PullRequest{}},
+			},
+			wantErr: port.ErrProposalLinkNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := &mockTransport{responses: tt.responses}
+			repo := NewProposalLinkRepository(github.NewClient(&http.Client{Transport: mt}), "owner", "repo")
+
+			link, err := repo.FindByConversation(context.Background(), data.NewURIUnsafe(conversationURI))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNumber, link.ProposalHandle.Value)
+			assert.Equal(t, tt.wantOpen, link.Open)
+		})
+	}
+}
+
+func TestProposalLinkRepository_ListOpen(t *testing.T) {
+	mt := &mockTransport{
+		responses: map[string]mockResponse{
+			"GET /search/issues": {
+				statusCode: http.StatusOK,
+				body: github.IssuesSearchResult{Issues: []*github.Issue{
+					{Number: github.Ptr(13), Title: github.Ptr("Fix typo"), Body: github.Ptr("Mentions docgent:conversation-uri in the text")},
+					{Number: github.Ptr(12), Title: github.Ptr("Add setup guide"), HTMLURL: github.Ptr("https://github.com/owner/repo/pull/12"), Body: github.Ptr("<!-- docgent:conversation-uri https://app.slack.com/client/T123/C456/1234567890.123456 -->")},
+				}},
+			},
+		},
+	}
+	repo := NewProposalLinkRepository(github.NewClient(&http.Client{Transport: mt}), "owner", "repo")
+
+	proposals, err := repo.ListOpen(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, proposals, 1)
+	assert.Equal(t, "12", proposals[0].ProposalHandle.Value)
+	assert.Equal(t, "Add setup guide", proposals[0].Title)
+	assert.Equal(t, "https://github.com/owner/repo/pull/12", proposals[0].URL)
+	assert.Equal(t, "https://app.slack.com/client/T123/C456/1234567890.123456", proposals[0].ConversationURI.String())
+}
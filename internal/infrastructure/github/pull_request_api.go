@@ -4,29 +4,58 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/google/go-github/v68/github"
 
 	"docgent/internal/domain"
+	"docgent/internal/domain/data"
 	"docgent/internal/infrastructure/github/diffutil"
 )
 
 type PullRequestAPI struct {
-	client        *github.Client
-	owner         string
-	repo          string
-	defaultBranch string
-	headBranch    string
+	client          *github.Client
+	owner           string
+	repo            string
+	defaultBranch   string
+	headBranch      string
+	proposalOptions ProposalOptions
 }
 
-func NewPullRequestAPI(client *github.Client, owner, repo, defaultBranch string, headBranch string) *PullRequestAPI {
-	return &PullRequestAPI{
+// ProposalOptions configures the pull requests created by PullRequestAPI
+type ProposalOptions struct {
+	// Draft opens pull requests as drafts
+	Draft bool
+	// Labels are added to pull requests
+	Labels []string
+	// RequestOwnerReviews requests reviews from the owners of the changed files in CODEOWNERS and the frontmatter
+	RequestOwnerReviews bool
+	// Reviewers are GitHub users or "org/team" requested in addition to the owners
+	Reviewers []string
+	// OnWarning is called when labels or reviewers could not be applied. The pull request is created anyway
+	OnWarning func(error)
+}
+
+type NewPullRequestAPIOption func(*PullRequestAPI)
+
+func WithProposalOptions(options ProposalOptions) NewPullRequestAPIOption {
+	return func(s *PullRequestAPI) {
+		s.proposalOptions = options
+	}
+}
+
+func NewPullRequestAPI(client *github.Client, owner, repo, defaultBranch string, headBranch string, options ...NewPullRequestAPIOption) *PullRequestAPI {
+	s := &PullRequestAPI{
 		client:        client,
 		owner:         owner,
 		repo:          repo,
 		defaultBranch: defaultBranch,
 		headBranch:    headBranch,
 	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
 }
 
 func (s *PullRequestAPI) NewProposalHandle(value string) domain.ProposalHandle {
@@ -52,6 +81,7 @@ func (s *PullRequestAPI) CreateProposal(diffs domain.Diffs, content domain.Propo
 		Body:  github.Ptr(content.Body),
 		Head:  github.Ptr(s.headBranch),
 		Base:  github.Ptr(s.defaultBranch),
+		Draft: github.Ptr(s.proposalOptions.Draft),
 	}
 
 	pr, _, err := s.client.PullRequests.Create(ctx, s.owner, s.repo, newPR)
@@ -59,6 +89,14 @@ func (s *PullRequestAPI) CreateProposal(diffs domain.Diffs, content domain.Propo
 		return domain.ProposalHandle{}, fmt.Errorf("failed to create pull request: %w", err)
 	}
 
+	// ラベルとレビュアーの設定に失敗しても、PRは作成済みなので提案の作成は成功とする
+	if err := s.addLabels(ctx, pr.GetNumber()); err != nil {
+		s.warn(err)
+	}
+	if err := s.requestReviewers(ctx, pr.GetNumber()); err != nil {
+		s.warn(err)
+	}
+
 	handle := s.NewProposalHandle(fmt.Sprintf("%d", pr.GetNumber()))
 	return handle, nil
 }
@@ -165,9 +203,17 @@ func (s *PullRequestAPI) UpdateProposalContent(proposalHandle domain.ProposalHan
 		return fmt.Errorf("failed to get pull request: %w", err)
 	}
 
+	// Keep the link to the originating conversation, which the agent does not know about
+	body := content.Body
+	if conversationURI, ok := parseProposalLinkMarker(pr.GetBody()); ok {
+		if _, ok := parseProposalLinkMarker(body); !ok {
+			body = strings.TrimRight(body, "\n") + "\n\n" + proposalLinkMarker(conversationURI)
+		}
+	}
+
 	updatePR := &github.PullRequest{
 		Title: github.Ptr(content.Title),
-		Body:  github.Ptr(content.Body),
+		Body:  github.Ptr(body),
 		Base:  pr.Base,
 		Head:  pr.Head,
 	}
@@ -187,3 +233,43 @@ func (s *PullRequestAPI) parseHandle(handle domain.ProposalHandle) (int, error)
 	}
 	return number, nil
 }
+
+// listMergedFilePermalinks returns permalinks at the merge commit of the files added or modified by the pull request
+func listMergedFilePermalinks(ctx context.Context, client *github.Client, owner, repo string, number int, mergeCommitSHA string) ([]*data.URI, error) {
+	paths, err := listChangedFiles(ctx, client, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	permalinks := make([]*data.URI, 0, len(paths))
+	for _, path := range paths {
+		uri, err := data.NewURI(fmt.Sprintf("%s/%s/%s/blob/%s/%s", webURLOf(client), owner, repo, mergeCommitSHA, path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create URI: %w", err)
+		}
+		permalinks = append(permalinks, uri)
+	}
+	return permalinks, nil
+}
+
+// listChangedFiles returns the paths of the files added or modified by the pull request
+func listChangedFiles(ctx context.Context, client *github.Client, owner, repo string, number int) ([]string, error) {
+	var paths []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request files: %w", err)
+		}
+		for _, file := range files {
+			if file.GetStatus() == "removed" {
+				continue
+			}
+			paths = append(paths, file.GetFilename())
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return paths, nil
+}
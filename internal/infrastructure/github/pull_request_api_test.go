@@ -0,0 +1,172 @@
+package github
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/domain"
+)
+
+func TestPullRequestAPI_CreateProposal(t *testing.T) {
+	encode := func(content string) *github.RepositoryContent {
+		return &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		options        ProposalOptions
+		responses      map[string]mockResponse
+		expectedReqs   []mockRequest
+		expectWarnings int
+	}{
+		{
+			name:    "オプションがなければPRを作成するだけ",
+			options: ProposalOptions{},
+			responses: map[string]mockResponse{
+				"POST /repos/owner/repo/pulls": {statusCode: http.StatusCreated, body: github.PullRequest{Number: github.Ptr(12)}},
+			},
+			expectedReqs: []mockRequest{
+				{
+					method: "POST",
+					path:   "/repos/owner/repo/pulls",
+					body: map[string]interface{}{
+						"title": "タイトル",
+						"body":  "本文",
+						"head":  "docgent/1",
+						"base":  "main",
+						"draft": false,
+					},
+				},
+			},
+		},
+		{
+			name: "ドラフトで作成し、ラベルとCODEOWNERS・フロントマター・参加者のレビュアーを設定する",
+			options: ProposalOptions{
+				Draft:               true,
+				Labels:              []string{"docs"},
+				RequestOwnerReviews: true,
+				Reviewers:           []string{"carol", "alice"},
+			},
+			responses: map[string]mockResponse{
+				"POST /repos/owner/repo/pulls":              {statusCode: http.StatusCreated, body: github.PullRequest{Number: github.Ptr(12)}},
+				"POST /repos/owner/repo/issues/12/labels":   {statusCode: http.StatusOK, body: []*github.Label{{Name: github.Ptr("docs")}}},
+				"GET /repos/owner/repo/pulls/12/files":      {statusCode: http.StatusOK, body: []*github.CommitFile{{Filename: github.Ptr("docs/api/users.md"), Status: github.Ptr("added")}}},
+				"GET /repos/owner/repo/contents/CODEOWNERS": {statusCode: http.StatusOK, body: encode("* @org/writers\ndocs/api/ @alice docs@example.com\n")},
+				"GET /repos/owner/repo/contents/docs/api/users.md": {
+					statusCode: http.StatusOK,
+					body:       encode("---\nsources: []\nowners:\n  - \"@bob\"\n---\n# Users\n"),
+				},
+				"POST /repos/owner/repo/pulls/12/requested_reviewers": {statusCode: http.StatusCreated, body: github.PullRequest{Number: github.Ptr(12)}},
+				"GET /repos/owner/repo/collaborators/carol":           {statusCode: http.StatusNoContent},
+			},
+			expectedReqs: []mockRequest{
+				{method: "POST", path: "/repos/owner/repo/pulls", body: map[string]interface{}{
+					"title": "タイトル",
+					"body":  "本文",
+					"head":  "docgent/1",
+					"base":  "main",
+					"draft": true,
+				}},
+				{method: "POST", path: "/repos/owner/repo/issues/12/labels", body: []interface{}{"docs"}},
+				{method: "GET", path: "/repos/owner/repo/pulls/12/files"},
+				{method: "GET", path: "/repos/owner/repo/contents/.github/CODEOWNERS"},
+				{method: "GET", path: "/repos/owner/repo/contents/CODEOWNERS"},
+				{method: "GET", path: "/repos/owner/repo/contents/docs/api/users.md"},
+				{method: "POST", path: "/repos/owner/repo/pulls/12/requested_reviewers", body: map[string]interface{}{
+					"reviewers": []interface{}{"alice", "bob"},
+				}},
+				// 参加者はオーナーとは別に、コラボレーターだけに依頼する
+				{method: "GET", path: "/repos/owner/repo/collaborators/carol"},
+				{method: "POST", path: "/repos/owner/repo/pulls/12/requested_reviewers", body: map[string]interface{}{
+					"reviewers": []interface{}{"carol"},
+				}},
+			},
+		},
+		{
+			name:    "変更されたファイルが複数ページにわたってもすべてのオーナーに依頼する",
+			options: ProposalOptions{RequestOwnerReviews: true},
+			responses: map[string]mockResponse{
+				"POST /repos/owner/repo/pulls": {statusCode: http.StatusCreated, body: github.PullRequest{Number: github.Ptr(12)}},
+				"GET /repos/owner/repo/pulls/12/files": {
+					statusCode: http.StatusOK,
+					body:       []*github.CommitFile{{Filename: github.Ptr("docs/guide.md"), Status: github.Ptr("removed")}},
+					nextPage:   2,
+				},
+				"GET /repos/owner/repo/pulls/12/files?page=2": {
+					statusCode: http.StatusOK,
+					body:       []*github.CommitFile{{Filename: github.Ptr("docs/api/users.md"), Status: github.Ptr("removed")}},
+				},
+				"GET /repos/owner/repo/contents/CODEOWNERS":           {statusCode: http.StatusOK, body: encode("docs/guide.md @alice\ndocs/api/ @bob\n")},
+				"POST /repos/owner/repo/pulls/12/requested_reviewers": {statusCode: http.StatusCreated, body: github.PullRequest{Number: github.Ptr(12)}},
+			},
+			expectedReqs: []mockRequest{
+				{method: "POST", path: "/repos/owner/repo/pulls"},
+				{method: "GET", path: "/repos/owner/repo/pulls/12/files"},
+				{method: "GET", path: "/repos/owner/repo/pulls/12/files"},
+				{method: "GET", path: "/repos/owner/repo/contents/.github/CODEOWNERS"},
+				{method: "GET", path: "/repos/owner/repo/contents/CODEOWNERS"},
+				{method: "POST", path: "/repos/owner/repo/pulls/12/requested_reviewers", body: map[string]interface{}{
+					"reviewers": []interface{}{"alice", "bob"},
+				}},
+			},
+		},
+		{
+			name: "コラボレーターでない参加者はレビュアーにしない",
+			options: ProposalOptions{
+				Reviewers: []string{"carol", "dave"},
+			},
+			responses: map[string]mockResponse{
+				"POST /repos/owner/repo/pulls":                        {statusCode: http.StatusCreated, body: github.PullRequest{Number: github.Ptr(12)}},
+				"GET /repos/owner/repo/collaborators/carol":           {statusCode: http.StatusNoContent},
+				"POST /repos/owner/repo/pulls/12/requested_reviewers": {statusCode: http.StatusCreated, body: github.PullRequest{Number: github.Ptr(12)}},
+			},
+			expectedReqs: []mockRequest{
+				{method: "POST", path: "/repos/owner/repo/pulls"},
+				{method: "GET", path: "/repos/owner/repo/collaborators/carol"},
+				{method: "GET", path: "/repos/owner/repo/collaborators/dave"},
+				{method: "POST", path: "/repos/owner/repo/pulls/12/requested_reviewers", body: map[string]interface{}{
+					"reviewers": []interface{}{"carol"},
+				}},
+			},
+		},
+		{
+			name:    "ラベルの追加に失敗してもPRの作成は成功する",
+			options: ProposalOptions{Labels: []string{"docs"}},
+			responses: map[string]mockResponse{
+				"POST /repos/owner/repo/pulls":            {statusCode: http.StatusCreated, body: github.PullRequest{Number: github.Ptr(12)}},
+				"POST /repos/owner/repo/issues/12/labels": {statusCode: http.StatusForbidden, body: map[string]string{"message": "forbidden"}},
+			},
+			expectedReqs: []mockRequest{
+				{method: "POST", path: "/repos/owner/repo/pulls"},
+				{method: "POST", path: "/repos/owner/repo/issues/12/labels"},
+			},
+			expectWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := &mockTransport{responses: tt.responses, expectedReqs: tt.expectedReqs}
+			var warnings []error
+			options := tt.options
+			options.OnWarning = func(err error) { warnings = append(warnings, err) }
+			api := NewPullRequestAPI(github.NewClient(&http.Client{Transport: mt}), "owner", "repo", "main", "docgent/1", WithProposalOptions(options))
+
+			handle, err := api.CreateProposal(domain.Diffs{}, domain.NewProposalContent("タイトル", "本文"))
+
+			assert.NoError(t, err)
+			assert.Equal(t, domain.NewProposalHandle("github-pull-request", "12"), handle)
+			assert.Len(t, warnings, tt.expectWarnings, "%v", errors.Join(warnings...))
+			mt.verify(t)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// discardPullRequest closes the pull request without merging and deletes its head branch.
+// Branches of forks are left as they are, since the app cannot delete them.
+func discardPullRequest(ctx context.Context, client *github.Client, owner, repo string, number int) error {
+	pr, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	if pr.GetState() != "closed" {
+		if _, _, err := client.PullRequests.Edit(ctx, owner, repo, number, &github.PullRequest{State: github.Ptr("closed")}); err != nil {
+			return fmt.Errorf("failed to close pull request: %w", err)
+		}
+	}
+
+	if pr.GetHead().GetRepo().GetFullName() != pr.GetBase().GetRepo().GetFullName() {
+		return nil
+	}
+	resp, err := client.Git.DeleteRef(ctx, owner, repo, "heads/"+pr.GetHead().GetRef())
+	// The branch may already be deleted by "Automatically delete head branches"
+	if err != nil && (resp == nil || resp.StatusCode != 422) {
+		return fmt.Errorf("failed to delete branch: %w", err)
+	}
+	return nil
+}
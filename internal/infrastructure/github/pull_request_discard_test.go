@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscardPullRequest(t *testing.T) {
+	pullRequest := func(state, headRepo string) github.PullRequest {
+		return github.PullRequest{
+			State: github.Ptr(state),
+			Head:  &github.PullRequestBranch{Ref: github.Ptr("docgent/1"), Repo: &github.Repository{FullName: github.Ptr(headRepo)}},
+			Base:  &github.PullRequestBranch{Ref: github.Ptr("main"), Repo: &github.Repository{FullName: github.Ptr("owner/repo")}},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		responses    map[string]mockResponse
+		expectedReqs []mockRequest
+		expectError  bool
+	}{
+		{
+			name: "PRをクローズしてブランチを削除する",
+			responses: map[string]mockResponse{
+				"GET /repos/owner/repo/pulls/1":                     {statusCode: http.StatusOK, body: pullRequest("open", "owner/repo")},
+				"PATCH /repos/owner/repo/pulls/1":                   {statusCode: http.StatusOK, body: pullRequest("closed", "owner/repo")},
+				"DELETE /repos/owner/repo/git/refs/heads/docgent/1": {statusCode: http.StatusNoContent},
+			},
+			expectedReqs: []mockRequest{
+				{method: "GET", path: "/repos/owner/repo/pulls/1"},
+				{method: "PATCH", path: "/repos/owner/repo/pulls/1", body: map[string]interface{}{"state": "closed"}},
+				{method: "DELETE", path: "/repos/owner/repo/git/refs/heads/docgent/1"},
+			},
+		},
+		{
+			name: "クローズ済みでブランチも削除済みなら何もしない",
+			responses: map[string]mockResponse{
+				"GET /repos/owner/repo/pulls/1":                     {statusCode: http.StatusOK, body: pullRequest("closed", "owner/repo")},
+				"DELETE /repos/owner/repo/git/refs/heads/docgent/1": {statusCode: http.StatusUnprocessableEntity, body: map[string]string{"message": "Reference does not exist"}},
+			},
+			expectedReqs: []mockRequest{
+				{method: "GET", path: "/repos/owner/repo/pulls/1"},
+				{method: "DELETE", path: "/repos/owner/repo/git/refs/heads/docgent/1"},
+			},
+		},
+		{
+			name: "フォークのブランチは削除しない",
+			responses: map[string]mockResponse{
+				"GET /repos/owner/repo/pulls/1":   {statusCode: http.StatusOK, body: pullRequest("open", "someone/repo")},
+				"PATCH /repos/owner/repo/pulls/1": {statusCode: http.StatusOK, body: pullRequest("closed", "someone/repo")},
+			},
+			expectedReqs: []mockRequest{
+				{method: "GET", path: "/repos/owner/repo/pulls/1"},
+				{method: "PATCH", path: "/repos/owner/repo/pulls/1"},
+			},
+		},
+		{
+			name:        "PRが存在しない場合はエラー",
+			responses:   map[string]mockResponse{},
+			expectError: true,
+			expectedReqs: []mockRequest{
+				{method: "GET", path: "/repos/owner/repo/pulls/1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := &mockTransport{responses: tt.responses, expectedReqs: tt.expectedReqs}
+			client := github.NewClient(&http.Client{Transport: mt})
+
+			err := discardPullRequest(context.Background(), client, "owner", "repo", 1)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			mt.verify(t)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+
+	"docgent/internal/infrastructure/yaml"
+)
+
+func (s *PullRequestAPI) warn(err error) {
+	if s.proposalOptions.OnWarning != nil {
+		s.proposalOptions.OnWarning(err)
+	}
+}
+
+func (s *PullRequestAPI) addLabels(ctx context.Context, number int) error {
+	if len(s.proposalOptions.Labels) == 0 {
+		return nil
+	}
+	if _, _, err := s.client.Issues.AddLabelsToIssue(ctx, s.owner, s.repo, number, s.proposalOptions.Labels); err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+	return nil
+}
+
+// requestReviewers requests reviews from the owners of the changed files and the configured reviewers.
+// GitHub rejects the whole request if any of the users is not a collaborator, so the owners are requested separately
+// from the configured reviewers, who are often conversation participants without access to the repository.
+// Configured reviewers who are not collaborators are skipped.
+func (s *PullRequestAPI) requestReviewers(ctx context.Context, number int) error {
+	var errs []error
+	var requested github.ReviewersRequest
+	if s.proposalOptions.RequestOwnerReviews {
+		owners, err := s.listOwnersOfChangedFiles(ctx, number)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			request := newReviewersRequest(owners)
+			if err := s.sendReviewersRequest(ctx, number, request); err != nil {
+				errs = append(errs, err)
+			} else {
+				requested = request
+			}
+		}
+	}
+
+	request := newReviewersRequest(s.proposalOptions.Reviewers)
+	request.Reviewers = slices.DeleteFunc(request.Reviewers, func(reviewer string) bool {
+		return slices.ContainsFunc(requested.Reviewers, func(r string) bool { return strings.EqualFold(r, reviewer) })
+	})
+	request.TeamReviewers = slices.DeleteFunc(request.TeamReviewers, func(team string) bool {
+		return slices.Contains(requested.TeamReviewers, team)
+	})
+	collaborators, err := s.filterCollaborators(ctx, request.Reviewers)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	request.Reviewers = collaborators
+	if err := s.sendReviewersRequest(ctx, number, request); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *PullRequestAPI) sendReviewersRequest(ctx context.Context, number int, request github.ReviewersRequest) error {
+	if len(request.Reviewers) == 0 && len(request.TeamReviewers) == 0 {
+		return nil
+	}
+	if _, _, err := s.client.PullRequests.RequestReviewers(ctx, s.owner, s.repo, number, request); err != nil {
+		return fmt.Errorf("failed to request reviewers %v %v: %w", request.Reviewers, request.TeamReviewers, err)
+	}
+	return nil
+}
+
+// filterCollaborators returns the users who are collaborators of the repository and can be requested to review.
+// Users whose collaborator status cannot be checked are dropped, and the error is returned along with the result.
+func (s *PullRequestAPI) filterCollaborators(ctx context.Context, users []string) ([]string, error) {
+	var collaborators []string
+	var errs []error
+	for _, user := range users {
+		ok, _, err := s.client.Repositories.IsCollaborator(ctx, s.owner, s.repo, user)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check if %s is a collaborator: %w", user, err))
+			continue
+		}
+		if ok {
+			collaborators = append(collaborators, user)
+		}
+	}
+	return collaborators, errors.Join(errs...)
+}
+
+// listOwnersOfChangedFiles returns the owners of the files changed by the pull request
+// from CODEOWNERS at the base branch and the owners field in the frontmatter at the head branch
+func (s *PullRequestAPI) listOwnersOfChangedFiles(ctx context.Context, number int) ([]string, error) {
+	var files []*github.CommitFile
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := s.client.PullRequests.ListFiles(ctx, s.owner, s.repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request files: %w", err)
+		}
+		files = append(files, page...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	codeowners, err := s.getCodeowners(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []string
+	for _, file := range files {
+		if codeowners != nil {
+			owners = append(owners, codeowners.OwnersOf(file.GetFilename())...)
+		}
+		if file.GetStatus() == "removed" {
+			continue
+		}
+		frontmatterOwners, err := s.getFrontmatterOwners(ctx, file.GetFilename())
+		if err != nil {
+			return nil, err
+		}
+		owners = append(owners, frontmatterOwners...)
+	}
+	return owners, nil
+}
+
+// getCodeowners returns nil if the repository has no CODEOWNERS
+func (s *PullRequestAPI) getCodeowners(ctx context.Context) (*Codeowners, error) {
+	for _, path := range codeownersPaths {
+		content, err := s.getFileContent(ctx, path, s.defaultBranch)
+		if errors.Is(err, errFileContentNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return ParseCodeowners(content), nil
+	}
+	return nil, nil
+}
+
+func (s *PullRequestAPI) getFrontmatterOwners(ctx context.Context, path string) ([]string, error) {
+	content, err := s.getFileContent(ctx, path, s.headBranch)
+	if errors.Is(err, errFileContentNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	frontmatter, _ := yaml.SplitContentAndFrontmatter(content)
+	owners, err := yaml.ParseOwners(frontmatter)
+	if err != nil {
+		// 壊れたフロントマターはレビュアーの決定には使わない
+		return nil, nil
+	}
+	return owners, nil
+}
+
+var errFileContentNotFound = errors.New("file content not found")
+
+func (s *PullRequestAPI) getFileContent(ctx context.Context, path, ref string) (string, error) {
+	file, _, resp, err := s.client.Repositories.GetContents(ctx, s.owner, s.repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", errFileContentNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s: %w", path, err)
+	}
+	if file == nil {
+		// ディレクトリ
+		return "", errFileContentNotFound
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return content, nil
+}
+
+// newReviewersRequest splits reviewers into users and teams. "@" prefixes, duplicates and emails are dropped.
+func newReviewersRequest(reviewers []string) github.ReviewersRequest {
+	var request github.ReviewersRequest
+	for _, reviewer := range reviewers {
+		reviewer = strings.TrimPrefix(strings.TrimSpace(reviewer), "@")
+		if reviewer == "" || strings.Contains(reviewer, "@") {
+			continue
+		}
+		if _, team, ok := strings.Cut(reviewer, "/"); ok {
+			if !slices.Contains(request.TeamReviewers, team) {
+				request.TeamReviewers = append(request.TeamReviewers, team)
+			}
+			continue
+		}
+		if !slices.ContainsFunc(request.Reviewers, func(r string) bool { return strings.EqualFold(r, reviewer) }) {
+			request.Reviewers = append(request.Reviewers, reviewer)
+		}
+	}
+	return request
+}
@@ -5,6 +5,7 @@ import (
 	"docgent/internal/application/port"
 	"docgent/internal/domain/data"
 	"fmt"
+	"strings"
 
 	"github.com/google/go-github/v68/github"
 )
@@ -16,6 +17,7 @@ type ReviewCommentConversationService struct {
 	prNumber        int
 	sourceCommentID int64
 	eyesReactionID  int64
+	threadRootID    int64  // スレッドの最初のコメントのID。返信はこのコメントに対して行う
 	fromUserID      string // ソースコメントの作者のID
 }
 
@@ -31,11 +33,36 @@ func NewReviewCommentConversationService(client *github.Client, owner, repo stri
 }
 
 func (s *ReviewCommentConversationService) GetHistory() (port.ConversationHistory, error) {
-	panic("not implemented")
+	ctx := context.Background()
+	comments, err := listReviewThread(ctx, s.client, s.owner, s.repo, s.prNumber, s.sourceCommentID)
+	if err != nil {
+		return port.ConversationHistory{}, err
+	}
+
+	user, _, err := s.client.Users.Get(ctx, "")
+	if err != nil {
+		return port.ConversationHistory{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	currentUserID := user.GetLogin()
+
+	conversationMessages := make([]port.ConversationMessage, 0, len(comments))
+	for _, comment := range comments {
+		conversationMessages = append(conversationMessages, port.ConversationMessage{
+			Author:       comment.GetUser().GetLogin(),
+			Content:      comment.GetBody(),
+			YouMentioned: strings.Contains(comment.GetBody(), fmt.Sprintf("@%s", currentUserID)),
+			IsYou:        comment.GetUser().GetLogin() == currentUserID,
+		})
+	}
+
+	return port.ConversationHistory{
+		URI:      s.URI(),
+		Messages: conversationMessages,
+	}, nil
 }
 
 func (s *ReviewCommentConversationService) URI() *data.URI {
-	panic("not implemented")
+	return NewReviewCommentRef(webURLOf(s.client), s.owner, s.repo, s.prNumber, s.sourceCommentID).ToURI()
 }
 
 func (s *ReviewCommentConversationService) Reply(input string, withMention bool) error {
@@ -46,6 +73,15 @@ func (s *ReviewCommentConversationService) Reply(input string, withMention bool)
 		message = fmt.Sprintf("@%s %s", s.fromUserID, input)
 	}
 
+	// 返信への返信はできないので、スレッドの最初のコメントに対して返信する
+	if s.threadRootID == 0 {
+		comment, _, err := s.client.PullRequests.GetComment(ctx, s.owner, s.repo, s.sourceCommentID)
+		if err != nil {
+			return fmt.Errorf("failed to get review comment: %w", err)
+		}
+		s.threadRootID = threadRootIDOf(comment)
+	}
+
 	// ReviewCommentの場合は返信として新しいReviewCommentを作成
 	_, _, err := s.client.PullRequests.CreateCommentInReplyTo(
 		ctx,
@@ -53,7 +89,7 @@ func (s *ReviewCommentConversationService) Reply(input string, withMention bool)
 		s.repo,
 		s.prNumber,
 		message,
-		s.sourceCommentID,
+		s.threadRootID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create review comment reply: %w", err)
@@ -87,3 +123,44 @@ func (s *ReviewCommentConversationService) RemoveEyes() error {
 
 	return nil
 }
+
+// threadRootIDOf returns the ID of the first comment of the review thread that the comment belongs to
+func threadRootIDOf(comment *github.PullRequestComment) int64 {
+	if comment.GetInReplyTo() != 0 {
+		return comment.GetInReplyTo()
+	}
+	return comment.GetID()
+}
+
+// listReviewThread returns the comments of the review thread that the comment belongs to, oldest first
+func listReviewThread(ctx context.Context, client *github.Client, owner, repo string, prNumber int, commentID int64) ([]*github.PullRequestComment, error) {
+	source, _, err := client.PullRequests.GetComment(ctx, owner, repo, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review comment: %w", err)
+	}
+	rootID := threadRootIDOf(source)
+
+	var thread []*github.PullRequestComment
+	opts := &github.PullRequestListCommentsOptions{
+		Sort:        "created",
+		Direction:   "asc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		comments, resp, err := client.PullRequests.ListComments(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list review comments: %w", err)
+		}
+		for _, comment := range comments {
+			if comment.GetID() == rootID || comment.GetInReplyTo() == rootID {
+				thread = append(thread, comment)
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return thread, nil
+}
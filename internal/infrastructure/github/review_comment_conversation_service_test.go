@@ -0,0 +1,82 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewCommentConversationService_URI(t *testing.T) {
+	service := NewReviewCommentConversationService(github.NewClient(nil), "kecbigmt", "docgent", 123, 456789, "")
+
+	got := service.URI()
+
+	assert.Equal(t, "https://github.com/kecbigmt/docgent/pull/123#discussion_r456789", got.String())
+}
+
+func TestReviewCommentConversationService_GetHistory(t *testing.T) {
+	mt := &mockTransport{
+		responses: map[string]mockResponse{
+			"GET /repos/owner/repo/pulls/comments/3": {
+				statusCode: http.StatusOK,
+				body:       github.PullRequestComment{ID: github.Ptr(int64(3)), InReplyTo: github.Ptr(int64(1))},
+			},
+			"GET /repos/owner/repo/pulls/123/comments": {
+				statusCode: http.StatusOK,
+				body: []github.PullRequestComment{
+					{ID: github.Ptr(int64(1)), Body: github.Ptr("この行は古いです"), User: &github.User{Login: github.Ptr("alice")}},
+					{ID: github.Ptr(int64(2)), Body: github.Ptr("別のスレッド"), User: &github.User{Login: github.Ptr("bob")}},
+					{ID: github.Ptr(int64(3)), InReplyTo: github.Ptr(int64(1)), Body: github.Ptr("@docgent 直してください"), User: &github.User{Login: github.Ptr("alice")}},
+				},
+			},
+			"GET /user": {
+				statusCode: http.StatusOK,
+				body:       github.User{Login: github.Ptr("docgent")},
+			},
+		},
+	}
+	client := github.NewClient(&http.Client{Transport: mt})
+	service := NewReviewCommentConversationService(client, "owner", "repo", 123, 3, "alice")
+
+	history, err := service.GetHistory()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/owner/repo/pull/123#discussion_r3", history.URI.String())
+	if assert.Len(t, history.Messages, 2) {
+		assert.Equal(t, "この行は古いです", history.Messages[0].Content)
+		assert.Equal(t, "@docgent 直してください", history.Messages[1].Content)
+		assert.True(t, history.Messages[1].YouMentioned)
+	}
+}
+
+func TestReviewCommentConversationService_Reply(t *testing.T) {
+	mt := &mockTransport{
+		responses: map[string]mockResponse{
+			"GET /repos/owner/repo/pulls/comments/3": {
+				statusCode: http.StatusOK,
+				body:       github.PullRequestComment{ID: github.Ptr(int64(3)), InReplyTo: github.Ptr(int64(1))},
+			},
+			"POST /repos/owner/repo/pulls/123/comments": {
+				statusCode: http.StatusCreated,
+				body:       github.PullRequestComment{ID: github.Ptr(int64(4))},
+			},
+		},
+		expectedReqs: []mockRequest{
+			{method: "GET", path: "/repos/owner/repo/pulls/comments/3"},
+			{
+				method: "POST",
+				path:   "/repos/owner/repo/pulls/123/comments",
+				body:   map[string]interface{}{"body": "修正しました", "in_reply_to": float64(1)},
+			},
+		},
+	}
+	client := github.NewClient(&http.Client{Transport: mt})
+	service := NewReviewCommentConversationService(client, "owner", "repo", 123, 3, "alice")
+
+	err := service.Reply("修正しました", false)
+
+	assert.NoError(t, err)
+	mt.verify(t)
+}
@@ -0,0 +1,61 @@
+package github
+
+import (
+	"docgent/internal/domain/data"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ReviewCommentRef points to an inline review comment on a pull request
+type ReviewCommentRef struct {
+	webURL    string
+	owner     string
+	repo      string
+	prNumber  int
+	commentID int64
+}
+
+func NewReviewCommentRef(webURL, owner, repo string, prNumber int, commentID int64) *ReviewCommentRef {
+	return &ReviewCommentRef{webURL: webURL, owner: owner, repo: repo, prNumber: prNumber, commentID: commentID}
+}
+
+func (u *ReviewCommentRef) ToURI() *data.URI {
+	rawURI := fmt.Sprintf("%s/%s/%s/pull/%d#discussion_r%d", u.webURL, u.owner, u.repo, u.prNumber, u.commentID)
+	return data.NewURIUnsafe(rawURI)
+}
+
+func (u *ReviewCommentRef) Owner() string {
+	return u.owner
+}
+
+func (u *ReviewCommentRef) Repo() string {
+	return u.repo
+}
+
+func (u *ReviewCommentRef) PRNumber() int {
+	return u.prNumber
+}
+
+func (u *ReviewCommentRef) CommentID() int64 {
+	return u.commentID
+}
+
+// {webURL}/{owner}/{repo}/pull/{prNumber}#discussion_r{commentID}
+var reReviewCommentURI = regexp.MustCompile(`^(https?://[^/]+)/([^/]+)/([^/]+)/pull/(\d+)(?:/files)?#discussion_r(\d+)$`)
+
+func ParseReviewCommentRef(uri *data.URI) (*ReviewCommentRef, error) {
+	matches := reReviewCommentURI.FindStringSubmatch(uri.String())
+	if len(matches) != 6 {
+		return nil, fmt.Errorf("invalid URI: %s", uri)
+	}
+	prNumber, err := strconv.Atoi(matches[4])
+	if err != nil {
+		return nil, fmt.Errorf("invalid PR number: %s", matches[4])
+	}
+	commentID, err := strconv.ParseInt(matches[5], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid review comment ID: %s", matches[5])
+	}
+	return NewReviewCommentRef(matches[1], matches[2], matches[3], prNumber, commentID), nil
+}
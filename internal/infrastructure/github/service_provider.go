@@ -6,6 +6,7 @@ import (
 
 	"docgent/internal/application/port"
 	"docgent/internal/domain"
+	"docgent/internal/domain/data"
 )
 
 // ServiceProvider implements the GitHubServiceProvider interface
@@ -19,6 +20,16 @@ func NewServiceProvider(api *API) *ServiceProvider {
 	}
 }
 
+// Mention returns the mention of the app in comments, or an empty string if it is unknown
+func (p *ServiceProvider) Mention() string {
+	return p.api.Mention()
+}
+
+// WebURL returns the web URL of the GitHub instance, e.g. "https://github.com"
+func (p *ServiceProvider) WebURL() string {
+	return p.api.WebURL()
+}
+
 // NewIssueCommentConversationService creates a conversation service with the proper context
 func (p *ServiceProvider) NewIssueCommentConversationService(installationID int64, ref *IssueCommentRef, fromUserID string) port.ConversationService {
 	return NewIssueCommentConversationService(
@@ -40,11 +51,26 @@ func (p *ServiceProvider) NewReviewCommentConversationService(installationID int
 	)
 }
 
+// NewIssueConversationService creates a conversation service for an issue
+func (p *ServiceProvider) NewIssueConversationService(installationID int64, owner, repo string, number int, fromUserID string) port.ConversationService {
+	return NewIssueConversationService(p.api.NewClient(installationID), owner, repo, number, fromUserID)
+}
+
+// NewConversationResolver creates a conversation resolver for issues
+func (p *ServiceProvider) NewConversationResolver(installationID int64) port.ConversationResolver {
+	return NewIssueConversationResolver(p.api.NewClient(installationID))
+}
+
 // NewFileQueryService creates a file query service with the proper context
 func (p *ServiceProvider) NewFileQueryService(installationID int64, owner, repo, branch string) port.FileQueryService {
 	return NewFileQueryService(p.api.NewClient(installationID), owner, repo, branch)
 }
 
+// NewFileHistoryService creates a file history service with the proper context
+func (p *ServiceProvider) NewFileHistoryService(installationID int64, owner, repo, branch string) port.FileHistoryService {
+	return NewFileQueryService(p.api.NewClient(installationID), owner, repo, branch)
+}
+
 // NewFileRepository creates a file repository with the proper context
 func (p *ServiceProvider) NewFileRepository(installationID int64, owner, repo, branch string) *FileRepository {
 	return NewFileRepository(p.api.NewClient(installationID), owner, repo, branch)
@@ -55,14 +81,49 @@ func (p *ServiceProvider) NewSourceRepository(installationID int64) *SourceRepos
 	return NewSourceRepository(p.api.NewClient(installationID))
 }
 
+// NewSourceLocator creates a source locator with the proper context
+func (p *ServiceProvider) NewSourceLocator(installationID int64) *SourceLocator {
+	return NewSourceLocator(p.api.NewClient(installationID))
+}
+
+// IsRepositoryPublic reports whether everyone in the organization can read the repository
+func (p *ServiceProvider) IsRepositoryPublic(ctx context.Context, installationID int64, owner, repo string) (bool, error) {
+	return isRepositoryPublic(ctx, p.api.NewClient(installationID), owner, repo)
+}
+
 // NewBranchService creates a branch service with the proper context
 func (p *ServiceProvider) NewBranchService(installationID int64, owner, repo string) *BranchService {
 	return NewBranchService(p.api.NewClient(installationID), owner, repo)
 }
 
 // NewPullRequestAPI creates a pull request API with the proper context
-func (p *ServiceProvider) NewPullRequestAPI(installationID int64, owner, repo, baseBranch, headBranch string) domain.ProposalRepository {
-	return NewPullRequestAPI(p.api.NewClient(installationID), owner, repo, baseBranch, headBranch)
+func (p *ServiceProvider) NewPullRequestAPI(installationID int64, owner, repo, baseBranch, headBranch string, options ...NewPullRequestAPIOption) domain.ProposalRepository {
+	return NewPullRequestAPI(p.api.NewClient(installationID), owner, repo, baseBranch, headBranch, options...)
+}
+
+// NewBaseSynchronizer creates a base synchronizer with the proper context
+func (p *ServiceProvider) NewBaseSynchronizer(installationID int64, owner, repo string) port.ProposalBaseSynchronizer {
+	return NewBaseSynchronizer(p.api.NewClient(installationID), owner, repo)
+}
+
+// NewProposalLinkRepository creates a proposal link repository with the proper context
+func (p *ServiceProvider) NewProposalLinkRepository(installationID int64, owner, repo string) port.ProposalLinkRepository {
+	return NewProposalLinkRepository(p.api.NewClient(installationID), owner, repo)
+}
+
+// ListMergedFilePermalinks returns permalinks of the files added or modified by a merged pull request
+func (p *ServiceProvider) ListMergedFilePermalinks(ctx context.Context, installationID int64, owner, repo string, number int, mergeCommitSHA string) ([]*data.URI, error) {
+	return listMergedFilePermalinks(ctx, p.api.NewClient(installationID), owner, repo, number, mergeCommitSHA)
+}
+
+// ListPullRequestFiles returns the paths of the files added or modified by a pull request
+func (p *ServiceProvider) ListPullRequestFiles(ctx context.Context, installationID int64, owner, repo string, number int) ([]string, error) {
+	return listChangedFiles(ctx, p.api.NewClient(installationID), owner, repo, number)
+}
+
+// NewCheckRunReporter creates a check run reporter with the proper context
+func (p *ServiceProvider) NewCheckRunReporter(installationID int64, owner, repo, headSHA string) port.DocumentLintReporter {
+	return NewCheckRunReporter(p.api.NewClient(installationID), owner, repo, headSHA)
 }
 
 // GetPullRequestHeadBranch gets the head branch of a pull request
@@ -76,6 +137,21 @@ func (p *ServiceProvider) GetPullRequestHeadBranch(ctx context.Context, installa
 	return pr.Head.GetRef(), nil
 }
 
+// ListOpenProposals returns the open pull requests of the repository that were generated from conversations
+func (p *ServiceProvider) ListOpenProposals(ctx context.Context, installationID int64, owner, repo string) ([]OpenProposal, error) {
+	return NewProposalLinkRepository(p.api.NewClient(installationID), owner, repo).ListOpen(ctx)
+}
+
+// DiscardPullRequest closes a pull request without merging and deletes its head branch
+func (p *ServiceProvider) DiscardPullRequest(ctx context.Context, installationID int64, owner, repo string, number int) error {
+	return discardPullRequest(ctx, p.api.NewClient(installationID), owner, repo, number)
+}
+
+// NewStaleDocumentNotifier creates a stale document notifier with the proper context
+func (p *ServiceProvider) NewStaleDocumentNotifier(installationID int64, owner, repo string) port.StaleDocumentNotifier {
+	return NewStaleDocumentNotifier(p.api.NewClient(installationID), owner, repo)
+}
+
 // NewResponseFormatter creates a response formatter for GitHub
 func (p *ServiceProvider) NewResponseFormatter() port.ResponseFormatter {
 	return NewResponseFormatter()
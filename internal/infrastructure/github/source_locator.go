@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/google/go-github/v68/github"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+var reRepositoryURI = regexp.MustCompile(`^https?://[^/]+/([^/]+)/([^/#?]+)`)
+
+// SourceLocator resolves the repository and visibility of GitHub sources
+type SourceLocator struct {
+	client *github.Client
+}
+
+func NewSourceLocator(client *github.Client) *SourceLocator {
+	return &SourceLocator{client: client}
+}
+
+func (l *SourceLocator) Match(uri *data.URI) bool {
+	return uri.Host() == webHostOf(l.client) && reRepositoryURI.MatchString(uri.String())
+}
+
+func (l *SourceLocator) Locate(ctx context.Context, uri *data.URI) (port.SourceLocation, error) {
+	matches := reRepositoryURI.FindStringSubmatch(uri.String())
+	if matches == nil {
+		return port.SourceLocation{}, fmt.Errorf("invalid GitHub URI: %s", uri)
+	}
+	owner, repo := matches[1], matches[2]
+
+	public, err := isRepositoryPublic(ctx, l.client, owner, repo)
+	if err != nil {
+		return port.SourceLocation{}, err
+	}
+
+	visibility := port.SourceVisibilityPrivate
+	if public {
+		visibility = port.SourceVisibilityPublic
+	}
+	return port.SourceLocation{
+		ContainerID: fmt.Sprintf("%s/%s", owner, repo),
+		Visibility:  visibility,
+	}, nil
+}
+
+// isRepositoryPublic reports whether everyone in the organization can read the repository.
+// Internal repositories are treated as public because they are visible to the whole company.
+func isRepositoryPublic(ctx context.Context, client *github.Client, owner, repo string) (bool, error) {
+	repository, _, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return false, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	switch repository.GetVisibility() {
+	case "public", "internal":
+		return true, nil
+	case "private":
+		return false, nil
+	}
+	return !repository.GetPrivate(), nil
+}
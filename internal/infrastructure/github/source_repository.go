@@ -2,9 +2,13 @@ package github
 
 import (
 	"context"
+	"docgent/internal/application/port"
 	"docgent/internal/domain/data"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v68/github"
 )
@@ -18,10 +22,14 @@ func NewSourceRepository(client *github.Client) *SourceRepository {
 }
 
 func (r *SourceRepository) Match(uri *data.URI) bool {
-	return uri.Host() == "github.com"
+	return uri.Host() == webHostOf(r.client)
 }
 
 func (r *SourceRepository) Find(ctx context.Context, uri *data.URI) (*data.Source, error) {
+	if reviewCommentRef, err := ParseReviewCommentRef(uri); err == nil {
+		return r.findReviewThread(ctx, uri, reviewCommentRef)
+	}
+
 	ref, err := ParseIssueCommentRef(uri)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse issue comment ref: %w", err)
@@ -65,3 +73,78 @@ func (r *SourceRepository) Find(ctx context.Context, uri *data.URI) (*data.Sourc
 
 	return data.NewSource(uri, content.String()), nil
 }
+
+// findReviewThread returns the inline review thread that the comment belongs to, with the file and diff hunk it is anchored to
+func (r *SourceRepository) findReviewThread(ctx context.Context, uri *data.URI, ref *ReviewCommentRef) (*data.Source, error) {
+	comments, err := listReviewThread(ctx, r.client, ref.Owner(), ref.Repo(), ref.PRNumber(), ref.CommentID())
+	if err != nil {
+		return nil, err
+	}
+
+	var content strings.Builder
+	if len(comments) > 0 {
+		content.WriteString(fmt.Sprintf("<conversation uri=%q path=%q>\n", uri, comments[0].GetPath()))
+		content.WriteString(fmt.Sprintf("<diff_hunk>\n%s\n</diff_hunk>\n", comments[0].GetDiffHunk()))
+	} else {
+		content.WriteString(fmt.Sprintf("<conversation uri=%q>\n", uri))
+	}
+
+	for _, comment := range comments {
+		if comment.GetID() == ref.CommentID() {
+			content.WriteString(fmt.Sprintf("<message user=%q highlighted=\"true\">\n%s\n</message>\n", comment.GetUser().GetLogin(), comment.GetBody()))
+		} else {
+			content.WriteString(fmt.Sprintf("<message user=%q>\n%s\n</message>\n", comment.GetUser().GetLogin(), comment.GetBody()))
+		}
+	}
+
+	content.WriteString("</conversation>")
+
+	return data.NewSource(uri, content.String()), nil
+}
+
+// {webURL}/{owner}/{repo}/pull/{number} or {webURL}/{owner}/{repo}/issues/{number}, optionally followed by a fragment
+var reIssueURI = regexp.MustCompile(`^https?://[^/]+/([^/]+)/([^/]+)/(?:pull|issues)/(\d+)`)
+
+// FindActivitySince returns comments posted or edited after the given time and state changes of the pull request or issue.
+// Other GitHub URIs such as files have no activity to report, so an empty activity is returned for them.
+func (r *SourceRepository) FindActivitySince(ctx context.Context, uri *data.URI, since time.Time) (port.SourceActivity, error) {
+	matches := reIssueURI.FindStringSubmatch(uri.String())
+	if matches == nil {
+		return port.SourceActivity{URI: uri}, nil
+	}
+	owner, repo := matches[1], matches[2]
+	number, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return port.SourceActivity{}, fmt.Errorf("invalid issue number: %s", matches[3])
+	}
+
+	issue, _, err := r.client.Issues.Get(ctx, owner, repo, number)
+	if err != nil {
+		return port.SourceActivity{}, fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	activity := port.SourceActivity{URI: uri}
+
+	if closedAt := issue.GetClosedAt(); issue.GetState() == "closed" && closedAt.After(since) {
+		if mergedAt := issue.GetPullRequestLinks().GetMergedAt(); !mergedAt.IsZero() {
+			activity.Events = append(activity.Events, fmt.Sprintf("pull request was merged at %s", mergedAt.Format(time.RFC3339)))
+		} else {
+			activity.Events = append(activity.Events, fmt.Sprintf("closed at %s", closedAt.Format(time.RFC3339)))
+		}
+	}
+
+	comments, _, err := r.client.Issues.ListComments(ctx, owner, repo, number, &github.IssueListCommentsOptions{
+		Since: &since,
+	})
+	if err != nil {
+		return port.SourceActivity{}, fmt.Errorf("failed to list comments: %w", err)
+	}
+	for _, comment := range comments {
+		activity.Messages = append(activity.Messages, port.ConversationMessage{
+			Author:  comment.GetUser().GetLogin(),
+			Content: comment.GetBody(),
+		})
+	}
+
+	return activity, nil
+}
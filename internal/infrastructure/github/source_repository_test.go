@@ -2,37 +2,65 @@ package github
 
 import (
 	"context"
-	"docgent/internal/domain/data"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v68/github"
 	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
 )
 
 func TestSourceRepository_Match(t *testing.T) {
 	tests := []struct {
-		name string
-		uri  string
-		want bool
+		name   string
+		client *github.Client
+		uri    string
+		want   bool
 	}{
 		{
-			name: "GitHubのURLの場合はtrueを返す",
-			uri:  "https://github.com/owner/repo/pull/123#issuecomment-456789",
-			want: true,
+			name:   "GitHubのURLの場合はtrueを返す",
+			client: github.NewClient(nil),
+			uri:    "https://github.com/owner/repo/pull/123#issuecomment-456789",
+			want:   true,
+		},
+		{
+			name:   "レビューコメントのURLの場合はtrueを返す",
+			client: github.NewClient(nil),
+			uri:    "https://github.com/owner/repo/pull/123#discussion_r456789",
+			want:   true,
+		},
+		{
+			name:   "GitHubのURL以外の場合はfalseを返す",
+			client: github.NewClient(nil),
+			uri:    "https://app.slack.com/client/T123/C456/thread/1234567890.123",
+			want:   false,
+		},
+		{
+			name:   "GitHub Enterprise ServerのURLの場合はtrueを返す",
+			client: newEnterpriseClient(t, "https://ghes.example.com/api/v3/"),
+			uri:    "https://ghes.example.com/owner/repo/pull/123#issuecomment-456789",
+			want:   true,
+		},
+		{
+			name:   "GHE.comのURLの場合はtrueを返す",
+			client: newEnterpriseClient(t, "https://api.octocorp.ghe.com/"),
+			uri:    "https://octocorp.ghe.com/owner/repo/pull/123#discussion_r456789",
+			want:   true,
 		},
 		{
-			name: "GitHubのURL以外の場合はfalseを返す",
-			uri:  "https://app.slack.com/client/T123/C456/thread/1234567890.123",
-			want: false,
+			name:   "GitHub Enterprise Serverの場合はgithub.comのURLにはfalseを返す",
+			client: newEnterpriseClient(t, "https://ghes.example.com/api/v3/"),
+			uri:    "https://github.com/owner/repo/pull/123#issuecomment-456789",
+			want:   false,
 		},
 	}
 
-	client := github.NewClient(nil)
-	repo := NewSourceRepository(client)
-
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			repo := NewSourceRepository(tt.client)
 			uri := data.NewURIUnsafe(tt.uri)
 			got := repo.Match(uri)
 			assert.Equal(t, tt.want, got)
@@ -43,6 +71,7 @@ func TestSourceRepository_Match(t *testing.T) {
 func TestSourceRepository_Find(t *testing.T) {
 	tests := []struct {
 		name         string
+		baseURL      string
 		uri          string
 		setup        func(*mockTransport)
 		wantErr      bool
@@ -95,6 +124,96 @@ func TestSourceRepository_Find(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "正常系：レビューコメントのスレッドを取得できる",
+			uri:  "https://github.com/owner/repo/pull/123#discussion_r3",
+			setup: func(mt *mockTransport) {
+				mt.responses = map[string]mockResponse{
+					"GET /repos/owner/repo/pulls/comments/3": {
+						statusCode: http.StatusOK,
+						body:       github.PullRequestComment{ID: github.Ptr(int64(3)), InReplyTo: github.Ptr(int64(1))},
+					},
+					"GET /repos/owner/repo/pulls/123/comments": {
+						statusCode: http.StatusOK,
+						body: []github.PullRequestComment{
+							{
+								ID:       github.Ptr(int64(1)),
+								Path:     github.Ptr("docs/setup.md"),
+								DiffHunk: github.Ptr("@@ -1 +1 @@\n-old\n+new"),
+								User:     &github.User{Login: github.Ptr("alice")},
+								Body:     github.Ptr("この手順は古いです"),
+							},
+							{
+								ID:   github.Ptr(int64(2)),
+								User: &github.User{Login: github.Ptr("bob")},
+								Body: github.Ptr("別のスレッド"),
+							},
+							{
+								ID:        github.Ptr(int64(3)),
+								InReplyTo: github.Ptr(int64(1)),
+								User:      &github.User{Login: github.Ptr("carol")},
+								Body:      github.Ptr("v2の手順に直しましょう"),
+							},
+						},
+					},
+				}
+			},
+			wantErr: false,
+			wantContent: `<conversation uri="https://github.com/owner/repo/pull/123#discussion_r3" path="docs/setup.md">
+<diff_hunk>
+@@ -1 +1 @@
+-old
++new
+</diff_hunk>
+<message user="alice">
+この手順は古いです
+</message>
+<message user="carol" highlighted="true">
+v2の手順に直しましょう
+</message>
+</conversation>`,
+			expectedReqs: []mockRequest{
+				{
+					method: "GET",
+					path:   "/repos/owner/repo/pulls/comments/3",
+				},
+				{
+					method: "GET",
+					path:   "/repos/owner/repo/pulls/123/comments",
+				},
+			},
+		},
+		{
+			name:    "正常系：GitHub Enterprise Serverのコメントを取得できる",
+			baseURL: "https://ghes.example.com/api/v3/",
+			uri:     "https://ghes.example.com/owner/repo/pull/123#issuecomment-456789",
+			setup: func(mt *mockTransport) {
+				mt.responses = map[string]mockResponse{
+					"GET /api/v3/repos/owner/repo/issues/123/comments": {
+						statusCode: http.StatusOK,
+						body: []github.IssueComment{
+							{
+								ID:   github.Ptr(int64(456789)),
+								User: &github.User{Login: github.Ptr("testuser")},
+								Body: github.Ptr("テストコメント"),
+							},
+						},
+					},
+				}
+			},
+			wantErr: false,
+			wantContent: `<conversation uri="https://ghes.example.com/owner/repo/pull/123#issuecomment-456789">
+<message user="testuser" highlighted="true">
+テストコメント
+</message>
+</conversation>`,
+			expectedReqs: []mockRequest{
+				{
+					method: "GET",
+					path:   "/api/v3/repos/owner/repo/issues/123/comments",
+				},
+			},
+		},
 		{
 			name: "異常系：不正なURI",
 			uri:  "https://github.com/invalid/url",
@@ -137,6 +256,11 @@ func TestSourceRepository_Find(t *testing.T) {
 			tt.setup(mt)
 
 			client := github.NewClient(&http.Client{Transport: mt})
+			if tt.baseURL != "" {
+				var err error
+				client, err = client.WithEnterpriseURLs(tt.baseURL, tt.baseURL)
+				assert.NoError(t, err)
+			}
 			repo := NewSourceRepository(client)
 
 			// テスト対象の実行
@@ -158,3 +282,61 @@ func TestSourceRepository_Find(t *testing.T) {
 		})
 	}
 }
+
+func TestSourceRepository_FindActivitySince(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mergedAt := github.Timestamp{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name      string
+		uri       string
+		responses map[string]mockResponse
+		want      port.SourceActivity
+	}{
+		{
+			name: "PRのコメントとマージを返す",
+			uri:  "https://github.com/owner/repo/pull/12",
+			responses: map[string]mockResponse{
+				"GET /repos/owner/repo/issues/12": {
+					statusCode: http.StatusOK,
+					body: github.Issue{
+						Number:           github.Ptr(12),
+						State:            github.Ptr("closed"),
+						ClosedAt:         &mergedAt,
+						PullRequestLinks: &github.PullRequestLinks{MergedAt: &mergedAt},
+					},
+				},
+				"GET /repos/owner/repo/issues/12/comments": {
+					statusCode: http.StatusOK,
+					body: []github.IssueComment{
+						{User: &github.User{Login: github.Ptr("alice")}, Body: github.Ptr("タイムアウトを30秒に変更しました")},
+					},
+				},
+			},
+			want: port.SourceActivity{
+				Events:   []string{"pull request was merged at 2024-01-02T00:00:00Z"},
+				Messages: []port.ConversationMessage{{Author: "alice", Content: "タイムアウトを30秒に変更しました"}},
+			},
+		},
+		{
+			name:      "ファイルなどIssueやPR以外のURIは空のアクティビティを返す",
+			uri:       "https://github.com/owner/repo/blob/main/docs/setup.md",
+			responses: map[string]mockResponse{},
+			want:      port.SourceActivity{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := &mockTransport{responses: tt.responses}
+			repository := NewSourceRepository(github.NewClient(&http.Client{Transport: mt}))
+			uri := data.NewURIUnsafe(tt.uri)
+
+			activity, err := repository.FindActivitySince(context.Background(), uri, since)
+
+			assert.NoError(t, err)
+			tt.want.URI = uri
+			assert.Equal(t, tt.want, activity)
+		})
+	}
+}
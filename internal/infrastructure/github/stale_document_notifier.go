@@ -0,0 +1,149 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+
+	"docgent/internal/application/port"
+)
+
+// StaleDocumentNotifier implements the port.StaleDocumentNotifier interface by opening a GitHub issue in the documents repository
+type StaleDocumentNotifier struct {
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+func NewStaleDocumentNotifier(client *github.Client, owner, repo string) *StaleDocumentNotifier {
+	return &StaleDocumentNotifier{
+		client: client,
+		owner:  owner,
+		repo:   repo,
+	}
+}
+
+// Notify opens an issue for the stale document.
+// If an open issue for the same document already exists, it adds a comment to the issue instead.
+// The marker in the issue body records when the activities were checked, which LastNotifiedAt reads on the next check.
+func (n *StaleDocumentNotifier) Notify(ctx context.Context, report port.StaleDocumentReport) error {
+	marker := staleDocumentMarker(report.FilePath, report.CheckedAt)
+	body := formatStaleDocumentReport(report)
+
+	issue, err := n.findOpenIssue(ctx, report.FilePath)
+	if err != nil {
+		return err
+	}
+
+	if issue != nil {
+		_, _, err := n.client.Issues.CreateComment(ctx, n.owner, n.repo, issue.GetNumber(), &github.IssueComment{
+			Body: github.Ptr(body),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create issue comment: %w", err)
+		}
+
+		_, _, err = n.client.Issues.Edit(ctx, n.owner, n.repo, issue.GetNumber(), &github.IssueRequest{
+			Body: github.Ptr(reStaleDocumentMarker.ReplaceAllLiteralString(issue.GetBody(), marker)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update issue marker: %w", err)
+		}
+		return nil
+	}
+
+	_, _, err = n.client.Issues.Create(ctx, n.owner, n.repo, &github.IssueRequest{
+		Title: github.Ptr(fmt.Sprintf("Docgent: %s may be outdated", report.FilePath)),
+		Body:  github.Ptr(body + "\n\n" + marker),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	return nil
+}
+
+// LastNotifiedAt returns the time recorded in the marker of the open issue for the document.
+// Once the issue is closed, the activities are reported again only if they are newer than the document.
+func (n *StaleDocumentNotifier) LastNotifiedAt(ctx context.Context, path string) (time.Time, error) {
+	issue, err := n.findOpenIssue(ctx, path)
+	if err != nil || issue == nil {
+		return time.Time{}, err
+	}
+
+	matches := reStaleDocumentMarker.FindStringSubmatch(issue.GetBody())
+	// 時刻を記録する前に作られたIssueのマーカーには時刻がない
+	if matches == nil || matches[1] == "" {
+		return time.Time{}, nil
+	}
+	checkedAt, err := time.Parse(time.RFC3339, matches[1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse checked-at of issue #%d: %w", issue.GetNumber(), err)
+	}
+	return checkedAt, nil
+}
+
+func (n *StaleDocumentNotifier) findOpenIssue(ctx context.Context, path string) (*github.Issue, error) {
+	prefix := staleDocumentMarkerPrefix(path)
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		issues, resp, err := n.client.Issues.ListByRepo(ctx, n.owner, n.repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+		for _, issue := range issues {
+			if issue.IsPullRequest() {
+				continue
+			}
+			if strings.Contains(issue.GetBody(), prefix) {
+				return issue, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// <!-- docgent:stale-document path="{path}" checked-at="{RFC 3339}" -->. Issues opened by older versions have no checked-at
+var reStaleDocumentMarker = regexp.MustCompile(`<!-- docgent:stale-document path="(?:[^"\\]|\\.)*"(?: checked-at="([^"]*)")? -->`)
+
+func staleDocumentMarkerPrefix(path string) string {
+	return fmt.Sprintf("<!-- docgent:stale-document path=%q", path)
+}
+
+func staleDocumentMarker(path string, checkedAt time.Time) string {
+	return fmt.Sprintf("%s checked-at=%q -->", staleDocumentMarkerPrefix(path), checkedAt.UTC().Format(time.RFC3339))
+}
+
+func formatStaleDocumentReport(report port.StaleDocumentReport) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("The sources of [%s](%s) have new activity since the document was last updated (%s).\n\n",
+		report.FilePath, report.FileURI, report.LastModifiedAt.Format(time.RFC3339)))
+
+	b.WriteString("## Summary\n\n")
+	b.WriteString(strings.TrimSpace(report.Summary))
+	b.WriteString("\n\n")
+
+	b.WriteString("## Sources\n\n")
+	for _, activity := range report.Activities {
+		b.WriteString(fmt.Sprintf("- %s", activity.URI))
+		if len(activity.Messages) > 0 {
+			b.WriteString(fmt.Sprintf(" (%d new messages)", len(activity.Messages)))
+		}
+		b.WriteString("\n")
+		for _, event := range activity.Events {
+			b.WriteString(fmt.Sprintf("  - %s\n", event))
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+func TestStaleDocumentNotifier_LastNotifiedAt(t *testing.T) {
+	tests := []struct {
+		name   string
+		issues []github.Issue
+		want   time.Time
+	}{
+		{
+			name: "マーカーに記録した時刻を返す",
+			issues: []github.Issue{
+				{Number: github.Ptr(1), Body: github.Ptr(`<!-- docgent:stale-document path="docs/other.md" checked-at="2025-03-09T00:00:00Z" -->`)},
+				{Number: github.Ptr(2), Body: github.Ptr("Summary\n\n" + `<!-- docgent:stale-document path="docs/api.md" checked-at="2025-03-08T00:00:00Z" -->`)},
+			},
+			want: time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "時刻のない古いマーカーはゼロ値を返す",
+			issues: []github.Issue{
+				{Number: github.Ptr(2), Body: github.Ptr(`<!-- docgent:stale-document path="docs/api.md" -->`)},
+			},
+			want: time.Time{},
+		},
+		{
+			name:   "開いているIssueがなければゼロ値を返す",
+			issues: []github.Issue{},
+			want:   time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := &mockTransport{
+				responses: map[string]mockResponse{
+					"GET /repos/owner/docs/issues": {statusCode: http.StatusOK, body: tt.issues},
+				},
+			}
+			notifier := NewStaleDocumentNotifier(github.NewClient(&http.Client{Transport: mt}), "owner", "docs")
+
+			got, err := notifier.LastNotifiedAt(context.Background(), "docs/api.md")
+
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s", got)
+		})
+	}
+}
+
+func TestStaleDocumentNotifier_Notify(t *testing.T) {
+	report := port.StaleDocumentReport{
+		FilePath:       "docs/api.md",
+		FileURI:        data.NewURIUnsafe("https://github.com/owner/docs/blob/abc123/docs/api.md"),
+		LastModifiedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		CheckedAt:      time.Date(2025, 3, 15, 9, 0, 0, 0, time.UTC),
+		Summary:        "Authentication changed to OAuth.",
+	}
+
+	t.Run("既存のIssueにコメントしてマーカーの時刻を更新する", func(t *testing.T) {
+		mt := &mockTransport{
+			responses: map[string]mockResponse{
+				"GET /repos/owner/docs/issues": {
+					statusCode: http.StatusOK,
+					body: []github.Issue{
+						{Number: github.Ptr(2), Body: github.Ptr("Summary\n\n" + `<!-- docgent:stale-document path="docs/api.md" checked-at="2025-03-08T00:00:00Z" -->`)},
+					},
+				},
+				"POST /repos/owner/docs/issues/2/comments": {statusCode: http.StatusCreated, body: github.IssueComment{}},
+				"PATCH /repos/owner/docs/issues/2":         {statusCode: http.StatusOK, body: github.Issue{}},
+			},
+			expectedReqs: []mockRequest{
+				{method: "GET", path: "/repos/owner/docs/issues"},
+				{method: "POST", path: "/repos/owner/docs/issues/2/comments"},
+				{
+					method: "PATCH",
+					path:   "/repos/owner/docs/issues/2",
+					body:   map[string]interface{}{"body": "Summary\n\n" + `<!-- docgent:stale-document path="docs/api.md" checked-at="2025-03-15T09:00:00Z" -->`},
+				},
+			},
+		}
+		notifier := NewStaleDocumentNotifier(github.NewClient(&http.Client{Transport: mt}), "owner", "docs")
+
+		require.NoError(t, notifier.Notify(context.Background(), report))
+		mt.verify(t)
+	})
+
+	t.Run("Issueがなければマーカー付きで作成する", func(t *testing.T) {
+		mt := &mockTransport{
+			responses: map[string]mockResponse{
+				"GET /repos/owner/docs/issues":  {statusCode: http.StatusOK, body: []github.Issue{}},
+				"POST /repos/owner/docs/issues": {statusCode: http.StatusCreated, body: github.Issue{}},
+			},
+		}
+		notifier := NewStaleDocumentNotifier(github.NewClient(&http.Client{Transport: mt}), "owner", "docs")
+
+		require.NoError(t, notifier.Notify(context.Background(), report))
+		require.Len(t, mt.requests, 2)
+		body := mt.requests[1].body.(map[string]interface{})["body"].(string)
+		assert.Contains(t, body, `<!-- docgent:stale-document path="docs/api.md" checked-at="2025-03-15T09:00:00Z" -->`)
+	})
+}
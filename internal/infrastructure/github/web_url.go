@@ -0,0 +1,44 @@
+package github
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v68/github"
+)
+
+// DefaultWebURL is the web URL of github.com
+const DefaultWebURL = "https://github.com"
+
+// webURLOf returns the web URL of the GitHub instance that the client talks to, e.g. "https://github.com".
+// GitHub Enterprise Server serves the API under /api/v3/ on the same host as the web UI.
+func webURLOf(client *github.Client) string {
+	if client == nil {
+		return DefaultWebURL
+	}
+	return webURLFromAPIURL(client.BaseURL)
+}
+
+// webHostOf returns the host of webURLOf
+func webHostOf(client *github.Client) string {
+	if client == nil {
+		return webHostFromAPIURL(nil)
+	}
+	return webHostFromAPIURL(client.BaseURL)
+}
+
+// webHostFromAPIURL strips the "api." prefix used by github.com and GHE.com.
+// GitHub Enterprise Server uses the same host for the API and the web UI.
+func webHostFromAPIURL(apiURL *url.URL) string {
+	if apiURL == nil {
+		return "github.com"
+	}
+	return strings.TrimPrefix(apiURL.Host, "api.")
+}
+
+func webURLFromAPIURL(apiURL *url.URL) string {
+	if apiURL == nil {
+		return DefaultWebURL
+	}
+	return apiURL.Scheme + "://" + webHostFromAPIURL(apiURL)
+}
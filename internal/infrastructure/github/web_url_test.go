@@ -0,0 +1,76 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v68/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"docgent/internal/domain/data"
+)
+
+func newEnterpriseClient(t *testing.T, baseURL string) *github.Client {
+	client, err := github.NewClient(nil).WithEnterpriseURLs(baseURL, baseURL)
+	require.NoError(t, err)
+	return client
+}
+
+func TestWebURLOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   *github.Client
+		expected string
+	}{
+		{
+			name:     "github.com",
+			client:   github.NewClient(nil),
+			expected: "https://github.com",
+		},
+		{
+			name:     "GitHub Enterprise Server",
+			client:   newEnterpriseClient(t, "https://ghes.example.com/api/v3/"),
+			expected: "https://ghes.example.com",
+		},
+		{
+			name:     "GHE.com",
+			client:   newEnterpriseClient(t, "https://api.octocorp.ghe.com/"),
+			expected: "https://octocorp.ghe.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, webURLOf(tt.client))
+		})
+	}
+}
+
+func TestEnterpriseURIs(t *testing.T) {
+	client := newEnterpriseClient(t, "https://ghes.example.com/api/v3/")
+
+	t.Run("コメントの参照をURIと相互に変換できる", func(t *testing.T) {
+		ref := NewIssueCommentRef(webURLOf(client), "owner", "repo", 1, 2)
+		assert.Equal(t, "https://ghes.example.com/owner/repo/pull/1#issuecomment-2", ref.ToURI().String())
+
+		parsed, err := ParseIssueCommentRef(ref.ToURI())
+		require.NoError(t, err)
+		assert.Equal(t, ref, parsed)
+	})
+
+	t.Run("設定したホストのURIだけを扱う", func(t *testing.T) {
+		repo := NewSourceRepository(client)
+		assert.True(t, repo.Match(data.NewURIUnsafe("https://ghes.example.com/owner/repo/pull/1")))
+		assert.False(t, repo.Match(data.NewURIUnsafe("https://github.com/owner/repo/pull/1")))
+	})
+
+	t.Run("パーマリンクからファイルパスを取り出せる", func(t *testing.T) {
+		service := NewFileQueryService(client, "owner", "repo", "main")
+		path, err := service.GetFilePath(data.NewURIUnsafe("https://ghes.example.com/owner/repo/blob/abc123/docs/a.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "docs/a.md", path)
+
+		_, err = service.GetFilePath(data.NewURIUnsafe("https://github.com/owner/repo/blob/abc123/docs/a.md"))
+		assert.Error(t, err)
+	})
+}
@@ -33,14 +33,35 @@ func (e *WebhookEvent) InnerEvent() interface{} {
  */
 
 type WebhookRequestParser struct {
-	webhookSecret string
+	webhookSecret  string
+	enterpriseHost string
 }
 
-func NewWebhookRequestParser(webhookSecret string) *WebhookRequestParser {
-	return &WebhookRequestParser{webhookSecret: webhookSecret}
+type NewWebhookRequestParserOption func(*WebhookRequestParser)
+
+// WithEnterpriseHost only accepts webhooks sent by the GitHub Enterprise Server of the host
+func WithEnterpriseHost(host string) NewWebhookRequestParserOption {
+	return func(p *WebhookRequestParser) {
+		p.enterpriseHost = host
+	}
+}
+
+func NewWebhookRequestParser(webhookSecret string, options ...NewWebhookRequestParserOption) *WebhookRequestParser {
+	p := &WebhookRequestParser{webhookSecret: webhookSecret}
+	for _, option := range options {
+		option(p)
+	}
+	return p
 }
 
 func (p *WebhookRequestParser) ParseRequest(r *http.Request) (*WebhookEvent, error) {
+	// GitHub Enterprise Server sends its hostname in X-GitHub-Enterprise-Host
+	if p.enterpriseHost != "" {
+		if host := r.Header.Get("X-GitHub-Enterprise-Host"); host != p.enterpriseHost {
+			return nil, fmt.Errorf("unexpected GitHub Enterprise host: %q", host)
+		}
+	}
+
 	payload, err := github.ValidatePayload(r, []byte(p.webhookSecret))
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate payload: %w", err)
@@ -54,6 +75,14 @@ func (p *WebhookRequestParser) ParseRequest(r *http.Request) (*WebhookEvent, err
 	switch event := ev.(type) {
 	case *github.IssueCommentEvent:
 		return NewWebhookEvent("issue_comment", event), nil
+	case *github.IssuesEvent:
+		return NewWebhookEvent("issues", event), nil
+	case *github.PullRequestEvent:
+		return NewWebhookEvent("pull_request", event), nil
+	case *github.PullRequestReviewEvent:
+		return NewWebhookEvent("pull_request_review", event), nil
+	case *github.PullRequestReviewCommentEvent:
+		return NewWebhookEvent("pull_request_review_comment", event), nil
 	case *github.PushEvent:
 		return NewWebhookEvent("push", event), nil
 	}
@@ -0,0 +1,129 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// DefaultWebURL is the URL of GitLab.com
+const DefaultWebURL = "https://gitlab.com"
+
+// API is a minimal client of the GitLab REST API v4 authenticated by a personal, group or project access token
+type API struct {
+	webURL     string
+	token      string
+	httpClient *http.Client
+
+	usernameLock sync.Mutex
+	username     string
+}
+
+type NewAPIOption func(*API)
+
+// WithHTTPClient replaces http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) NewAPIOption {
+	return func(a *API) {
+		a.httpClient = httpClient
+	}
+}
+
+// NewAPI creates an API of the GitLab instance at webURL, e.g. https://gitlab.example.com
+func NewAPI(webURL, token string, options ...NewAPIOption) *API {
+	if webURL == "" {
+		webURL = DefaultWebURL
+	}
+	a := &API{
+		webURL:     strings.TrimSuffix(webURL, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+	for _, option := range options {
+		option(a)
+	}
+	return a
+}
+
+// WebURL returns the URL of the GitLab instance, e.g. https://gitlab.com
+func (a *API) WebURL() string {
+	return a.webURL
+}
+
+// Username returns the user of the token, which is the author of Docgent's notes
+func (a *API) Username(ctx context.Context) (string, error) {
+	a.usernameLock.Lock()
+	defer a.usernameLock.Unlock()
+	if a.username != "" {
+		return a.username, nil
+	}
+
+	var user struct {
+		Username string `json:"username"`
+	}
+	if _, err := a.do(ctx, http.MethodGet, "/user", nil, nil, &user); err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+	a.username = user.Username
+	return a.username, nil
+}
+
+// do sends a request to the path under /api/v4. body and out are encoded and decoded as JSON if not nil.
+// Path segments such as project IDs and file paths must already be escaped.
+func (a *API) do(ctx context.Context, method, path string, query url.Values, body, out any) (*http.Response, error) {
+	rawURL := a.webURL + "/api/v4" + path
+	if len(query) > 0 {
+		rawURL += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", a.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return resp, newErrorResponse(resp)
+	}
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+// projectPath returns the API path of a project such as "group/subgroup/project"
+func projectPath(project string) string {
+	return "/projects/" + url.PathEscape(project)
+}
+
+// nextPage returns 0 if the response is the last page
+func nextPage(resp *http.Response) int {
+	var page int
+	fmt.Sscan(resp.Header.Get("X-Next-Page"), &page)
+	return page
+}
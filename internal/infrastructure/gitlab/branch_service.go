@@ -0,0 +1,32 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type BranchService struct {
+	api     *API
+	project string
+}
+
+func NewBranchService(api *API, project string) *BranchService {
+	return &BranchService{
+		api:     api,
+		project: project,
+	}
+}
+
+// CreateBranch creates a new branch from the specified base branch.
+func (s *BranchService) CreateBranch(ctx context.Context, baseBranchName, newBranchName string) error {
+	query := url.Values{
+		"branch": {newBranchName},
+		"ref":    {baseBranchName},
+	}
+	if _, err := s.api.do(ctx, http.MethodPost, projectPath(s.project)+"/repository/branches", query, nil, nil); err != nil {
+		return fmt.Errorf("failed to create new branch: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"docgent/internal/domain"
+)
+
+// applyDiff applies a diff generated by the agent to the branch in a single commit
+func applyDiff(ctx context.Context, api *API, project, branch string, diff domain.Diff) error {
+	dmp := diffmatchpatch.New()
+	patches, err := dmp.PatchFromText(diff.Body)
+	if err != nil {
+		return fmt.Errorf("failed to parse diff: %w", err)
+	}
+
+	if diff.IsNewFile {
+		newText, results := dmp.PatchApply(patches, "")
+		if !anyPatchApplied(results) {
+			return fmt.Errorf("no changes were applied to the file")
+		}
+		return commit(ctx, api, project, branch, fmt.Sprintf("Create file %s", diff.NewName), commitAction{
+			Action:   "create",
+			FilePath: diff.NewName,
+			Content:  newText,
+		})
+	}
+
+	// リネームの場合も、エージェントは新しい名前のファイルに対する差分を出力する
+	content, err := getFile(ctx, api, project, diff.NewName, branch)
+	if err != nil {
+		return fmt.Errorf("failed to get file content: %w", err)
+	}
+	patchedText, results := dmp.PatchApply(patches, content)
+	if !anyPatchApplied(results) {
+		return fmt.Errorf("no changes were applied to the file")
+	}
+
+	if diff.OldName != diff.NewName {
+		return commit(ctx, api, project, branch, fmt.Sprintf("Rename file %s to %s", diff.OldName, diff.NewName), commitAction{
+			Action:       "move",
+			FilePath:     diff.NewName,
+			PreviousPath: diff.OldName,
+			Content:      patchedText,
+		})
+	}
+	return commit(ctx, api, project, branch, fmt.Sprintf("Update file %s", diff.NewName), commitAction{
+		Action:   "update",
+		FilePath: diff.NewName,
+		Content:  patchedText,
+	})
+}
+
+// anyPatchApplied checks if any of the patches were successfully applied
+func anyPatchApplied(results []bool) bool {
+	for _, applied := range results {
+		if applied {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrorResponse is an error returned by the GitLab API
+type ErrorResponse struct {
+	StatusCode int
+	Message    string
+}
+
+func newErrorResponse(resp *http.Response) *ErrorResponse {
+	e := &ErrorResponse{StatusCode: resp.StatusCode}
+	body, _ := io.ReadAll(resp.Body)
+	// GitLab returns {"message": ...} or {"error": ...}, and the message may be an object of field errors
+	var payload struct {
+		Message any    `json:"message"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		switch {
+		case payload.Message != nil:
+			e.Message = fmt.Sprint(payload.Message)
+		case payload.Error != "":
+			e.Message = payload.Error
+		}
+	}
+	if e.Message == "" {
+		e.Message = string(body)
+	}
+	return e
+}
+
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("gitlab: %d %s", e.StatusCode, e.Message)
+}
+
+func isNotFound(err error) bool {
+	var errorResponse *ErrorResponse
+	return errors.As(err, &errorResponse) && errorResponse.StatusCode == http.StatusNotFound
+}
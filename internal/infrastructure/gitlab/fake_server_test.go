@@ -0,0 +1,30 @@
+package gitlab
+
+import (
+	"testing"
+
+	"docgent/internal/testutil/fakeapi"
+)
+
+// fakeServer is an httptest fake of the GitLab REST API.
+// Responses are keyed by "METHOD escaped-path" because project IDs and file paths are URL-escaped.
+// Paginated responses are keyed with "?page=N" after the first page.
+type fakeServer struct {
+	*fakeapi.Server
+}
+
+type fakeResponse = fakeapi.Response
+
+func newFakeServer(t *testing.T, responses map[string]fakeResponse) *fakeServer {
+	return &fakeServer{fakeapi.NewServer(t, fakeapi.Config{
+		AuthHeader:   "PRIVATE-TOKEN",
+		AuthValue:    "token",
+		NotFoundBody: `{"message":"404 Not Found"}`,
+		KeyQuery:     fakeapi.PageQuery,
+	}, responses)}
+}
+
+// newAPI returns an API connected to the fake server
+func (f *fakeServer) newAPI() *API {
+	return NewAPI(f.URL, "token")
+}
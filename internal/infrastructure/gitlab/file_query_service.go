@@ -0,0 +1,147 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// FileQueryService reads files of a branch of a GitLab project
+type FileQueryService struct {
+	api     *API
+	project string
+	branch  string
+
+	commitSHALock sync.RWMutex
+	commitSHA     string
+}
+
+var _ port.FileQueryService = (*FileQueryService)(nil)
+
+func NewFileQueryService(api *API, project, branch string) *FileQueryService {
+	return &FileQueryService{
+		api:     api,
+		project: project,
+		branch:  branch,
+	}
+}
+
+func (s *FileQueryService) FindFile(ctx context.Context, path string) (data.File, error) {
+	content, err := getFile(ctx, s.api, s.project, path, s.branch)
+	if isNotFound(err) {
+		return data.File{}, port.ErrFileNotFound
+	}
+	if err != nil {
+		return data.File{}, fmt.Errorf("failed to get file contents: %w", err)
+	}
+	return data.File{
+		Path:    path,
+		Content: content,
+	}, nil
+}
+
+// GetTree lists the tree of the branch. WithGetTreeTreeSHA is interpreted as the path of a directory,
+// since the GitLab API lists trees by path
+func (s *FileQueryService) GetTree(ctx context.Context, options ...port.GetTreeOption) ([]port.TreeMetadata, error) {
+	treeOptions := &port.GetTreeOptions{}
+	for _, option := range options {
+		option(treeOptions)
+	}
+
+	query := url.Values{
+		"ref":       {s.branch},
+		"recursive": {strconv.FormatBool(treeOptions.Recursive)},
+		"per_page":  {"100"},
+	}
+	if treeOptions.TreeSHA != "" {
+		query.Set("path", treeOptions.TreeSHA)
+	}
+
+	var treeMetadata []port.TreeMetadata
+	for page := 1; page != 0; {
+		query.Set("page", strconv.Itoa(page))
+		var entries []struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+			Path string `json:"path"`
+		}
+		resp, err := s.api.do(ctx, http.MethodGet, projectPath(s.project)+"/repository/tree", query, nil, &entries)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tree: %w", err)
+		}
+		for _, entry := range entries {
+			treeType := port.NodeTypeFile
+			if entry.Type == "tree" {
+				treeType = port.NodeTypeDirectory
+			}
+			treeMetadata = append(treeMetadata, port.TreeMetadata{
+				Type: treeType,
+				SHA:  entry.ID,
+				Path: entry.Path,
+			})
+		}
+		page = nextPage(resp)
+	}
+	return treeMetadata, nil
+}
+
+// GetURI returns a permalink of the file at the latest commit of the branch
+func (s *FileQueryService) GetURI(ctx context.Context, path string) (*data.URI, error) {
+	commitSHA, err := s.getCommitSHA(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Format: {webURL}/{project}/-/blob/{commitSHA}/{path}
+	uri, err := data.NewURI(fmt.Sprintf("%s/%s/-/blob/%s/%s", s.api.WebURL(), s.project, commitSHA, path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create URI: %w", err)
+	}
+	return uri, nil
+}
+
+func (s *FileQueryService) getCommitSHA(ctx context.Context) (string, error) {
+	s.commitSHALock.RLock()
+	cachedSHA := s.commitSHA
+	s.commitSHALock.RUnlock()
+	if cachedSHA != "" {
+		return cachedSHA, nil
+	}
+
+	var branch struct {
+		Commit struct {
+			ID string `json:"id"`
+		} `json:"commit"`
+	}
+	if _, err := s.api.do(ctx, http.MethodGet, projectPath(s.project)+"/repository/branches/"+url.PathEscape(s.branch), nil, nil, &branch); err != nil {
+		return "", fmt.Errorf("failed to get branch: %w", err)
+	}
+
+	s.commitSHALock.Lock()
+	s.commitSHA = branch.Commit.ID
+	s.commitSHALock.Unlock()
+	return branch.Commit.ID, nil
+}
+
+func (s *FileQueryService) GetFilePath(uri *data.URI) (string, error) {
+	prefix := fmt.Sprintf("%s/%s/-/blob/", s.api.WebURL(), s.project)
+	rest, ok := strings.CutPrefix(uri.Value(), prefix)
+	if !ok {
+		return "", fmt.Errorf("invalid GitLab URI: %s", uri)
+	}
+	_, path, ok := strings.Cut(rest, "/")
+	if !ok || path == "" {
+		return "", errors.New("GitLab URI has no file path: " + uri.Value())
+	}
+	path, _, _ = strings.Cut(path, "?")
+	path, _, _ = strings.Cut(path, "#")
+	return path, nil
+}
@@ -0,0 +1,149 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+func encodedFile(content string) map[string]string {
+	return map[string]string{
+		"encoding": "base64",
+		"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+	}
+}
+
+func TestFileQueryService_FindFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]fakeResponse
+		want      data.File
+		wantErr   error
+	}{
+		{
+			name: "ファイルを取得できる",
+			responses: map[string]fakeResponse{
+				"GET /api/v4/projects/group%2Fdocs/repository/files/docs%2Fguide.md": {Body: encodedFile("# Guide")},
+			},
+			want: data.File{Path: "docs/guide.md", Content: "# Guide"},
+		},
+		{
+			name:      "ファイルが存在しない場合はErrFileNotFound",
+			responses: map[string]fakeResponse{},
+			wantErr:   port.ErrFileNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t, tt.responses)
+			service := NewFileQueryService(server.newAPI(), "group/docs", "main")
+
+			got, err := service.FindFile(context.Background(), "docs/guide.md")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, "main", server.FindRequest(t, http.MethodGet, "/api/v4/projects/group%2Fdocs/repository/files/docs%2Fguide.md").Query["ref"])
+		})
+	}
+}
+
+func TestFileQueryService_GetTree(t *testing.T) {
+	server := newFakeServer(t, map[string]fakeResponse{
+		"GET /api/v4/projects/group%2Fdocs/repository/tree": {
+			Body: []map[string]string{
+				{"id": "sha1", "type": "tree", "path": "docs"},
+			},
+			NextPage: 2,
+		},
+		"GET /api/v4/projects/group%2Fdocs/repository/tree?page=2": {
+			Body: []map[string]string{
+				{"id": "sha2", "type": "blob", "path": "docs/guide.md"},
+			},
+		},
+	})
+	service := NewFileQueryService(server.newAPI(), "group/docs", "main")
+
+	got, err := service.GetTree(context.Background(), port.WithGetTreeRecursive(), port.WithGetTreeTreeSHA("docs"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []port.TreeMetadata{
+		{Type: port.NodeTypeDirectory, SHA: "sha1", Path: "docs"},
+		{Type: port.NodeTypeFile, SHA: "sha2", Path: "docs/guide.md"},
+	}, got)
+	assert.Len(t, server.Requests(), 2)
+	assert.Equal(t, "true", server.Requests()[0].Query["recursive"])
+	assert.Equal(t, "docs", server.Requests()[0].Query["path"])
+	assert.Equal(t, "main", server.Requests()[0].Query["ref"])
+}
+
+func TestFileQueryService_GetURI(t *testing.T) {
+	server := newFakeServer(t, map[string]fakeResponse{
+		"GET /api/v4/projects/group%2Fdocs/repository/branches/feature%2Fdocs": {
+			Body: map[string]any{"commit": map[string]string{"id": "abc123"}},
+		},
+	})
+	service := NewFileQueryService(server.newAPI(), "group/docs", "feature/docs")
+
+	for range 2 {
+		got, err := service.GetURI(context.Background(), "docs/guide.md")
+		assert.NoError(t, err)
+		assert.Equal(t, server.URL+"/group/docs/-/blob/abc123/docs/guide.md", got.Value())
+	}
+	// コミットSHAはキャッシュされる
+	assert.Len(t, server.Requests(), 1)
+}
+
+func TestFileQueryService_GetFilePath(t *testing.T) {
+	service := NewFileQueryService(NewAPI("https://gitlab.example.com", ""), "group/docs", "main")
+
+	tests := []struct {
+		name    string
+		uri     *data.URI
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "パーマリンクからパスを取得できる",
+			uri:  data.NewURIUnsafe("https://gitlab.example.com/group/docs/-/blob/abc123/docs/guide.md"),
+			want: "docs/guide.md",
+		},
+		{
+			name: "クエリとフラグメントは無視する",
+			uri:  data.NewURIUnsafe("https://gitlab.example.com/group/docs/-/blob/abc123/docs/guide.md?plain=1#L3"),
+			want: "docs/guide.md",
+		},
+		{
+			name:    "別のプロジェクトのURIはエラー",
+			uri:     data.NewURIUnsafe("https://gitlab.example.com/group/other/-/blob/abc123/docs/guide.md"),
+			wantErr: true,
+		},
+		{
+			name:    "ファイルパスがないURIはエラー",
+			uri:     data.NewURIUnsafe("https://gitlab.example.com/group/docs/-/blob/abc123"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.GetFilePath(tt.uri)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
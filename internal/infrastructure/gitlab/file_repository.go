@@ -0,0 +1,127 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"docgent/internal/domain/data"
+	"docgent/internal/infrastructure/yaml"
+)
+
+// FileRepository はGitLab上でのファイル操作を実装
+type FileRepository struct {
+	api     *API
+	project string
+	branch  string
+}
+
+var _ data.FileRepository = (*FileRepository)(nil)
+
+func NewFileRepository(api *API, project, branch string) *FileRepository {
+	return &FileRepository{
+		api:     api,
+		project: project,
+		branch:  branch,
+	}
+}
+
+// Create はファイルを作成します
+func (r *FileRepository) Create(ctx context.Context, file *data.File) error {
+	// ファイルの存在確認
+	_, err := getFile(ctx, r.api, r.project, file.Path, r.branch)
+	if err == nil {
+		return data.ErrFileAlreadyExists
+	}
+	if !isNotFound(err) {
+		return fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+
+	frontmatter, err := yaml.GenerateFrontmatter(file.SourceURIs)
+	if err != nil {
+		return fmt.Errorf("failed to generate frontmatter: %w", err)
+	}
+	content := yaml.CombineContentAndFrontmatter(frontmatter, file.Content)
+
+	body := map[string]string{
+		"branch":         r.branch,
+		"content":        content,
+		"commit_message": fmt.Sprintf("Create file %s", file.Path),
+	}
+	if _, err := r.api.do(ctx, http.MethodPost, filePath(r.project, file.Path), nil, body, nil); err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	return nil
+}
+
+func (r *FileRepository) Update(ctx context.Context, file *data.File) error {
+	currentContent, err := getFile(ctx, r.api, r.project, file.Path, r.branch)
+	if isNotFound(err) {
+		return fmt.Errorf("%w: %s", data.ErrFileNotFound, file.Path)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+
+	// 人間が設定した管理者は引き継ぐ
+	currentFrontmatter, _ := yaml.SplitContentAndFrontmatter(currentContent)
+	owners, _ := yaml.ParseOwners(currentFrontmatter)
+
+	frontmatter, err := yaml.GenerateFrontmatter(file.SourceURIs, owners...)
+	if err != nil {
+		return fmt.Errorf("%w: %s", data.ErrInvalidKnowledgeSource, err.Error())
+	}
+	content := yaml.CombineContentAndFrontmatter(frontmatter, file.Content)
+
+	body := map[string]string{
+		"branch":         r.branch,
+		"content":        content,
+		"commit_message": fmt.Sprintf("Update file %s", file.Path),
+	}
+	if _, err := r.api.do(ctx, http.MethodPut, filePath(r.project, file.Path), nil, body, nil); err != nil {
+		return fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+	return nil
+}
+
+func (r *FileRepository) Get(ctx context.Context, path string) (*data.File, error) {
+	content, err := getFile(ctx, r.api, r.project, path, r.branch)
+	if isNotFound(err) {
+		return nil, fmt.Errorf("%w: %s", data.ErrFileNotFound, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+
+	// フロントマターとコンテンツを分離
+	frontmatter, body := yaml.SplitContentAndFrontmatter(content)
+
+	var sourceURIs []*data.URI
+	if frontmatter != "" {
+		sourceURIs, err = yaml.ParseFrontmatter(frontmatter)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", data.ErrInvalidFrontmatter, err.Error())
+		}
+	}
+
+	return &data.File{
+		Path:       path,
+		Content:    body,
+		SourceURIs: sourceURIs,
+	}, nil
+}
+
+func (r *FileRepository) Delete(ctx context.Context, path string) error {
+	body := map[string]string{
+		"branch":         r.branch,
+		"commit_message": fmt.Sprintf("Delete file %s", path),
+	}
+	_, err := r.api.do(ctx, http.MethodDelete, filePath(r.project, path), nil, body, nil)
+	if isNotFound(err) {
+		return fmt.Errorf("%w: %s", data.ErrFileNotFound, path)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+	return nil
+}
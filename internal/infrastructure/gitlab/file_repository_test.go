@@ -0,0 +1,158 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/domain/data"
+)
+
+const guideFilePath = "/api/v4/projects/group%2Fdocs/repository/files/docs%2Fguide.md"
+
+func TestFileRepository_Create(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]fakeResponse
+		wantErr   error
+	}{
+		{
+			name: "ファイルをフロントマター付きで作成できる",
+			responses: map[string]fakeResponse{
+				"POST " + guideFilePath: {StatusCode: http.StatusCreated, Body: map[string]string{"file_path": "docs/guide.md"}},
+			},
+		},
+		{
+			name: "既にファイルが存在する場合はErrFileAlreadyExists",
+			responses: map[string]fakeResponse{
+				"GET " + guideFilePath: {Body: encodedFile("# Guide")},
+			},
+			wantErr: data.ErrFileAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t, tt.responses)
+			repository := NewFileRepository(server.newAPI(), "group/docs", "docgent/branch")
+
+			err := repository.Create(context.Background(), &data.File{
+				Path:       "docs/guide.md",
+				Content:    "# Guide\n",
+				SourceURIs: []*data.URI{data.NewURIUnsafe("https://example.slack.com/archives/C1/p1")},
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			body := server.FindRequest(t, http.MethodPost, guideFilePath).Body.(map[string]any)
+			assert.Equal(t, "docgent/branch", body["branch"])
+			assert.Contains(t, body["content"], "https://example.slack.com/archives/C1/p1")
+			assert.Contains(t, body["content"], "# Guide\n")
+		})
+	}
+}
+
+func TestFileRepository_Get(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]fakeResponse
+		want      *data.File
+		wantErr   error
+	}{
+		{
+			name: "フロントマターから知識源を取得できる",
+			responses: map[string]fakeResponse{
+				"GET " + guideFilePath: {Body: encodedFile("---\nsources:\n  - https://example.com/source\n---\n# Guide\n")},
+			},
+			want: &data.File{
+				Path:       "docs/guide.md",
+				Content:    "# Guide\n",
+				SourceURIs: []*data.URI{data.NewURIUnsafe("https://example.com/source")},
+			},
+		},
+		{
+			name:      "ファイルが存在しない場合はErrFileNotFound",
+			responses: map[string]fakeResponse{},
+			wantErr:   data.ErrFileNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t, tt.responses)
+			repository := NewFileRepository(server.newAPI(), "group/docs", "main")
+
+			got, err := repository.Get(context.Background(), "docs/guide.md")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFileRepository_Update(t *testing.T) {
+	server := newFakeServer(t, map[string]fakeResponse{
+		"GET " + guideFilePath: {Body: encodedFile("---\nsources:\n  - https://example.com/old\nowners:\n  - alice\n---\n# Old\n")},
+		"PUT " + guideFilePath: {Body: map[string]string{"file_path": "docs/guide.md"}},
+	})
+	repository := NewFileRepository(server.newAPI(), "group/docs", "main")
+
+	err := repository.Update(context.Background(), &data.File{
+		Path:       "docs/guide.md",
+		Content:    "# New\n",
+		SourceURIs: []*data.URI{data.NewURIUnsafe("https://example.com/new")},
+	})
+
+	assert.NoError(t, err)
+	content := server.FindRequest(t, http.MethodPut, guideFilePath).Body.(map[string]any)["content"]
+	assert.Contains(t, content, "https://example.com/new")
+	assert.NotContains(t, content, "https://example.com/old")
+	// 人間が設定した管理者は引き継がれる
+	assert.Contains(t, content, "alice")
+	assert.Contains(t, content, "# New\n")
+}
+
+func TestFileRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]fakeResponse
+		wantErr   error
+	}{
+		{
+			name: "ファイルを削除できる",
+			responses: map[string]fakeResponse{
+				"DELETE " + guideFilePath: {StatusCode: http.StatusNoContent},
+			},
+		},
+		{
+			name:      "ファイルが存在しない場合はErrFileNotFound",
+			responses: map[string]fakeResponse{},
+			wantErr:   data.ErrFileNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t, tt.responses)
+			repository := NewFileRepository(server.newAPI(), "group/docs", "main")
+
+			err := repository.Delete(context.Background(), "docs/guide.md")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "main", server.FindRequest(t, http.MethodDelete, guideFilePath).Body.(map[string]any)["branch"])
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// repositoryFile is a file of the repository files API
+type repositoryFile struct {
+	FilePath     string `json:"file_path"`
+	Encoding     string `json:"encoding"`
+	Content      string `json:"content"`
+	LastCommitID string `json:"last_commit_id"`
+}
+
+func (f *repositoryFile) decodedContent() (string, error) {
+	if f.Encoding != "base64" {
+		return f.Content, nil
+	}
+	content, err := base64.StdEncoding.DecodeString(f.Content)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64 content: %w", err)
+	}
+	return string(content), nil
+}
+
+func filePath(project, path string) string {
+	return projectPath(project) + "/repository/files/" + url.PathEscape(path)
+}
+
+// getFile returns an error that satisfies isNotFound if the file does not exist at the ref
+func getFile(ctx context.Context, api *API, project, path, ref string) (string, error) {
+	var file repositoryFile
+	if _, err := api.do(ctx, http.MethodGet, filePath(project, path), url.Values{"ref": {ref}}, nil, &file); err != nil {
+		return "", err
+	}
+	return file.decodedContent()
+}
+
+// commitAction is an action of the commits API
+type commitAction struct {
+	Action       string `json:"action"`
+	FilePath     string `json:"file_path"`
+	PreviousPath string `json:"previous_path,omitempty"`
+	Content      string `json:"content,omitempty"`
+}
+
+// commit changes files on the branch in a single commit
+func commit(ctx context.Context, api *API, project, branch, message string, actions ...commitAction) error {
+	body := map[string]any{
+		"branch":         branch,
+		"commit_message": message,
+		"actions":        actions,
+	}
+	if _, err := api.do(ctx, http.MethodPost, projectPath(project)+"/repository/commits", nil, body, nil); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"docgent/internal/domain"
+)
+
+const (
+	proposalHandleSource = "gitlab-merge-request"
+	commentHandleSource  = "gitlab-merge-request-note"
+)
+
+// MergeRequestAPI implements domain.ProposalRepository with merge requests
+type MergeRequestAPI struct {
+	api           *API
+	project       string
+	defaultBranch string
+	headBranch    string
+}
+
+var _ domain.ProposalRepository = (*MergeRequestAPI)(nil)
+
+func NewMergeRequestAPI(api *API, project, defaultBranch, headBranch string) *MergeRequestAPI {
+	return &MergeRequestAPI{
+		api:           api,
+		project:       project,
+		defaultBranch: defaultBranch,
+		headBranch:    headBranch,
+	}
+}
+
+type mergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	State        string `json:"state"`
+	WebURL       string `json:"web_url"`
+}
+
+type note struct {
+	ID     int64  `json:"id"`
+	Body   string `json:"body"`
+	System bool   `json:"system"`
+	Author struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+func (s *MergeRequestAPI) NewProposalHandle(value string) domain.ProposalHandle {
+	return domain.NewProposalHandle(proposalHandleSource, value)
+}
+
+func (s *MergeRequestAPI) NewCommentHandle(noteID string) domain.CommentHandle {
+	return domain.NewCommentHandle(commentHandleSource, noteID)
+}
+
+func (s *MergeRequestAPI) CreateProposal(diffs domain.Diffs, content domain.ProposalContent) (domain.ProposalHandle, error) {
+	ctx := context.Background()
+
+	for _, diff := range diffs {
+		if err := applyDiff(ctx, s.api, s.project, s.headBranch, diff); err != nil {
+			return domain.ProposalHandle{}, fmt.Errorf("failed to resolve diff: %w", err)
+		}
+	}
+
+	body := map[string]any{
+		"source_branch":        s.headBranch,
+		"target_branch":        s.defaultBranch,
+		"title":                content.Title,
+		"description":          content.Body,
+		"remove_source_branch": true,
+	}
+	var mr mergeRequest
+	if _, err := s.api.do(ctx, http.MethodPost, projectPath(s.project)+"/merge_requests", nil, body, &mr); err != nil {
+		return domain.ProposalHandle{}, fmt.Errorf("failed to create merge request: %w", err)
+	}
+
+	return s.NewProposalHandle(strconv.Itoa(mr.IID)), nil
+}
+
+func (s *MergeRequestAPI) GetProposal(handle domain.ProposalHandle) (domain.Proposal, error) {
+	ctx := context.Background()
+
+	mr, err := s.getMergeRequest(ctx, handle)
+	if err != nil {
+		return domain.Proposal{}, err
+	}
+
+	diffs, err := s.listDiffs(ctx, mr.IID)
+	if err != nil {
+		return domain.Proposal{}, err
+	}
+
+	notes, err := listNotes(ctx, s.api, s.project, mr.IID)
+	if err != nil {
+		return domain.Proposal{}, err
+	}
+	var comments []domain.Comment
+	for _, note := range notes {
+		handle := s.NewCommentHandle(strconv.FormatInt(note.ID, 10))
+		comments = append(comments, domain.NewComment(handle, note.Author.Username, note.Body))
+	}
+
+	content := domain.ProposalContent{
+		Title: mr.Title,
+		Body:  mr.Description,
+	}
+	return domain.NewProposal(handle, diffs, content, comments), nil
+}
+
+func (s *MergeRequestAPI) CreateComment(proposalHandle domain.ProposalHandle, commentBody string) (domain.Comment, error) {
+	ctx := context.Background()
+
+	iid, err := s.parseHandle(proposalHandle)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	created, err := createNote(ctx, s.api, s.project, iid, commentBody)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	handle := s.NewCommentHandle(strconv.FormatInt(created.ID, 10))
+	return domain.NewComment(handle, created.Author.Username, commentBody), nil
+}
+
+func (s *MergeRequestAPI) ApplyProposalDiffs(handle domain.ProposalHandle, diffs domain.Diffs) error {
+	ctx := context.Background()
+
+	mr, err := s.getMergeRequest(ctx, handle)
+	if err != nil {
+		return err
+	}
+
+	for _, diff := range diffs {
+		if err := applyDiff(ctx, s.api, s.project, mr.SourceBranch, diff); err != nil {
+			return fmt.Errorf("failed to resolve diff: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *MergeRequestAPI) UpdateProposalContent(proposalHandle domain.ProposalHandle, content domain.ProposalContent) error {
+	ctx := context.Background()
+
+	iid, err := s.parseHandle(proposalHandle)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]string{
+		"title":       content.Title,
+		"description": content.Body,
+	}
+	if _, err := s.api.do(ctx, http.MethodPut, mergeRequestPath(s.project, iid), nil, body, nil); err != nil {
+		return fmt.Errorf("failed to update merge request: %w", err)
+	}
+	return nil
+}
+
+func (s *MergeRequestAPI) getMergeRequest(ctx context.Context, handle domain.ProposalHandle) (mergeRequest, error) {
+	iid, err := s.parseHandle(handle)
+	if err != nil {
+		return mergeRequest{}, err
+	}
+
+	var mr mergeRequest
+	if _, err := s.api.do(ctx, http.MethodGet, mergeRequestPath(s.project, iid), nil, nil, &mr); err != nil {
+		return mergeRequest{}, fmt.Errorf("failed to get merge request: %w", err)
+	}
+	return mr, nil
+}
+
+func (s *MergeRequestAPI) listDiffs(ctx context.Context, iid int) (domain.Diffs, error) {
+	var diffs domain.Diffs
+	query := url.Values{"per_page": {"100"}}
+	for page := 1; page != 0; {
+		query.Set("page", strconv.Itoa(page))
+		var changes []struct {
+			OldPath     string `json:"old_path"`
+			NewPath     string `json:"new_path"`
+			Diff        string `json:"diff"`
+			NewFile     bool   `json:"new_file"`
+			DeletedFile bool   `json:"deleted_file"`
+		}
+		resp, err := s.api.do(ctx, http.MethodGet, mergeRequestPath(s.project, iid)+"/diffs", query, nil, &changes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get merge request diffs: %w", err)
+		}
+		for _, change := range changes {
+			if change.NewFile {
+				diffs = append(diffs, domain.NewCreateDiff(change.NewPath, change.Diff))
+				continue
+			}
+			if change.DeletedFile {
+				diffs = append(diffs, domain.NewDeleteDiff(change.OldPath, change.Diff))
+				continue
+			}
+			diffs = append(diffs, domain.NewUpdateDiff(change.OldPath, change.NewPath, change.Diff))
+		}
+		page = nextPage(resp)
+	}
+	return diffs, nil
+}
+
+func (s *MergeRequestAPI) parseHandle(handle domain.ProposalHandle) (int, error) {
+	iid, err := strconv.Atoi(handle.Value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse proposal handle to merge request IID: %w", err)
+	}
+	return iid, nil
+}
+
+func mergeRequestPath(project string, iid int) string {
+	return fmt.Sprintf("%s/merge_requests/%d", projectPath(project), iid)
+}
+
+// listNotes returns the notes of the merge request written by users in chronological order
+func listNotes(ctx context.Context, api *API, project string, iid int) ([]note, error) {
+	var notes []note
+	query := url.Values{
+		"sort":     {"asc"},
+		"order_by": {"created_at"},
+		"per_page": {"100"},
+	}
+	for page := 1; page != 0; {
+		query.Set("page", strconv.Itoa(page))
+		var pageNotes []note
+		resp, err := api.do(ctx, http.MethodGet, mergeRequestPath(project, iid)+"/notes", query, nil, &pageNotes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list merge request notes: %w", err)
+		}
+		for _, n := range pageNotes {
+			// ラベルの変更やコミットの追加などのシステムノートは会話ではない
+			if !n.System {
+				notes = append(notes, n)
+			}
+		}
+		page = nextPage(resp)
+	}
+	return notes, nil
+}
+
+func createNote(ctx context.Context, api *API, project string, iid int, body string) (note, error) {
+	var created note
+	if _, err := api.do(ctx, http.MethodPost, mergeRequestPath(project, iid)+"/notes", nil, map[string]string{"body": body}, &created); err != nil {
+		return note{}, fmt.Errorf("failed to add note: %w", err)
+	}
+	return created, nil
+}
@@ -0,0 +1,131 @@
+package gitlab
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/domain"
+)
+
+const mergeRequestsPath = "/api/v4/projects/group%2Fdocs/merge_requests"
+
+func TestMergeRequestAPI_CreateProposal(t *testing.T) {
+	server := newFakeServer(t, map[string]fakeResponse{
+		"POST /api/v4/projects/group%2Fdocs/repository/commits": {StatusCode: http.StatusCreated, Body: map[string]string{"id": "abc123"}},
+		"POST " + mergeRequestsPath:                             {StatusCode: http.StatusCreated, Body: map[string]any{"iid": 7}},
+	})
+	api := NewMergeRequestAPI(server.newAPI(), "group/docs", "main", "docgent/branch")
+
+	handle, err := api.CreateProposal(
+		domain.Diffs{domain.NewCreateDiff("docs/guide.md", "@@ -0,0 +1,7 @@\n+# Guide\n")},
+		domain.ProposalContent{Title: "Add guide", Body: "Adds a guide"},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewProposalHandle("gitlab-merge-request", "7"), handle)
+
+	commitBody := server.FindRequest(t, http.MethodPost, "/api/v4/projects/group%2Fdocs/repository/commits").Body.(map[string]any)
+	assert.Equal(t, "docgent/branch", commitBody["branch"])
+	assert.Equal(t, []any{map[string]any{"action": "create", "file_path": "docs/guide.md", "content": "# Guide"}}, commitBody["actions"])
+
+	assert.Equal(t, map[string]any{
+		"source_branch":        "docgent/branch",
+		"target_branch":        "main",
+		"title":                "Add guide",
+		"description":          "Adds a guide",
+		"remove_source_branch": true,
+	}, server.FindRequest(t, http.MethodPost, mergeRequestsPath).Body)
+}
+
+func TestMergeRequestAPI_GetProposal(t *testing.T) {
+	server := newFakeServer(t, map[string]fakeResponse{
+		"GET " + mergeRequestsPath + "/7": {Body: map[string]any{"iid": 7, "title": "Add guide", "description": "Adds a guide", "source_branch": "docgent/branch"}},
+		"GET " + mergeRequestsPath + "/7/diffs": {
+			Body:     []map[string]any{{"old_path": "docs/guide.md", "new_path": "docs/guide.md", "diff": "@@ -1 +1 @@\n-a\n+b\n", "new_file": true}},
+			NextPage: 2,
+		},
+		"GET " + mergeRequestsPath + "/7/diffs?page=2": {
+			Body: []map[string]any{
+				{"old_path": "docs/old.md", "new_path": "docs/new.md", "diff": "@@ -1 +1 @@\n-c\n+d\n"},
+				{"old_path": "docs/gone.md", "new_path": "docs/gone.md", "diff": "@@ -1 +0,0 @@\n-e\n", "deleted_file": true},
+			},
+		},
+		"GET " + mergeRequestsPath + "/7/notes": {
+			Body: []map[string]any{
+				{"id": 1, "body": "Please fix the title", "author": map[string]string{"username": "alice"}},
+				{"id": 2, "body": "added 1 commit", "system": true, "author": map[string]string{"username": "docgent"}},
+			},
+		},
+	})
+	api := NewMergeRequestAPI(server.newAPI(), "group/docs", "main", "")
+	handle := api.NewProposalHandle("7")
+
+	got, err := api.GetProposal(handle)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewProposal(
+		handle,
+		domain.Diffs{
+			domain.NewCreateDiff("docs/guide.md", "@@ -1 +1 @@\n-a\n+b\n"),
+			domain.NewUpdateDiff("docs/old.md", "docs/new.md", "@@ -1 +1 @@\n-c\n+d\n"),
+			domain.NewDeleteDiff("docs/gone.md", "@@ -1 +0,0 @@\n-e\n"),
+		},
+		domain.ProposalContent{Title: "Add guide", Body: "Adds a guide"},
+		[]domain.Comment{
+			// システムノートは含まれない
+			domain.NewComment(api.NewCommentHandle("1"), "alice", "Please fix the title"),
+		},
+	), got)
+}
+
+func TestMergeRequestAPI_CreateComment(t *testing.T) {
+	server := newFakeServer(t, map[string]fakeResponse{
+		"POST " + mergeRequestsPath + "/7/notes": {StatusCode: http.StatusCreated, Body: map[string]any{"id": 42, "body": "Done", "author": map[string]string{"username": "docgent"}}},
+	})
+	api := NewMergeRequestAPI(server.newAPI(), "group/docs", "main", "")
+
+	got, err := api.CreateComment(api.NewProposalHandle("7"), "Done")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewComment(api.NewCommentHandle("42"), "docgent", "Done"), got)
+	assert.Equal(t, map[string]any{"body": "Done"}, server.FindRequest(t, http.MethodPost, mergeRequestsPath+"/7/notes").Body)
+}
+
+func TestMergeRequestAPI_UpdateProposalContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		handle  string
+		wantErr bool
+	}{
+		{
+			name:   "タイトルと説明を更新できる",
+			handle: "7",
+		},
+		{
+			name:    "不正なハンドルはエラー",
+			handle:  "invalid",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t, map[string]fakeResponse{
+				"PUT " + mergeRequestsPath + "/7": {Body: map[string]any{"iid": 7}},
+			})
+			api := NewMergeRequestAPI(server.newAPI(), "group/docs", "main", "")
+
+			err := api.UpdateProposalContent(api.NewProposalHandle(tt.handle), domain.ProposalContent{Title: "New title", Body: "New body"})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, server.Requests())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]any{"title": "New title", "description": "New body"}, server.FindRequest(t, http.MethodPut, mergeRequestsPath+"/7").Body)
+		})
+	}
+}
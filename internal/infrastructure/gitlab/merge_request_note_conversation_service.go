@@ -0,0 +1,99 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// MergeRequestNoteConversationService treats the notes of a merge request as a conversation
+type MergeRequestNoteConversationService struct {
+	api          *API
+	ref          *MergeRequestNoteRef
+	eyesAwardID  int64
+	fromUsername string // ソースノートの作者のユーザー名
+}
+
+func NewMergeRequestNoteConversationService(api *API, ref *MergeRequestNoteRef, fromUsername string) port.ConversationService {
+	return &MergeRequestNoteConversationService{
+		api:          api,
+		ref:          ref,
+		fromUsername: fromUsername,
+	}
+}
+
+func (s *MergeRequestNoteConversationService) GetHistory() (port.ConversationHistory, error) {
+	ctx := context.Background()
+
+	notes, err := listNotes(ctx, s.api, s.ref.Project(), s.ref.IID())
+	if err != nil {
+		return port.ConversationHistory{}, err
+	}
+
+	username, err := s.api.Username(ctx)
+	if err != nil {
+		return port.ConversationHistory{}, err
+	}
+
+	messages := make([]port.ConversationMessage, 0, len(notes))
+	for _, note := range notes {
+		messages = append(messages, port.ConversationMessage{
+			Author:       note.Author.Username,
+			Content:      note.Body,
+			YouMentioned: strings.Contains(note.Body, "@"+username),
+			IsYou:        note.Author.Username == username,
+		})
+	}
+
+	return port.ConversationHistory{
+		URI:      s.ref.ToURI(),
+		Messages: messages,
+	}, nil
+}
+
+func (s *MergeRequestNoteConversationService) URI() *data.URI {
+	return s.ref.ToURI()
+}
+
+func (s *MergeRequestNoteConversationService) Reply(input string, withMention bool) error {
+	message := input
+	if withMention && s.fromUsername != "" {
+		message = fmt.Sprintf("@%s\n%s", s.fromUsername, input)
+	}
+
+	if _, err := createNote(context.Background(), s.api, s.ref.Project(), s.ref.IID(), message); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *MergeRequestNoteConversationService) MarkEyes() error {
+	var award struct {
+		ID int64 `json:"id"`
+	}
+	if _, err := s.api.do(context.Background(), http.MethodPost, s.awardEmojiPath(), nil, map[string]string{"name": "eyes"}, &award); err != nil {
+		return fmt.Errorf("failed to add eyes emoji to note: %w", err)
+	}
+	s.eyesAwardID = award.ID
+	return nil
+}
+
+func (s *MergeRequestNoteConversationService) RemoveEyes() error {
+	if s.eyesAwardID == 0 {
+		return nil
+	}
+
+	path := fmt.Sprintf("%s/%d", s.awardEmojiPath(), s.eyesAwardID)
+	if _, err := s.api.do(context.Background(), http.MethodDelete, path, nil, nil, nil); err != nil {
+		return fmt.Errorf("failed to remove eyes emoji from note: %w", err)
+	}
+	return nil
+}
+
+func (s *MergeRequestNoteConversationService) awardEmojiPath() string {
+	return fmt.Sprintf("%s/notes/%d/award_emoji", mergeRequestPath(s.ref.Project(), s.ref.IID()), s.ref.NoteID())
+}
@@ -0,0 +1,35 @@
+package gitlab
+
+import (
+	"fmt"
+
+	"docgent/internal/domain/data"
+)
+
+// MergeRequestNoteRef points to a note on a merge request
+type MergeRequestNoteRef struct {
+	webURL  string
+	project string
+	iid     int
+	noteID  int64
+}
+
+func NewMergeRequestNoteRef(webURL, project string, iid int, noteID int64) *MergeRequestNoteRef {
+	return &MergeRequestNoteRef{webURL: webURL, project: project, iid: iid, noteID: noteID}
+}
+
+func (r *MergeRequestNoteRef) ToURI() *data.URI {
+	return data.NewURIUnsafe(fmt.Sprintf("%s/%s/-/merge_requests/%d#note_%d", r.webURL, r.project, r.iid, r.noteID))
+}
+
+func (r *MergeRequestNoteRef) Project() string {
+	return r.project
+}
+
+func (r *MergeRequestNoteRef) IID() int {
+	return r.iid
+}
+
+func (r *MergeRequestNoteRef) NoteID() int64 {
+	return r.noteID
+}
@@ -0,0 +1,19 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// isProjectPublic reports whether users other than the members can read the project.
+// Internal projects are readable by every signed-in user, so they are treated as public.
+func isProjectPublic(ctx context.Context, api *API, project string) (bool, error) {
+	var p struct {
+		Visibility string `json:"visibility"`
+	}
+	if _, err := api.do(ctx, http.MethodGet, projectPath(project), nil, nil, &p); err != nil {
+		return false, fmt.Errorf("failed to get project: %w", err)
+	}
+	return p.Visibility != "private", nil
+}
@@ -0,0 +1,41 @@
+package gitlab
+
+import (
+	"fmt"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/tooluse"
+)
+
+// ResponseFormatter implements the port.ResponseFormatter interface for GitLab
+type ResponseFormatter struct{}
+
+func NewResponseFormatter() port.ResponseFormatter {
+	return &ResponseFormatter{}
+}
+
+func (f *ResponseFormatter) FormatResponse(toolUse tooluse.AttemptComplete) (string, error) {
+	var builder strings.Builder
+
+	// GitLab Flavored Markdown supports footnotes
+	for _, m := range toolUse.Messages {
+		builder.WriteString(m.Text)
+		if m.SourceID != "" {
+			for _, sourceID := range m.GetSourceIDs() {
+				builder.WriteString(fmt.Sprintf("[^%s]", sourceID))
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	if len(toolUse.Sources) > 0 {
+		builder.WriteString("\n")
+		for _, s := range toolUse.Sources {
+			// Format: [^ID]: [Name](URI)
+			builder.WriteString(fmt.Sprintf("[^%s]: [%s](%s)\n", s.ID, s.Name, s.URI))
+		}
+	}
+
+	return strings.TrimSpace(builder.String()), nil
+}
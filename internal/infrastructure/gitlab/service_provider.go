@@ -0,0 +1,62 @@
+package gitlab
+
+import (
+	"context"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+)
+
+// ServiceProvider creates GitLab services that share the API
+type ServiceProvider struct {
+	api *API
+}
+
+func NewServiceProvider(api *API) *ServiceProvider {
+	return &ServiceProvider{api: api}
+}
+
+// WebURL returns the URL of the GitLab instance
+func (p *ServiceProvider) WebURL() string {
+	return p.api.WebURL()
+}
+
+// Username returns the user of the token, which is mentioned to call Docgent
+func (p *ServiceProvider) Username(ctx context.Context) (string, error) {
+	return p.api.Username(ctx)
+}
+
+// IsProjectPublic reports whether users other than the members can read the project
+func (p *ServiceProvider) IsProjectPublic(ctx context.Context, project string) (bool, error) {
+	return isProjectPublic(ctx, p.api, project)
+}
+
+// NewFileQueryService creates a file query service of the branch
+func (p *ServiceProvider) NewFileQueryService(project, branch string) *FileQueryService {
+	return NewFileQueryService(p.api, project, branch)
+}
+
+// NewFileRepository creates a file repository of the branch
+func (p *ServiceProvider) NewFileRepository(project, branch string) *FileRepository {
+	return NewFileRepository(p.api, project, branch)
+}
+
+// NewBranchService creates a branch service of the project
+func (p *ServiceProvider) NewBranchService(project string) *BranchService {
+	return NewBranchService(p.api, project)
+}
+
+// NewMergeRequestAPI creates a proposal repository of merge requests
+func (p *ServiceProvider) NewMergeRequestAPI(project, baseBranch, headBranch string) domain.ProposalRepository {
+	return NewMergeRequestAPI(p.api, project, baseBranch, headBranch)
+}
+
+// NewMergeRequestNoteConversationService creates a conversation service of the notes of a merge request
+func (p *ServiceProvider) NewMergeRequestNoteConversationService(ref *MergeRequestNoteRef, fromUsername string) port.ConversationService {
+	return NewMergeRequestNoteConversationService(p.api, ref, fromUsername)
+}
+
+// NewResponseFormatter creates a response formatter for GitLab, whose notes are Markdown like GitHub's
+func (p *ServiceProvider) NewResponseFormatter() port.ResponseFormatter {
+	return NewResponseFormatter()
+}
@@ -0,0 +1,123 @@
+package gitlab
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+/**
+ * Events
+ */
+
+type Project struct {
+	ID                int64  `json:"id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+	DefaultBranch     string `json:"default_branch"`
+}
+
+type User struct {
+	Username string `json:"username"`
+}
+
+// NoteEvent is the payload of "Note Hook"
+type NoteEvent struct {
+	User             User    `json:"user"`
+	Project          Project `json:"project"`
+	ObjectAttributes struct {
+		ID           int64  `json:"id"`
+		Note         string `json:"note"`
+		NoteableType string `json:"noteable_type"`
+		URL          string `json:"url"`
+	} `json:"object_attributes"`
+	MergeRequest *struct {
+		IID          int    `json:"iid"`
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+		State        string `json:"state"`
+	} `json:"merge_request"`
+}
+
+// PushEvent is the payload of "Push Hook"
+type PushEvent struct {
+	Ref     string       `json:"ref"`
+	Project Project      `json:"project"`
+	Commits []PushCommit `json:"commits"`
+}
+
+type PushCommit struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Added     []string  `json:"added"`
+	Modified  []string  `json:"modified"`
+	Removed   []string  `json:"removed"`
+}
+
+/**
+ * WebhookEvent
+ */
+
+type WebhookEvent struct {
+	eventType  string
+	innerEvent interface{}
+}
+
+func NewWebhookEvent(eventType string, innerEvent interface{}) *WebhookEvent {
+	return &WebhookEvent{eventType: eventType, innerEvent: innerEvent}
+}
+
+func (e *WebhookEvent) EventType() string {
+	return e.eventType
+}
+
+func (e *WebhookEvent) InnerEvent() interface{} {
+	return e.innerEvent
+}
+
+/**
+ * WebhookRequestParser
+ */
+
+// WebhookRequestParser verifies the secret token of GitLab webhooks and parses their payloads
+type WebhookRequestParser struct {
+	secretToken string
+}
+
+func NewWebhookRequestParser(secretToken string) *WebhookRequestParser {
+	return &WebhookRequestParser{secretToken: secretToken}
+}
+
+func (p *WebhookRequestParser) ParseRequest(r *http.Request) (*WebhookEvent, error) {
+	if p.secretToken == "" {
+		return nil, errors.New("GitLab webhook secret token is not configured")
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Gitlab-Token")), []byte(p.secretToken)) != 1 {
+		return nil, errors.New("invalid GitLab webhook token")
+	}
+
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payload: %w", err)
+	}
+
+	var event interface{}
+	var eventType string
+	switch r.Header.Get("X-Gitlab-Event") {
+	case "Note Hook":
+		event, eventType = &NoteEvent{}, "note"
+	case "Push Hook":
+		event, eventType = &PushEvent{}, "push"
+	default:
+		return nil, fmt.Errorf("unsupported event type: %s", r.Header.Get("X-Gitlab-Event"))
+	}
+
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook: %w", err)
+	}
+	return NewWebhookEvent(eventType, event), nil
+}
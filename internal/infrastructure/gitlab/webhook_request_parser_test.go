@@ -0,0 +1,87 @@
+package gitlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookRequestParser_ParseRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		secretToken   string
+		token         string
+		event         string
+		payload       string
+		wantEventType string
+		wantErr       bool
+	}{
+		{
+			name:          "ノートイベントを解析できる",
+			secretToken:   "secret",
+			token:         "secret",
+			event:         "Note Hook",
+			payload:       `{"user":{"username":"alice"},"project":{"path_with_namespace":"group/docs"},"object_attributes":{"id":1,"note":"/docgent refine","noteable_type":"MergeRequest"},"merge_request":{"iid":7,"source_branch":"feature"}}`,
+			wantEventType: "note",
+		},
+		{
+			name:          "プッシュイベントを解析できる",
+			secretToken:   "secret",
+			token:         "secret",
+			event:         "Push Hook",
+			payload:       `{"ref":"refs/heads/main","project":{"path_with_namespace":"group/docs"},"commits":[{"id":"abc","timestamp":"2025-01-01T00:00:00Z","added":["a.md"]}]}`,
+			wantEventType: "push",
+		},
+		{
+			name:        "トークンが一致しない場合はエラー",
+			secretToken: "secret",
+			token:       "wrong",
+			event:       "Push Hook",
+			payload:     `{}`,
+			wantErr:     true,
+		},
+		{
+			name:        "シークレットが未設定の場合はエラー",
+			secretToken: "",
+			token:       "",
+			event:       "Push Hook",
+			payload:     `{}`,
+			wantErr:     true,
+		},
+		{
+			name:        "未対応のイベントはエラー",
+			secretToken: "secret",
+			token:       "secret",
+			event:       "Issue Hook",
+			payload:     `{}`,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/gitlab/events", strings.NewReader(tt.payload))
+			req.Header.Set("X-Gitlab-Token", tt.token)
+			req.Header.Set("X-Gitlab-Event", tt.event)
+
+			got, err := NewWebhookRequestParser(tt.secretToken).ParseRequest(req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEventType, got.EventType())
+			switch ev := got.InnerEvent().(type) {
+			case *NoteEvent:
+				assert.Equal(t, "group/docs", ev.Project.PathWithNamespace)
+				assert.Equal(t, 7, ev.MergeRequest.IID)
+			case *PushEvent:
+				assert.Equal(t, []string{"a.md"}, ev.Commits[0].Added)
+			}
+		})
+	}
+}
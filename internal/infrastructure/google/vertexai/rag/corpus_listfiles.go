@@ -27,22 +27,11 @@ func (c *Corpus) ListFiles(ctx context.Context) ([]port.RAGFile, error) {
 		}
 
 		for _, file := range filesResult.Files {
-			parts := strings.Split(file.Name, "/")
-			idStr := parts[len(parts)-1]
-			id, err := strconv.ParseInt(idStr, 10, 64)
+			ragFile, err := newRAGFile(file)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse file ID: %w", err)
+				return nil, err
 			}
-			uri, err := data.NewURI(file.DisplayName)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create URI: %w", err)
-			}
-
-			ragFiles = append(ragFiles, port.RAGFile{
-				ID:          id,
-				URI:         uri,
-				Description: file.Description,
-			})
+			ragFiles = append(ragFiles, ragFile)
 		}
 
 		if filesResult.NextPageToken == "" {
@@ -54,3 +43,24 @@ func (c *Corpus) ListFiles(ctx context.Context) ([]port.RAGFile, error) {
 
 	return ragFiles, nil
 }
+
+// newRAGFile converts the file of the API. The ID is the last segment of its resource name
+// (projects/{project}/locations/{location}/ragCorpora/{corpus}/ragFiles/{id}), and the display name is its URI.
+func newRAGFile(file lib.File) (port.RAGFile, error) {
+	parts := strings.Split(file.Name, "/")
+	idStr := parts[len(parts)-1]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return port.RAGFile{}, fmt.Errorf("failed to parse file ID: %w", err)
+	}
+	uri, err := data.NewURI(file.DisplayName)
+	if err != nil {
+		return port.RAGFile{}, fmt.Errorf("failed to create URI: %w", err)
+	}
+
+	return port.RAGFile{
+		ID:          id,
+		URI:         uri,
+		Description: file.Description,
+	}, nil
+}
@@ -9,13 +9,13 @@ import (
 	"docgent/internal/infrastructure/google/vertexai/rag/lib"
 )
 
-func (c *Corpus) UploadFile(ctx context.Context, file io.Reader, uri *data.URI, options ...port.RAGCorpusUploadFileOption) error {
+func (c *Corpus) UploadFile(ctx context.Context, file io.Reader, uri *data.URI, options ...port.RAGCorpusUploadFileOption) (port.RAGFile, error) {
 	uploadFileOptions := &port.RAGCorpusUploadFileOptions{}
 	for _, option := range options {
 		option(uploadFileOptions)
 	}
 
-	_, err := c.client.UploadFile(ctx, c.corpusId, file, uri.String(), func(o *lib.UploadFileOptions) {
+	uploadedFile, err := c.client.UploadFile(ctx, c.corpusId, file, uri.String(), func(o *lib.UploadFileOptions) {
 		if uploadFileOptions.Description != "" {
 			o.Description = uploadFileOptions.Description
 		}
@@ -26,6 +26,9 @@ func (c *Corpus) UploadFile(ctx context.Context, file io.Reader, uri *data.URI,
 			}
 		}
 	})
+	if err != nil {
+		return port.RAGFile{}, err
+	}
 
-	return err
+	return newRAGFile(uploadedFile)
 }
@@ -1,14 +1,254 @@
 package handler
 
-import "errors"
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+	"docgent/internal/infrastructure/discord"
+	"docgent/internal/infrastructure/mattermost"
+	"docgent/internal/infrastructure/slack"
+)
 
 type Workspace struct {
-	SlackWorkspaceID     string `json:"slack_workspace_id"`
+	SlackWorkspaceID     string             `json:"slack_workspace_id"`
+	GitHubInstallationID int64              `json:"github_installation_id"`
+	GitHubOwner          string             `json:"github_owner"`
+	GitHubRepo           string             `json:"github_repo"`
+	GitHubDefaultBranch  string             `json:"github_default_branch"`
+	VertexAICorpusID     int64              `json:"vertexai_rag_corpus_id"`
+	SourcePolicy         SourcePolicyConfig `json:"source_policy"`
+	// GitHubIssueLabel is the label that starts generating a proposal from an issue
+	GitHubIssueLabel string `json:"github_issue_label"`
+	// Repositories are docs repositories in addition to the default one configured above
+	Repositories []DocsRepository `json:"repositories"`
+	// AgentSelectsRepository lets the model choose the repository of a proposal when no routing rule matches
+	AgentSelectsRepository bool `json:"agent_selects_repository"`
+	// Proposal configures the pull requests of proposals
+	Proposal ProposalConfig `json:"proposal"`
+	// SlackGitHubUsers maps Slack user IDs to GitHub logins to request reviews from the participants of a thread
+	SlackGitHubUsers map[string]string `json:"slack_github_users"`
+	// GitLab is a docs project on GitLab. Notes on its merge requests and pushes to it are handled like GitHub's
+	GitLab GitLabProjectConfig `json:"gitlab"`
+	// Discord is a Discord server whose mentions and reactions are handled like Slack's
+	Discord DiscordGuildConfig `json:"discord"`
+	// Mattermost is a team on the Mattermost server whose mentions and reactions are handled like Slack's
+	Mattermost MattermostTeamConfig `json:"mattermost"`
+	// RAGBackend is RAGBackendVertexAI (default) or RAGBackendLocal. The corpus IDs of the workspace identify corpora of the backend
+	RAGBackend string `json:"rag_backend"`
+}
+
+// DefaultDiscordReaction is used when the reaction of DiscordGuildConfig is not configured
+const DefaultDiscordReaction = "📝"
+
+// DiscordGuildConfig is a Discord server (guild) of the workspace
+type DiscordGuildConfig struct {
+	// GuildID is the ID of the server. Discord is disabled if empty
+	GuildID string `json:"guild_id"`
+	// Reaction starts generating a proposal from the conversation of the message. It is a Unicode emoji or the name of a custom emoji
+	Reaction string `json:"reaction"`
+	// GitHubUsers maps Discord user IDs to GitHub logins to request reviews from the participants of a conversation
+	GitHubUsers map[string]string `json:"github_users"`
+}
+
+// DocReaction returns the reaction that starts generating a proposal
+func (c DiscordGuildConfig) DocReaction() string {
+	if c.Reaction == "" {
+		return DefaultDiscordReaction
+	}
+	return c.Reaction
+}
+
+// DefaultMattermostReaction is used when the reaction of MattermostTeamConfig is not configured
+const DefaultMattermostReaction = "memo"
+
+// MattermostTeamConfig is a team of the Mattermost server of the workspace
+type MattermostTeamConfig struct {
+	// TeamID is the ID of the team. Mattermost is disabled if empty
+	TeamID string `json:"team_id"`
+	// Reaction starts generating a proposal from the thread of the post. It is the name of the emoji without colons
+	Reaction string `json:"reaction"`
+	// OutgoingWebhookToken verifies the requests of the outgoing webhook of the team. The webhook is rejected if empty
+	OutgoingWebhookToken string `json:"outgoing_webhook_token"`
+	// GitHubUsers maps Mattermost user IDs to GitHub logins to request reviews from the participants of a thread
+	GitHubUsers map[string]string `json:"github_users"`
+}
+
+// DocReaction returns the reaction that starts generating a proposal
+func (c MattermostTeamConfig) DocReaction() string {
+	if c.Reaction == "" {
+		return DefaultMattermostReaction
+	}
+	return c.Reaction
+}
+
+// GitLabProjectConfig is a docs project on GitLab
+type GitLabProjectConfig struct {
+	// Project is the path with namespace such as "group/docs". GitLab is disabled if empty
+	Project          string `json:"project"`
+	DefaultBranch    string `json:"default_branch"`
+	VertexAICorpusID int64  `json:"vertexai_rag_corpus_id"`
+}
+
+// ProposalConfig configures the pull requests of proposals
+type ProposalConfig struct {
+	// Draft opens proposals as draft pull requests
+	Draft bool `json:"draft"`
+	// Labels are added to proposals
+	Labels []string `json:"labels"`
+	// DisableAutoReviewers stops requesting reviews from CODEOWNERS, the owners in the frontmatter and the participants
+	DisableAutoReviewers bool `json:"disable_auto_reviewers"`
+}
+
+// DocsRepository is a docs repository that proposals can be routed to
+type DocsRepository struct {
+	// Name identifies the repository. It is shown to the model when the model selects the repository
+	Name string `json:"name"`
+	// Description tells the model what the repository documents
+	Description string `json:"description"`
+	// GitHubInstallationID defaults to the installation of the workspace
 	GitHubInstallationID int64  `json:"github_installation_id"`
 	GitHubOwner          string `json:"github_owner"`
 	GitHubRepo           string `json:"github_repo"`
 	GitHubDefaultBranch  string `json:"github_default_branch"`
 	VertexAICorpusID     int64  `json:"vertexai_rag_corpus_id"`
+	// ChannelIDs routes threads in these Slack, Discord or Mattermost channels to the repository
+	ChannelIDs []string `json:"channel_ids"`
+	// Keywords routes conversations containing any of them to the repository. Matching is case-insensitive
+	Keywords []string `json:"keywords"`
+}
+
+// DefaultRepository returns the repository configured at the top level of the workspace
+func (w Workspace) DefaultRepository() DocsRepository {
+	return DocsRepository{
+		Name:                 w.GitHubOwner + "/" + w.GitHubRepo,
+		GitHubInstallationID: w.GitHubInstallationID,
+		GitHubOwner:          w.GitHubOwner,
+		GitHubRepo:           w.GitHubRepo,
+		GitHubDefaultBranch:  w.GitHubDefaultBranch,
+		VertexAICorpusID:     w.VertexAICorpusID,
+	}
+}
+
+// AllRepositories returns the default repository followed by the additional ones
+func (w Workspace) AllRepositories() []DocsRepository {
+	return append([]DocsRepository{w.DefaultRepository()}, w.Repositories...)
+}
+
+// ForRepository returns a copy of the workspace whose GitHub and RAG settings point to the repository
+func (w Workspace) ForRepository(repository DocsRepository) Workspace {
+	w.GitHubInstallationID = repository.GitHubInstallationID
+	w.GitHubOwner = repository.GitHubOwner
+	w.GitHubRepo = repository.GitHubRepo
+	w.GitHubDefaultBranch = repository.GitHubDefaultBranch
+	w.VertexAICorpusID = repository.VertexAICorpusID
+	return w
+}
+
+// FindRepository returns the repository of the workspace with the owner and name
+func (w Workspace) FindRepository(owner, repo string) (DocsRepository, bool) {
+	for _, repository := range w.AllRepositories() {
+		if strings.EqualFold(repository.GitHubOwner, owner) && strings.EqualFold(repository.GitHubRepo, repo) {
+			return repository, true
+		}
+	}
+	return DocsRepository{}, false
+}
+
+// ForGitHubRepository scopes the workspace to the repository of a GitHub event.
+// It returns false and the workspace as is if the repository is not a docs repository of the workspace.
+func (w Workspace) ForGitHubRepository(owner, repo string) (Workspace, bool) {
+	repository, ok := w.FindRepository(owner, repo)
+	if !ok {
+		return w, false
+	}
+	return w.ForRepository(repository), true
+}
+
+// RouteRepository returns the repository whose routing rules match the conversation.
+// Channel rules take precedence over keyword rules. It returns false if no rule matches.
+func (w Workspace) RouteRepository(channelID, conversationText string) (DocsRepository, bool) {
+	if channelID != "" {
+		for _, repository := range w.Repositories {
+			if slices.Contains(repository.ChannelIDs, channelID) {
+				return repository, true
+			}
+		}
+	}
+
+	text := strings.ToLower(conversationText)
+	for _, repository := range w.Repositories {
+		for _, keyword := range repository.Keywords {
+			if keyword != "" && strings.Contains(text, strings.ToLower(keyword)) {
+				return repository, true
+			}
+		}
+	}
+
+	return DocsRepository{}, false
+}
+
+// hasKeywordRoutes reports whether any repository is routed by keywords
+func (w Workspace) hasKeywordRoutes() bool {
+	for _, repository := range w.Repositories {
+		if len(repository.Keywords) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// participantGitHubUsers returns the GitHub logins of the participants of the conversation.
+// Slack, Discord and Mattermost users are mapped by SlackGitHubUsers, Discord.GitHubUsers and Mattermost.GitHubUsers,
+// and the ones without a mapping are skipped.
+func (w Workspace) participantGitHubUsers(conversationURI *data.URI, history port.ConversationHistory) []string {
+	var userMap map[string]string
+	isChat := true
+	if _, err := slack.ParseConversationRef(conversationURI); err == nil {
+		userMap = w.SlackGitHubUsers
+	} else if _, err := discord.ParseConversationRef(conversationURI); err == nil {
+		userMap = w.Discord.GitHubUsers
+	} else if mattermost.IsPermalink(conversationURI) {
+		userMap = w.Mattermost.GitHubUsers
+	} else {
+		isChat = false
+	}
+	var users []string
+	for _, message := range history.Messages {
+		if message.IsYou || message.Author == "" {
+			continue
+		}
+		user := message.Author
+		if isChat {
+			var ok bool
+			if user, ok = userMap[message.Author]; !ok {
+				continue
+			}
+		}
+		if !slices.Contains(users, user) {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+// DefaultGitHubIssueLabel is used when GitHubIssueLabel is not configured
+const DefaultGitHubIssueLabel = "docgent"
+
+// SourcePolicyConfig restricts which conversations may be documented in the workspace's repository
+type SourcePolicyConfig struct {
+	// AllowedChannelIDs restricts sources to these Slack, Discord or Mattermost channels if not empty
+	AllowedChannelIDs []string `json:"allowed_channel_ids"`
+	// DeniedChannelIDs are never documented
+	DeniedChannelIDs []string `json:"denied_channel_ids"`
+	// DenyPrivateChannels refuses threads in private channels
+	DenyPrivateChannels bool `json:"deny_private_channels"`
+	// DenyDirectMessages refuses threads in direct messages and group DMs
+	DenyDirectMessages bool `json:"deny_direct_messages"`
+	// AllowPrivateSourcesInPublicRepo lets private channels and DMs be documented in a public or internal repository
+	AllowPrivateSourcesInPublicRepo bool `json:"allow_private_sources_in_public_repo"`
 }
 
 var ErrWorkspaceNotFound = errors.New("workspace not found")
@@ -16,4 +256,14 @@ var ErrWorkspaceNotFound = errors.New("workspace not found")
 type ApplicationConfigService interface {
 	GetWorkspaceBySlackWorkspaceID(slackWorkspaceID string) (Workspace, error)
 	GetWorkspaceByGitHubInstallationID(githubInstallationID int64) (Workspace, error)
+	GetWorkspaceByGitLabProject(project string) (Workspace, error)
+	GetWorkspaceByDiscordGuildID(guildID string) (Workspace, error)
+	GetWorkspaceByMattermostTeamID(teamID string) (Workspace, error)
+	ListWorkspaces() []Workspace
+}
+
+// JobConfig is the configuration of the endpoints called by schedulers such as Cloud Scheduler
+type JobConfig struct {
+	// AuthToken is the bearer token that job requests must present
+	AuthToken string
 }
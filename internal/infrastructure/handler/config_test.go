@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+func TestWorkspace_RouteRepository(t *testing.T) {
+	platform := DocsRepository{Name: "platform", GitHubOwner: "org", GitHubRepo: "platform-docs", ChannelIDs: []string{"C001"}, Keywords: []string{"Kubernetes"}}
+	security := DocsRepository{Name: "security", GitHubOwner: "org", GitHubRepo: "security-docs", Keywords: []string{"vulnerability"}}
+	workspace := Workspace{
+		GitHubOwner:  "org",
+		GitHubRepo:   "docs",
+		Repositories: []DocsRepository{platform, security},
+	}
+
+	tests := []struct {
+		name      string
+		channelID string
+		text      string
+		expected  string
+		ok        bool
+	}{
+		{
+			name:      "チャンネルで振り分ける",
+			channelID: "C001",
+			text:      "vulnerability",
+			expected:  "platform",
+			ok:        true,
+		},
+		{
+			name:     "キーワードで振り分ける（大文字小文字を区別しない）",
+			text:     "A VULNERABILITY was reported",
+			expected: "security",
+			ok:       true,
+		},
+		{
+			name:      "どのルールにも当てはまらない",
+			channelID: "C999",
+			text:      "hello",
+			ok:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, ok := workspace.RouteRepository(tt.channelID, tt.text)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.expected, repository.Name)
+			}
+		})
+	}
+}
+
+func TestWorkspace_ForGitHubRepository(t *testing.T) {
+	workspace := Workspace{
+		GitHubInstallationID: 1,
+		GitHubOwner:          "org",
+		GitHubRepo:           "docs",
+		VertexAICorpusID:     10,
+		Repositories: []DocsRepository{
+			{Name: "platform", GitHubInstallationID: 2, GitHubOwner: "org", GitHubRepo: "platform-docs", GitHubDefaultBranch: "develop", VertexAICorpusID: 20},
+		},
+	}
+
+	scoped, ok := workspace.ForGitHubRepository("org", "platform-docs")
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), scoped.GitHubInstallationID)
+	assert.Equal(t, "platform-docs", scoped.GitHubRepo)
+	assert.Equal(t, "develop", scoped.GitHubDefaultBranch)
+	assert.Equal(t, int64(20), scoped.VertexAICorpusID)
+
+	scoped, ok = workspace.ForGitHubRepository("org", "app")
+	assert.False(t, ok)
+	assert.Equal(t, "docs", scoped.GitHubRepo)
+}
+
+func TestWorkspace_participantGitHubUsers(t *testing.T) {
+	workspace := Workspace{
+		SlackGitHubUsers: map[string]string{"U001": "alice", "U002": "bob"},
+		Discord:          DiscordGuildConfig{GitHubUsers: map[string]string{"1001": "carol"}},
+		Mattermost:       MattermostTeamConfig{GitHubUsers: map[string]string{"mmuser1": "dave"}},
+	}
+	history := port.ConversationHistory{
+		Messages: []port.ConversationMessage{
+			{Author: "U001", Content: "質問です"},
+			{Author: "U999", Content: "対応表にないユーザー"},
+			{Author: "U002", Content: "回答です"},
+			{Author: "U001", Content: "ありがとうございます"},
+			{Author: "UBOT", Content: "PR: https://github.com/org/docs/pull/1", IsYou: true},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		uri      *data.URI
+		history  port.ConversationHistory
+		expected []string
+	}{
+		{
+			name:     "Slackのユーザーは対応表でGitHubのユーザーに変換される",
+			uri:      data.NewURIUnsafe("https://app.slack.com/client/T001/C001/1234567890.123456"),
+			history:  history,
+			expected: []string{"alice", "bob"},
+		},
+		{
+			name: "Discordのユーザーは対応表でGitHubのユーザーに変換される",
+			uri:  data.NewURIUnsafe("https://discord.com/channels/100/200"),
+			history: port.ConversationHistory{
+				Messages: []port.ConversationMessage{
+					{Author: "1001"},
+					{Author: "1002"},
+				},
+			},
+			expected: []string{"carol"},
+		},
+		{
+			name: "Mattermostのユーザーは対応表でGitHubのユーザーに変換される",
+			uri:  data.NewURIUnsafe("https://chat.example.com/eng/pl/rootpost000000000000000000"),
+			history: port.ConversationHistory{
+				Messages: []port.ConversationMessage{
+					{Author: "mmuser1"},
+					{Author: "mmuser2"},
+				},
+			},
+			expected: []string{"dave"},
+		},
+		{
+			name: "GitHubの会話の参加者はそのまま使われる",
+			uri:  data.NewURIUnsafe("https://github.com/org/app/issues/1"),
+			history: port.ConversationHistory{
+				Messages: []port.ConversationMessage{
+					{Author: "carol"},
+					{Author: "docgent[bot]", IsYou: true},
+				},
+			},
+			expected: []string{"carol"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, workspace.participantGitHubUsers(tt.uri, tt.history))
+		})
+	}
+}
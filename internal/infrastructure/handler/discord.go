@@ -0,0 +1,8 @@
+package handler
+
+import "docgent/internal/infrastructure/discord"
+
+type DiscordEventRoute interface {
+	ConsumeEvent(event discord.Event, workspace Workspace)
+	EventType() string
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/infrastructure/discord"
+)
+
+type DiscordGatewayListenerParams struct {
+	fx.In
+
+	Lifecycle                fx.Lifecycle
+	Logger                   *zap.Logger
+	EventRoutes              []DiscordEventRoute `group:"discord_event_routes"`
+	DiscordAPI               *discord.API
+	ApplicationConfigService ApplicationConfigService
+}
+
+// DiscordGatewayListener receives the messages and reactions of Discord servers over the Gateway
+// and passes them to the DiscordEventRoute consumers of the workspace of the server.
+type DiscordGatewayListener struct {
+	log                      *zap.Logger
+	discordAPI               *discord.API
+	eventRoutes              []DiscordEventRoute
+	applicationConfigService ApplicationConfigService
+}
+
+// NewDiscordGatewayListener creates a listener which connects to Discord when the application starts
+func NewDiscordGatewayListener(params DiscordGatewayListenerParams) *DiscordGatewayListener {
+	l := &DiscordGatewayListener{
+		log:                      params.Logger,
+		discordAPI:               params.DiscordAPI,
+		eventRoutes:              params.EventRoutes,
+		applicationConfigService: params.ApplicationConfigService,
+	}
+	if !l.discordAPI.Enabled() {
+		l.log.Info("Discord is disabled because DISCORD_BOT_TOKEN is not set")
+		return l
+	}
+	runUntilStopped(params.Lifecycle, l.log, "Discord Gateway", func(ctx context.Context) error {
+		return l.discordAPI.NewGatewayClient().Run(ctx, l.log, l.dispatch)
+	})
+	return l
+}
+
+// dispatch consumes the event in the background so that the Gateway keeps reading events
+func (l *DiscordGatewayListener) dispatch(event discord.Event) {
+	// DMはサーバーに紐づかないので、ワークスペースを決められない
+	if event.GuildID == "" {
+		return
+	}
+	workspace, err := l.applicationConfigService.GetWorkspaceByDiscordGuildID(event.GuildID)
+	if err != nil {
+		if err == ErrWorkspaceNotFound {
+			l.log.Debug("Unknown Discord guild ID", zap.String("discord_guild_id", event.GuildID))
+			return
+		}
+		l.log.Error("Failed to get workspace", zap.Error(err))
+		return
+	}
+
+	for _, route := range l.eventRoutes {
+		if route.EventType() == event.Type {
+			go route.ConsumeEvent(event, workspace)
+			break
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"docgent/internal/infrastructure/discord"
+)
+
+func (s *fakeApplicationConfigService) GetWorkspaceByDiscordGuildID(guildID string) (Workspace, error) {
+	for _, workspace := range s.workspaces {
+		if workspace.Discord.GuildID == guildID {
+			return workspace, nil
+		}
+	}
+	return Workspace{}, ErrWorkspaceNotFound
+}
+
+type fakeDiscordEventRoute struct {
+	eventType  string
+	workspaces chan Workspace
+}
+
+func (r *fakeDiscordEventRoute) EventType() string {
+	return r.eventType
+}
+
+func (r *fakeDiscordEventRoute) ConsumeEvent(event discord.Event, workspace Workspace) {
+	r.workspaces <- workspace
+}
+
+func TestDiscordGatewayListener_dispatch(t *testing.T) {
+	workspace := Workspace{Discord: DiscordGuildConfig{GuildID: "100"}, GitHubRepo: "docs"}
+
+	tests := []struct {
+		name         string
+		guildID      string
+		eventType    string
+		wantConsumed bool
+	}{
+		{
+			name:         "イベントの種類に合うコンシューマーに渡す",
+			guildID:      "100",
+			eventType:    discord.EventTypeMessageReactionAdd,
+			wantConsumed: true,
+		},
+		{
+			name:      "コンシューマーのないイベントは無視する",
+			guildID:   "100",
+			eventType: discord.EventTypeMessageCreate,
+		},
+		{
+			name:      "未知のサーバーは無視する",
+			guildID:   "999",
+			eventType: discord.EventTypeMessageReactionAdd,
+		},
+		{
+			name:      "DMは無視する",
+			eventType: discord.EventTypeMessageReactionAdd,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := &fakeDiscordEventRoute{eventType: discord.EventTypeMessageReactionAdd, workspaces: make(chan Workspace, 1)}
+			listener := &DiscordGatewayListener{
+				log:                      zap.NewNop(),
+				eventRoutes:              []DiscordEventRoute{route},
+				applicationConfigService: &fakeApplicationConfigService{workspaces: []Workspace{workspace}},
+			}
+
+			listener.dispatch(discord.Event{Type: tt.eventType, GuildID: tt.guildID})
+
+			select {
+			case got := <-route.workspaces:
+				assert.True(t, tt.wantConsumed, "コンシューマーが呼ばれるべきではありません")
+				assert.Equal(t, workspace, got)
+			case <-time.After(100 * time.Millisecond):
+				assert.False(t, tt.wantConsumed, "コンシューマーが呼ばれていません")
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/infrastructure/discord"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/slack"
+)
+
+type DiscordMentionEventConsumerParams struct {
+	fx.In
+
+	Logger                 *zap.Logger
+	ChatModel              domain.ChatModel
+	RAGServices            RAGServices
+	DiscordServiceProvider *discord.ServiceProvider
+	SlackServiceProvider   *slack.ServiceProvider
+	GitHubServiceProvider  *github.ServiceProvider
+}
+
+// DiscordMentionEventConsumer answers the messages that mention Docgent on Discord, like SlackMentionEventConsumer
+type DiscordMentionEventConsumer struct {
+	log                    *zap.Logger
+	chatModel              domain.ChatModel
+	ragServices            RAGServices
+	discordServiceProvider *discord.ServiceProvider
+	slackServiceProvider   *slack.ServiceProvider
+	githubServiceProvider  *github.ServiceProvider
+}
+
+func NewDiscordMentionEventConsumer(params DiscordMentionEventConsumerParams) *DiscordMentionEventConsumer {
+	return &DiscordMentionEventConsumer{
+		log:                    params.Logger,
+		chatModel:              params.ChatModel,
+		ragServices:            params.RAGServices,
+		discordServiceProvider: params.DiscordServiceProvider,
+		slackServiceProvider:   params.SlackServiceProvider,
+		githubServiceProvider:  params.GitHubServiceProvider,
+	}
+}
+
+func (c *DiscordMentionEventConsumer) EventType() string {
+	return discord.EventTypeMessageCreate
+}
+
+func (c *DiscordMentionEventConsumer) ConsumeEvent(event discord.Event, workspace Workspace) {
+	message := event.Message
+	if message == nil {
+		c.log.Error("MESSAGE_CREATE event has no message")
+		return
+	}
+	// Docgent自身や他のボットの投稿には応答しない
+	if message.Author.Bot {
+		return
+	}
+
+	ctx := context.Background()
+
+	// Discordはすべてのメッセージを送ってくるので、メンションされたものだけを処理する
+	currentUser, err := c.discordServiceProvider.CurrentUser(ctx)
+	if err != nil {
+		c.log.Error("Failed to get Discord bot user", zap.Error(err))
+		return
+	}
+	if !message.MentionsUser(currentUser.ID) {
+		return
+	}
+
+	// 会話サービスを初期化
+	ref := discord.NewConversationRef(event.GuildID, message.ChannelID, message.ID)
+	conversationService := c.discordServiceProvider.NewConversationService(ref, message.Author.ID)
+
+	// ワークスペースのポリシーで許可されていない会話には応答しない
+	sourcePolicy, err := newSourcePolicy(ctx, workspace, c.githubServiceProvider, c.slackServiceProvider, c.discordServiceProvider.NewSourceLocator())
+	if err != nil {
+		c.log.Error("Failed to build source policy", zap.Error(err))
+		conversationService.Reply(":warning: エラー: ポリシーの確認に失敗しました", false)
+		return
+	}
+	if err := sourcePolicy.Check(ctx, ref.ToURI()); err != nil {
+		c.log.Info("Conversation is not allowed by source policy", zap.String("channel", message.ChannelID), zap.Error(err))
+		conversationService.Reply(sourcePolicyRefusalMessage, false)
+		return
+	}
+
+	options := []application.NewConversationUsecaseOption{
+		application.WithConversationSourcePolicy(sourcePolicy),
+	}
+	// Search the RAG corpora of all repositories in the workspace
+	if ragCorpus := newWorkspaceRAGCorpus(c.ragServices, workspace); ragCorpus != nil {
+		options = append(options, application.WithConversationRAGCorpus(ragCorpus))
+	}
+
+	sourceRepositories := []port.SourceRepository{
+		c.discordServiceProvider.NewSourceRepository(),
+		c.slackServiceProvider.NewSourceRepository(),
+		c.githubServiceProvider.NewSourceRepository(workspace.GitHubInstallationID),
+	}
+	fileQueryService := c.githubServiceProvider.NewFileQueryService(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, workspace.GitHubDefaultBranch)
+
+	conversationUsecase := application.NewConversationUsecase(
+		c.chatModel,
+		conversationService,
+		fileQueryService,
+		sourceRepositories,
+		c.discordServiceProvider.NewResponseFormatter(),
+		options...,
+	)
+
+	if err := conversationUsecase.Execute(ctx); err != nil {
+		c.log.Error("Failed to execute conversation usecase", zap.Error(err))
+		conversationService.Reply(":warning: エラー: 会話の処理に失敗しました", false)
+		return
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application/redaction"
+	"docgent/internal/domain"
+	"docgent/internal/infrastructure/discord"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/slack"
+)
+
+type DiscordReactionEventConsumerParams struct {
+	fx.In
+
+	Logger                 *zap.Logger
+	GitHubServiceProvider  *github.ServiceProvider
+	SlackServiceProvider   *slack.ServiceProvider
+	DiscordServiceProvider *discord.ServiceProvider
+	ChatModel              domain.ChatModel
+	RAGServices            RAGServices
+	Redactor               *redaction.Redactor
+}
+
+// DiscordReactionEventConsumer documents the conversation of a message when the reaction of the workspace is added to it,
+// like the doc_it reaction on Slack
+type DiscordReactionEventConsumer struct {
+	logger                 *zap.Logger
+	githubServiceProvider  *github.ServiceProvider
+	slackServiceProvider   *slack.ServiceProvider
+	discordServiceProvider *discord.ServiceProvider
+	chatModel              domain.ChatModel
+	ragServices            RAGServices
+	redactor               *redaction.Redactor
+}
+
+func NewDiscordReactionEventConsumer(params DiscordReactionEventConsumerParams) *DiscordReactionEventConsumer {
+	return &DiscordReactionEventConsumer{
+		logger:                 params.Logger,
+		githubServiceProvider:  params.GitHubServiceProvider,
+		slackServiceProvider:   params.SlackServiceProvider,
+		discordServiceProvider: params.DiscordServiceProvider,
+		chatModel:              params.ChatModel,
+		ragServices:            params.RAGServices,
+		redactor:               params.Redactor,
+	}
+}
+
+func (c *DiscordReactionEventConsumer) EventType() string {
+	return discord.EventTypeMessageReactionAdd
+}
+
+func (c *DiscordReactionEventConsumer) ConsumeEvent(event discord.Event, workspace Workspace) {
+	reaction := event.Reaction
+	if reaction == nil {
+		c.logger.Error("MESSAGE_REACTION_ADD event has no reaction")
+		return
+	}
+
+	// カスタム絵文字は名前で指定する
+	if reaction.Emoji.Name != workspace.Discord.DocReaction() {
+		c.logger.Debug("Reaction is not the doc reaction", zap.String("reaction", reaction.Emoji.Name))
+		return
+	}
+
+	ctx := context.Background()
+
+	// スレッド内のメッセージやスレッドの起点にリアクションされた場合は、スレッド全体を1つの会話として扱う
+	ref, err := c.discordServiceProvider.ResolveConversationRef(ctx, event.GuildID, reaction.ChannelID, reaction.MessageID)
+	if err != nil {
+		c.logger.Error("Failed to resolve conversation", zap.String("channel", reaction.ChannelID), zap.Error(err))
+		return
+	}
+	conversationService := c.discordServiceProvider.NewConversationService(ref, reaction.UserID)
+
+	generator := &proposalGenerator{
+		logger:                 c.logger,
+		chatModel:              c.chatModel,
+		ragServices:            c.ragServices,
+		githubServiceProvider:  c.githubServiceProvider,
+		slackServiceProvider:   c.slackServiceProvider,
+		discordServiceProvider: c.discordServiceProvider,
+		redactor:               c.redactor,
+	}
+	proposal, err := generator.generate(ctx, workspace, conversationService, c.discordServiceProvider.NewResponseFormatter())
+	if err != nil {
+		c.logger.Error("Failed to generate proposal", zap.String("channel", reaction.ChannelID), zap.Error(err))
+		return
+	}
+
+	// 新しいメッセージがなく提案を更新しなかったことは返信済み
+	if proposal.unchanged {
+		return
+	}
+
+	if err := conversationService.Reply(proposal.message(), false); err != nil {
+		c.logger.Warn("Failed to reply proposal", zap.Error(err))
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"docgent/internal/application"
 	"docgent/internal/application/port"
+	"docgent/internal/application/redaction"
 	"docgent/internal/domain"
 	infragithub "docgent/internal/infrastructure/github"
 	"docgent/internal/infrastructure/slack"
@@ -23,8 +24,9 @@ type GitHubIssueCommentEventConsumerParams struct {
 	Logger                   *zap.Logger
 	GitHubServiceProvider    *infragithub.ServiceProvider
 	SlackServiceProvider     *slack.ServiceProvider
-	RAGService               port.RAGService
+	RAGServices              RAGServices
 	ApplicationConfigService ApplicationConfigService
+	Redactor                 *redaction.Redactor
 }
 
 type GitHubIssueCommentEventConsumer struct {
@@ -32,8 +34,9 @@ type GitHubIssueCommentEventConsumer struct {
 	logger                   *zap.Logger
 	githubServiceProvider    *infragithub.ServiceProvider
 	slackServiceProvider     *slack.ServiceProvider
-	ragService               port.RAGService
+	ragServices              RAGServices
 	applicationConfigService ApplicationConfigService
+	redactor                 *redaction.Redactor
 }
 
 func NewGitHubIssueCommentEventConsumer(params GitHubIssueCommentEventConsumerParams) *GitHubIssueCommentEventConsumer {
@@ -42,8 +45,9 @@ func NewGitHubIssueCommentEventConsumer(params GitHubIssueCommentEventConsumerPa
 		logger:                   params.Logger,
 		githubServiceProvider:    params.GitHubServiceProvider,
 		slackServiceProvider:     params.SlackServiceProvider,
-		ragService:               params.RAGService,
+		ragServices:              params.RAGServices,
 		applicationConfigService: params.ApplicationConfigService,
+		redactor:                 params.Redactor,
 	}
 }
 
@@ -78,12 +82,12 @@ func (c *GitHubIssueCommentEventConsumer) ConsumeEvent(event interface{}) {
 	if ev.Issue.PullRequestLinks == nil {
 		c.logger.Debug(
 			"Skipping non-pull request comment",
-			zap.String("issue", fmt.Sprintf("https://github.com/%s/%s/issues/%s", ownerName, repoName, issueNumber)),
+			zap.String("issue", fmt.Sprintf("%s/%s/%s/issues/%s", c.githubServiceProvider.WebURL(), ownerName, repoName, issueNumber)),
 		)
 		return
 	}
 
-	pullRequestPath := fmt.Sprintf("https://github.com/%s/%s/pull/%s", ownerName, repoName, issueNumber)
+	pullRequestPath := fmt.Sprintf("%s/%s/%s/pull/%s", c.githubServiceProvider.WebURL(), ownerName, repoName, issueNumber)
 
 	if ev.Sender.GetType() != "User" {
 		c.logger.Debug(
@@ -93,6 +97,23 @@ func (c *GitHubIssueCommentEventConsumer) ConsumeEvent(event interface{}) {
 		return
 	}
 
+	// Plain comments are meant for humans. Only handle commands and mentions of the app
+	command, ok := application.ParseCommand(ev.Comment.GetBody(), c.githubServiceProvider.Mention())
+	if !ok {
+		c.logger.Debug(
+			"Skipping comment without command",
+			zap.String("pull_request", pullRequestPath),
+		)
+		return
+	}
+	if command.Name == application.CommandIgnore {
+		c.logger.Debug(
+			"Ignoring comment by command",
+			zap.String("pull_request", pullRequestPath),
+		)
+		return
+	}
+
 	workspace, err := c.applicationConfigService.GetWorkspaceByGitHubInstallationID(installationID)
 	if err != nil {
 		if err == ErrWorkspaceNotFound {
@@ -102,15 +123,30 @@ func (c *GitHubIssueCommentEventConsumer) ConsumeEvent(event interface{}) {
 		c.logger.Error("Failed to get workspace", zap.Error(err))
 		return
 	}
+	// Use the RAG corpus of the repository that the pull request belongs to
+	workspace, _ = workspace.ForGitHubRepository(ownerName, repoName)
 
 	ctx := context.Background()
 
 	// Create conversation service with PR and comment context
 	commentID := ev.Comment.GetID()
-	ref := infragithub.NewIssueCommentRef(ownerName, repoName, ev.Issue.GetNumber(), commentID)
+	ref := infragithub.NewIssueCommentRef(c.githubServiceProvider.WebURL(), ownerName, repoName, ev.Issue.GetNumber(), commentID)
 	fromUserID := ev.Comment.GetUser().GetLogin()
 	conversationService := c.githubServiceProvider.NewIssueCommentConversationService(installationID, ref, fromUserID)
 
+	// Commands which don't need the agent
+	switch command.Name {
+	case application.CommandRefine:
+		if command.Args == "" {
+			conversationService.Reply(application.CommandHelpMessage(), false)
+			return
+		}
+	case application.CommandRetitle, application.CommandSplit, application.CommandSources, application.CommandSyncBase:
+	default:
+		conversationService.Reply(application.CommandHelpMessage(), false)
+		return
+	}
+
 	// Get PR head branch using service provider
 	headBranch, err := c.githubServiceProvider.GetPullRequestHeadBranch(ctx, installationID, ownerName, repoName, ev.Issue.GetNumber())
 	if err != nil {
@@ -122,7 +158,7 @@ func (c *GitHubIssueCommentEventConsumer) ConsumeEvent(event interface{}) {
 	fileQueryService := c.githubServiceProvider.NewFileQueryService(installationID, ownerName, repoName, headBranch)
 
 	// Create file change service
-	fileRepository := c.githubServiceProvider.NewFileRepository(installationID, ownerName, repoName, headBranch)
+	fileRepository := redaction.NewFileRepository(c.githubServiceProvider.NewFileRepository(installationID, ownerName, repoName, headBranch), c.redactor)
 
 	sourceRepositories := []port.SourceRepository{
 		c.githubServiceProvider.NewSourceRepository(installationID),
@@ -132,11 +168,49 @@ func (c *GitHubIssueCommentEventConsumer) ConsumeEvent(event interface{}) {
 	// Create proposal service
 	// TODO: PRの作成以外ではブランチ名が不要なので、サービスを分ける
 	proposalService := c.githubServiceProvider.NewPullRequestAPI(installationID, ownerName, repoName, defaultBranch, "")
+	handle := proposalService.NewProposalHandle(strconv.Itoa(ev.Issue.GetNumber()))
+
+	if command.Name != application.CommandRefine {
+		commandUsecase := application.NewProposalCommandUsecase(c.chatModel, conversationService, fileRepository, proposalService)
+		switch command.Name {
+		case application.CommandRetitle:
+			err = commandUsecase.Retitle(ctx, handle, command.Args)
+		case application.CommandSplit:
+			err = commandUsecase.Split(ctx, handle, newSplitProposalRepository(
+				c.githubServiceProvider, installationID, ownerName, repoName, defaultBranch, workspaceProposalOptions(workspace, c.logger),
+			))
+		case application.CommandSources:
+			err = commandUsecase.ListSources(ctx, handle)
+		case application.CommandSyncBase:
+			synchronizer := redaction.NewBaseSynchronizer(c.githubServiceProvider.NewBaseSynchronizer(installationID, ownerName, repoName), c.redactor)
+			err = application.NewProposalSyncBaseUsecase(c.chatModel, conversationService, synchronizer).Execute(ctx, handle)
+		}
+		if err != nil {
+			c.logger.Error("Command failed", zap.String("command", string(command.Name)), zap.Error(err))
+			conversationService.Reply(fmt.Sprintf("`%s %s` failed.", application.CommandPrefix, command.Name), false)
+			return
+		}
+		c.logger.Info(
+			"Command processed",
+			zap.String("pull_request", pullRequestPath),
+			zap.String("command", string(command.Name)),
+		)
+		return
+	}
+
+	// Restrict find_source to the sources allowed by the workspace policy
+	sourcePolicy, err := newSourcePolicy(ctx, workspace, c.githubServiceProvider, c.slackServiceProvider)
+	if err != nil {
+		c.logger.Error("Failed to build source policy", zap.Error(err))
+		return
+	}
 
-	var options []application.NewProposalRefineUsecaseOption
+	options := []application.NewProposalRefineUsecaseOption{
+		application.WithProposalRefineSourcePolicy(sourcePolicy),
+	}
 	// If VertexAICorpusID is set, use RAG corpus
 	if workspace.VertexAICorpusID > 0 {
-		options = append(options, application.WithProposalRefineRAGCorpus(c.ragService.GetCorpus(workspace.VertexAICorpusID)))
+		options = append(options, application.WithProposalRefineRAGCorpus(c.ragServices.GetCorpus(workspace, workspace.VertexAICorpusID)))
 	}
 
 	// Create response formatter
@@ -155,8 +229,7 @@ func (c *GitHubIssueCommentEventConsumer) ConsumeEvent(event interface{}) {
 	)
 
 	// Process feedbacks
-	handle := proposalService.NewProposalHandle(strconv.Itoa(ev.Issue.GetNumber()))
-	if err := workflow.Refine(handle, ev.Comment.GetBody()); err != nil {
+	if err := workflow.Refine(handle, command.Args); err != nil {
 		c.logger.Error("Refinement failed", zap.Error(err))
 		return
 	}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v68/github"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application/redaction"
+	"docgent/internal/domain"
+	infragithub "docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/slack"
+)
+
+type GitHubIssuesEventConsumerParams struct {
+	fx.In
+
+	ChatModel                domain.ChatModel
+	Logger                   *zap.Logger
+	GitHubServiceProvider    *infragithub.ServiceProvider
+	SlackServiceProvider     *slack.ServiceProvider
+	RAGServices              RAGServices
+	ApplicationConfigService ApplicationConfigService
+	Redactor                 *redaction.Redactor
+}
+
+// GitHubIssuesEventConsumer generates a proposal from an issue when the configured label is added to it
+type GitHubIssuesEventConsumer struct {
+	chatModel                domain.ChatModel
+	logger                   *zap.Logger
+	githubServiceProvider    *infragithub.ServiceProvider
+	slackServiceProvider     *slack.ServiceProvider
+	ragServices              RAGServices
+	applicationConfigService ApplicationConfigService
+	redactor                 *redaction.Redactor
+}
+
+func NewGitHubIssuesEventConsumer(params GitHubIssuesEventConsumerParams) *GitHubIssuesEventConsumer {
+	return &GitHubIssuesEventConsumer{
+		chatModel:                params.ChatModel,
+		logger:                   params.Logger,
+		githubServiceProvider:    params.GitHubServiceProvider,
+		slackServiceProvider:     params.SlackServiceProvider,
+		ragServices:              params.RAGServices,
+		applicationConfigService: params.ApplicationConfigService,
+		redactor:                 params.Redactor,
+	}
+}
+
+func (c *GitHubIssuesEventConsumer) EventType() string {
+	return "issues"
+}
+
+func (c *GitHubIssuesEventConsumer) ConsumeEvent(event interface{}) {
+	ev, ok := event.(*github.IssuesEvent)
+	if !ok {
+		c.logger.Error("Failed to convert event data to IssuesEvent")
+		return
+	}
+
+	if ev.GetAction() != "labeled" {
+		c.logger.Debug("Skip issues event", zap.String("action", ev.GetAction()))
+		return
+	}
+
+	installationID := ev.GetInstallation().GetID()
+	workspace, err := c.applicationConfigService.GetWorkspaceByGitHubInstallationID(installationID)
+	if err != nil {
+		if err == ErrWorkspaceNotFound {
+			c.logger.Warn("Unknown GitHub installation ID", zap.Int64("installation_id", installationID))
+			return
+		}
+		c.logger.Error("Failed to get workspace", zap.Error(err))
+		return
+	}
+
+	if ev.GetLabel().GetName() != workspace.GitHubIssueLabel {
+		c.logger.Debug("Skip issue labeled with other label", zap.String("label", ev.GetLabel().GetName()))
+		return
+	}
+
+	ownerName := ev.GetRepo().GetOwner().GetLogin()
+	repoName := ev.GetRepo().GetName()
+	issue := ev.GetIssue()
+	issuePath := fmt.Sprintf("%s/%s/%s/issues/%d", c.githubServiceProvider.WebURL(), ownerName, repoName, issue.GetNumber())
+
+	ctx := context.Background()
+
+	// The issue may be in any repository of the installation, while the proposal is created in the workspace's repository
+	conversationService := c.githubServiceProvider.NewIssueConversationService(installationID, ownerName, repoName, issue.GetNumber(), ev.GetSender().GetLogin())
+
+	generator := &proposalGenerator{
+		logger:                c.logger,
+		chatModel:             c.chatModel,
+		ragServices:           c.ragServices,
+		githubServiceProvider: c.githubServiceProvider,
+		slackServiceProvider:  c.slackServiceProvider,
+		redactor:              c.redactor,
+	}
+	proposal, err := generator.generate(ctx, workspace, conversationService, c.githubServiceProvider.NewResponseFormatter())
+	if err != nil {
+		c.logger.Error("Failed to generate proposal", zap.String("issue", issuePath), zap.Error(err))
+		return
+	}
+
+	// 新しいメッセージがなく提案を更新しなかったことは返信済み
+	if proposal.unchanged {
+		return
+	}
+
+	conversationService.Reply(proposal.message(), false)
+
+	c.logger.Info("Proposal generated from issue", zap.String("issue", issuePath))
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-github/v68/github"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+	"docgent/internal/infrastructure/discord"
+	infragithub "docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/mattermost"
+	"docgent/internal/infrastructure/slack"
+)
+
+type GitHubPullRequestEventConsumerParams struct {
+	fx.In
+
+	ChatModel                 domain.ChatModel
+	Logger                    *zap.Logger
+	GitHubServiceProvider     *infragithub.ServiceProvider
+	SlackServiceProvider      *slack.ServiceProvider
+	DiscordServiceProvider    *discord.ServiceProvider
+	MattermostServiceProvider *mattermost.ServiceProvider
+	ApplicationConfigService  ApplicationConfigService
+}
+
+// GitHubPullRequestEventConsumer lints the documents changed by pull requests to docs repositories,
+// and notifies the originating conversation when a proposal is merged or closed
+type GitHubPullRequestEventConsumer struct {
+	chatModel                 domain.ChatModel
+	logger                    *zap.Logger
+	githubServiceProvider     *infragithub.ServiceProvider
+	slackServiceProvider      *slack.ServiceProvider
+	discordServiceProvider    *discord.ServiceProvider
+	mattermostServiceProvider *mattermost.ServiceProvider
+	applicationConfigService  ApplicationConfigService
+}
+
+func NewGitHubPullRequestEventConsumer(params GitHubPullRequestEventConsumerParams) *GitHubPullRequestEventConsumer {
+	return &GitHubPullRequestEventConsumer{
+		chatModel:                 params.ChatModel,
+		logger:                    params.Logger,
+		githubServiceProvider:     params.GitHubServiceProvider,
+		slackServiceProvider:      params.SlackServiceProvider,
+		discordServiceProvider:    params.DiscordServiceProvider,
+		mattermostServiceProvider: params.MattermostServiceProvider,
+		applicationConfigService:  params.ApplicationConfigService,
+	}
+}
+
+func (c *GitHubPullRequestEventConsumer) EventType() string {
+	return "pull_request"
+}
+
+func (c *GitHubPullRequestEventConsumer) ConsumeEvent(event interface{}) {
+	ev, ok := event.(*github.PullRequestEvent)
+	if !ok {
+		c.logger.Error("Failed to convert event data to PullRequestEvent")
+		return
+	}
+
+	switch ev.GetAction() {
+	case "opened", "synchronize", "reopened":
+		c.lint(ev)
+		return
+	case "closed":
+	default:
+		c.logger.Debug("Skip pull request event", zap.String("action", ev.GetAction()))
+		return
+	}
+
+	installationID := ev.GetInstallation().GetID()
+	ownerName := ev.GetRepo().GetOwner().GetLogin()
+	repoName := ev.GetRepo().GetName()
+	pr := ev.GetPullRequest()
+
+	ctx := context.Background()
+
+	proposalURI, err := data.NewURI(pr.GetHTMLURL())
+	if err != nil {
+		c.logger.Error("Invalid pull request URL", zap.String("url", pr.GetHTMLURL()), zap.Error(err))
+		return
+	}
+
+	update := application.ProposalStatusUpdate{
+		Handle:      domain.NewProposalHandle("github-pull-request", strconv.Itoa(pr.GetNumber())),
+		Status:      application.ProposalStatusClosed,
+		ProposalURI: proposalURI,
+		Actor:       ev.GetSender().GetLogin(),
+	}
+	if pr.GetMerged() {
+		update.Status = application.ProposalStatusMerged
+		documentURIs, err := c.githubServiceProvider.ListMergedFilePermalinks(ctx, installationID, ownerName, repoName, pr.GetNumber(), pr.GetMergeCommitSHA())
+		if err != nil {
+			// Notify without links rather than not at all
+			c.logger.Warn("Failed to list merged files", zap.Error(err))
+		}
+		update.DocumentURIs = documentURIs
+	}
+
+	usecase := application.NewProposalStatusNotifyUsecase(
+		c.githubServiceProvider.NewProposalLinkRepository(installationID, ownerName, repoName),
+		[]port.ConversationResolver{
+			c.slackServiceProvider.NewConversationResolver(),
+			c.discordServiceProvider.NewConversationResolver(),
+			c.mattermostServiceProvider.NewConversationResolver(),
+			c.githubServiceProvider.NewConversationResolver(installationID),
+		},
+	)
+	if err := usecase.Execute(ctx, update); err != nil {
+		c.logger.Error("Failed to notify proposal status", zap.Error(err))
+		return
+	}
+
+	c.logger.Info(
+		"Pull request status processed",
+		zap.String("pull_request", fmt.Sprintf("%s/%s/%s/pull/%d", c.githubServiceProvider.WebURL(), ownerName, repoName, pr.GetNumber())),
+		zap.String("status", string(update.Status)),
+	)
+}
+
+// lint reports the problems in the changed documents as a check run of the head commit.
+// Pull requests by anyone are linted, not only the proposals.
+func (c *GitHubPullRequestEventConsumer) lint(ev *github.PullRequestEvent) {
+	installationID := ev.GetInstallation().GetID()
+	ownerName := ev.GetRepo().GetOwner().GetLogin()
+	repoName := ev.GetRepo().GetName()
+	pr := ev.GetPullRequest()
+	headSHA := pr.GetHead().GetSHA()
+
+	workspace, err := c.applicationConfigService.GetWorkspaceByGitHubInstallationID(installationID)
+	if err != nil {
+		if err == ErrWorkspaceNotFound {
+			c.logger.Warn("Unknown GitHub installation ID", zap.Int64("installation_id", installationID))
+			return
+		}
+		c.logger.Error("Failed to get workspace", zap.Error(err))
+		return
+	}
+	if _, ok := workspace.ForGitHubRepository(ownerName, repoName); !ok {
+		c.logger.Debug("Skip linting a repository which is not a docs repository", zap.String("repository", ownerName+"/"+repoName))
+		return
+	}
+
+	ctx := context.Background()
+
+	paths, err := c.githubServiceProvider.ListPullRequestFiles(ctx, installationID, ownerName, repoName, pr.GetNumber())
+	if err != nil {
+		c.logger.Error("Failed to list pull request files", zap.Error(err))
+		return
+	}
+
+	// Read the files at the head commit, which also works for pull requests from forks
+	usecase := application.NewDocumentLintUsecase(
+		c.chatModel,
+		c.githubServiceProvider.NewFileQueryService(installationID, ownerName, repoName, headSHA),
+		c.githubServiceProvider.NewFileRepository(installationID, ownerName, repoName, headSHA),
+		[]port.SourceRepository{
+			c.slackServiceProvider.NewSourceRepository(),
+			c.githubServiceProvider.NewSourceRepository(installationID),
+		},
+		c.githubServiceProvider.NewCheckRunReporter(installationID, ownerName, repoName, headSHA),
+	)
+	if err := usecase.Execute(ctx, paths); err != nil {
+		c.logger.Error("Failed to lint pull request", zap.Error(err))
+		return
+	}
+
+	c.logger.Info(
+		"Pull request linted",
+		zap.String("pull_request", fmt.Sprintf("%s/%s/%s/pull/%d", c.githubServiceProvider.WebURL(), ownerName, repoName, pr.GetNumber())),
+	)
+}
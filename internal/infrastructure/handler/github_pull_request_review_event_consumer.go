@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-github/v68/github"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+	"docgent/internal/infrastructure/discord"
+	infragithub "docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/mattermost"
+	"docgent/internal/infrastructure/slack"
+)
+
+type GitHubPullRequestReviewEventConsumerParams struct {
+	fx.In
+
+	Logger                    *zap.Logger
+	GitHubServiceProvider     *infragithub.ServiceProvider
+	SlackServiceProvider      *slack.ServiceProvider
+	DiscordServiceProvider    *discord.ServiceProvider
+	MattermostServiceProvider *mattermost.ServiceProvider
+}
+
+// GitHubPullRequestReviewEventConsumer notifies the originating conversation when a proposal is reviewed
+type GitHubPullRequestReviewEventConsumer struct {
+	logger                    *zap.Logger
+	githubServiceProvider     *infragithub.ServiceProvider
+	slackServiceProvider      *slack.ServiceProvider
+	discordServiceProvider    *discord.ServiceProvider
+	mattermostServiceProvider *mattermost.ServiceProvider
+}
+
+func NewGitHubPullRequestReviewEventConsumer(params GitHubPullRequestReviewEventConsumerParams) *GitHubPullRequestReviewEventConsumer {
+	return &GitHubPullRequestReviewEventConsumer{
+		logger:                    params.Logger,
+		githubServiceProvider:     params.GitHubServiceProvider,
+		slackServiceProvider:      params.SlackServiceProvider,
+		discordServiceProvider:    params.DiscordServiceProvider,
+		mattermostServiceProvider: params.MattermostServiceProvider,
+	}
+}
+
+func (c *GitHubPullRequestReviewEventConsumer) EventType() string {
+	return "pull_request_review"
+}
+
+func (c *GitHubPullRequestReviewEventConsumer) ConsumeEvent(event interface{}) {
+	ev, ok := event.(*github.PullRequestReviewEvent)
+	if !ok {
+		c.logger.Error("Failed to convert event data to PullRequestReviewEvent")
+		return
+	}
+
+	if ev.GetAction() != "submitted" {
+		c.logger.Debug("Skip pull request review event", zap.String("action", ev.GetAction()))
+		return
+	}
+
+	if ev.GetSender().GetType() != "User" {
+		c.logger.Debug("Skipping non-user review")
+		return
+	}
+
+	review := ev.GetReview()
+	var status application.ProposalStatus
+	switch review.GetState() {
+	case "approved":
+		status = application.ProposalStatusApproved
+	case "changes_requested":
+		status = application.ProposalStatusChangesRequested
+	case "commented":
+		// Inline comments create a review without a body, which are handled by the review comment consumer
+		if review.GetBody() == "" {
+			return
+		}
+		status = application.ProposalStatusCommented
+	default:
+		c.logger.Debug("Skip review", zap.String("state", review.GetState()))
+		return
+	}
+
+	installationID := ev.GetInstallation().GetID()
+	ownerName := ev.GetRepo().GetOwner().GetLogin()
+	repoName := ev.GetRepo().GetName()
+	pr := ev.GetPullRequest()
+
+	proposalURI, err := data.NewURI(pr.GetHTMLURL())
+	if err != nil {
+		c.logger.Error("Invalid pull request URL", zap.String("url", pr.GetHTMLURL()), zap.Error(err))
+		return
+	}
+
+	update := application.ProposalStatusUpdate{
+		Handle:      domain.NewProposalHandle("github-pull-request", strconv.Itoa(pr.GetNumber())),
+		Status:      status,
+		ProposalURI: proposalURI,
+		Actor:       review.GetUser().GetLogin(),
+		Comment:     review.GetBody(),
+	}
+
+	ctx := context.Background()
+	usecase := application.NewProposalStatusNotifyUsecase(
+		c.githubServiceProvider.NewProposalLinkRepository(installationID, ownerName, repoName),
+		[]port.ConversationResolver{
+			c.slackServiceProvider.NewConversationResolver(),
+			c.discordServiceProvider.NewConversationResolver(),
+			c.mattermostServiceProvider.NewConversationResolver(),
+			c.githubServiceProvider.NewConversationResolver(installationID),
+		},
+	)
+	if err := usecase.Execute(ctx, update); err != nil {
+		c.logger.Error("Failed to notify proposal review", zap.Error(err))
+		return
+	}
+
+	c.logger.Info(
+		"Pull request review processed",
+		zap.String("pull_request", fmt.Sprintf("%s/%s/%s/pull/%d", c.githubServiceProvider.WebURL(), ownerName, repoName, pr.GetNumber())),
+		zap.String("status", string(status)),
+	)
+}
@@ -2,13 +2,13 @@ package handler
 
 import (
 	"sort"
+	"time"
 
 	"github.com/google/go-github/v68/github"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
 	"docgent/internal/application"
-	"docgent/internal/application/port"
 	infragithub "docgent/internal/infrastructure/github"
 )
 
@@ -17,14 +17,14 @@ type GitHubPushEventConsumerParams struct {
 
 	Logger                   *zap.Logger
 	GitHubServiceProvider    *infragithub.ServiceProvider
-	RAGService               port.RAGService
+	RAGServices              RAGServices
 	ApplicationConfigService ApplicationConfigService
 }
 
 type GitHubPushEventConsumer struct {
 	logger                   *zap.Logger
 	githubServiceProvider    *infragithub.ServiceProvider
-	ragService               port.RAGService
+	ragServices              RAGServices
 	applicationConfigService ApplicationConfigService
 }
 
@@ -32,7 +32,7 @@ func NewGitHubPushEventConsumer(params GitHubPushEventConsumerParams) *GitHubPus
 	return &GitHubPushEventConsumer{
 		logger:                   params.Logger,
 		githubServiceProvider:    params.GitHubServiceProvider,
-		ragService:               params.RAGService,
+		ragServices:              params.RAGServices,
 		applicationConfigService: params.ApplicationConfigService,
 	}
 }
@@ -56,6 +56,13 @@ func (c *GitHubPushEventConsumer) ConsumeEvent(event interface{}) {
 		return
 	}
 
+	// Only docs repositories of the workspace are synced to their RAG corpora
+	workspace, ok = workspace.ForGitHubRepository(ev.GetRepo().GetOwner().GetLogin(), ev.GetRepo().GetName())
+	if !ok {
+		c.logger.Info("Skipping push to non-docs repository", zap.String("repository", ev.GetRepo().GetFullName()))
+		return
+	}
+
 	defaultBranchRef := "refs/heads/" + workspace.GitHubDefaultBranch
 
 	if ev.GetRef() != defaultBranchRef {
@@ -66,7 +73,7 @@ func (c *GitHubPushEventConsumer) ConsumeEvent(event interface{}) {
 	newFiles, modifiedFiles, deletedFiles := classifyFilesBySimulation(ev.GetCommits())
 
 	fileQueryService := c.githubServiceProvider.NewFileQueryService(installationID, workspace.GitHubOwner, workspace.GitHubRepo, workspace.GitHubDefaultBranch)
-	ragCorpus := c.ragService.GetCorpus(workspace.VertexAICorpusID)
+	ragCorpus := c.ragServices.GetCorpus(workspace, workspace.VertexAICorpusID)
 	ragFileSyncUsecase := application.NewRagFileSyncUsecase(ragCorpus, fileQueryService)
 
 	c.logger.Info("Syncing RAG files...", zap.Strings("newFiles", newFiles), zap.Strings("modifiedFiles", modifiedFiles), zap.Strings("deletedFiles", deletedFiles))
@@ -88,10 +95,31 @@ const (
 	fileStatusRemoved
 )
 
+// pushedCommit is a commit of a push event, common to GitHub and GitLab
+type pushedCommit struct {
+	timestamp time.Time
+	added     []string
+	modified  []string
+	removed   []string
+}
+
 func classifyFilesBySimulation(commits []*github.HeadCommit) (newFiles, modifiedFiles, deletedFiles []string) {
+	pushedCommits := make([]pushedCommit, 0, len(commits))
+	for _, c := range commits {
+		pushedCommits = append(pushedCommits, pushedCommit{
+			timestamp: c.GetTimestamp().Time,
+			added:     c.Added,
+			modified:  c.Modified,
+			removed:   c.Removed,
+		})
+	}
+	return classifyPushedFiles(pushedCommits)
+}
+
+func classifyPushedFiles(commits []pushedCommit) (newFiles, modifiedFiles, deletedFiles []string) {
 	// Sort commits by timestamp
-	sort.Slice(commits, func(i, j int) bool {
-		return commits[i].Timestamp.Before(*commits[j].Timestamp.GetTime())
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].timestamp.Before(commits[j].timestamp)
 	})
 
 	// Record file status
@@ -100,7 +128,7 @@ func classifyFilesBySimulation(commits []*github.HeadCommit) (newFiles, modified
 	// Process each commit in chronological order
 	for _, c := range commits {
 		// (1) Process removed files first: files removed in the commit will no longer exist
-		for _, file := range c.Removed {
+		for _, file := range c.removed {
 			status, exists := fileStates[file]
 			if exists && status == fileStatusAdded {
 				delete(fileStates, file)
@@ -109,7 +137,7 @@ func classifyFilesBySimulation(commits []*github.HeadCommit) (newFiles, modified
 			fileStates[file] = fileStatusRemoved
 		}
 		// (2) Process added files: files added in the commit will be new
-		for _, file := range c.Added {
+		for _, file := range c.added {
 			status, exists := fileStates[file]
 			if exists && status == fileStatusRemoved {
 				fileStates[file] = fileStatusModified
@@ -118,7 +146,7 @@ func classifyFilesBySimulation(commits []*github.HeadCommit) (newFiles, modified
 			fileStates[file] = fileStatusAdded
 		}
 		// (3) Process modified files: if there is a change, it is updated
-		for _, file := range c.Modified {
+		for _, file := range c.modified {
 			status, exists := fileStates[file]
 			if exists && status == fileStatusAdded {
 				continue
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-github/v68/github"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/application/redaction"
+	"docgent/internal/domain"
+	infragithub "docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/slack"
+)
+
+type GitHubReviewCommentEventConsumerParams struct {
+	fx.In
+
+	ChatModel                domain.ChatModel
+	Logger                   *zap.Logger
+	GitHubServiceProvider    *infragithub.ServiceProvider
+	SlackServiceProvider     *slack.ServiceProvider
+	RAGServices              RAGServices
+	ApplicationConfigService ApplicationConfigService
+	Redactor                 *redaction.Redactor
+}
+
+// GitHubReviewCommentEventConsumer refines a proposal when a reviewer leaves an inline comment on it
+type GitHubReviewCommentEventConsumer struct {
+	chatModel                domain.ChatModel
+	logger                   *zap.Logger
+	githubServiceProvider    *infragithub.ServiceProvider
+	slackServiceProvider     *slack.ServiceProvider
+	ragServices              RAGServices
+	applicationConfigService ApplicationConfigService
+	redactor                 *redaction.Redactor
+}
+
+func NewGitHubReviewCommentEventConsumer(params GitHubReviewCommentEventConsumerParams) *GitHubReviewCommentEventConsumer {
+	return &GitHubReviewCommentEventConsumer{
+		chatModel:                params.ChatModel,
+		logger:                   params.Logger,
+		githubServiceProvider:    params.GitHubServiceProvider,
+		slackServiceProvider:     params.SlackServiceProvider,
+		ragServices:              params.RAGServices,
+		applicationConfigService: params.ApplicationConfigService,
+		redactor:                 params.Redactor,
+	}
+}
+
+func (c *GitHubReviewCommentEventConsumer) EventType() string {
+	return "pull_request_review_comment"
+}
+
+func (c *GitHubReviewCommentEventConsumer) ConsumeEvent(event interface{}) {
+	ev, ok := event.(*github.PullRequestReviewCommentEvent)
+	if !ok {
+		c.logger.Error("Failed to convert event data to PullRequestReviewCommentEvent")
+		return
+	}
+
+	action := ev.GetAction()
+	installationID := ev.GetInstallation().GetID()
+	repo := ev.GetRepo()
+	repoName := repo.GetName()
+	defaultBranch := repo.GetDefaultBranch()
+	ownerName := repo.GetOwner().GetLogin()
+	prNumber := ev.GetPullRequest().GetNumber()
+	pullRequestPath := fmt.Sprintf("%s/%s/%s/pull/%d", c.githubServiceProvider.WebURL(), ownerName, repoName, prNumber)
+
+	if action != "created" {
+		c.logger.Debug(
+			"Skip non-created review comment event",
+			zap.String("action", action),
+		)
+		return
+	}
+
+	if ev.GetSender().GetType() != "User" {
+		c.logger.Debug(
+			"Skipping non-user review comment",
+			zap.String("pull_request", pullRequestPath),
+		)
+		return
+	}
+
+	// Like pull request comments, plain review comments are meant for humans. Only handle commands and mentions of the app
+	comment := ev.GetComment()
+	command, ok := application.ParseCommand(comment.GetBody(), c.githubServiceProvider.Mention())
+	if !ok || command.Name == application.CommandIgnore {
+		c.logger.Debug(
+			"Skipping review comment without command",
+			zap.String("pull_request", pullRequestPath),
+		)
+		return
+	}
+
+	workspace, err := c.applicationConfigService.GetWorkspaceByGitHubInstallationID(installationID)
+	if err != nil {
+		if err == ErrWorkspaceNotFound {
+			c.logger.Warn("Unknown GitHub installation ID", zap.Int64("installation_id", installationID))
+			return
+		}
+		c.logger.Error("Failed to get workspace", zap.Error(err))
+		return
+	}
+	// Use the RAG corpus of the repository that the pull request belongs to
+	workspace, _ = workspace.ForGitHubRepository(ownerName, repoName)
+
+	ctx := context.Background()
+
+	// Reply in the review thread of the comment
+	fromUserID := comment.GetUser().GetLogin()
+	conversationService := c.githubServiceProvider.NewReviewCommentConversationService(installationID, ownerName, repoName, prNumber, comment.GetID(), fromUserID)
+
+	// Inline comments only refine the commented lines. Other commands work on the whole pull request
+	switch command.Name {
+	case application.CommandRefine:
+		if command.Args == "" {
+			conversationService.Reply(application.CommandHelpMessage(), false)
+			return
+		}
+	case application.CommandRetitle, application.CommandSplit, application.CommandSources, application.CommandSyncBase:
+		conversationService.Reply(fmt.Sprintf("`%s %s` is only available in pull request comments.", application.CommandPrefix, command.Name), false)
+		return
+	default:
+		conversationService.Reply(application.CommandHelpMessage(), false)
+		return
+	}
+
+	// Inline comments are always left on the head branch of the pull request
+	headBranch := ev.GetPullRequest().GetHead().GetRef()
+
+	fileQueryService := c.githubServiceProvider.NewFileQueryService(installationID, ownerName, repoName, headBranch)
+	fileRepository := redaction.NewFileRepository(c.githubServiceProvider.NewFileRepository(installationID, ownerName, repoName, headBranch), c.redactor)
+
+	sourceRepositories := []port.SourceRepository{
+		c.githubServiceProvider.NewSourceRepository(installationID),
+		c.slackServiceProvider.NewSourceRepository(),
+	}
+
+	proposalService := c.githubServiceProvider.NewPullRequestAPI(installationID, ownerName, repoName, defaultBranch, "")
+
+	// Restrict find_source to the sources allowed by the workspace policy
+	sourcePolicy, err := newSourcePolicy(ctx, workspace, c.githubServiceProvider, c.slackServiceProvider)
+	if err != nil {
+		c.logger.Error("Failed to build source policy", zap.Error(err))
+		return
+	}
+
+	options := []application.NewProposalRefineUsecaseOption{
+		application.WithProposalRefineSourcePolicy(sourcePolicy),
+	}
+	// If VertexAICorpusID is set, use RAG corpus
+	if workspace.VertexAICorpusID > 0 {
+		options = append(options, application.WithProposalRefineRAGCorpus(c.ragServices.GetCorpus(workspace, workspace.VertexAICorpusID)))
+	}
+
+	responseFormatter := c.githubServiceProvider.NewResponseFormatter()
+
+	workflow := application.NewProposalRefineUsecase(
+		c.chatModel,
+		conversationService,
+		fileQueryService,
+		fileRepository,
+		sourceRepositories,
+		proposalService,
+		responseFormatter,
+		options...,
+	)
+
+	handle := proposalService.NewProposalHandle(strconv.Itoa(prNumber))
+	if err := workflow.RefineAt(handle, command.Args, newFeedbackAnchor(comment)); err != nil {
+		c.logger.Error("Refinement failed", zap.Error(err))
+		return
+	}
+
+	c.logger.Info(
+		"Review comment processed and refinement applied",
+		zap.String("pull_request", pullRequestPath),
+		zap.String("path", comment.GetPath()),
+	)
+}
+
+// newFeedbackAnchor converts the position of an inline comment to a feedback anchor.
+// Comments on outdated diffs only have the original line numbers.
+func newFeedbackAnchor(comment *github.PullRequestComment) application.FeedbackAnchor {
+	endLine := comment.GetLine()
+	if endLine == 0 {
+		endLine = comment.GetOriginalLine()
+	}
+	startLine := comment.GetStartLine()
+	if startLine == 0 {
+		startLine = comment.GetOriginalStartLine()
+	}
+	if startLine == 0 {
+		startLine = endLine
+	}
+
+	return application.FeedbackAnchor{
+		FilePath:  comment.GetPath(),
+		StartLine: startLine,
+		EndLine:   endLine,
+		DiffHunk:  comment.GetDiffHunk(),
+	}
+}
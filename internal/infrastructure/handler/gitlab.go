@@ -0,0 +1,6 @@
+package handler
+
+type GitLabEventRoute interface {
+	ConsumeEvent(event interface{})
+	EventType() string
+}
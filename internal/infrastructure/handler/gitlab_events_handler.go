@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/infrastructure/gitlab"
+)
+
+type GitLabWebhookHandlerParams struct {
+	fx.In
+
+	Logger                     *zap.Logger
+	EventRoutes                []GitLabEventRoute `group:"gitlab_event_routes"`
+	GitLabWebhookRequestParser *gitlab.WebhookRequestParser
+}
+
+type GitLabWebhookHandler struct {
+	logger                     *zap.Logger
+	eventRoutes                []GitLabEventRoute
+	gitlabWebhookRequestParser *gitlab.WebhookRequestParser
+}
+
+func NewGitLabWebhookHandler(params GitLabWebhookHandlerParams) *GitLabWebhookHandler {
+	return &GitLabWebhookHandler{
+		logger:                     params.Logger,
+		eventRoutes:                params.EventRoutes,
+		gitlabWebhookRequestParser: params.GitLabWebhookRequestParser,
+	}
+}
+
+func (h *GitLabWebhookHandler) Pattern() string {
+	return "/api/gitlab/events"
+}
+
+func (h *GitLabWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ev, err := h.gitlabWebhookRequestParser.ParseRequest(r)
+	if err != nil {
+		h.logger.Warn("Failed to parse request", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	for _, route := range h.eventRoutes {
+		if route.EventType() == ev.EventType() {
+			go route.ConsumeEvent(ev.InnerEvent())
+			break
+		}
+	}
+
+	// GitLab disables webhooks which time out, so respond immediately
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/application/redaction"
+	"docgent/internal/domain"
+	"docgent/internal/infrastructure/gitlab"
+	"docgent/internal/infrastructure/slack"
+)
+
+type GitLabNoteEventConsumerParams struct {
+	fx.In
+
+	ChatModel                domain.ChatModel
+	Logger                   *zap.Logger
+	GitLabServiceProvider    *gitlab.ServiceProvider
+	SlackServiceProvider     *slack.ServiceProvider
+	RAGServices              RAGServices
+	ApplicationConfigService ApplicationConfigService
+	Redactor                 *redaction.Redactor
+}
+
+type GitLabNoteEventConsumer struct {
+	chatModel                domain.ChatModel
+	logger                   *zap.Logger
+	gitlabServiceProvider    *gitlab.ServiceProvider
+	slackServiceProvider     *slack.ServiceProvider
+	ragServices              RAGServices
+	applicationConfigService ApplicationConfigService
+	redactor                 *redaction.Redactor
+}
+
+func NewGitLabNoteEventConsumer(params GitLabNoteEventConsumerParams) *GitLabNoteEventConsumer {
+	return &GitLabNoteEventConsumer{
+		chatModel:                params.ChatModel,
+		logger:                   params.Logger,
+		gitlabServiceProvider:    params.GitLabServiceProvider,
+		slackServiceProvider:     params.SlackServiceProvider,
+		ragServices:              params.RAGServices,
+		applicationConfigService: params.ApplicationConfigService,
+		redactor:                 params.Redactor,
+	}
+}
+
+func (c *GitLabNoteEventConsumer) EventType() string {
+	return "note"
+}
+
+func (c *GitLabNoteEventConsumer) ConsumeEvent(event interface{}) {
+	ev, ok := event.(*gitlab.NoteEvent)
+	if !ok {
+		c.logger.Error("Failed to convert event data to NoteEvent")
+		return
+	}
+
+	// Skip notes on issues, commits and snippets
+	if ev.ObjectAttributes.NoteableType != "MergeRequest" || ev.MergeRequest == nil {
+		c.logger.Debug("Skipping non-merge request note", zap.String("noteable_type", ev.ObjectAttributes.NoteableType))
+		return
+	}
+
+	project := ev.Project.PathWithNamespace
+	mergeRequestPath := fmt.Sprintf("%s/%s/-/merge_requests/%d", c.gitlabServiceProvider.WebURL(), project, ev.MergeRequest.IID)
+
+	ctx := context.Background()
+
+	username, err := c.gitlabServiceProvider.Username(ctx)
+	if err != nil {
+		c.logger.Error("Failed to get GitLab username", zap.Error(err))
+		return
+	}
+	// Skip Docgent's own notes
+	if ev.User.Username == username {
+		c.logger.Debug("Skipping own note", zap.String("merge_request", mergeRequestPath))
+		return
+	}
+
+	// Plain notes are meant for humans. Only handle commands and mentions of the app
+	command, ok := application.ParseCommand(ev.ObjectAttributes.Note, "@"+username)
+	if !ok {
+		c.logger.Debug("Skipping note without command", zap.String("merge_request", mergeRequestPath))
+		return
+	}
+	if command.Name == application.CommandIgnore {
+		c.logger.Debug("Ignoring note by command", zap.String("merge_request", mergeRequestPath))
+		return
+	}
+
+	workspace, err := c.applicationConfigService.GetWorkspaceByGitLabProject(project)
+	if err != nil {
+		if err == ErrWorkspaceNotFound {
+			c.logger.Warn("Unknown GitLab project", zap.String("project", project))
+			return
+		}
+		c.logger.Error("Failed to get workspace", zap.Error(err))
+		return
+	}
+
+	ref := gitlab.NewMergeRequestNoteRef(c.gitlabServiceProvider.WebURL(), project, ev.MergeRequest.IID, ev.ObjectAttributes.ID)
+	conversationService := c.gitlabServiceProvider.NewMergeRequestNoteConversationService(ref, ev.User.Username)
+
+	// Commands which don't need the agent
+	switch command.Name {
+	case application.CommandRefine:
+		if command.Args == "" {
+			conversationService.Reply(application.CommandHelpMessage(), false)
+			return
+		}
+	case application.CommandSplit, application.CommandSyncBase:
+		conversationService.Reply(fmt.Sprintf("`%s %s` is not supported on GitLab yet.", application.CommandPrefix, command.Name), false)
+		return
+	case application.CommandRetitle, application.CommandSources:
+	default:
+		conversationService.Reply(application.CommandHelpMessage(), false)
+		return
+	}
+
+	sourceBranch := ev.MergeRequest.SourceBranch
+	fileQueryService := c.gitlabServiceProvider.NewFileQueryService(project, sourceBranch)
+	fileRepository := redaction.NewFileRepository(c.gitlabServiceProvider.NewFileRepository(project, sourceBranch), c.redactor)
+
+	proposalService := c.gitlabServiceProvider.NewMergeRequestAPI(project, workspace.GitLab.DefaultBranch, "")
+	handle := proposalService.NewProposalHandle(strconv.Itoa(ev.MergeRequest.IID))
+
+	if command.Name != application.CommandRefine {
+		commandUsecase := application.NewProposalCommandUsecase(c.chatModel, conversationService, fileRepository, proposalService)
+		switch command.Name {
+		case application.CommandRetitle:
+			err = commandUsecase.Retitle(ctx, handle, command.Args)
+		case application.CommandSources:
+			err = commandUsecase.ListSources(ctx, handle)
+		}
+		if err != nil {
+			c.logger.Error("Command failed", zap.String("command", string(command.Name)), zap.Error(err))
+			conversationService.Reply(fmt.Sprintf("`%s %s` failed.", application.CommandPrefix, command.Name), false)
+			return
+		}
+		c.logger.Info(
+			"Command processed",
+			zap.String("merge_request", mergeRequestPath),
+			zap.String("command", string(command.Name)),
+		)
+		return
+	}
+
+	// Restrict find_source to the sources allowed by the workspace policy
+	sourcePolicy, err := newGitLabSourcePolicy(ctx, workspace, c.gitlabServiceProvider, c.slackServiceProvider)
+	if err != nil {
+		c.logger.Error("Failed to build source policy", zap.Error(err))
+		return
+	}
+
+	options := []application.NewProposalRefineUsecaseOption{
+		application.WithProposalRefineSourcePolicy(sourcePolicy),
+	}
+	if workspace.GitLab.VertexAICorpusID > 0 {
+		options = append(options, application.WithProposalRefineRAGCorpus(c.ragServices.GetCorpus(workspace, workspace.GitLab.VertexAICorpusID)))
+	}
+
+	workflow := application.NewProposalRefineUsecase(
+		c.chatModel,
+		conversationService,
+		fileQueryService,
+		fileRepository,
+		[]port.SourceRepository{c.slackServiceProvider.NewSourceRepository()},
+		proposalService,
+		c.gitlabServiceProvider.NewResponseFormatter(),
+		options...,
+	)
+
+	if err := workflow.Refine(handle, command.Args); err != nil {
+		c.logger.Error("Refinement failed", zap.Error(err))
+		return
+	}
+
+	c.logger.Info(
+		"Note processed and refinement applied",
+		zap.String("merge_request", mergeRequestPath),
+	)
+}
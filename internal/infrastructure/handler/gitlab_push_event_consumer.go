@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application"
+	"docgent/internal/infrastructure/gitlab"
+)
+
+type GitLabPushEventConsumerParams struct {
+	fx.In
+
+	Logger                   *zap.Logger
+	GitLabServiceProvider    *gitlab.ServiceProvider
+	RAGServices              RAGServices
+	ApplicationConfigService ApplicationConfigService
+}
+
+type GitLabPushEventConsumer struct {
+	logger                   *zap.Logger
+	gitlabServiceProvider    *gitlab.ServiceProvider
+	ragServices              RAGServices
+	applicationConfigService ApplicationConfigService
+}
+
+func NewGitLabPushEventConsumer(params GitLabPushEventConsumerParams) *GitLabPushEventConsumer {
+	return &GitLabPushEventConsumer{
+		logger:                   params.Logger,
+		gitlabServiceProvider:    params.GitLabServiceProvider,
+		ragServices:              params.RAGServices,
+		applicationConfigService: params.ApplicationConfigService,
+	}
+}
+
+func (c *GitLabPushEventConsumer) EventType() string {
+	return "push"
+}
+
+func (c *GitLabPushEventConsumer) ConsumeEvent(event interface{}) {
+	ev, ok := event.(*gitlab.PushEvent)
+	if !ok {
+		c.logger.Error("Failed to convert event data to PushEvent")
+		return
+	}
+
+	project := ev.Project.PathWithNamespace
+	workspace, err := c.applicationConfigService.GetWorkspaceByGitLabProject(project)
+	if err != nil {
+		c.logger.Error("Failed to get workspace", zap.String("project", project), zap.Error(err))
+		return
+	}
+
+	if workspace.GitLab.VertexAICorpusID <= 0 {
+		c.logger.Info("Skipping push to project without RAG corpus", zap.String("project", project))
+		return
+	}
+
+	if ev.Ref != "refs/heads/"+workspace.GitLab.DefaultBranch {
+		c.logger.Info("Skipping non-default branch push", zap.String("ref", ev.Ref))
+		return
+	}
+
+	commits := make([]pushedCommit, 0, len(ev.Commits))
+	for _, commit := range ev.Commits {
+		commits = append(commits, pushedCommit{
+			timestamp: commit.Timestamp,
+			added:     commit.Added,
+			modified:  commit.Modified,
+			removed:   commit.Removed,
+		})
+	}
+	newFiles, modifiedFiles, deletedFiles := classifyPushedFiles(commits)
+
+	fileQueryService := c.gitlabServiceProvider.NewFileQueryService(project, workspace.GitLab.DefaultBranch)
+	ragCorpus := c.ragServices.GetCorpus(workspace, workspace.GitLab.VertexAICorpusID)
+	ragFileSyncUsecase := application.NewRagFileSyncUsecase(ragCorpus, fileQueryService)
+
+	c.logger.Info("Syncing RAG files...", zap.Strings("newFiles", newFiles), zap.Strings("modifiedFiles", modifiedFiles), zap.Strings("deletedFiles", deletedFiles))
+
+	if err := ragFileSyncUsecase.Execute(newFiles, modifiedFiles, deletedFiles); err != nil {
+		c.logger.Error("Failed to sync RAG files", zap.Error(err))
+		return
+	}
+
+	c.logger.Info("Synced RAG files", zap.Strings("newFiles", newFiles), zap.Strings("modifiedFiles", modifiedFiles), zap.Strings("deletedFiles", deletedFiles))
+}
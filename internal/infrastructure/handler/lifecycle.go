@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// runUntilStopped runs the connection in the background from the start of the application until it stops.
+// Errors other than the cancellation on stop are logged with the name of the connection.
+func runUntilStopped(lc fx.Lifecycle, log *zap.Logger, name string, run func(ctx context.Context) error) {
+	var cancel context.CancelFunc
+	done := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			// The hook's context expires when the application has started, so the connection has its own context
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			go func() {
+				defer close(done)
+				if err := run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+					log.Error(name+" connection closed", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
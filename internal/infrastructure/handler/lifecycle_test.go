@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+)
+
+func TestRunUntilStopped(t *testing.T) {
+	lc := fxtest.NewLifecycle(t)
+	started := make(chan struct{})
+	stopped := false
+	runUntilStopped(lc, zap.NewNop(), "Test", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		stopped = true
+		return ctx.Err()
+	})
+
+	lc.RequireStart()
+	<-started
+	lc.RequireStop()
+
+	// 停止時には接続の終了を待つ
+	assert.True(t, stopped)
+}
@@ -0,0 +1,8 @@
+package handler
+
+import "docgent/internal/infrastructure/mattermost"
+
+type MattermostEventRoute interface {
+	ConsumeEvent(event mattermost.Event, workspace Workspace)
+	EventType() string
+}
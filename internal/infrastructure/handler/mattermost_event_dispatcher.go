@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"go.uber.org/zap"
+
+	"docgent/internal/infrastructure/mattermost"
+)
+
+// mattermostEventDispatcher passes Mattermost events to the MattermostEventRoute consumers,
+// whether they are received by the outgoing webhook or over the WebSocket
+type mattermostEventDispatcher struct {
+	log                      *zap.Logger
+	eventRoutes              []MattermostEventRoute
+	applicationConfigService ApplicationConfigService
+}
+
+func newMattermostEventDispatcher(log *zap.Logger, eventRoutes []MattermostEventRoute, applicationConfigService ApplicationConfigService) *mattermostEventDispatcher {
+	return &mattermostEventDispatcher{
+		log:                      log,
+		eventRoutes:              eventRoutes,
+		applicationConfigService: applicationConfigService,
+	}
+}
+
+// dispatch consumes the event of the workspace in the background
+func (d *mattermostEventDispatcher) dispatch(event mattermost.Event, workspace Workspace) {
+	for _, route := range d.eventRoutes {
+		if route.EventType() == event.Type {
+			go route.ConsumeEvent(event, workspace)
+			break
+		}
+	}
+}
+
+// workspace returns the workspace of the team, or ErrWorkspaceNotFound if the team is unknown
+func (d *mattermostEventDispatcher) workspace(teamID string) (Workspace, error) {
+	workspace, err := d.applicationConfigService.GetWorkspaceByMattermostTeamID(teamID)
+	if err != nil {
+		if err == ErrWorkspaceNotFound {
+			d.log.Debug("Unknown Mattermost team ID", zap.String("mattermost_team_id", teamID))
+			return Workspace{}, err
+		}
+		d.log.Error("Failed to get workspace", zap.Error(err))
+		return Workspace{}, err
+	}
+	return workspace, nil
+}
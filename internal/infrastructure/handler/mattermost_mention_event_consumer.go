@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/mattermost"
+	"docgent/internal/infrastructure/slack"
+)
+
+type MattermostMentionEventConsumerParams struct {
+	fx.In
+
+	Logger                    *zap.Logger
+	ChatModel                 domain.ChatModel
+	RAGServices               RAGServices
+	MattermostServiceProvider *mattermost.ServiceProvider
+	SlackServiceProvider      *slack.ServiceProvider
+	GitHubServiceProvider     *github.ServiceProvider
+}
+
+// MattermostMentionEventConsumer answers the posts that mention Docgent on Mattermost, like SlackMentionEventConsumer
+type MattermostMentionEventConsumer struct {
+	log                       *zap.Logger
+	chatModel                 domain.ChatModel
+	ragServices               RAGServices
+	mattermostServiceProvider *mattermost.ServiceProvider
+	slackServiceProvider      *slack.ServiceProvider
+	githubServiceProvider     *github.ServiceProvider
+}
+
+func NewMattermostMentionEventConsumer(params MattermostMentionEventConsumerParams) *MattermostMentionEventConsumer {
+	return &MattermostMentionEventConsumer{
+		log:                       params.Logger,
+		chatModel:                 params.ChatModel,
+		ragServices:               params.RAGServices,
+		mattermostServiceProvider: params.MattermostServiceProvider,
+		slackServiceProvider:      params.SlackServiceProvider,
+		githubServiceProvider:     params.GitHubServiceProvider,
+	}
+}
+
+func (c *MattermostMentionEventConsumer) EventType() string {
+	return mattermost.EventTypePosted
+}
+
+func (c *MattermostMentionEventConsumer) ConsumeEvent(event mattermost.Event, workspace Workspace) {
+	post := event.Post
+	if post == nil {
+		c.log.Error("posted event has no post")
+		return
+	}
+
+	ctx := context.Background()
+
+	// WebSocketではすべての投稿が届くので、メンションされたものだけを処理する
+	currentUser, err := c.mattermostServiceProvider.CurrentUser(ctx)
+	if err != nil {
+		c.log.Error("Failed to get Mattermost user", zap.Error(err))
+		return
+	}
+	// Docgent自身の投稿には応答しない
+	if post.UserID == currentUser.ID || !event.MentionsUser(currentUser.ID) {
+		return
+	}
+
+	// 会話サービスを初期化
+	ref, err := c.mattermostServiceProvider.ResolveConversationRef(ctx, event.TeamID, post.ID)
+	if err != nil {
+		c.log.Error("Failed to resolve conversation", zap.String("post", post.ID), zap.Error(err))
+		return
+	}
+	conversationService := c.mattermostServiceProvider.NewConversationService(ref, post.UserID)
+
+	// ワークスペースのポリシーで許可されていない会話には応答しない
+	sourcePolicy, err := newSourcePolicy(ctx, workspace, c.githubServiceProvider, c.slackServiceProvider, c.mattermostServiceProvider.NewSourceLocator())
+	if err != nil {
+		c.log.Error("Failed to build source policy", zap.Error(err))
+		conversationService.Reply(":warning: エラー: ポリシーの確認に失敗しました", false)
+		return
+	}
+	if err := sourcePolicy.Check(ctx, ref.ToURI()); err != nil {
+		c.log.Info("Conversation is not allowed by source policy", zap.String("channel", ref.ChannelID()), zap.Error(err))
+		conversationService.Reply(sourcePolicyRefusalMessage, false)
+		return
+	}
+
+	options := []application.NewConversationUsecaseOption{
+		application.WithConversationSourcePolicy(sourcePolicy),
+	}
+	// Search the RAG corpora of all repositories in the workspace
+	if ragCorpus := newWorkspaceRAGCorpus(c.ragServices, workspace); ragCorpus != nil {
+		options = append(options, application.WithConversationRAGCorpus(ragCorpus))
+	}
+
+	sourceRepositories := []port.SourceRepository{
+		c.mattermostServiceProvider.NewSourceRepository(),
+		c.slackServiceProvider.NewSourceRepository(),
+		c.githubServiceProvider.NewSourceRepository(workspace.GitHubInstallationID),
+	}
+	fileQueryService := c.githubServiceProvider.NewFileQueryService(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, workspace.GitHubDefaultBranch)
+
+	conversationUsecase := application.NewConversationUsecase(
+		c.chatModel,
+		conversationService,
+		fileQueryService,
+		sourceRepositories,
+		c.mattermostServiceProvider.NewResponseFormatter(),
+		options...,
+	)
+
+	if err := conversationUsecase.Execute(ctx); err != nil {
+		c.log.Error("Failed to execute conversation usecase", zap.Error(err))
+		conversationService.Reply(":warning: エラー: 会話の処理に失敗しました", false)
+		return
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application/redaction"
+	"docgent/internal/domain"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/mattermost"
+	"docgent/internal/infrastructure/slack"
+)
+
+type MattermostReactionEventConsumerParams struct {
+	fx.In
+
+	Logger                    *zap.Logger
+	GitHubServiceProvider     *github.ServiceProvider
+	SlackServiceProvider      *slack.ServiceProvider
+	MattermostServiceProvider *mattermost.ServiceProvider
+	ChatModel                 domain.ChatModel
+	RAGServices               RAGServices
+	Redactor                  *redaction.Redactor
+}
+
+// MattermostReactionEventConsumer documents the thread of a post when the reaction of the workspace is added to it,
+// like the doc_it reaction on Slack
+type MattermostReactionEventConsumer struct {
+	logger                    *zap.Logger
+	githubServiceProvider     *github.ServiceProvider
+	slackServiceProvider      *slack.ServiceProvider
+	mattermostServiceProvider *mattermost.ServiceProvider
+	chatModel                 domain.ChatModel
+	ragServices               RAGServices
+	redactor                  *redaction.Redactor
+}
+
+func NewMattermostReactionEventConsumer(params MattermostReactionEventConsumerParams) *MattermostReactionEventConsumer {
+	return &MattermostReactionEventConsumer{
+		logger:                    params.Logger,
+		githubServiceProvider:     params.GitHubServiceProvider,
+		slackServiceProvider:      params.SlackServiceProvider,
+		mattermostServiceProvider: params.MattermostServiceProvider,
+		chatModel:                 params.ChatModel,
+		ragServices:               params.RAGServices,
+		redactor:                  params.Redactor,
+	}
+}
+
+func (c *MattermostReactionEventConsumer) EventType() string {
+	return mattermost.EventTypeReactionAdded
+}
+
+func (c *MattermostReactionEventConsumer) ConsumeEvent(event mattermost.Event, workspace Workspace) {
+	reaction := event.Reaction
+	if reaction == nil {
+		c.logger.Error("reaction_added event has no reaction")
+		return
+	}
+
+	if reaction.EmojiName != workspace.Mattermost.DocReaction() {
+		c.logger.Debug("Reaction is not the doc reaction", zap.String("reaction", reaction.EmojiName))
+		return
+	}
+
+	ctx := context.Background()
+
+	// スレッド内の投稿にリアクションされた場合も、スレッド全体を1つの会話として扱う
+	ref, err := c.mattermostServiceProvider.ResolveConversationRef(ctx, event.TeamID, reaction.PostID)
+	if err != nil {
+		c.logger.Error("Failed to resolve conversation", zap.String("post", reaction.PostID), zap.Error(err))
+		return
+	}
+	conversationService := c.mattermostServiceProvider.NewConversationService(ref, reaction.UserID)
+
+	generator := &proposalGenerator{
+		logger:                    c.logger,
+		chatModel:                 c.chatModel,
+		ragServices:               c.ragServices,
+		githubServiceProvider:     c.githubServiceProvider,
+		slackServiceProvider:      c.slackServiceProvider,
+		mattermostServiceProvider: c.mattermostServiceProvider,
+		redactor:                  c.redactor,
+	}
+	proposal, err := generator.generate(ctx, workspace, conversationService, c.mattermostServiceProvider.NewResponseFormatter())
+	if err != nil {
+		c.logger.Error("Failed to generate proposal", zap.String("post", reaction.PostID), zap.Error(err))
+		return
+	}
+
+	// 新しいメッセージがなく提案を更新しなかったことは返信済み
+	if proposal.unchanged {
+		return
+	}
+
+	if err := conversationService.Reply(proposal.message(), false); err != nil {
+		c.logger.Warn("Failed to reply proposal", zap.Error(err))
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/infrastructure/mattermost"
+)
+
+type MattermostWebhookHandlerParams struct {
+	fx.In
+
+	Logger                   *zap.Logger
+	EventRoutes              []MattermostEventRoute `group:"mattermost_event_routes"`
+	ApplicationConfigService ApplicationConfigService
+}
+
+// MattermostWebhookHandler receives the outgoing webhooks of Mattermost teams, which are sent for posts starting with the trigger word.
+// It is an alternative to the WebSocket for servers that Docgent cannot keep a connection to.
+type MattermostWebhookHandler struct {
+	log        *zap.Logger
+	dispatcher *mattermostEventDispatcher
+}
+
+func NewMattermostWebhookHandler(params MattermostWebhookHandlerParams) *MattermostWebhookHandler {
+	return &MattermostWebhookHandler{
+		log:        params.Logger,
+		dispatcher: newMattermostEventDispatcher(params.Logger, params.EventRoutes, params.ApplicationConfigService),
+	}
+}
+
+func (h *MattermostWebhookHandler) Pattern() string {
+	return "/api/mattermost/webhook"
+}
+
+func (h *MattermostWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	webhook, err := mattermost.ParseOutgoingWebhook(r)
+	if err != nil {
+		h.log.Warn("Failed to parse request", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	workspace, err := h.dispatcher.workspace(webhook.TeamID)
+	if err != nil {
+		if err == ErrWorkspaceNotFound {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// トークンはWebhookごとに発行されるので、チームの設定と照合する
+	if !webhook.VerifyToken(workspace.Mattermost.OutgoingWebhookToken) {
+		h.log.Warn("Invalid Mattermost outgoing webhook token", zap.String("mattermost_team_id", webhook.TeamID))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	h.dispatcher.dispatch(webhook.Event(), workspace)
+
+	// 返信はAPIで投稿するので、Webhookの応答では何も投稿しない
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte("{}"))
+}
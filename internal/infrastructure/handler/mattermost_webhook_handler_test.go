@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"docgent/internal/infrastructure/mattermost"
+)
+
+func (s *fakeApplicationConfigService) GetWorkspaceByMattermostTeamID(teamID string) (Workspace, error) {
+	for _, workspace := range s.workspaces {
+		if workspace.Mattermost.TeamID == teamID {
+			return workspace, nil
+		}
+	}
+	return Workspace{}, ErrWorkspaceNotFound
+}
+
+type fakeMattermostEventRoute struct {
+	events chan mattermost.Event
+}
+
+func (r *fakeMattermostEventRoute) EventType() string {
+	return mattermost.EventTypePosted
+}
+
+func (r *fakeMattermostEventRoute) ConsumeEvent(event mattermost.Event, workspace Workspace) {
+	r.events <- event
+}
+
+func TestMattermostWebhookHandler_ServeHTTP(t *testing.T) {
+	workspace := Workspace{Mattermost: MattermostTeamConfig{TeamID: "team1", OutgoingWebhookToken: "secret"}, GitHubRepo: "docs"}
+
+	tests := []struct {
+		name         string
+		body         string
+		wantStatus   int
+		wantConsumed bool
+	}{
+		{
+			name:         "トークンが正しいWebhookを投稿のイベントとして渡す",
+			body:         "token=secret&team_id=team1&channel_id=channel1&post_id=post1&user_id=alice&text=docgent+hello",
+			wantStatus:   http.StatusOK,
+			wantConsumed: true,
+		},
+		{
+			name:       "トークンが違うWebhookは拒否する",
+			body:       "token=wrong&team_id=team1&channel_id=channel1&post_id=post1&user_id=alice&text=docgent+hello",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "未知のチームは拒否する",
+			body:       "token=secret&team_id=team9&channel_id=channel1&post_id=post1&user_id=alice&text=docgent+hello",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := &fakeMattermostEventRoute{events: make(chan mattermost.Event, 1)}
+			handler := NewMattermostWebhookHandler(MattermostWebhookHandlerParams{
+				Logger:                   zap.NewNop(),
+				EventRoutes:              []MattermostEventRoute{route},
+				ApplicationConfigService: &fakeApplicationConfigService{workspaces: []Workspace{workspace}},
+			})
+			r := httptest.NewRequest(http.MethodPost, handler.Pattern(), strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			select {
+			case event := <-route.events:
+				assert.True(t, tt.wantConsumed, "コンシューマーが呼ばれるべきではありません")
+				assert.Equal(t, "post1", event.Post.ID)
+				assert.True(t, event.Triggered)
+			case <-time.After(100 * time.Millisecond):
+				assert.False(t, tt.wantConsumed, "コンシューマーが呼ばれていません")
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/infrastructure/mattermost"
+)
+
+type MattermostWebSocketListenerParams struct {
+	fx.In
+
+	Lifecycle                fx.Lifecycle
+	Logger                   *zap.Logger
+	EventRoutes              []MattermostEventRoute `group:"mattermost_event_routes"`
+	MattermostAPI            *mattermost.API
+	ApplicationConfigService ApplicationConfigService
+}
+
+// MattermostWebSocketListener receives the posts and reactions of Mattermost teams over the WebSocket API
+// and passes them to the MattermostEventRoute consumers of the workspace of the team.
+type MattermostWebSocketListener struct {
+	log           *zap.Logger
+	mattermostAPI *mattermost.API
+	dispatcher    *mattermostEventDispatcher
+}
+
+// NewMattermostWebSocketListener creates a listener which connects to Mattermost when the application starts
+func NewMattermostWebSocketListener(params MattermostWebSocketListenerParams) *MattermostWebSocketListener {
+	l := &MattermostWebSocketListener{
+		log:           params.Logger,
+		mattermostAPI: params.MattermostAPI,
+		dispatcher:    newMattermostEventDispatcher(params.Logger, params.EventRoutes, params.ApplicationConfigService),
+	}
+	if !l.mattermostAPI.Enabled() {
+		l.log.Info("Mattermost is disabled because MATTERMOST_URL or MATTERMOST_TOKEN is not set")
+		return l
+	}
+	runUntilStopped(params.Lifecycle, l.log, "Mattermost WebSocket", func(ctx context.Context) error {
+		return l.mattermostAPI.NewWebSocketClient().Run(ctx, l.log, l.dispatch)
+	})
+	return l
+}
+
+// dispatch consumes the event in the background so that the connection keeps reading events
+func (l *MattermostWebSocketListener) dispatch(event mattermost.Event) {
+	// DMはチームに紐づかないので、ワークスペースを決められない
+	if event.TeamID == "" {
+		return
+	}
+	workspace, err := l.dispatcher.workspace(event.TeamID)
+	if err != nil {
+		return
+	}
+	l.dispatcher.dispatch(event, workspace)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"docgent/internal/application"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+	"docgent/internal/infrastructure/slack"
+)
+
+// proposalCardValue identifies the proposal of a card. It is the value of the buttons and the private metadata of the modal
+type proposalCardValue struct {
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+	// Conversation is the URI of the Slack thread that the proposal was generated from
+	Conversation string `json:"conversation"`
+}
+
+func (v proposalCardValue) encode() string {
+	// エンコードできない値は含まれない
+	value, _ := json.Marshal(v)
+	return string(value)
+}
+
+func parseProposalCardValue(value string) (proposalCardValue, error) {
+	var v proposalCardValue
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return proposalCardValue{}, fmt.Errorf("failed to parse proposal card value: %w", err)
+	}
+	if v.Owner == "" || v.Repo == "" || v.Number == 0 {
+		return proposalCardValue{}, fmt.Errorf("proposal card value is incomplete: %s", value)
+	}
+	return v, nil
+}
+
+func (v proposalCardValue) handle() domain.ProposalHandle {
+	return domain.NewProposalHandle("github-pull-request", strconv.Itoa(v.Number))
+}
+
+// replyProposal posts the card of the proposal to the thread.
+// If the card cannot be built, the link to the proposal is replied instead.
+func (g *proposalGenerator) replyProposal(ctx context.Context, workspace Workspace, ref *slack.ConversationRef, proposal generatedProposal) {
+	card, err := g.proposalCard(ctx, workspace, ref.ToURI(), proposal)
+	if err == nil {
+		err = g.slackServiceProvider.PostProposalCard(ctx, ref, card)
+	}
+	if err != nil {
+		g.logger.Warn("Failed to post proposal card", zap.Error(err))
+		g.slackServiceProvider.NewConversationService(ref, "").Reply(proposal.message(), false)
+	}
+}
+
+// proposalCard builds the card of the proposal from its title, changed files and their sources
+func (g *proposalGenerator) proposalCard(ctx context.Context, workspace Workspace, conversationURI *data.URI, proposal generatedProposal) (slack.ProposalCard, error) {
+	number, err := strconv.Atoi(proposal.handle.Value)
+	if err != nil {
+		return slack.ProposalCard{}, fmt.Errorf("failed to parse proposal handle to pull request number: %w", err)
+	}
+
+	headBranch, err := g.githubServiceProvider.GetPullRequestHeadBranch(ctx, workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, number)
+	if err != nil {
+		return slack.ProposalCard{}, fmt.Errorf("failed to get pull request head branch: %w", err)
+	}
+
+	summary, err := application.NewProposalSummaryUsecase(
+		g.githubServiceProvider.NewPullRequestAPI(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, workspace.GitHubDefaultBranch, ""),
+		g.githubServiceProvider.NewFileRepository(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, headBranch),
+	).Execute(ctx, proposal.handle)
+	if err != nil {
+		return slack.ProposalCard{}, fmt.Errorf("failed to summarize proposal: %w", err)
+	}
+
+	card := slack.ProposalCard{
+		Title:   summary.Title,
+		URL:     proposal.url,
+		Updated: proposal.updated,
+		ActionValue: proposalCardValue{
+			Owner:        workspace.GitHubOwner,
+			Repo:         workspace.GitHubRepo,
+			Number:       number,
+			Conversation: conversationURI.Value(),
+		}.encode(),
+	}
+	for _, file := range summary.Files {
+		card.Files = append(card.Files, slack.ProposalCardFile{
+			Path:      file.Path,
+			OldPath:   file.OldPath,
+			Status:    string(file.Status),
+			Additions: file.Additions,
+			Deletions: file.Deletions,
+		})
+	}
+	for _, source := range summary.Sources {
+		card.Sources = append(card.Sources, source.Value())
+	}
+	return card, nil
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProposalCardValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    proposalCardValue
+		wantErr bool
+	}{
+		{
+			name:  "エンコードした値を復元できる",
+			value: proposalCardValue{Owner: "owner", Repo: "repo", Number: 1, Conversation: "https://app.slack.com/client/T1/C1/1.1"}.encode(),
+			want:  proposalCardValue{Owner: "owner", Repo: "repo", Number: 1, Conversation: "https://app.slack.com/client/T1/C1/1.1"},
+		},
+		{
+			name:    "PR番号がない場合はエラー",
+			value:   `{"owner":"owner","repo":"repo"}`,
+			wantErr: true,
+		},
+		{
+			name:    "JSONでない場合はエラー",
+			value:   "owner/repo#1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProposalCardValue(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, "1", got.handle().Value)
+		})
+	}
+}
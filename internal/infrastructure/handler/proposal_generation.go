@@ -0,0 +1,446 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/application/redaction"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+	"docgent/internal/infrastructure/discord"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/mattermost"
+	"docgent/internal/infrastructure/slack"
+)
+
+// proposalGenerator generates a proposal in the workspace's repository from a conversation.
+// It is shared by the consumers that start ProposalGenerateUsecase.
+type proposalGenerator struct {
+	logger                *zap.Logger
+	chatModel             domain.ChatModel
+	ragServices           RAGServices
+	githubServiceProvider *github.ServiceProvider
+	slackServiceProvider  *slack.ServiceProvider
+	// discordServiceProvider is set when the conversation is on Discord
+	discordServiceProvider *discord.ServiceProvider
+	// mattermostServiceProvider is set when the conversation is on Mattermost
+	mattermostServiceProvider *mattermost.ServiceProvider
+	redactor                  *redaction.Redactor
+}
+
+// generatedProposal is the result of proposalGenerator.generate
+type generatedProposal struct {
+	handle domain.ProposalHandle
+	// updated is true if the open proposal of the conversation was refined instead of creating a new one
+	updated bool
+	// unchanged is true if the open proposal was left as is because the conversation had no new messages.
+	// The conversation has already been told so, so callers should not reply the proposal again
+	unchanged bool
+	url       string
+}
+
+// message is the reply to the conversation that links to the proposal
+func (p generatedProposal) message() string {
+	if p.unchanged {
+		return "No new messages to reflect in PR: " + p.url
+	}
+	if p.updated {
+		return "Updated PR: " + p.url
+	}
+	return "PR: " + p.url
+}
+
+// generate creates a branch and a proposal, and links the proposal to the conversation.
+// The repository is chosen by the routing rules of the workspace.
+// If the conversation already has an open proposal, it refines that proposal with the new messages instead,
+// so that a conversation never has more than one open proposal.
+// Errors are replied to the conversation, so callers only need to log them.
+func (g *proposalGenerator) generate(ctx context.Context, workspace Workspace, conversationService port.ConversationService, responseFormatter port.ResponseFormatter) (generatedProposal, error) {
+	conversationURI := conversationService.URI()
+
+	workspace, link, err := g.findOpenProposal(ctx, workspace, conversationURI)
+	if err != nil {
+		conversationService.Reply(":warning: エラー: 既存のPRの確認に失敗しました", true)
+		return generatedProposal{}, err
+	}
+	if link == nil {
+		workspace, err = g.route(ctx, workspace, conversationService)
+		if err != nil {
+			conversationService.Reply(":warning: エラー: 作成先のリポジトリの決定に失敗しました", true)
+			return generatedProposal{}, err
+		}
+	}
+
+	// ワークスペースのポリシーで許可されていない会話はドキュメント化しない
+	sourcePolicy, err := g.sourcePolicy(ctx, workspace)
+	if err != nil {
+		conversationService.Reply(":warning: エラー: ポリシーの確認に失敗しました", true)
+		return generatedProposal{}, fmt.Errorf("failed to build source policy: %w", err)
+	}
+	if err := sourcePolicy.Check(ctx, conversationURI); err != nil {
+		conversationService.Reply(sourcePolicyRefusalMessage, false)
+		return generatedProposal{}, err
+	}
+
+	if link != nil {
+		refined, err := g.refine(ctx, workspace, conversationService, responseFormatter, sourcePolicy, link.ProposalHandle, "")
+		if err != nil {
+			return generatedProposal{}, err
+		}
+		proposal := g.newGeneratedProposal(workspace, link.ProposalHandle, true)
+		proposal.unchanged = !refined
+		return proposal, nil
+	}
+
+	baseBranchName := workspace.GitHubDefaultBranch
+	newBranchName := fmt.Sprintf("docgent/%d", time.Now().Unix())
+
+	branchService := g.githubServiceProvider.NewBranchService(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo)
+	err = branchService.CreateBranch(ctx, baseBranchName, newBranchName)
+	if err != nil {
+		conversationService.Reply(":warning: エラー: ブランチの作成に失敗しました", true)
+		return generatedProposal{}, fmt.Errorf("failed to create branch: %w", err)
+	}
+
+	fileQueryService := g.githubServiceProvider.NewFileQueryService(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, newBranchName)
+	fileRepository := redaction.NewFileRepository(g.githubServiceProvider.NewFileRepository(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, newBranchName), g.redactor)
+
+	sourceRepositories := g.sourceRepositories(workspace)
+
+	githubPullRequestAPI := g.githubServiceProvider.NewPullRequestAPI(
+		workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, baseBranchName, newBranchName,
+		github.WithProposalOptions(g.proposalOptions(workspace, conversationService)),
+	)
+
+	options := []application.NewProposalGenerateUsecaseOption{
+		application.WithProposalGenerateSourcePolicy(sourcePolicy),
+	}
+	// If VertexAICorpusID is set, use RAG corpus
+	if workspace.VertexAICorpusID > 0 {
+		options = append(options, application.WithProposalGenerateRAGCorpus(g.ragServices.GetCorpus(workspace, workspace.VertexAICorpusID)))
+	}
+
+	// ドキュメントを生成
+	proposalGenerateUsecase := application.NewProposalGenerateUsecase(
+		g.chatModel,
+		conversationService,
+		fileQueryService,
+		fileRepository,
+		sourceRepositories,
+		githubPullRequestAPI,
+		responseFormatter,
+		options...,
+	)
+	proposalHandle, err := proposalGenerateUsecase.Execute(ctx)
+	if err != nil {
+		conversationService.Reply(":warning: エラー: ドキュメントの生成に失敗しました", true)
+		return generatedProposal{}, fmt.Errorf("failed to generate proposal: %w", err)
+	}
+
+	// PRの状態の変化の通知や再実行時の更新のために、会話との紐付けを保存
+	proposalLinkRepository := g.githubServiceProvider.NewProposalLinkRepository(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo)
+	if err := proposalLinkRepository.Save(ctx, port.ProposalLink{ProposalHandle: proposalHandle, ConversationURI: conversationURI}); err != nil {
+		g.logger.Warn("Failed to save proposal link", zap.Error(err))
+	}
+
+	return g.newGeneratedProposal(workspace, proposalHandle, false), nil
+}
+
+func (g *proposalGenerator) newGeneratedProposal(workspace Workspace, handle domain.ProposalHandle, updated bool) generatedProposal {
+	return generatedProposal{
+		handle:  handle,
+		updated: updated,
+		url:     fmt.Sprintf("%s/%s/%s/pull/%s", g.githubServiceProvider.WebURL(), workspace.GitHubOwner, workspace.GitHubRepo, handle.Value),
+	}
+}
+
+// findOpenProposal looks for the open proposal of the conversation in all repositories of the workspace.
+// If found, it returns the workspace scoped to the repository of the proposal.
+func (g *proposalGenerator) findOpenProposal(ctx context.Context, workspace Workspace, conversationURI *data.URI) (Workspace, *port.ProposalLink, error) {
+	for _, repository := range workspace.AllRepositories() {
+		proposalLinkRepository := g.githubServiceProvider.NewProposalLinkRepository(repository.GitHubInstallationID, repository.GitHubOwner, repository.GitHubRepo)
+		link, err := proposalLinkRepository.FindByConversation(ctx, conversationURI)
+		if errors.Is(err, port.ErrProposalLinkNotFound) {
+			continue
+		}
+		if err != nil {
+			return workspace, nil, fmt.Errorf("failed to find proposal link: %w", err)
+		}
+		if link.Open {
+			return workspace.ForRepository(repository), &link, nil
+		}
+	}
+	return workspace, nil, nil
+}
+
+// route returns the workspace scoped to the repository where the proposal of the conversation should be created.
+// Routing rules are tried first, then the model if the workspace allows it. Otherwise the default repository is used.
+func (g *proposalGenerator) route(ctx context.Context, workspace Workspace, conversationService port.ConversationService) (Workspace, error) {
+	if len(workspace.Repositories) == 0 {
+		return workspace, nil
+	}
+
+	var channelID string
+	if ref, err := slack.ParseConversationRef(conversationService.URI()); err == nil {
+		channelID = ref.ChannelID()
+	} else {
+		// Discordのスレッドは親チャンネルのルールでルーティングする
+		for _, locator := range g.chatSourceLocators() {
+			if !locator.Match(conversationService.URI()) {
+				continue
+			}
+			if location, err := locator.Locate(ctx, conversationService.URI()); err == nil {
+				channelID = location.ContainerID
+			}
+			break
+		}
+	}
+
+	var conversationText string
+	if workspace.hasKeywordRoutes() {
+		history, err := conversationService.GetHistory()
+		if err != nil {
+			return workspace, fmt.Errorf("failed to get conversation history: %w", err)
+		}
+		var text strings.Builder
+		for _, message := range history.Messages {
+			text.WriteString(message.Content)
+			text.WriteString("\n")
+		}
+		conversationText = text.String()
+	}
+
+	if repository, ok := workspace.RouteRepository(channelID, conversationText); ok {
+		return workspace.ForRepository(repository), nil
+	}
+
+	if !workspace.AgentSelectsRepository {
+		return workspace, nil
+	}
+
+	repositories := workspace.AllRepositories()
+	targets := make([]application.ProposalTarget, len(repositories))
+	for i, repository := range repositories {
+		targets[i] = application.ProposalTarget{Name: repository.Name, Description: repository.Description}
+	}
+	target, err := application.NewProposalTargetSelectUsecase(g.chatModel, conversationService).Execute(ctx, targets)
+	if err != nil {
+		// モデルが選べなかった場合はデフォルトのリポジトリに作成する
+		g.logger.Warn("Failed to select repository", zap.Error(err))
+		return workspace, nil
+	}
+	for _, repository := range repositories {
+		if repository.Name == target.Name {
+			return workspace.ForRepository(repository), nil
+		}
+	}
+	return workspace, nil
+}
+
+// refine updates the open proposal of the conversation with the feedback.
+// If the feedback is empty, the messages added to the conversation since the last reply are used instead,
+// and false is returned if there are no such messages.
+func (g *proposalGenerator) refine(ctx context.Context, workspace Workspace, conversationService port.ConversationService, responseFormatter port.ResponseFormatter, sourcePolicy *port.SourcePolicy, handle domain.ProposalHandle, feedback string) (bool, error) {
+	number, err := strconv.Atoi(handle.Value)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse proposal handle to pull request number: %w", err)
+	}
+
+	headBranch, err := g.githubServiceProvider.GetPullRequestHeadBranch(ctx, workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, number)
+	if err != nil {
+		conversationService.Reply(":warning: エラー: 既存のPRの取得に失敗しました", true)
+		return false, fmt.Errorf("failed to get pull request head branch: %w", err)
+	}
+
+	fileQueryService := g.githubServiceProvider.NewFileQueryService(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, headBranch)
+	fileRepository := redaction.NewFileRepository(g.githubServiceProvider.NewFileRepository(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, headBranch), g.redactor)
+
+	sourceRepositories := g.sourceRepositories(workspace)
+
+	githubPullRequestAPI := g.githubServiceProvider.NewPullRequestAPI(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, workspace.GitHubDefaultBranch, "")
+
+	options := []application.NewProposalRefineUsecaseOption{
+		application.WithProposalRefineSourcePolicy(sourcePolicy),
+	}
+	// If VertexAICorpusID is set, use RAG corpus
+	if workspace.VertexAICorpusID > 0 {
+		options = append(options, application.WithProposalRefineRAGCorpus(g.ragServices.GetCorpus(workspace, workspace.VertexAICorpusID)))
+	}
+
+	proposalRefineUsecase := application.NewProposalRefineUsecase(
+		g.chatModel,
+		conversationService,
+		fileQueryService,
+		fileRepository,
+		sourceRepositories,
+		githubPullRequestAPI,
+		responseFormatter,
+		options...,
+	)
+	refined := true
+	if feedback == "" {
+		refined, err = proposalRefineUsecase.RefineFromConversation(handle)
+	} else {
+		err = proposalRefineUsecase.Refine(handle, feedback)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to refine proposal: %w", err)
+	}
+
+	return refined, nil
+}
+
+// runCommand runs a proposal command sent in the conversation on the open proposal of the conversation,
+// so that chat tools accept the same commands as pull request comments.
+// Errors are replied to the conversation, so callers only need to log them.
+func (g *proposalGenerator) runCommand(ctx context.Context, workspace Workspace, conversationService port.ConversationService, responseFormatter port.ResponseFormatter, command application.Command) error {
+	switch command.Name {
+	case application.CommandIgnore:
+		return nil
+	case application.CommandRefine:
+		if command.Args == "" {
+			return conversationService.Reply(application.CommandHelpMessage(), false)
+		}
+	case application.CommandRetitle, application.CommandSplit, application.CommandSources, application.CommandSyncBase:
+	default:
+		return conversationService.Reply(application.CommandHelpMessage(), false)
+	}
+
+	workspace, link, err := g.findOpenProposal(ctx, workspace, conversationService.URI())
+	if err != nil {
+		conversationService.Reply(":warning: エラー: 既存のPRの確認に失敗しました", true)
+		return err
+	}
+	if link == nil {
+		return conversationService.Reply(fmt.Sprintf("This conversation has no open PR. Create one before running `%s %s`.", application.CommandPrefix, command.Name), false)
+	}
+
+	if command.Name == application.CommandRefine {
+		sourcePolicy, err := g.sourcePolicy(ctx, workspace)
+		if err != nil {
+			conversationService.Reply(":warning: エラー: ポリシーの確認に失敗しました", true)
+			return fmt.Errorf("failed to build source policy: %w", err)
+		}
+		if _, err := g.refine(ctx, workspace, conversationService, responseFormatter, sourcePolicy, link.ProposalHandle, command.Args); err != nil {
+			conversationService.Reply(":warning: エラー: PRの更新に失敗しました", true)
+			return err
+		}
+		return conversationService.Reply(g.newGeneratedProposal(workspace, link.ProposalHandle, true).message(), false)
+	}
+
+	number, err := strconv.Atoi(link.ProposalHandle.Value)
+	if err != nil {
+		return fmt.Errorf("failed to parse proposal handle to pull request number: %w", err)
+	}
+	headBranch, err := g.githubServiceProvider.GetPullRequestHeadBranch(ctx, workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, number)
+	if err != nil {
+		conversationService.Reply(":warning: エラー: 既存のPRの取得に失敗しました", true)
+		return fmt.Errorf("failed to get pull request head branch: %w", err)
+	}
+
+	fileRepository := redaction.NewFileRepository(g.githubServiceProvider.NewFileRepository(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, headBranch), g.redactor)
+	pullRequestAPI := g.githubServiceProvider.NewPullRequestAPI(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, workspace.GitHubDefaultBranch, "")
+	commandUsecase := application.NewProposalCommandUsecase(g.chatModel, conversationService, fileRepository, pullRequestAPI)
+	switch command.Name {
+	case application.CommandRetitle:
+		err = commandUsecase.Retitle(ctx, link.ProposalHandle, command.Args)
+	case application.CommandSplit:
+		err = commandUsecase.Split(ctx, link.ProposalHandle, newSplitProposalRepository(
+			g.githubServiceProvider, workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, workspace.GitHubDefaultBranch,
+			workspaceProposalOptions(workspace, g.logger),
+		))
+	case application.CommandSources:
+		err = commandUsecase.ListSources(ctx, link.ProposalHandle)
+	case application.CommandSyncBase:
+		synchronizer := redaction.NewBaseSynchronizer(g.githubServiceProvider.NewBaseSynchronizer(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo), g.redactor)
+		err = application.NewProposalSyncBaseUsecase(g.chatModel, conversationService, synchronizer).Execute(ctx, link.ProposalHandle)
+	}
+	if err != nil {
+		conversationService.Reply(fmt.Sprintf("`%s %s` failed.", application.CommandPrefix, command.Name), false)
+		return fmt.Errorf("failed to run command %s: %w", command.Name, err)
+	}
+	return nil
+}
+
+// sourcePolicy builds the source policy of the workspace, including Discord or Mattermost if the conversation is there
+func (g *proposalGenerator) sourcePolicy(ctx context.Context, workspace Workspace) (*port.SourcePolicy, error) {
+	return newSourcePolicy(ctx, workspace, g.githubServiceProvider, g.slackServiceProvider, g.chatSourceLocators()...)
+}
+
+// chatSourceLocators returns the locators of the chat tools other than Slack that are set
+func (g *proposalGenerator) chatSourceLocators() []port.SourceLocator {
+	var locators []port.SourceLocator
+	if g.discordServiceProvider != nil {
+		locators = append(locators, g.discordServiceProvider.NewSourceLocator())
+	}
+	if g.mattermostServiceProvider != nil {
+		locators = append(locators, g.mattermostServiceProvider.NewSourceLocator())
+	}
+	return locators
+}
+
+// sourceRepositories returns the repositories of the sources that the model can read
+func (g *proposalGenerator) sourceRepositories(workspace Workspace) []port.SourceRepository {
+	sourceRepositories := []port.SourceRepository{
+		g.slackServiceProvider.NewSourceRepository(),
+		g.githubServiceProvider.NewSourceRepository(workspace.GitHubInstallationID),
+	}
+	if g.discordServiceProvider != nil {
+		sourceRepositories = append(sourceRepositories, g.discordServiceProvider.NewSourceRepository())
+	}
+	if g.mattermostServiceProvider != nil {
+		sourceRepositories = append(sourceRepositories, g.mattermostServiceProvider.NewSourceRepository())
+	}
+	return sourceRepositories
+}
+
+// proposalOptions returns the pull request options of a new proposal from the conversation
+func (g *proposalGenerator) proposalOptions(workspace Workspace, conversationService port.ConversationService) github.ProposalOptions {
+	options := workspaceProposalOptions(workspace, g.logger)
+	if workspace.Proposal.DisableAutoReviewers {
+		return options
+	}
+
+	history, err := conversationService.GetHistory()
+	if err != nil {
+		g.logger.Warn("Failed to get conversation history for reviewers", zap.Error(err))
+		return options
+	}
+	options.Reviewers = workspace.participantGitHubUsers(conversationService.URI(), history)
+	return options
+}
+
+// newSplitProposalRepository returns the repositories for ProposalCommandUsecase.Split.
+// Each split proposal is opened as a pull request from a new branch created from the base branch.
+func newSplitProposalRepository(githubServiceProvider *github.ServiceProvider, installationID int64, owner, repo, baseBranch string, options github.ProposalOptions) func(ctx context.Context, i int) (domain.ProposalRepository, error) {
+	branchName := fmt.Sprintf("docgent/%d", time.Now().Unix())
+	return func(ctx context.Context, i int) (domain.ProposalRepository, error) {
+		newBranchName := fmt.Sprintf("%s-%d", branchName, i+1)
+		branchService := githubServiceProvider.NewBranchService(installationID, owner, repo)
+		if err := branchService.CreateBranch(ctx, baseBranch, newBranchName); err != nil {
+			return nil, fmt.Errorf("failed to create branch: %w", err)
+		}
+		return githubServiceProvider.NewPullRequestAPI(installationID, owner, repo, baseBranch, newBranchName, github.WithProposalOptions(options)), nil
+	}
+}
+
+// workspaceProposalOptions returns the pull request options set in the workspace.
+// Reviews are requested only from the owners of the changed files, since the participants depend on the conversation
+func workspaceProposalOptions(workspace Workspace, logger *zap.Logger) github.ProposalOptions {
+	return github.ProposalOptions{
+		Draft:               workspace.Proposal.Draft,
+		Labels:              workspace.Proposal.Labels,
+		RequestOwnerReviews: !workspace.Proposal.DisableAutoReviewers,
+		OnWarning: func(err error) {
+			logger.Warn("Failed to set up proposal", zap.Error(err))
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"go.uber.org/fx"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+)
+
+const (
+	// RAGBackendVertexAI searches corpora of Vertex AI RAG Engine. It is the default
+	RAGBackendVertexAI = "vertexai"
+	// RAGBackendLocal searches corpora embedded on the local disk of the server
+	RAGBackendLocal = "local"
+)
+
+// RAGServices are the backends of RAG corpora. Each workspace selects one with RAGBackend
+type RAGServices struct {
+	fx.In
+
+	VertexAI port.RAGService
+	Local    port.RAGService `name:"local_rag_service"`
+}
+
+// GetCorpus returns the corpus of the ID in the backend of the workspace
+func (s RAGServices) GetCorpus(workspace Workspace, corpusID int64) port.RAGCorpus {
+	if workspace.RAGBackend == RAGBackendLocal {
+		return s.Local.GetCorpus(corpusID)
+	}
+	return s.VertexAI.GetCorpus(corpusID)
+}
+
+// newWorkspaceRAGCorpus returns a corpus that searches the corpora of all repositories in the workspace.
+// It returns nil if no repository has a corpus.
+func newWorkspaceRAGCorpus(ragServices RAGServices, workspace Workspace) port.RAGCorpus {
+	var corpora []port.RAGCorpus
+	seen := make(map[int64]bool)
+	for _, repository := range workspace.AllRepositories() {
+		if repository.VertexAICorpusID <= 0 || seen[repository.VertexAICorpusID] {
+			continue
+		}
+		seen[repository.VertexAICorpusID] = true
+		corpora = append(corpora, ragServices.GetCorpus(workspace, repository.VertexAICorpusID))
+	}
+
+	switch len(corpora) {
+	case 0:
+		return nil
+	case 1:
+		return corpora[0]
+	default:
+		return application.NewMultiRAGCorpus(corpora...)
+	}
+}
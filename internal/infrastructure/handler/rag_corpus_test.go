@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+)
+
+type fakeRAGService struct {
+	name string
+}
+
+type fakeRAGCorpus struct {
+	port.RAGCorpus
+	service string
+	id      int64
+}
+
+func (s fakeRAGService) GetCorpus(corpusID int64) port.RAGCorpus {
+	return fakeRAGCorpus{service: s.name, id: corpusID}
+}
+
+func TestRAGServices_GetCorpus(t *testing.T) {
+	ragServices := RAGServices{VertexAI: fakeRAGService{name: "vertexai"}, Local: fakeRAGService{name: "local"}}
+
+	tests := []struct {
+		name       string
+		ragBackend string
+		want       port.RAGCorpus
+	}{
+		{name: "未設定の場合はVertex AI", ragBackend: "", want: fakeRAGCorpus{service: "vertexai", id: 1}},
+		{name: "Vertex AI", ragBackend: RAGBackendVertexAI, want: fakeRAGCorpus{service: "vertexai", id: 1}},
+		{name: "ローカル", ragBackend: RAGBackendLocal, want: fakeRAGCorpus{service: "local", id: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ragServices.GetCorpus(Workspace{RAGBackend: tt.ragBackend}, 1))
+		})
+	}
+}
+
+func TestNewWorkspaceRAGCorpus(t *testing.T) {
+	ragServices := RAGServices{VertexAI: fakeRAGService{name: "vertexai"}, Local: fakeRAGService{name: "local"}}
+
+	assert.Nil(t, newWorkspaceRAGCorpus(ragServices, Workspace{}))
+	assert.Equal(t,
+		fakeRAGCorpus{service: "local", id: 1},
+		newWorkspaceRAGCorpus(ragServices, Workspace{VertexAICorpusID: 1, RAGBackend: RAGBackendLocal}),
+	)
+	assert.Equal(t,
+		application.NewMultiRAGCorpus(fakeRAGCorpus{service: "local", id: 1}, fakeRAGCorpus{service: "local", id: 2}),
+		newWorkspaceRAGCorpus(ragServices, Workspace{
+			VertexAICorpusID: 1,
+			RAGBackend:       RAGBackendLocal,
+			Repositories:     []DocsRepository{{VertexAICorpusID: 2}, {VertexAICorpusID: 1}},
+		}),
+	)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/slack-go/slack/slackevents"
@@ -21,18 +22,16 @@ type SlackEventHandlerParams struct {
 }
 
 type SlackEventHandler struct {
-	log                      *zap.Logger
-	eventRoutes              []SlackEventRoute
-	slackAPI                 *slack.API
-	applicationConfigService ApplicationConfigService
+	log        *zap.Logger
+	slackAPI   *slack.API
+	dispatcher *slackEventDispatcher
 }
 
 func NewSlackEventHandler(params SlackEventHandlerParams) *SlackEventHandler {
 	return &SlackEventHandler{
-		log:                      params.Logger,
-		eventRoutes:              params.EventRoutes,
-		slackAPI:                 params.SlackAPI,
-		applicationConfigService: params.ApplicationConfigService,
+		log:        params.Logger,
+		slackAPI:   params.SlackAPI,
+		dispatcher: newSlackEventDispatcher(params.Logger, params.EventRoutes, params.ApplicationConfigService),
 	}
 }
 
@@ -45,7 +44,7 @@ func (h *SlackEventHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	event, err := requestParser.ParseRequest(r)
 	if err != nil {
-		if err == slack.ErrUnauthorizedRequest {
+		if errors.Is(err, slack.ErrUnauthorizedRequest) {
 			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
@@ -68,38 +67,64 @@ func (h *SlackEventHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	workspace, err := h.applicationConfigService.GetWorkspaceBySlackWorkspaceID(event.TeamID)
-	if err != nil {
+	if err := h.dispatcher.dispatch(event); err != nil {
 		if err == ErrWorkspaceNotFound {
-			h.log.Warn("Unknown Slack workspace ID", zap.String("slack_workspace_id", event.TeamID))
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
-		h.log.Error("Failed to get workspace", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	// Slackイベントには即座に200 OKを返す
+	w.WriteHeader(http.StatusOK)
+}
+
+// slackEventDispatcher passes Events API events to the SlackEventRoute consumers, whether they are received over HTTP or Socket Mode
+type slackEventDispatcher struct {
+	log                      *zap.Logger
+	eventRoutes              []SlackEventRoute
+	applicationConfigService ApplicationConfigService
+}
+
+func newSlackEventDispatcher(log *zap.Logger, eventRoutes []SlackEventRoute, applicationConfigService ApplicationConfigService) *slackEventDispatcher {
+	return &slackEventDispatcher{
+		log:                      log,
+		eventRoutes:              eventRoutes,
+		applicationConfigService: applicationConfigService,
+	}
+}
+
+// dispatch consumes the event in the background. It returns ErrWorkspaceNotFound if the event is from an unknown workspace
+func (d *slackEventDispatcher) dispatch(event slackevents.EventsAPIEvent) error {
+	workspace, err := d.applicationConfigService.GetWorkspaceBySlackWorkspaceID(event.TeamID)
+	if err != nil {
+		if err == ErrWorkspaceNotFound {
+			d.log.Warn("Unknown Slack workspace ID", zap.String("slack_workspace_id", event.TeamID))
+			return err
+		}
+		d.log.Error("Failed to get workspace", zap.Error(err))
+		return err
+	}
+
 	// イベントの処理
 	if event.Type == slackevents.CallbackEvent {
 		innerEvent := event.InnerEvent
 
 		eventJSON, err := json.Marshal(innerEvent)
 		if err != nil {
-			h.log.Warn("Failed to marshal inner event", zap.Error(err))
-			h.log.Info("Received event", zap.Any("event", innerEvent))
+			d.log.Warn("Failed to marshal inner event", zap.Error(err))
+			d.log.Info("Received event", zap.Any("event", innerEvent))
 		} else {
-			h.log.Info("Received event", zap.String("event", string(eventJSON)))
+			d.log.Info("Received event", zap.String("event", string(eventJSON)))
 		}
 
-		for _, route := range h.eventRoutes {
+		for _, route := range d.eventRoutes {
 			if innerEvent.Type == route.EventType() {
 				go route.ConsumeEvent(innerEvent, workspace)
 				break
 			}
 		}
 	}
-
-	// Slackイベントには即座に200 OKを返す
-	w.WriteHeader(http.StatusOK)
+	return nil
 }
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack/slackevents"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeApplicationConfigService struct {
+	ApplicationConfigService
+	workspaces []Workspace
+}
+
+func (s *fakeApplicationConfigService) GetWorkspaceBySlackWorkspaceID(slackWorkspaceID string) (Workspace, error) {
+	for _, workspace := range s.workspaces {
+		if workspace.SlackWorkspaceID == slackWorkspaceID {
+			return workspace, nil
+		}
+	}
+	return Workspace{}, ErrWorkspaceNotFound
+}
+
+type fakeSlackEventRoute struct {
+	eventType  string
+	workspaces chan Workspace
+}
+
+func (r *fakeSlackEventRoute) EventType() string {
+	return r.eventType
+}
+
+func (r *fakeSlackEventRoute) ConsumeEvent(event slackevents.EventsAPIInnerEvent, workspace Workspace) {
+	r.workspaces <- workspace
+}
+
+func TestSlackEventDispatcher_dispatch(t *testing.T) {
+	workspace := Workspace{SlackWorkspaceID: "T001", GitHubRepo: "docs"}
+
+	tests := []struct {
+		name         string
+		teamID       string
+		eventType    string
+		wantErr      error
+		wantConsumed bool
+	}{
+		{
+			name:         "イベントの種類に合うコンシューマーに渡す",
+			teamID:       "T001",
+			eventType:    string(slackevents.AppMention),
+			wantConsumed: true,
+		},
+		{
+			name:      "コンシューマーのないイベントは無視する",
+			teamID:    "T001",
+			eventType: string(slackevents.Message),
+		},
+		{
+			name:      "未知のワークスペースはエラー",
+			teamID:    "T999",
+			eventType: string(slackevents.AppMention),
+			wantErr:   ErrWorkspaceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := &fakeSlackEventRoute{eventType: string(slackevents.AppMention), workspaces: make(chan Workspace, 1)}
+			dispatcher := newSlackEventDispatcher(
+				zap.NewNop(),
+				[]SlackEventRoute{route},
+				&fakeApplicationConfigService{workspaces: []Workspace{workspace}},
+			)
+
+			err := dispatcher.dispatch(slackevents.EventsAPIEvent{
+				TeamID:     tt.teamID,
+				Type:       slackevents.CallbackEvent,
+				InnerEvent: slackevents.EventsAPIInnerEvent{Type: tt.eventType},
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			select {
+			case got := <-route.workspaces:
+				assert.True(t, tt.wantConsumed, "コンシューマーが呼ばれるべきではありません")
+				assert.Equal(t, workspace, got)
+			case <-time.After(100 * time.Millisecond):
+				assert.False(t, tt.wantConsumed, "コンシューマーが呼ばれていません")
+			}
+		})
+	}
+}
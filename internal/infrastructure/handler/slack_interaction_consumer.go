@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	slackgo "github.com/slack-go/slack"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application/port"
+	"docgent/internal/application/redaction"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/slack"
+)
+
+type SlackInteractionConsumerParams struct {
+	fx.In
+
+	Logger                   *zap.Logger
+	GitHubServiceProvider    *github.ServiceProvider
+	SlackServiceProvider     *slack.ServiceProvider
+	ChatModel                domain.ChatModel
+	RAGServices              RAGServices
+	ApplicationConfigService ApplicationConfigService
+	Redactor                 *redaction.Redactor
+}
+
+// SlackInteractionConsumer handles the buttons of proposal cards and the request changes modal,
+// whether the interactions are received over HTTP or Socket Mode.
+type SlackInteractionConsumer struct {
+	logger                   *zap.Logger
+	githubServiceProvider    *github.ServiceProvider
+	slackServiceProvider     *slack.ServiceProvider
+	chatModel                domain.ChatModel
+	ragServices              RAGServices
+	applicationConfigService ApplicationConfigService
+	redactor                 *redaction.Redactor
+}
+
+func NewSlackInteractionConsumer(params SlackInteractionConsumerParams) *SlackInteractionConsumer {
+	return &SlackInteractionConsumer{
+		logger:                   params.Logger,
+		githubServiceProvider:    params.GitHubServiceProvider,
+		slackServiceProvider:     params.SlackServiceProvider,
+		chatModel:                params.ChatModel,
+		ragServices:              params.RAGServices,
+		applicationConfigService: params.ApplicationConfigService,
+		redactor:                 params.Redactor,
+	}
+}
+
+func (c *SlackInteractionConsumer) ConsumeInteraction(callback slackgo.InteractionCallback) {
+	ctx := context.Background()
+
+	switch callback.Type {
+	case slackgo.InteractionTypeBlockActions:
+		for _, action := range callback.ActionCallback.BlockActions {
+			switch action.ActionID {
+			case slack.RequestChangesActionID:
+				c.openRequestChangesModal(ctx, callback, action.Value)
+			case slack.DiscardProposalActionID:
+				c.discard(ctx, callback, action.Value)
+			}
+			// Open PR はURLを開くだけなので何もしない
+		}
+	case slackgo.InteractionTypeViewSubmission:
+		if callback.View.CallbackID == slack.RequestChangesCallbackID {
+			c.requestChanges(ctx, callback)
+		}
+	}
+}
+
+func (c *SlackInteractionConsumer) openRequestChangesModal(ctx context.Context, callback slackgo.InteractionCallback, value string) {
+	// 送信時に対象の提案がわかるように、ボタンの値をそのままモーダルに渡す
+	if _, err := parseProposalCardValue(value); err != nil {
+		c.logger.Error("Invalid proposal card value", zap.Error(err))
+		return
+	}
+	if err := c.slackServiceProvider.OpenRequestChangesModal(ctx, callback.TriggerID, value); err != nil {
+		c.logger.Error("Failed to open request changes modal", zap.Error(err))
+	}
+}
+
+func (c *SlackInteractionConsumer) discard(ctx context.Context, callback slackgo.InteractionCallback, value string) {
+	cardValue, workspace, err := c.resolve(callback.Team.ID, value)
+	if err != nil {
+		c.logger.Error("Failed to resolve proposal card", zap.Error(err))
+		return
+	}
+
+	// PRを閉じられるのはスレッドの参加者だけ
+	conversationService, err := c.conversationService(cardValue, callback.User.ID)
+	if err != nil {
+		c.logger.Error("Failed to parse conversation of proposal card", zap.Error(err))
+		return
+	}
+	history, err := conversationService.GetHistory()
+	if err != nil {
+		c.logger.Error("Failed to get conversation history", zap.Error(err))
+		return
+	}
+	if !isConversationParticipant(history, callback.User.ID) {
+		c.logger.Info("Refused to discard proposal by non-participant", zap.String("user", callback.User.ID), zap.Int("number", cardValue.Number))
+		if err := slack.RespondEphemeral(ctx, callback.ResponseURL, ":no_entry: PRを破棄できるのはスレッドの参加者だけです"); err != nil {
+			c.logger.Warn("Failed to respond to interaction", zap.Error(err))
+		}
+		return
+	}
+
+	err = c.githubServiceProvider.DiscardPullRequest(ctx, workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, cardValue.Number)
+	if err != nil {
+		c.logger.Error("Failed to discard pull request", zap.Int("number", cardValue.Number), zap.Error(err))
+		conversationService.Reply(":warning: エラー: PRの破棄に失敗しました", true)
+		return
+	}
+
+	blocks := slack.DiscardedProposalCardBlocks(callback.Message.Blocks.BlockSet, callback.User.ID)
+	if err := c.slackServiceProvider.UpdateMessage(ctx, callback.Channel.ID, callback.Message.Timestamp, callback.Message.Text, blocks); err != nil {
+		c.logger.Warn("Failed to update proposal card", zap.Error(err))
+	}
+}
+
+func (c *SlackInteractionConsumer) requestChanges(ctx context.Context, callback slackgo.InteractionCallback) {
+	cardValue, workspace, err := c.resolve(callback.Team.ID, callback.View.PrivateMetadata)
+	if err != nil {
+		c.logger.Error("Failed to resolve proposal card", zap.Error(err))
+		return
+	}
+	feedback := slack.RequestChangesFeedback(callback.View)
+	if feedback == "" {
+		return
+	}
+
+	ref, err := slack.ParseConversationRef(data.NewURIUnsafe(cardValue.Conversation))
+	if err != nil {
+		c.logger.Error("Failed to parse conversation of proposal card", zap.Error(err))
+		return
+	}
+	conversationService := c.slackServiceProvider.NewConversationService(ref, callback.User.ID)
+
+	sourcePolicy, err := newSourcePolicy(ctx, workspace, c.githubServiceProvider, c.slackServiceProvider)
+	if err != nil {
+		conversationService.Reply(":warning: エラー: ポリシーの確認に失敗しました", true)
+		c.logger.Error("Failed to build source policy", zap.Error(err))
+		return
+	}
+
+	generator := &proposalGenerator{
+		logger:                c.logger,
+		chatModel:             c.chatModel,
+		ragServices:           c.ragServices,
+		githubServiceProvider: c.githubServiceProvider,
+		slackServiceProvider:  c.slackServiceProvider,
+		redactor:              c.redactor,
+	}
+	handle := cardValue.handle()
+	if _, err := generator.refine(ctx, workspace, conversationService, c.slackServiceProvider.NewResponseFormatter(), sourcePolicy, handle, feedback); err != nil {
+		conversationService.Reply(":warning: エラー: ドキュメントの修正に失敗しました", true)
+		c.logger.Error("Failed to refine proposal", zap.Int("number", cardValue.Number), zap.Error(err))
+		return
+	}
+
+	generator.replyProposal(ctx, workspace, ref, generator.newGeneratedProposal(workspace, handle, true))
+}
+
+// resolve returns the proposal of the card and the workspace scoped to the repository of the proposal
+func (c *SlackInteractionConsumer) resolve(teamID, value string) (proposalCardValue, Workspace, error) {
+	cardValue, err := parseProposalCardValue(value)
+	if err != nil {
+		return proposalCardValue{}, Workspace{}, err
+	}
+
+	workspace, err := c.applicationConfigService.GetWorkspaceBySlackWorkspaceID(teamID)
+	if err != nil {
+		return proposalCardValue{}, Workspace{}, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	// カードの値はユーザーから送られるので、ワークスペースのリポジトリ以外は操作しない
+	workspace, ok := workspace.ForGitHubRepository(cardValue.Owner, cardValue.Repo)
+	if !ok {
+		return proposalCardValue{}, Workspace{}, fmt.Errorf("repository %s/%s is not in the workspace", cardValue.Owner, cardValue.Repo)
+	}
+	return cardValue, workspace, nil
+}
+
+func (c *SlackInteractionConsumer) conversationService(cardValue proposalCardValue, userID string) (port.ConversationService, error) {
+	ref, err := slack.ParseConversationRef(data.NewURIUnsafe(cardValue.Conversation))
+	if err != nil {
+		return nil, err
+	}
+	return c.slackServiceProvider.NewConversationService(ref, userID), nil
+}
+
+// isConversationParticipant reports whether the user posted in the conversation
+func isConversationParticipant(history port.ConversationHistory, userID string) bool {
+	for _, message := range history.Messages {
+		if !message.IsYou && message.Author == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+)
+
+func TestIsConversationParticipant(t *testing.T) {
+	history := port.ConversationHistory{
+		Messages: []port.ConversationMessage{
+			{Author: "U001", Content: "デプロイ手順を変えました"},
+			{Author: "UBOT", Content: "PR: https://github.com/owner/repo/pull/1", IsYou: true},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		want   bool
+	}{
+		{name: "スレッドに投稿したユーザー", userID: "U001", want: true},
+		{name: "スレッドに投稿していないユーザー", userID: "U999", want: false},
+		{name: "Docgent自身", userID: "UBOT", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isConversationParticipant(history, tt.userID))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/infrastructure/slack"
+)
+
+type SlackInteractionHandlerParams struct {
+	fx.In
+
+	Logger              *zap.Logger
+	SlackAPI            *slack.API
+	InteractionConsumer *SlackInteractionConsumer
+}
+
+// SlackInteractionHandler receives interactions, such as button clicks on proposal cards, at the interactivity request URL
+type SlackInteractionHandler struct {
+	log                 *zap.Logger
+	slackAPI            *slack.API
+	interactionConsumer *SlackInteractionConsumer
+}
+
+func NewSlackInteractionHandler(params SlackInteractionHandlerParams) *SlackInteractionHandler {
+	return &SlackInteractionHandler{
+		log:                 params.Logger,
+		slackAPI:            params.SlackAPI,
+		interactionConsumer: params.InteractionConsumer,
+	}
+}
+
+func (h *SlackInteractionHandler) Pattern() string {
+	return "/api/slack/interactions"
+}
+
+func (h *SlackInteractionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestParser := slack.NewInteractionRequestParser(h.slackAPI, h.log)
+
+	callback, err := requestParser.ParseRequest(r)
+	if err != nil {
+		if errors.Is(err, slack.ErrUnauthorizedRequest) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	h.log.Info("Received interaction", zap.String("type", string(callback.Type)))
+	go h.interactionConsumer.ConsumeInteraction(callback)
+
+	// 空のレスポンスで、送信されたモーダルは閉じられる
+	w.WriteHeader(http.StatusOK)
+}
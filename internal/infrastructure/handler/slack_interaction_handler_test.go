@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"docgent/internal/infrastructure/slack"
+)
+
+func TestSlackInteractionHandler_ServeHTTP(t *testing.T) {
+	handler := NewSlackInteractionHandler(SlackInteractionHandlerParams{
+		Logger:   zap.NewNop(),
+		SlackAPI: slack.NewAPI("xoxb-test", "signing-secret"),
+	})
+
+	// 署名が一致しないリクエストは 401 を返す
+	req := httptest.NewRequest(http.MethodPost, "/api/slack/interactions", strings.NewReader("payload=%7B%7D"))
+	req.Header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+	req.Header.Set("X-Slack-Signature", "v0=0000000000000000000000000000000000000000000000000000000000000000")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
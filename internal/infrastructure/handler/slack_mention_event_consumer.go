@@ -4,9 +4,11 @@ import (
 	"context"
 	"docgent/internal/application"
 	"docgent/internal/application/port"
+	"docgent/internal/application/redaction"
 	"docgent/internal/domain"
 	"docgent/internal/infrastructure/github"
 	"docgent/internal/infrastructure/slack"
+	"regexp"
 
 	"github.com/slack-go/slack/slackevents"
 	"go.uber.org/fx"
@@ -18,26 +20,32 @@ type SlackMentionEventConsumerParams struct {
 
 	Logger                *zap.Logger
 	ChatModel             domain.ChatModel
-	RAGService            port.RAGService
+	RAGServices           RAGServices
 	SlackServiceProvider  *slack.ServiceProvider
 	GitHubServiceProvider *github.ServiceProvider
+	Redactor              *redaction.Redactor
 }
 
+// reSlackUserMention matches mentions of users such as "<@U012AB3CD>" in the text of a message
+var reSlackUserMention = regexp.MustCompile(`<@[A-Z0-9]+(?:\|[^>]*)?>`)
+
 type SlackMentionEventConsumer struct {
 	log                   *zap.Logger
 	chatModel             domain.ChatModel
-	ragService            port.RAGService
+	ragServices           RAGServices
 	slackServiceProvider  *slack.ServiceProvider
 	githubServiceProvider *github.ServiceProvider
+	redactor              *redaction.Redactor
 }
 
 func NewSlackMentionEventConsumer(params SlackMentionEventConsumerParams) *SlackMentionEventConsumer {
 	return &SlackMentionEventConsumer{
 		log:                   params.Logger,
 		chatModel:             params.ChatModel,
-		ragService:            params.RAGService,
+		ragServices:           params.RAGServices,
 		slackServiceProvider:  params.SlackServiceProvider,
 		githubServiceProvider: params.GitHubServiceProvider,
+		redactor:              params.Redactor,
 	}
 }
 
@@ -64,10 +72,41 @@ func (c *SlackMentionEventConsumer) ConsumeEvent(event slackevents.EventsAPIInne
 
 	ctx := context.Background()
 
-	var options []application.NewConversationUsecaseOption
-	// If VertexAICorpusID is set, use RAG corpus
-	if workspace.VertexAICorpusID > 0 {
-		options = append(options, application.WithConversationRAGCorpus(c.ragService.GetCorpus(workspace.VertexAICorpusID)))
+	// ワークスペースのポリシーで許可されていない会話には応答しない
+	sourcePolicy, err := newSourcePolicy(ctx, workspace, c.githubServiceProvider, c.slackServiceProvider)
+	if err != nil {
+		c.log.Error("Failed to build source policy", zap.Error(err))
+		conversationService.Reply(":warning: エラー: ポリシーの確認に失敗しました", false)
+		return
+	}
+	if err := sourcePolicy.Check(ctx, ref.ToURI()); err != nil {
+		c.log.Info("Conversation is not allowed by source policy", zap.String("channel", appMentionEvent.Channel), zap.Error(err))
+		conversationService.Reply(sourcePolicyRefusalMessage, false)
+		return
+	}
+
+	// "/docgent <command>" が続くメンションは、スレッドの提案に対するコマンドとして扱う
+	if command, ok := application.ParseCommand(reSlackUserMention.ReplaceAllString(appMentionEvent.Text, "")); ok {
+		generator := &proposalGenerator{
+			logger:                c.log,
+			chatModel:             c.chatModel,
+			ragServices:           c.ragServices,
+			githubServiceProvider: c.githubServiceProvider,
+			slackServiceProvider:  c.slackServiceProvider,
+			redactor:              c.redactor,
+		}
+		if err := generator.runCommand(ctx, workspace, conversationService, c.slackServiceProvider.NewResponseFormatter(), command); err != nil {
+			c.log.Error("Command failed", zap.String("command", string(command.Name)), zap.Error(err))
+		}
+		return
+	}
+
+	options := []application.NewConversationUsecaseOption{
+		application.WithConversationSourcePolicy(sourcePolicy),
+	}
+	// Search the RAG corpora of all repositories in the workspace
+	if ragCorpus := newWorkspaceRAGCorpus(c.ragServices, workspace); ragCorpus != nil {
+		options = append(options, application.WithConversationRAGCorpus(ragCorpus))
 	}
 
 	sourceRepositories := []port.SourceRepository{
@@ -89,7 +128,7 @@ func (c *SlackMentionEventConsumer) ConsumeEvent(event slackevents.EventsAPIInne
 		options...,
 	)
 
-	err := conversationUsecase.Execute(ctx)
+	err = conversationUsecase.Execute(ctx)
 	if err != nil {
 		c.log.Error("Failed to execute conversation usecase", zap.Error(err))
 		conversationService.Reply(":warning: エラー: 会話の処理に失敗しました", false)
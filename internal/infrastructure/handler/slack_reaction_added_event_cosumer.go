@@ -2,15 +2,12 @@ package handler
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/slack-go/slack/slackevents"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
-	"docgent/internal/application"
-	"docgent/internal/application/port"
+	"docgent/internal/application/redaction"
 	"docgent/internal/domain"
 	"docgent/internal/infrastructure/github"
 	"docgent/internal/infrastructure/slack"
@@ -24,8 +21,9 @@ type SlackReactionAddedEventConsumerParams struct {
 	GitHubServiceProvider    *github.ServiceProvider
 	SlackServiceProvider     *slack.ServiceProvider
 	ChatModel                domain.ChatModel
-	RAGService               port.RAGService
+	RAGServices              RAGServices
 	ApplicationConfigService ApplicationConfigService
+	Redactor                 *redaction.Redactor
 }
 
 type SlackReactionAddedEventConsumer struct {
@@ -34,8 +32,9 @@ type SlackReactionAddedEventConsumer struct {
 	githubServiceProvider    *github.ServiceProvider
 	slackServiceProvider     *slack.ServiceProvider
 	chatModel                domain.ChatModel
-	ragService               port.RAGService
+	ragServices              RAGServices
 	applicationConfigService ApplicationConfigService
+	redactor                 *redaction.Redactor
 }
 
 func NewSlackReactionAddedEventConsumer(params SlackReactionAddedEventConsumerParams) *SlackReactionAddedEventConsumer {
@@ -45,8 +44,9 @@ func NewSlackReactionAddedEventConsumer(params SlackReactionAddedEventConsumerPa
 		githubServiceProvider:    params.GitHubServiceProvider,
 		slackServiceProvider:     params.SlackServiceProvider,
 		chatModel:                params.ChatModel,
-		ragService:               params.RAGService,
+		ragServices:              params.RAGServices,
 		applicationConfigService: params.ApplicationConfigService,
+		redactor:                 params.Redactor,
 	}
 }
 
@@ -66,65 +66,35 @@ func (h *SlackReactionAddedEventConsumer) ConsumeEvent(event slackevents.EventsA
 		return
 	}
 
-	threadTimestamp := ev.Item.Timestamp
-
-	ref := slack.NewConversationRef(workspace.SlackWorkspaceID, ev.Item.Channel, threadTimestamp, threadTimestamp)
-	conversationService := h.slackServiceProvider.NewConversationService(ref, ev.User)
-
 	ctx := context.Background()
-	baseBranchName := workspace.GitHubDefaultBranch
-	newBranchName := fmt.Sprintf("docgent/%d", time.Now().Unix())
 
-	branchService := h.githubServiceProvider.NewBranchService(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo)
-	err := branchService.CreateBranch(ctx, baseBranchName, newBranchName)
+	// スレッド内のメッセージにリアクションされた場合も、スレッド全体を1つの会話として扱う
+	ref, err := h.slackServiceProvider.ResolveThreadRootRef(ctx, workspace.SlackWorkspaceID, ev.Item.Channel, ev.Item.Timestamp)
 	if err != nil {
-		h.logger.Error("Failed to create branch", zap.Error(err))
-		conversationService.Reply(":warning: エラー: ブランチの作成に失敗しました", true)
+		h.logger.Error("Failed to resolve thread", zap.String("channel", ev.Item.Channel), zap.Error(err))
 		return
 	}
+	conversationService := h.slackServiceProvider.NewConversationService(ref, ev.User)
 
-	fileQueryService := h.githubServiceProvider.NewFileQueryService(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, newBranchName)
-	fileRepository := h.githubServiceProvider.NewFileRepository(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, newBranchName)
-
-	sourceRepositories := []port.SourceRepository{
-		h.slackServiceProvider.NewSourceRepository(),
-		h.githubServiceProvider.NewSourceRepository(workspace.GitHubInstallationID),
+	generator := &proposalGenerator{
+		logger:                h.logger,
+		chatModel:             h.chatModel,
+		ragServices:           h.ragServices,
+		githubServiceProvider: h.githubServiceProvider,
+		slackServiceProvider:  h.slackServiceProvider,
+		redactor:              h.redactor,
 	}
-
-	githubPullRequestAPI := h.githubServiceProvider.NewPullRequestAPI(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, baseBranchName, newBranchName)
-
-	var options []application.NewProposalGenerateUsecaseOption
-	// If VertexAICorpusID is set, use RAG corpus
-	if workspace.VertexAICorpusID > 0 {
-		options = append(options, application.WithProposalGenerateRAGCorpus(h.ragService.GetCorpus(workspace.VertexAICorpusID)))
+	proposal, err := generator.generate(ctx, workspace, conversationService, h.slackServiceProvider.NewResponseFormatter())
+	if err != nil {
+		h.logger.Error("Failed to generate proposal", zap.String("channel", ev.Item.Channel), zap.Error(err))
+		return
 	}
 
-	// Slackのレスポンスフォーマッターを取得
-	responseFormatter := h.slackServiceProvider.NewResponseFormatter()
-
-	// ドキュメントを生成
-	proposalGenerateUsecase := application.NewProposalGenerateUsecase(
-		h.chatModel,
-		conversationService,
-		fileQueryService,
-		fileRepository,
-		sourceRepositories,
-		githubPullRequestAPI,
-		responseFormatter,
-		options...,
-	)
-	proposalHandle, err := proposalGenerateUsecase.Execute(ctx)
-	if err != nil {
-		h.logger.Error("Failed to generate increment", zap.Error(err))
-		conversationService.Reply(":warning: エラー: ドキュメントの生成に失敗しました", true)
+	// 新しいメッセージがなく提案を更新しなかったことは返信済み
+	if proposal.unchanged {
 		return
 	}
 
-	// 成功メッセージを投稿
-	conversationService.Reply(fmt.Sprintf(
-		"PR: https://github.com/%s/%s/pull/%s",
-		workspace.GitHubOwner,
-		workspace.GitHubRepo,
-		proposalHandle.Value,
-	), false)
+	// 提案のカードを投稿
+	generator.replyProposal(ctx, workspace, ref, proposal)
 }
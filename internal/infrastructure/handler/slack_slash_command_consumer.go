@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	slackgo "github.com/slack-go/slack"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/application/redaction"
+	"docgent/internal/domain"
+	"docgent/internal/domain/data"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/slack"
+)
+
+// slashCommandUsage is the response to /docgent help
+const slashCommandUsage = "*Usage*\n" +
+	"• `/docgent ask <question>` answers the question only to you\n" +
+	"• `/docgent doc <thread link>` documents the thread as a PR\n" +
+	"• `/docgent status` lists the open PRs generated from conversations\n" +
+	"• `/docgent search <terms>` searches the documents\n" +
+	"• `/docgent help` shows this message"
+
+// searchSnippetLength is the maximum length of the content of each hit in /docgent search
+const searchSnippetLength = 200
+
+type SlackSlashCommandConsumerParams struct {
+	fx.In
+
+	Logger                   *zap.Logger
+	GitHubServiceProvider    *github.ServiceProvider
+	SlackServiceProvider     *slack.ServiceProvider
+	ChatModel                domain.ChatModel
+	RAGServices              RAGServices
+	ApplicationConfigService ApplicationConfigService
+	Redactor                 *redaction.Redactor
+}
+
+// SlackSlashCommandConsumer runs the subcommands of /docgent, whether the commands are received over HTTP or Socket Mode.
+// Responses are only visible to the user who ran the command.
+type SlackSlashCommandConsumer struct {
+	logger                   *zap.Logger
+	githubServiceProvider    *github.ServiceProvider
+	slackServiceProvider     *slack.ServiceProvider
+	chatModel                domain.ChatModel
+	ragServices              RAGServices
+	applicationConfigService ApplicationConfigService
+	redactor                 *redaction.Redactor
+}
+
+func NewSlackSlashCommandConsumer(params SlackSlashCommandConsumerParams) *SlackSlashCommandConsumer {
+	return &SlackSlashCommandConsumer{
+		logger:                   params.Logger,
+		githubServiceProvider:    params.GitHubServiceProvider,
+		slackServiceProvider:     params.SlackServiceProvider,
+		chatModel:                params.ChatModel,
+		ragServices:              params.RAGServices,
+		applicationConfigService: params.ApplicationConfigService,
+		redactor:                 params.Redactor,
+	}
+}
+
+func (c *SlackSlashCommandConsumer) ConsumeSlashCommand(command slackgo.SlashCommand) {
+	ctx := context.Background()
+	respond := func(text string) {
+		if err := slack.RespondEphemeral(ctx, command.ResponseURL, text); err != nil {
+			c.logger.Warn("Failed to respond to slash command", zap.Error(err))
+		}
+	}
+
+	workspace, err := c.applicationConfigService.GetWorkspaceBySlackWorkspaceID(command.TeamID)
+	if err != nil {
+		c.logger.Warn("Failed to get workspace", zap.String("slack_workspace_id", command.TeamID), zap.Error(err))
+		respond(":warning: エラー: このワークスペースは登録されていません")
+		return
+	}
+
+	subcommand, args := parseSlashCommandText(command.Text)
+	switch subcommand {
+	case "ask":
+		if args == "" {
+			respond("Usage: `/docgent ask <question>`")
+			return
+		}
+		c.ask(ctx, workspace, command, args, respond)
+	case "doc":
+		if args == "" {
+			respond("Usage: `/docgent doc <thread link>`")
+			return
+		}
+		c.doc(ctx, workspace, command, args, respond)
+	case "status":
+		c.status(ctx, workspace, respond)
+	case "search":
+		if args == "" {
+			respond("Usage: `/docgent search <terms>`")
+			return
+		}
+		c.search(ctx, workspace, args, respond)
+	case "", "help":
+		respond(slashCommandUsage)
+	default:
+		respond(fmt.Sprintf("Unknown subcommand `%s`\n\n%s", subcommand, slashCommandUsage))
+	}
+}
+
+// ask answers the question with ConversationUsecase. The question is not posted, so only the user sees the conversation
+func (c *SlackSlashCommandConsumer) ask(ctx context.Context, workspace Workspace, command slackgo.SlashCommand, question string, respond func(string)) {
+	conversationService := slack.NewEphemeralConversationService(command.TeamID, command.ChannelID, command.UserID, question, command.ResponseURL)
+
+	// 回答は本人にしか見えないので、チャンネルは確認せず、参照できるソースだけをポリシーで制限する
+	sourcePolicy, err := newSourcePolicy(ctx, workspace, c.githubServiceProvider, c.slackServiceProvider)
+	if err != nil {
+		c.logger.Error("Failed to build source policy", zap.Error(err))
+		respond(":warning: エラー: ポリシーの確認に失敗しました")
+		return
+	}
+
+	options := []application.NewConversationUsecaseOption{
+		application.WithConversationSourcePolicy(sourcePolicy),
+	}
+	if ragCorpus := newWorkspaceRAGCorpus(c.ragServices, workspace); ragCorpus != nil {
+		options = append(options, application.WithConversationRAGCorpus(ragCorpus))
+	}
+
+	sourceRepositories := []port.SourceRepository{
+		c.slackServiceProvider.NewSourceRepository(),
+		c.githubServiceProvider.NewSourceRepository(workspace.GitHubInstallationID),
+	}
+	fileQueryService := c.githubServiceProvider.NewFileQueryService(workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo, workspace.GitHubDefaultBranch)
+
+	conversationUsecase := application.NewConversationUsecase(
+		c.chatModel,
+		conversationService,
+		fileQueryService,
+		sourceRepositories,
+		c.slackServiceProvider.NewResponseFormatter(),
+		options...,
+	)
+	if err := conversationUsecase.Execute(ctx); err != nil {
+		c.logger.Error("Failed to execute conversation usecase", zap.Error(err))
+		respond(":warning: エラー: 会話の処理に失敗しました")
+	}
+}
+
+// doc documents the thread of the link in the same way as the doc_it reaction
+func (c *SlackSlashCommandConsumer) doc(ctx context.Context, workspace Workspace, command slackgo.SlashCommand, link string, respond func(string)) {
+	linkRef, err := parseThreadLink(command.TeamID, link)
+	if err != nil {
+		respond(fmt.Sprintf(":warning: スレッドのリンクを指定してください: %s", link))
+		return
+	}
+
+	// 参加していないチャンネル（プライベートチャンネルを含む）のスレッドはドキュメント化させない
+	isMember, err := c.slackServiceProvider.IsChannelMember(ctx, linkRef.ChannelID(), command.UserID)
+	if err != nil {
+		c.logger.Error("Failed to check channel membership", zap.String("channel", linkRef.ChannelID()), zap.Error(err))
+		respond(":warning: エラー: チャンネルの参加状況を確認できませんでした。Docgentがチャンネルに参加しているか確認してください")
+		return
+	}
+	if !isMember {
+		respond(":no_entry: 参加していないチャンネルのスレッドはドキュメント化できません")
+		return
+	}
+
+	// スレッド内のメッセージのリンクでも、スレッド全体を1つの会話として扱う
+	ref, err := c.slackServiceProvider.ResolveThreadRootRef(ctx, command.TeamID, linkRef.ChannelID(), linkRef.SourceMessageTimestamp())
+	if err != nil {
+		c.logger.Error("Failed to resolve thread", zap.String("channel", linkRef.ChannelID()), zap.Error(err))
+		respond(":warning: エラー: スレッドを取得できませんでした。Docgentがチャンネルに参加しているか確認してください")
+		return
+	}
+	conversationService := c.slackServiceProvider.NewConversationService(ref, command.UserID)
+
+	respond("ドキュメント化しています…")
+
+	generator := &proposalGenerator{
+		logger:                c.logger,
+		chatModel:             c.chatModel,
+		ragServices:           c.ragServices,
+		githubServiceProvider: c.githubServiceProvider,
+		slackServiceProvider:  c.slackServiceProvider,
+		redactor:              c.redactor,
+	}
+	proposal, err := generator.generate(ctx, workspace, conversationService, c.slackServiceProvider.NewResponseFormatter())
+	if err != nil {
+		c.logger.Error("Failed to generate proposal", zap.String("channel", ref.ChannelID()), zap.Error(err))
+		respond(":warning: エラー: ドキュメント化に失敗しました。詳細はスレッドを確認してください")
+		return
+	}
+
+	if !proposal.unchanged {
+		generator.replyProposal(ctx, workspace, ref, proposal)
+	}
+	respond(proposal.message())
+}
+
+// status lists the open proposals in all repositories of the workspace
+func (c *SlackSlashCommandConsumer) status(ctx context.Context, workspace Workspace, respond func(string)) {
+	var proposals []github.OpenProposal
+	for _, repository := range workspace.AllRepositories() {
+		repositoryProposals, err := c.githubServiceProvider.ListOpenProposals(ctx, repository.GitHubInstallationID, repository.GitHubOwner, repository.GitHubRepo)
+		if err != nil {
+			c.logger.Error("Failed to list open proposals", zap.String("repository", repository.Name), zap.Error(err))
+			respond(":warning: エラー: PRの一覧を取得できませんでした")
+			return
+		}
+		proposals = append(proposals, repositoryProposals...)
+	}
+	respond(formatOpenProposals(proposals))
+}
+
+// search returns the raw hits of the RAG corpora of the workspace, as the query_rag tool sees them
+func (c *SlackSlashCommandConsumer) search(ctx context.Context, workspace Workspace, terms string, respond func(string)) {
+	ragCorpus := newWorkspaceRAGCorpus(c.ragServices, workspace)
+	if ragCorpus == nil {
+		respond("このワークスペースにはRAGコーパスが設定されていません")
+		return
+	}
+
+	docs, err := ragCorpus.Query(ctx, terms, 10, 0.7)
+	if err != nil {
+		c.logger.Error("Failed to query RAG corpus", zap.Error(err))
+		respond(":warning: エラー: 検索に失敗しました")
+		return
+	}
+	respond(formatRAGDocuments(docs))
+}
+
+// parseSlashCommandText splits the text of /docgent into the subcommand and its arguments
+func parseSlashCommandText(text string) (subcommand, args string) {
+	text = strings.TrimSpace(text)
+	subcommand, args, _ = strings.Cut(text, " ")
+	return strings.ToLower(subcommand), strings.TrimSpace(args)
+}
+
+// parseThreadLink accepts a Slack permalink or a conversation URI of Docgent
+func parseThreadLink(teamID, link string) (*slack.ConversationRef, error) {
+	if ref, err := slack.ParsePermalink(teamID, link); err == nil {
+		return ref, nil
+	}
+	uri, err := data.NewURI(strings.Trim(link, "<>"))
+	if err != nil {
+		return nil, err
+	}
+	return slack.ParseConversationRef(uri)
+}
+
+func formatOpenProposals(proposals []github.OpenProposal) string {
+	if len(proposals) == 0 {
+		return "No open PRs"
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("*Open PRs (%d)*", len(proposals)))
+	for _, proposal := range proposals {
+		builder.WriteString(fmt.Sprintf("\n• <%s|%s> from <%s|conversation>", proposal.URL, proposal.Title, proposal.ConversationURI))
+	}
+	return builder.String()
+}
+
+func formatRAGDocuments(docs []port.RAGDocument) string {
+	if len(docs) == 0 {
+		return "No relevant documents found"
+	}
+	var builder strings.Builder
+	for i, doc := range docs {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		source := fmt.Sprintf("`%s`", doc.Source)
+		if strings.HasPrefix(doc.Source, "https://") || strings.HasPrefix(doc.Source, "http://") {
+			source = fmt.Sprintf("<%s>", doc.Source)
+		}
+		builder.WriteString(fmt.Sprintf("*%d.* %s (score %.2f)\n> %s", i+1, source, doc.Score, snippet(doc.Content)))
+	}
+	return builder.String()
+}
+
+// snippet collapses the content into a single line of up to searchSnippetLength characters
+func snippet(content string) string {
+	runes := []rune(strings.Join(strings.Fields(content), " "))
+	if len(runes) <= searchSnippetLength {
+		return string(runes)
+	}
+	return string(runes[:searchSnippetLength]) + "…"
+}
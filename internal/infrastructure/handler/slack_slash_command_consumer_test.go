@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+)
+
+func TestParseSlashCommandText(t *testing.T) {
+	tests := []struct {
+		name           string
+		text           string
+		wantSubcommand string
+		wantArgs       string
+	}{
+		{
+			name:           "サブコマンドと引数に分ける",
+			text:           "ask  How do I deploy? ",
+			wantSubcommand: "ask",
+			wantArgs:       "How do I deploy?",
+		},
+		{
+			name:           "サブコマンドは大文字小文字を区別しない",
+			text:           "Status",
+			wantSubcommand: "status",
+		},
+		{
+			name: "空の場合",
+			text: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subcommand, args := parseSlashCommandText(tt.text)
+			assert.Equal(t, tt.wantSubcommand, subcommand)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestParseThreadLink(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		wantErr bool
+	}{
+		{
+			name: "Slackのパーマリンクの場合",
+			link: "<https://example.slack.com/archives/C789012/p1234567890123456>",
+		},
+		{
+			name: "会話のURIの場合",
+			link: "https://app.slack.com/client/T123456/C789012/1234567890.123456",
+		},
+		{
+			name:    "リンクでない場合",
+			link:    "the thread about deploys",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := parseThreadLink("T123456", tt.link)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "C789012", ref.ChannelID())
+			assert.Equal(t, "1234567890.123456", ref.ThreadTimestamp())
+		})
+	}
+}
+
+func TestFormatRAGDocuments(t *testing.T) {
+	docs := []port.RAGDocument{
+		{Source: "https://github.com/owner/repo/blob/abc/docs/deploy.md", Content: "Deploy\nwith   make deploy", Score: 0.91},
+		{Source: "docs/setup.md", Content: strings.Repeat("a", 250), Score: 0.75},
+	}
+
+	got := formatRAGDocuments(docs)
+
+	want := "*1.* <https://github.com/owner/repo/blob/abc/docs/deploy.md> (score 0.91)\n> Deploy with make deploy\n" +
+		"*2.* `docs/setup.md` (score 0.75)\n> " + strings.Repeat("a", 200) + "…"
+	assert.Equal(t, want, got)
+	assert.Equal(t, "No relevant documents found", formatRAGDocuments(nil))
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/infrastructure/slack"
+)
+
+type SlackSlashCommandHandlerParams struct {
+	fx.In
+
+	Logger               *zap.Logger
+	SlackAPI             *slack.API
+	SlashCommandConsumer *SlackSlashCommandConsumer
+}
+
+// SlackSlashCommandHandler receives /docgent at the request URL of the slash command
+type SlackSlashCommandHandler struct {
+	log                  *zap.Logger
+	slackAPI             *slack.API
+	slashCommandConsumer *SlackSlashCommandConsumer
+}
+
+func NewSlackSlashCommandHandler(params SlackSlashCommandHandlerParams) *SlackSlashCommandHandler {
+	return &SlackSlashCommandHandler{
+		log:                  params.Logger,
+		slackAPI:             params.SlackAPI,
+		slashCommandConsumer: params.SlashCommandConsumer,
+	}
+}
+
+func (h *SlackSlashCommandHandler) Pattern() string {
+	return "/api/slack/commands"
+}
+
+func (h *SlackSlashCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestParser := slack.NewSlashCommandRequestParser(h.slackAPI, h.log)
+
+	command, err := requestParser.ParseRequest(r)
+	if err != nil {
+		if errors.Is(err, slack.ErrUnauthorizedRequest) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	h.log.Info("Received slash command", zap.String("command", command.Command), zap.String("text", command.Text))
+	go h.slashCommandConsumer.ConsumeSlashCommand(command)
+
+	// 応答はresponse_urlに送るので、コマンドには空のレスポンスを返す
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+
+	slackgo "github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/infrastructure/slack"
+)
+
+type SlackSocketModeListenerParams struct {
+	fx.In
+
+	Lifecycle                fx.Lifecycle
+	Logger                   *zap.Logger
+	EventRoutes              []SlackEventRoute `group:"slack_event_routes"`
+	SlackAPI                 *slack.API
+	ApplicationConfigService ApplicationConfigService
+	InteractionConsumer      *SlackInteractionConsumer
+	SlashCommandConsumer     *SlackSlashCommandConsumer
+}
+
+// SlackSocketModeListener receives Slack events over Socket Mode, so that no public endpoint is required.
+// Events, interactions and slash commands are passed to the same consumers as the HTTP handlers.
+type SlackSocketModeListener struct {
+	log                  *zap.Logger
+	dispatcher           *slackEventDispatcher
+	interactionConsumer  *SlackInteractionConsumer
+	slashCommandConsumer *SlackSlashCommandConsumer
+}
+
+// NewSlackSocketModeListener creates a listener which connects to Slack when the application starts
+func NewSlackSocketModeListener(params SlackSocketModeListenerParams) (*SlackSocketModeListener, error) {
+	client, err := params.SlackAPI.NewSocketModeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	l := &SlackSocketModeListener{
+		log:                  params.Logger,
+		dispatcher:           newSlackEventDispatcher(params.Logger, params.EventRoutes, params.ApplicationConfigService),
+		interactionConsumer:  params.InteractionConsumer,
+		slashCommandConsumer: params.SlashCommandConsumer,
+	}
+	runUntilStopped(params.Lifecycle, l.log, "Socket Mode", func(ctx context.Context) error {
+		return client.Run(ctx, l.log, slack.SocketModeHandlers{
+			Event: func(event slackevents.EventsAPIEvent) {
+				// Unknown workspaces are logged by the dispatcher, and Socket Mode has no response to report them
+				l.dispatcher.dispatch(event)
+			},
+			Interaction: func(callback slackgo.InteractionCallback) {
+				go l.interactionConsumer.ConsumeInteraction(callback)
+			},
+			SlashCommand: func(command slackgo.SlashCommand) {
+				go l.slashCommandConsumer.ConsumeSlashCommand(command)
+			},
+		})
+	})
+	return l, nil
+}
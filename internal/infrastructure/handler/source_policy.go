@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"docgent/internal/application/port"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/gitlab"
+	"docgent/internal/infrastructure/slack"
+)
+
+// sourcePolicyRefusalMessage is posted when a conversation cannot be used because of the workspace policy
+const sourcePolicyRefusalMessage = ":no_entry: この会話はワークスペースのポリシーによりドキュメント化できません（非公開チャンネル・DM、または許可されていないチャンネルの可能性があります）"
+
+// newSourcePolicy builds the source policy of the workspace.
+// The visibility of the documentation repository is looked up on GitHub so that private conversations do not flow into a public repository.
+// chatLocators are chat tools other than Slack, such as Discord, whose channels follow the same rule as Slack channels.
+func newSourcePolicy(ctx context.Context, workspace Workspace, githubServiceProvider *github.ServiceProvider, slackServiceProvider *slack.ServiceProvider, chatLocators ...port.SourceLocator) (*port.SourcePolicy, error) {
+	repositoryPublic, err := githubServiceProvider.IsRepositoryPublic(ctx, workspace.GitHubInstallationID, workspace.GitHubOwner, workspace.GitHubRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository visibility: %w", err)
+	}
+
+	config := workspace.SourcePolicy
+	slackRule := newSlackSourcePolicyRule(config, repositoryPublic)
+	// Channel lists are for Slack, so GitHub sources are only checked by visibility
+	githubRule := port.SourcePolicyRule{
+		RepositoryPublic:                        repositoryPublic,
+		AllowNonPublicSourcesInPublicRepository: config.AllowPrivateSourcesInPublicRepo,
+	}
+
+	entries := []port.SourcePolicyEntry{
+		{Locator: slackServiceProvider.NewSourceLocator(), Rule: slackRule},
+		{Locator: githubServiceProvider.NewSourceLocator(workspace.GitHubInstallationID), Rule: githubRule},
+	}
+	for _, locator := range chatLocators {
+		entries = append(entries, port.SourcePolicyEntry{Locator: locator, Rule: slackRule})
+	}
+	return port.NewSourcePolicy(entries...), nil
+}
+
+// newGitLabSourcePolicy builds the source policy of the workspace's GitLab project.
+// Only Slack sources can be found from GitLab merge requests.
+func newGitLabSourcePolicy(ctx context.Context, workspace Workspace, gitlabServiceProvider *gitlab.ServiceProvider, slackServiceProvider *slack.ServiceProvider) (*port.SourcePolicy, error) {
+	projectPublic, err := gitlabServiceProvider.IsProjectPublic(ctx, workspace.GitLab.Project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project visibility: %w", err)
+	}
+
+	return port.NewSourcePolicy(
+		port.SourcePolicyEntry{Locator: slackServiceProvider.NewSourceLocator(), Rule: newSlackSourcePolicyRule(workspace.SourcePolicy, projectPublic)},
+	), nil
+}
+
+func newSlackSourcePolicyRule(config SourcePolicyConfig, repositoryPublic bool) port.SourcePolicyRule {
+	return port.SourcePolicyRule{
+		AllowedContainerIDs:                     config.AllowedChannelIDs,
+		DeniedContainerIDs:                      config.DeniedChannelIDs,
+		DenyPrivate:                             config.DenyPrivateChannels,
+		DenyDirectMessages:                      config.DenyDirectMessages,
+		RepositoryPublic:                        repositoryPublic,
+		AllowNonPublicSourcesInPublicRepository: config.AllowPrivateSourcesInPublicRepo,
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"docgent/internal/application"
+	"docgent/internal/application/port"
+	"docgent/internal/domain"
+	"docgent/internal/infrastructure/github"
+	"docgent/internal/infrastructure/slack"
+)
+
+type StaleDocumentCheckHandlerParams struct {
+	fx.In
+	Logger                   *zap.Logger
+	ChatModel                domain.ChatModel
+	GitHubServiceProvider    *github.ServiceProvider
+	SlackServiceProvider     *slack.ServiceProvider
+	ApplicationConfigService ApplicationConfigService
+	JobConfig                JobConfig
+}
+
+// StaleDocumentCheckHandler is a job endpoint, typically called by Cloud Scheduler,
+// that detects documents whose sources have changed after the documents were last updated.
+type StaleDocumentCheckHandler struct {
+	log                      *zap.Logger
+	chatModel                domain.ChatModel
+	githubServiceProvider    *github.ServiceProvider
+	slackServiceProvider     *slack.ServiceProvider
+	applicationConfigService ApplicationConfigService
+	jobConfig                JobConfig
+}
+
+func NewStaleDocumentCheckHandler(params StaleDocumentCheckHandlerParams) *StaleDocumentCheckHandler {
+	return &StaleDocumentCheckHandler{
+		log:                      params.Logger,
+		chatModel:                params.ChatModel,
+		githubServiceProvider:    params.GitHubServiceProvider,
+		slackServiceProvider:     params.SlackServiceProvider,
+		applicationConfigService: params.ApplicationConfigService,
+		jobConfig:                params.JobConfig,
+	}
+}
+
+func (h *StaleDocumentCheckHandler) Pattern() string {
+	return "/api/jobs/stale-documents"
+}
+
+func (h *StaleDocumentCheckHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.authorize(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	for _, workspace := range h.applicationConfigService.ListWorkspaces() {
+		for _, repository := range workspace.AllRepositories() {
+			go h.checkWorkspace(workspace.ForRepository(repository))
+		}
+	}
+
+	// ジョブは非同期で実行するので即座に202 Acceptedを返す
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *StaleDocumentCheckHandler) authorize(r *http.Request) bool {
+	if h.jobConfig.AuthToken == "" {
+		return false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.jobConfig.AuthToken)) == 1
+}
+
+func (h *StaleDocumentCheckHandler) checkWorkspace(workspace Workspace) {
+	ctx := context.Background()
+
+	installationID := workspace.GitHubInstallationID
+	owner := workspace.GitHubOwner
+	repo := workspace.GitHubRepo
+	branch := workspace.GitHubDefaultBranch
+
+	sourceActivityRepositories := []port.SourceActivityRepository{
+		h.slackServiceProvider.NewSourceRepository(),
+		h.githubServiceProvider.NewSourceRepository(installationID),
+	}
+
+	usecase := application.NewStaleDocumentDetectUsecase(
+		h.chatModel,
+		h.githubServiceProvider.NewFileQueryService(installationID, owner, repo, branch),
+		h.githubServiceProvider.NewFileRepository(installationID, owner, repo, branch),
+		h.githubServiceProvider.NewFileHistoryService(installationID, owner, repo, branch),
+		sourceActivityRepositories,
+		h.githubServiceProvider.NewStaleDocumentNotifier(installationID, owner, repo),
+	)
+
+	reports, err := usecase.Execute(ctx)
+	if err != nil {
+		h.log.Error("Failed to check stale documents", zap.String("repository", owner+"/"+repo), zap.Error(err))
+	}
+
+	for _, report := range reports {
+		h.log.Info("Stale document notified", zap.String("repository", owner+"/"+repo), zap.String("path", report.FilePath))
+	}
+}
@@ -0,0 +1,114 @@
+package hybridrag
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+)
+
+const (
+	// bm25K1 and bm25B are the usual parameters of Okapi BM25
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// keywordChunk is a chunk of a file in the keyword index
+type keywordChunk struct {
+	FileID  int64  `json:"file_id"`
+	Source  string `json:"source"`
+	Content string `json:"content"`
+
+	// termFrequencies and length are computed from Content when the chunk is added or loaded
+	termFrequencies map[string]int
+	length          int
+}
+
+func newKeywordChunk(fileID int64, source, content string) keywordChunk {
+	chunk := keywordChunk{FileID: fileID, Source: source, Content: content}
+	chunk.analyze()
+	return chunk
+}
+
+// UnmarshalJSON analyzes the content of the chunk loaded from the index
+func (c *keywordChunk) UnmarshalJSON(b []byte) error {
+	type plain keywordChunk
+	if err := json.Unmarshal(b, (*plain)(c)); err != nil {
+		return err
+	}
+	c.analyze()
+	return nil
+}
+
+func (c *keywordChunk) analyze() {
+	terms := tokenize(c.Content)
+	c.termFrequencies = make(map[string]int, len(terms))
+	for _, term := range terms {
+		c.termFrequencies[term]++
+	}
+	c.length = len(terms)
+}
+
+type scoredChunk struct {
+	chunk keywordChunk
+	score float64
+}
+
+// searchBM25 returns up to topK chunks matching any term of the query, in descending order of BM25 score
+func searchBM25(chunks []keywordChunk, query string, topK int) []scoredChunk {
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	queryTerms := uniqueTerms(tokenize(query))
+	documentFrequencies := make(map[string]int, len(queryTerms))
+	var totalLength int
+	for _, chunk := range chunks {
+		totalLength += chunk.length
+		for _, term := range queryTerms {
+			if chunk.termFrequencies[term] > 0 {
+				documentFrequencies[term]++
+			}
+		}
+	}
+	averageLength := float64(totalLength) / float64(len(chunks))
+	if averageLength == 0 {
+		return nil
+	}
+
+	var results []scoredChunk
+	for _, chunk := range chunks {
+		var score float64
+		for _, term := range queryTerms {
+			tf := float64(chunk.termFrequencies[term])
+			if tf == 0 {
+				continue
+			}
+			df := float64(documentFrequencies[term])
+			idf := math.Log(1 + (float64(len(chunks))-df+0.5)/(df+0.5))
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*float64(chunk.length)/averageLength))
+		}
+		if score > 0 {
+			results = append(results, scoredChunk{chunk: chunk, score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return results
+}
+
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	var unique []string
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			unique = append(unique, term)
+		}
+	}
+	return unique
+}
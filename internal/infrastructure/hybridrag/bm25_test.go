@@ -0,0 +1,48 @@
+package hybridrag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchBM25(t *testing.T) {
+	chunks := []keywordChunk{
+		newKeywordChunk(1, "docs/deploy.md", "Run make deploy to deploy the API server."),
+		newKeywordChunk(2, "docs/errors.md", "ERR_CONN_RESET means the database closed the connection. Raise db.max_connections."),
+		newKeywordChunk(3, "docs/lunch.md", "The cafeteria opens at noon."),
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "識別子に完全一致", query: "What is ERR_CONN_RESET?", want: []string{"docs/errors.md"}},
+		{name: "設定キー", query: "db.max_connections", want: []string{"docs/errors.md"}},
+		{name: "一致する単語が多いほど上位", query: "deploy API server connection", want: []string{"docs/deploy.md", "docs/errors.md"}},
+		{name: "一致しない場合", query: "vacation policy", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, result := range searchBM25(chunks, tt.query, 10) {
+				got = append(got, result.chunk.Source)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSearchBM25_TopK(t *testing.T) {
+	chunks := []keywordChunk{
+		newKeywordChunk(1, "a.md", "deploy deploy"),
+		newKeywordChunk(2, "b.md", "deploy"),
+	}
+
+	results := searchBM25(chunks, "deploy", 1)
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, "a.md", results[0].chunk.Source)
+}
@@ -0,0 +1,143 @@
+package hybridrag
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"docgent/internal/application/chunking"
+	"docgent/internal/application/port"
+	"docgent/internal/application/rankfusion"
+	"docgent/internal/domain/data"
+	"docgent/internal/infrastructure/ragstore"
+)
+
+// defaultSimilarityTopK is used when the query does not limit the number of documents
+const defaultSimilarityTopK = 10
+
+// Corpus decorates a corpus with a BM25 keyword index of the same files.
+// Vector search finds documents with similar meanings, but misses exact identifiers such as error codes,
+// config keys and service names, which keyword search finds. Both results are merged with reciprocal rank fusion.
+type Corpus struct {
+	ragCorpus port.RAGCorpus
+
+	// writeLock serializes uploads and deletions, which take a while on the underlying corpus
+	writeLock sync.Mutex
+	// lock protects the index
+	lock  sync.Mutex
+	index *ragstore.JSONFile[[]keywordChunk]
+}
+
+var _ port.RAGCorpus = (*Corpus)(nil)
+
+// NewCorpus creates a corpus that keeps the keyword index of ragCorpus in the file at path
+func NewCorpus(ragCorpus port.RAGCorpus, path string) *Corpus {
+	return &Corpus{
+		ragCorpus: ragCorpus,
+		index:     ragstore.NewJSONFile[[]keywordChunk](path, "keyword index"),
+	}
+}
+
+// Query searches both the underlying corpus and the keyword index, and returns up to similarityTopK documents ranked by both.
+// vectorDistanceThreshold applies only to the underlying corpus.
+func (c *Corpus) Query(ctx context.Context, query string, similarityTopK int32, vectorDistanceThreshold float64) ([]port.RAGDocument, error) {
+	if similarityTopK <= 0 {
+		similarityTopK = defaultSimilarityTopK
+	}
+
+	vectorDocuments, err := c.ragCorpus.Query(ctx, query, similarityTopK, vectorDistanceThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	chunks, err := c.index.Load()
+	if err != nil {
+		c.lock.Unlock()
+		return nil, err
+	}
+	results := searchBM25(*chunks, query, int(similarityTopK))
+	c.lock.Unlock()
+
+	keywordDocuments := make([]port.RAGDocument, len(results))
+	for i, result := range results {
+		keywordDocuments[i] = port.RAGDocument{
+			Content: result.chunk.Content,
+			Source:  result.chunk.Source,
+			Score:   result.score,
+		}
+	}
+
+	documents := rankfusion.Fuse(vectorDocuments, keywordDocuments)
+	if len(documents) > int(similarityTopK) {
+		documents = documents[:similarityTopK]
+	}
+	return documents, nil
+}
+
+// UploadFile uploads the file to the underlying corpus and indexes its chunks under the ID that the corpus assigned.
+func (c *Corpus) UploadFile(ctx context.Context, file io.Reader, uri *data.URI, options ...port.RAGCorpusUploadFileOption) (port.RAGFile, error) {
+	uploadFileOptions := ragstore.UploadFileOptions(options...)
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return port.RAGFile{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	ragFile, err := c.ragCorpus.UploadFile(ctx, bytes.NewReader(content), uri, options...)
+	if err != nil {
+		return port.RAGFile{}, err
+	}
+
+	texts := chunking.Split(string(content), uploadFileOptions.ChunkingConfig.ChunkSize, uploadFileOptions.ChunkingConfig.ChunkOverlap)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	chunks, err := c.index.Load()
+	if err != nil {
+		return port.RAGFile{}, err
+	}
+	for _, text := range texts {
+		*chunks = append(*chunks, newKeywordChunk(ragFile.ID, uri.Value(), text))
+	}
+	if err := c.index.Save(); err != nil {
+		return port.RAGFile{}, err
+	}
+	return ragFile, nil
+}
+
+func (c *Corpus) ListFiles(ctx context.Context) ([]port.RAGFile, error) {
+	return c.ragCorpus.ListFiles(ctx)
+}
+
+// DeleteFile deletes the file from the underlying corpus and its chunks from the keyword index
+func (c *Corpus) DeleteFile(ctx context.Context, fileID int64) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if err := c.ragCorpus.DeleteFile(ctx, fileID); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	chunks, err := c.index.Load()
+	if err != nil {
+		return err
+	}
+	kept := (*chunks)[:0]
+	for _, chunk := range *chunks {
+		if chunk.FileID != fileID {
+			kept = append(kept, chunk)
+		}
+	}
+	*chunks = kept
+	return c.index.Save()
+}
@@ -0,0 +1,112 @@
+package hybridrag
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// fakeRAGCorpus returns fixed documents to queries and assigns IDs like Vertex AI, which are not sequential
+type fakeRAGCorpus struct {
+	documents []port.RAGDocument
+	files     []port.RAGFile
+	nextID    int64
+	listed    int
+}
+
+func (c *fakeRAGCorpus) Query(ctx context.Context, query string, similarityTopK int32, vectorDistanceThreshold float64) ([]port.RAGDocument, error) {
+	return c.documents, nil
+}
+
+func (c *fakeRAGCorpus) UploadFile(ctx context.Context, file io.Reader, uri *data.URI, options ...port.RAGCorpusUploadFileOption) (port.RAGFile, error) {
+	c.nextID += 1000
+	ragFile := port.RAGFile{ID: c.nextID, URI: uri}
+	c.files = append(c.files, ragFile)
+	return ragFile, nil
+}
+
+func (c *fakeRAGCorpus) ListFiles(ctx context.Context) ([]port.RAGFile, error) {
+	c.listed++
+	return append([]port.RAGFile(nil), c.files...), nil
+}
+
+func (c *fakeRAGCorpus) DeleteFile(ctx context.Context, fileID int64) error {
+	for i, file := range c.files {
+		if file.ID == fileID {
+			c.files = append(c.files[:i], c.files[i+1:]...)
+			return nil
+		}
+	}
+	return assert.AnError
+}
+
+func TestCorpus(t *testing.T) {
+	ctx := context.Background()
+	deployURI := data.NewURIUnsafe("https://github.com/owner/repo/blob/main/docs/deploy.md")
+	errorsURI := data.NewURIUnsafe("https://github.com/owner/repo/blob/main/docs/errors.md")
+	deployDocument := port.RAGDocument{Source: deployURI.Value(), Content: "Run make deploy to deploy the API server.", Score: 0.9}
+	ragCorpus := &fakeRAGCorpus{documents: []port.RAGDocument{deployDocument}}
+	path := filepath.Join(t.TempDir(), "1", keywordIndexFileName)
+
+	corpus := NewCorpus(ragCorpus, path)
+	file, err := corpus.UploadFile(ctx, strings.NewReader(deployDocument.Content), deployURI)
+	require.NoError(t, err)
+	assert.Equal(t, port.RAGFile{ID: 1000, URI: deployURI}, file)
+	_, err = corpus.UploadFile(ctx, strings.NewReader("ERR_CONN_RESET means the database closed the connection."), errorsURI)
+	require.NoError(t, err)
+	// アップロードしたファイルのIDは一覧を取得せずにもとのコーパスの応答から得る
+	assert.Zero(t, ragCorpus.listed)
+
+	// ベクトル検索で見つからない識別子もキーワード検索で見つかる
+	documents, err := corpus.Query(ctx, "What is ERR_CONN_RESET?", 10, 0.5)
+	require.NoError(t, err)
+	require.Len(t, documents, 2)
+	assert.ElementsMatch(t, []string{deployURI.Value(), errorsURI.Value()}, []string{documents[0].Source, documents[1].Source})
+
+	// 両方で見つかったチャンクは1つにまとめて上位にする
+	documents, err = corpus.Query(ctx, "deploy API server", 10, 0.5)
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+	assert.Equal(t, deployURI.Value(), documents[0].Source)
+	assert.InDelta(t, 1.0, documents[0].Score, 1e-9)
+
+	// インデックスはディスクから読み直せる
+	reopened := NewCorpus(ragCorpus, path)
+	documents, err = reopened.Query(ctx, "ERR_CONN_RESET", 1, 0.5)
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+
+	// 削除したファイルはキーワード検索でも見つからない
+	require.NoError(t, reopened.DeleteFile(ctx, 2000))
+	ragCorpus.documents = nil
+	documents, err = reopened.Query(ctx, "ERR_CONN_RESET", 10, 0.5)
+	require.NoError(t, err)
+	assert.Empty(t, documents)
+
+	// もとのコーパスで削除に失敗した場合はインデックスを残す
+	assert.Error(t, reopened.DeleteFile(ctx, 3000))
+	documents, err = reopened.Query(ctx, "deploy", 10, 0.5)
+	require.NoError(t, err)
+	assert.Len(t, documents, 1)
+}
+
+func TestService_GetCorpus(t *testing.T) {
+	service := NewService(fakeRAGService{}, t.TempDir())
+
+	assert.Same(t, service.GetCorpus(1), service.GetCorpus(1))
+	assert.NotSame(t, service.GetCorpus(1), service.GetCorpus(2))
+}
+
+type fakeRAGService struct{}
+
+func (fakeRAGService) GetCorpus(corpusID int64) port.RAGCorpus {
+	return &fakeRAGCorpus{}
+}
@@ -0,0 +1,45 @@
+package hybridrag
+
+import (
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"docgent/internal/application/port"
+)
+
+// keywordIndexFileName is the file in the directory of a corpus that holds its keyword index
+const keywordIndexFileName = "keyword_index.json"
+
+// Service decorates the corpora of a RAG service with keyword indexes stored under the directory
+type Service struct {
+	ragService port.RAGService
+	dir        string
+
+	lock    sync.Mutex
+	corpora map[int64]*Corpus
+}
+
+var _ port.RAGService = (*Service)(nil)
+
+func NewService(ragService port.RAGService, dir string) *Service {
+	return &Service{
+		ragService: ragService,
+		dir:        dir,
+		corpora:    make(map[int64]*Corpus),
+	}
+}
+
+// GetCorpus returns the corpus of the ID. The same instance is returned for the same ID so that writes to the index are serialized
+func (s *Service) GetCorpus(corpusID int64) port.RAGCorpus {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	corpus, ok := s.corpora[corpusID]
+	if !ok {
+		path := filepath.Join(s.dir, strconv.FormatInt(corpusID, 10), keywordIndexFileName)
+		corpus = NewCorpus(s.ragService.GetCorpus(corpusID), path)
+		s.corpora[corpusID] = corpus
+	}
+	return corpus
+}
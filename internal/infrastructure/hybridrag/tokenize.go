@@ -0,0 +1,69 @@
+package hybridrag
+
+import (
+	"strings"
+	"unicode"
+)
+
+// identifierPunctuation joins the parts of identifiers such as ERR_CONN_RESET, db.max_connections and user-service
+const identifierPunctuation = "_-./:"
+
+// tokenize splits the text into lowercase terms for the keyword index.
+// An identifier is indexed as a whole and as its parts, so both "ERR_CONN_RESET" and "reset" match it.
+// Texts in Chinese and Japanese, which do not separate words with spaces, are indexed as character bigrams.
+func tokenize(text string) []string {
+	var terms []string
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r) && !strings.ContainsRune(identifierPunctuation, r)
+	})
+	for _, field := range fields {
+		field = strings.Trim(field, identifierPunctuation)
+		if field == "" {
+			continue
+		}
+
+		parts := strings.FieldsFunc(field, func(r rune) bool { return !isWordRune(r) })
+		if len(parts) > 1 {
+			terms = append(terms, field)
+		}
+		for _, part := range parts {
+			terms = append(terms, splitCJK(part)...)
+		}
+	}
+	return terms
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// splitCJK splits a word into runs of CJK and other characters, and the CJK runs into bigrams
+func splitCJK(word string) []string {
+	var terms []string
+	runes := []rune(word)
+	for start := 0; start < len(runes); {
+		cjk := isCJK(runes[start])
+		end := start + 1
+		for end < len(runes) && isCJK(runes[end]) == cjk {
+			end++
+		}
+
+		run := runes[start:end]
+		switch {
+		case !cjk:
+			terms = append(terms, string(run))
+		case len(run) == 1:
+			terms = append(terms, string(run))
+		default:
+			for i := 0; i+2 <= len(run); i++ {
+				terms = append(terms, string(run[i:i+2]))
+			}
+		}
+		start = end
+	}
+	return terms
+}
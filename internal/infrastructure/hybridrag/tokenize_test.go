@@ -0,0 +1,42 @@
+package hybridrag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "英単語",
+			text: "Deploy the API server.",
+			want: []string{"deploy", "the", "api", "server"},
+		},
+		{
+			name: "識別子は全体と部分の両方",
+			text: "Got ERR_CONN_RESET from user-service (db.max_connections)",
+			want: []string{"got", "err_conn_reset", "err", "conn", "reset", "from", "user-service", "user", "service", "db.max_connections", "db", "max", "connections"},
+		},
+		{
+			name: "日本語は文字のbigram",
+			text: "デプロイ手順",
+			want: []string{"デプ", "プロ", "ロイ", "イ手", "手順"},
+		},
+		{
+			name: "日本語と英数字の混在",
+			text: "APIの設定",
+			want: []string{"api", "の設", "設定"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tokenize(tt.text))
+		})
+	}
+}
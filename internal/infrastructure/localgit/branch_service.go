@@ -0,0 +1,24 @@
+package localgit
+
+import (
+	"context"
+	"fmt"
+)
+
+type BranchService struct {
+	repository *Repository
+}
+
+func NewBranchService(repository *Repository) *BranchService {
+	return &BranchService{
+		repository: repository,
+	}
+}
+
+// CreateBranch creates a new branch from the specified base branch.
+func (s *BranchService) CreateBranch(ctx context.Context, baseBranchName, newBranchName string) error {
+	if _, err := s.repository.run(ctx, nil, nil, "branch", "--no-track", newBranchName, "refs/heads/"+baseBranchName); err != nil {
+		return fmt.Errorf("failed to create new branch: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package localgit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"docgent/internal/domain"
+)
+
+// applyDiff applies a diff generated by the agent to the branch in a single commit
+func applyDiff(ctx context.Context, repository *Repository, branch string, diff domain.Diff) error {
+	dmp := diffmatchpatch.New()
+	patches, err := dmp.PatchFromText(diff.Body)
+	if err != nil {
+		return fmt.Errorf("failed to parse diff: %w", err)
+	}
+
+	if diff.IsNewFile {
+		newText, results := dmp.PatchApply(patches, "")
+		if !anyPatchApplied(results) {
+			return fmt.Errorf("no changes were applied to the file")
+		}
+		return repository.commit(ctx, branch, fmt.Sprintf("Create file %s", diff.NewName), fileChange{
+			Path:    diff.NewName,
+			Content: newText,
+		})
+	}
+
+	// リネームの場合も、エージェントは新しい名前のファイルに対する差分を出力する
+	content, err := repository.readFile(ctx, "refs/heads/"+branch, diff.NewName)
+	if err != nil {
+		return fmt.Errorf("failed to get file content: %w", err)
+	}
+	patchedText, results := dmp.PatchApply(patches, content)
+	if !anyPatchApplied(results) {
+		return fmt.Errorf("no changes were applied to the file")
+	}
+
+	if diff.OldName != diff.NewName {
+		return repository.commit(ctx, branch, fmt.Sprintf("Rename file %s to %s", diff.OldName, diff.NewName),
+			fileChange{Path: diff.OldName, Delete: true},
+			fileChange{Path: diff.NewName, Content: patchedText},
+		)
+	}
+	return repository.commit(ctx, branch, fmt.Sprintf("Update file %s", diff.NewName), fileChange{
+		Path:    diff.NewName,
+		Content: patchedText,
+	})
+}
+
+// anyPatchApplied checks if any of the patches were successfully applied
+func anyPatchApplied(results []bool) bool {
+	for _, applied := range results {
+		if applied {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package localgit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// FileQueryService reads files of a branch of the local repository
+type FileQueryService struct {
+	repository *Repository
+	branch     string
+}
+
+var _ port.FileQueryService = (*FileQueryService)(nil)
+
+func NewFileQueryService(repository *Repository, branch string) *FileQueryService {
+	return &FileQueryService{
+		repository: repository,
+		branch:     branch,
+	}
+}
+
+func (s *FileQueryService) FindFile(ctx context.Context, path string) (data.File, error) {
+	content, err := s.repository.readFile(ctx, "refs/heads/"+s.branch, path)
+	if errors.Is(err, errNotFound) {
+		return data.File{}, port.ErrFileNotFound
+	}
+	if err != nil {
+		return data.File{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data.File{
+		Path:    path,
+		Content: content,
+	}, nil
+}
+
+// GetTree lists the tree of the branch, or the tree of WithGetTreeTreeSHA if specified.
+// Paths are relative to the listed tree, as the GitHub API returns them.
+func (s *FileQueryService) GetTree(ctx context.Context, options ...port.GetTreeOption) ([]port.TreeMetadata, error) {
+	treeOptions := &port.GetTreeOptions{}
+	for _, option := range options {
+		option(treeOptions)
+	}
+
+	treeish := "refs/heads/" + s.branch
+	if treeOptions.TreeSHA != "" {
+		treeish = treeOptions.TreeSHA
+	}
+
+	args := []string{"ls-tree", "-z"}
+	if treeOptions.Recursive {
+		// -t also lists the directories, which are skipped by -r alone
+		args = append(args, "-r", "-t")
+	}
+	out, err := s.repository.run(ctx, nil, nil, append(args, treeish)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+
+	var treeMetadata []port.TreeMetadata
+	for _, entry := range strings.Split(out, "\x00") {
+		// Format: <mode> SP <type> SP <object> TAB <path>
+		info, path, ok := strings.Cut(entry, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(info)
+		if len(fields) != 3 {
+			continue
+		}
+		var treeType port.TreeType
+		switch fields[1] {
+		case "blob":
+			treeType = port.NodeTypeFile
+		case "tree":
+			treeType = port.NodeTypeDirectory
+		default:
+			// Submodules are not a part of the documents
+			continue
+		}
+		treeMetadata = append(treeMetadata, port.TreeMetadata{
+			Type: treeType,
+			SHA:  fields[2],
+			Path: path,
+		})
+	}
+	return treeMetadata, nil
+}
+
+// GetURI returns the URI of the file at the latest commit of the branch.
+// It is a file:// URI of the repository unless a URI template is configured.
+func (s *FileQueryService) GetURI(ctx context.Context, path string) (*data.URI, error) {
+	if s.repository.uriTemplate == "" {
+		uri := url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(s.repository.dir, path))}
+		return data.NewURI(uri.String())
+	}
+
+	commitSHA, err := s.repository.resolve(ctx, s.branch)
+	if err != nil {
+		return nil, err
+	}
+	replacer := strings.NewReplacer("{commit}", commitSHA, "{path}", path)
+	uri, err := data.NewURI(replacer.Replace(s.repository.uriTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create URI: %w", err)
+	}
+	return uri, nil
+}
+
+func (s *FileQueryService) GetFilePath(uri *data.URI) (string, error) {
+	if s.repository.uriTemplate == "" {
+		if uri.Scheme() != "file" {
+			return "", fmt.Errorf("invalid file URI: %s", uri)
+		}
+		path, err := filepath.Rel(s.repository.dir, filepath.FromSlash(uri.Path()))
+		if err != nil || path == "." || strings.HasPrefix(path, "..") {
+			return "", fmt.Errorf("URI is not in the repository: %s", uri)
+		}
+		return filepath.ToSlash(path), nil
+	}
+
+	matches := uriTemplatePattern(s.repository.uriTemplate).FindStringSubmatch(uri.Value())
+	if len(matches) != 2 {
+		return "", fmt.Errorf("URI does not match the template: %s", uri)
+	}
+	path := matches[1]
+	path, _, _ = strings.Cut(path, "?")
+	path, _, _ = strings.Cut(path, "#")
+	if path == "" {
+		return "", errors.New("URI has no file path: " + uri.Value())
+	}
+	return path, nil
+}
+
+// uriTemplatePattern converts the URI template to a pattern whose group is the path
+func uriTemplatePattern(template string) *regexp.Regexp {
+	pattern := regexp.QuoteMeta(template)
+	pattern = strings.ReplaceAll(pattern, regexp.QuoteMeta("{commit}"), "[0-9a-f]+")
+	pattern = strings.Replace(pattern, regexp.QuoteMeta("{path}"), "(.+)", 1)
+	return regexp.MustCompile("^" + pattern + "$")
+}
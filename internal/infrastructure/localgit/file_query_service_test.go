@@ -0,0 +1,127 @@
+package localgit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+func TestFileQueryService_FindFile(t *testing.T) {
+	repository := newTestRepository(t, map[string]string{"docs/guide.md": "# Guide\n"})
+	service := NewFileQueryService(repository, "main")
+
+	got, err := service.FindFile(context.Background(), "docs/guide.md")
+	require.NoError(t, err)
+	assert.Equal(t, data.File{Path: "docs/guide.md", Content: "# Guide\n"}, got)
+
+	_, err = service.FindFile(context.Background(), "docs/missing.md")
+	assert.ErrorIs(t, err, port.ErrFileNotFound)
+}
+
+func TestFileQueryService_GetTree(t *testing.T) {
+	repository := newTestRepository(t, map[string]string{
+		"README.md":     "# Docs\n",
+		"docs/guide.md": "# Guide\n",
+	})
+	service := NewFileQueryService(repository, "main")
+	ctx := context.Background()
+
+	tree, err := service.GetTree(ctx)
+	require.NoError(t, err)
+	require.Len(t, tree, 2)
+	assert.Equal(t, port.TreeMetadata{Type: port.NodeTypeFile, SHA: tree[0].SHA, Path: "README.md"}, tree[0])
+	assert.Equal(t, port.TreeMetadata{Type: port.NodeTypeDirectory, SHA: tree[1].SHA, Path: "docs"}, tree[1])
+
+	recursiveTree, err := service.GetTree(ctx, port.WithGetTreeRecursive())
+	require.NoError(t, err)
+	var paths []string
+	for _, entry := range recursiveTree {
+		paths = append(paths, entry.Path)
+	}
+	assert.Equal(t, []string{"README.md", "docs", "docs/guide.md"}, paths)
+
+	// ディレクトリのSHAを指定するとその中身を返す
+	subTree, err := service.GetTree(ctx, port.WithGetTreeTreeSHA(tree[1].SHA))
+	require.NoError(t, err)
+	require.Len(t, subTree, 1)
+	assert.Equal(t, "guide.md", subTree[0].Path)
+}
+
+func TestFileQueryService_GetURI(t *testing.T) {
+	tests := []struct {
+		name        string
+		uriTemplate string
+		wantPrefix  string
+	}{
+		{
+			name:       "テンプレートがない場合はfile URI",
+			wantPrefix: "file://",
+		},
+		{
+			name:        "テンプレートのURL",
+			uriTemplate: "https://git.example.com/docs/blob/{commit}/{path}",
+			wantPrefix:  "https://git.example.com/docs/blob/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var options []OpenOption
+			if tt.uriTemplate != "" {
+				options = append(options, WithURITemplate(tt.uriTemplate))
+			}
+			repository := newTestRepository(t, map[string]string{"docs/guide.md": "# Guide\n"}, options...)
+			service := NewFileQueryService(repository, "main")
+
+			uri, err := service.GetURI(context.Background(), "docs/guide.md")
+			require.NoError(t, err)
+			assert.Contains(t, uri.Value(), tt.wantPrefix)
+			assert.Contains(t, uri.Value(), "docs/guide.md")
+
+			// GetURIの結果はGetFilePathで元のパスに戻る
+			path, err := service.GetFilePath(uri)
+			require.NoError(t, err)
+			assert.Equal(t, "docs/guide.md", path)
+		})
+	}
+}
+
+func TestFileQueryService_GetFilePath(t *testing.T) {
+	repository := newTestRepository(t, nil, WithURITemplate("https://git.example.com/docs/blob/{commit}/{path}"))
+	service := NewFileQueryService(repository, "main")
+
+	tests := []struct {
+		name    string
+		uri     *data.URI
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "クエリとフラグメントは無視する",
+			uri:  data.NewURIUnsafe("https://git.example.com/docs/blob/abc123/docs/guide.md?plain=1#L3"),
+			want: "docs/guide.md",
+		},
+		{
+			name:    "テンプレートに一致しないURIはエラー",
+			uri:     data.NewURIUnsafe("https://git.example.com/other/blob/abc123/docs/guide.md"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.GetFilePath(tt.uri)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
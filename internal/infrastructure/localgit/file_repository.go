@@ -0,0 +1,121 @@
+package localgit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"docgent/internal/domain/data"
+	"docgent/internal/infrastructure/yaml"
+)
+
+// FileRepository はローカルのリポジトリのブランチに対するファイル操作を実装
+type FileRepository struct {
+	repository *Repository
+	branch     string
+}
+
+var _ data.FileRepository = (*FileRepository)(nil)
+
+func NewFileRepository(repository *Repository, branch string) *FileRepository {
+	return &FileRepository{
+		repository: repository,
+		branch:     branch,
+	}
+}
+
+// Create はファイルを作成します
+func (r *FileRepository) Create(ctx context.Context, file *data.File) error {
+	// ファイルの存在確認
+	_, err := r.repository.readFile(ctx, "refs/heads/"+r.branch, file.Path)
+	if err == nil {
+		return data.ErrFileAlreadyExists
+	}
+	if !errors.Is(err, errNotFound) {
+		return fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+
+	frontmatter, err := yaml.GenerateFrontmatter(file.SourceURIs)
+	if err != nil {
+		return fmt.Errorf("failed to generate frontmatter: %w", err)
+	}
+	content := yaml.CombineContentAndFrontmatter(frontmatter, file.Content)
+
+	change := fileChange{Path: file.Path, Content: content}
+	if err := r.repository.commit(ctx, r.branch, fmt.Sprintf("Create file %s", file.Path), change); err != nil {
+		return fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+	return nil
+}
+
+// Update はファイルを更新します
+func (r *FileRepository) Update(ctx context.Context, file *data.File) error {
+	currentContent, err := r.repository.readFile(ctx, "refs/heads/"+r.branch, file.Path)
+	if errors.Is(err, errNotFound) {
+		return fmt.Errorf("%w: %s", data.ErrFileNotFound, file.Path)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+
+	// 人間が設定した管理者は引き継ぐ
+	currentFrontmatter, _ := yaml.SplitContentAndFrontmatter(currentContent)
+	owners, _ := yaml.ParseOwners(currentFrontmatter)
+
+	frontmatter, err := yaml.GenerateFrontmatter(file.SourceURIs, owners...)
+	if err != nil {
+		return fmt.Errorf("%w: %s", data.ErrInvalidKnowledgeSource, err.Error())
+	}
+	content := yaml.CombineContentAndFrontmatter(frontmatter, file.Content)
+
+	change := fileChange{Path: file.Path, Content: content}
+	if err := r.repository.commit(ctx, r.branch, fmt.Sprintf("Update file %s", file.Path), change); err != nil {
+		return fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+	return nil
+}
+
+// Get はファイルを取得します
+func (r *FileRepository) Get(ctx context.Context, path string) (*data.File, error) {
+	content, err := r.repository.readFile(ctx, "refs/heads/"+r.branch, path)
+	if errors.Is(err, errNotFound) {
+		return nil, fmt.Errorf("%w: %s", data.ErrFileNotFound, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+
+	// フロントマターとコンテンツを分離
+	frontmatter, body := yaml.SplitContentAndFrontmatter(content)
+
+	var sourceURIs []*data.URI
+	if frontmatter != "" {
+		sourceURIs, err = yaml.ParseFrontmatter(frontmatter)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", data.ErrInvalidFrontmatter, err.Error())
+		}
+	}
+
+	return &data.File{
+		Path:       path,
+		Content:    body,
+		SourceURIs: sourceURIs,
+	}, nil
+}
+
+// Delete はファイルを削除します
+func (r *FileRepository) Delete(ctx context.Context, path string) error {
+	_, err := r.repository.readFile(ctx, "refs/heads/"+r.branch, path)
+	if errors.Is(err, errNotFound) {
+		return fmt.Errorf("%w: %s", data.ErrFileNotFound, path)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+
+	change := fileChange{Path: path, Delete: true}
+	if err := r.repository.commit(ctx, r.branch, fmt.Sprintf("Delete file %s", path), change); err != nil {
+		return fmt.Errorf("%w: %s", data.ErrFailedToAccessFile, err.Error())
+	}
+	return nil
+}
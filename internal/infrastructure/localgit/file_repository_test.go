@@ -0,0 +1,58 @@
+package localgit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"docgent/internal/domain/data"
+)
+
+func TestFileRepository(t *testing.T) {
+	repository := newTestRepository(t, map[string]string{
+		"docs/owned.md": "---\nsources:\n  - https://example.com/old\nowners:\n  - alice\n---\n# Owned\n",
+	})
+	fileRepository := NewFileRepository(repository, "main")
+	ctx := context.Background()
+	source := data.NewURIUnsafe("https://example.com/source")
+
+	t.Run("作成したファイルを取得できる", func(t *testing.T) {
+		err := fileRepository.Create(ctx, &data.File{Path: "docs/guide.md", Content: "# Guide\n", SourceURIs: []*data.URI{source}})
+		require.NoError(t, err)
+
+		got, err := fileRepository.Get(ctx, "docs/guide.md")
+		require.NoError(t, err)
+		assert.Equal(t, &data.File{Path: "docs/guide.md", Content: "# Guide\n", SourceURIs: []*data.URI{source}}, got)
+	})
+
+	t.Run("既に存在するファイルは作成できない", func(t *testing.T) {
+		err := fileRepository.Create(ctx, &data.File{Path: "docs/guide.md", Content: "# Guide\n"})
+		assert.ErrorIs(t, err, data.ErrFileAlreadyExists)
+	})
+
+	t.Run("更新しても管理者は引き継がれる", func(t *testing.T) {
+		err := fileRepository.Update(ctx, &data.File{Path: "docs/owned.md", Content: "# New\n", SourceURIs: []*data.URI{source}})
+		require.NoError(t, err)
+
+		content, err := repository.readFile(ctx, "refs/heads/main", "docs/owned.md")
+		require.NoError(t, err)
+		assert.Contains(t, content, "alice")
+		assert.Contains(t, content, "https://example.com/source")
+		assert.NotContains(t, content, "https://example.com/old")
+	})
+
+	t.Run("存在しないファイルは更新できない", func(t *testing.T) {
+		err := fileRepository.Update(ctx, &data.File{Path: "docs/missing.md", Content: "# Missing\n"})
+		assert.ErrorIs(t, err, data.ErrFileNotFound)
+	})
+
+	t.Run("削除したファイルは取得できない", func(t *testing.T) {
+		require.NoError(t, fileRepository.Delete(ctx, "docs/guide.md"))
+
+		_, err := fileRepository.Get(ctx, "docs/guide.md")
+		assert.ErrorIs(t, err, data.ErrFileNotFound)
+		assert.ErrorIs(t, fileRepository.Delete(ctx, "docs/guide.md"), data.ErrFileNotFound)
+	})
+}
@@ -0,0 +1,257 @@
+package localgit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"docgent/internal/domain"
+	"docgent/internal/infrastructure/github/diffutil"
+)
+
+const (
+	proposalHandleSource = "localgit-proposal"
+	commentHandleSource  = "localgit-proposal-comment"
+
+	// proposalRefPrefix is the namespace of the metadata of proposals.
+	// Each ref points to a JSON blob, like a note that is not attached to a commit, so it is neither checked out nor pushed by default
+	proposalRefPrefix = "refs/docgent/proposals/"
+)
+
+// ProposalRepository implements domain.ProposalRepository with local branches.
+// A proposal is the diff between its head branch and the base branch, and its title, description and comments are kept as metadata in the repository.
+type ProposalRepository struct {
+	repository    *Repository
+	defaultBranch string
+	headBranch    string
+	commentAuthor string
+}
+
+var _ domain.ProposalRepository = (*ProposalRepository)(nil)
+
+func NewProposalRepository(repository *Repository, defaultBranch, headBranch string) *ProposalRepository {
+	return &ProposalRepository{
+		repository:    repository,
+		defaultBranch: defaultBranch,
+		headBranch:    headBranch,
+		commentAuthor: repository.authorName,
+	}
+}
+
+// proposalMetadata is stored as JSON in the repository
+type proposalMetadata struct {
+	Title      string            `json:"title"`
+	Body       string            `json:"body"`
+	BaseBranch string            `json:"base_branch"`
+	HeadBranch string            `json:"head_branch"`
+	Comments   []proposalComment `json:"comments"`
+}
+
+type proposalComment struct {
+	ID     int    `json:"id"`
+	Author string `json:"author"`
+	Body   string `json:"body"`
+}
+
+func (s *ProposalRepository) NewProposalHandle(value string) domain.ProposalHandle {
+	return domain.NewProposalHandle(proposalHandleSource, value)
+}
+
+func (s *ProposalRepository) NewCommentHandle(commentID string) domain.CommentHandle {
+	return domain.NewCommentHandle(commentHandleSource, commentID)
+}
+
+func (s *ProposalRepository) CreateProposal(diffs domain.Diffs, content domain.ProposalContent) (domain.ProposalHandle, error) {
+	ctx := context.Background()
+
+	for _, diff := range diffs {
+		if err := applyDiff(ctx, s.repository, s.headBranch, diff); err != nil {
+			return domain.ProposalHandle{}, fmt.Errorf("failed to resolve diff: %w", err)
+		}
+	}
+
+	s.repository.refLock.Lock()
+	defer s.repository.refLock.Unlock()
+
+	number, err := s.nextNumber(ctx)
+	if err != nil {
+		return domain.ProposalHandle{}, err
+	}
+	metadata := proposalMetadata{
+		Title:      content.Title,
+		Body:       content.Body,
+		BaseBranch: s.defaultBranch,
+		HeadBranch: s.headBranch,
+	}
+	if err := s.writeMetadata(ctx, number, metadata); err != nil {
+		return domain.ProposalHandle{}, err
+	}
+
+	return s.NewProposalHandle(strconv.Itoa(number)), nil
+}
+
+func (s *ProposalRepository) GetProposal(handle domain.ProposalHandle) (domain.Proposal, error) {
+	ctx := context.Background()
+
+	number, err := s.parseHandle(handle)
+	if err != nil {
+		return domain.Proposal{}, err
+	}
+	metadata, err := s.readMetadata(ctx, number)
+	if err != nil {
+		return domain.Proposal{}, err
+	}
+
+	// Like a pull request, the diff is taken from the merge base
+	diff, err := s.repository.run(ctx, nil, nil, "diff", "--no-color", "--no-ext-diff", "--find-renames",
+		"refs/heads/"+metadata.BaseBranch+"...refs/heads/"+metadata.HeadBranch)
+	if err != nil {
+		return domain.Proposal{}, fmt.Errorf("failed to get proposal diff: %w", err)
+	}
+	diffs := diffutil.NewParser().Execute(diff)
+
+	comments := make([]domain.Comment, len(metadata.Comments))
+	for i, comment := range metadata.Comments {
+		comments[i] = domain.NewComment(s.NewCommentHandle(strconv.Itoa(comment.ID)), comment.Author, comment.Body)
+	}
+
+	content := domain.ProposalContent{
+		Title: metadata.Title,
+		Body:  metadata.Body,
+	}
+	return domain.NewProposal(handle, diffs, content, comments), nil
+}
+
+// GetHeadBranch returns the branch that the proposal is committed to, so that it can be refined outside of this repository
+func (s *ProposalRepository) GetHeadBranch(handle domain.ProposalHandle) (string, error) {
+	number, err := s.parseHandle(handle)
+	if err != nil {
+		return "", err
+	}
+	metadata, err := s.readMetadata(context.Background(), number)
+	if err != nil {
+		return "", err
+	}
+	return metadata.HeadBranch, nil
+}
+
+func (s *ProposalRepository) CreateComment(proposalHandle domain.ProposalHandle, commentBody string) (domain.Comment, error) {
+	ctx := context.Background()
+
+	number, err := s.parseHandle(proposalHandle)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	s.repository.refLock.Lock()
+	defer s.repository.refLock.Unlock()
+
+	metadata, err := s.readMetadata(ctx, number)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	comment := proposalComment{
+		ID:     len(metadata.Comments) + 1,
+		Author: s.commentAuthor,
+		Body:   commentBody,
+	}
+	metadata.Comments = append(metadata.Comments, comment)
+	if err := s.writeMetadata(ctx, number, metadata); err != nil {
+		return domain.Comment{}, err
+	}
+
+	return domain.NewComment(s.NewCommentHandle(strconv.Itoa(comment.ID)), comment.Author, comment.Body), nil
+}
+
+func (s *ProposalRepository) ApplyProposalDiffs(handle domain.ProposalHandle, diffs domain.Diffs) error {
+	ctx := context.Background()
+
+	number, err := s.parseHandle(handle)
+	if err != nil {
+		return err
+	}
+	metadata, err := s.readMetadata(ctx, number)
+	if err != nil {
+		return err
+	}
+
+	for _, diff := range diffs {
+		if err := applyDiff(ctx, s.repository, metadata.HeadBranch, diff); err != nil {
+			return fmt.Errorf("failed to resolve diff: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *ProposalRepository) UpdateProposalContent(proposalHandle domain.ProposalHandle, content domain.ProposalContent) error {
+	ctx := context.Background()
+
+	number, err := s.parseHandle(proposalHandle)
+	if err != nil {
+		return err
+	}
+
+	s.repository.refLock.Lock()
+	defer s.repository.refLock.Unlock()
+
+	metadata, err := s.readMetadata(ctx, number)
+	if err != nil {
+		return err
+	}
+	metadata.Title = content.Title
+	metadata.Body = content.Body
+	return s.writeMetadata(ctx, number, metadata)
+}
+
+func (s *ProposalRepository) parseHandle(handle domain.ProposalHandle) (int, error) {
+	number, err := strconv.Atoi(handle.Value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse proposal handle to number: %w", err)
+	}
+	return number, nil
+}
+
+// nextNumber returns the number following the largest one. The caller must hold refLock
+func (s *ProposalRepository) nextNumber(ctx context.Context) (int, error) {
+	out, err := s.repository.run(ctx, nil, nil, "for-each-ref", "--format=%(refname)", proposalRefPrefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list proposals: %w", err)
+	}
+	next := 1
+	for _, ref := range strings.Fields(out) {
+		if number, err := strconv.Atoi(strings.TrimPrefix(ref, proposalRefPrefix)); err == nil && number >= next {
+			next = number + 1
+		}
+	}
+	return next, nil
+}
+
+func (s *ProposalRepository) readMetadata(ctx context.Context, number int) (proposalMetadata, error) {
+	out, err := s.repository.run(ctx, nil, nil, "cat-file", "blob", proposalRefPrefix+strconv.Itoa(number))
+	if err != nil {
+		return proposalMetadata{}, fmt.Errorf("%w: proposal %d", errNotFound, number)
+	}
+	var metadata proposalMetadata
+	if err := json.Unmarshal([]byte(out), &metadata); err != nil {
+		return proposalMetadata{}, fmt.Errorf("failed to parse metadata of proposal %d: %w", number, err)
+	}
+	return metadata, nil
+}
+
+// writeMetadata stores the metadata as a blob and points the ref of the proposal to it
+func (s *ProposalRepository) writeMetadata(ctx context.Context, number int, metadata proposalMetadata) error {
+	b, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	blob, err := s.repository.run(ctx, nil, b, "hash-object", "-w", "--stdin")
+	if err != nil {
+		return err
+	}
+	if _, err := s.repository.run(ctx, nil, nil, "update-ref", proposalRefPrefix+strconv.Itoa(number), strings.TrimSpace(blob)); err != nil {
+		return fmt.Errorf("failed to save proposal %d: %w", number, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package localgit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"docgent/internal/domain"
+)
+
+func TestProposalRepository(t *testing.T) {
+	repository := newTestRepository(t, map[string]string{"README.md": "# Docs\n"})
+	ctx := context.Background()
+	require.NoError(t, NewBranchService(repository).CreateBranch(ctx, "main", "docgent/guide"))
+
+	proposalRepository := NewProposalRepository(repository, "main", "docgent/guide")
+
+	handle, err := proposalRepository.CreateProposal(
+		domain.Diffs{domain.NewCreateDiff("docs/guide.md", "@@ -0,0 +1,8 @@\n+# Guide%0A\n")},
+		domain.ProposalContent{Title: "Add guide", Body: "Adds a guide"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewProposalHandle("localgit-proposal", "1"), handle)
+
+	// 提案はヘッドブランチにだけコミットされる
+	_, err = repository.readFile(ctx, "refs/heads/main", "docs/guide.md")
+	assert.ErrorIs(t, err, errNotFound)
+
+	comment, err := proposalRepository.CreateComment(handle, "Please review")
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewComment(proposalRepository.NewCommentHandle("1"), "Docgent", "Please review"), comment)
+
+	require.NoError(t, proposalRepository.UpdateProposalContent(handle, domain.ProposalContent{Title: "Add a guide", Body: "Adds a guide for users"}))
+	require.NoError(t, proposalRepository.ApplyProposalDiffs(handle, domain.Diffs{
+		domain.NewUpdateDiff("docs/guide.md", "docs/guide.md", "@@ -1,8 +1,12 @@\n-# Guide%0A\n+# User Guide%0A\n"),
+	}))
+
+	// ヘッドブランチを指定しなくてもメタデータから提案を取得できる
+	proposal, err := NewProposalRepository(repository, "main", "").GetProposal(handle)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProposalContent{Title: "Add a guide", Body: "Adds a guide for users"}, proposal.ProposalContent)
+	assert.Equal(t, []domain.Comment{comment}, proposal.Comments)
+	require.Len(t, proposal.Diffs, 1)
+	assert.Equal(t, "docs/guide.md", proposal.Diffs[0].NewName)
+	assert.True(t, proposal.Diffs[0].IsNewFile)
+	assert.Contains(t, proposal.Diffs[0].Body, "+# User Guide")
+
+	headBranch, err := NewProposalRepository(repository, "main", "").GetHeadBranch(handle)
+	require.NoError(t, err)
+	assert.Equal(t, "docgent/guide", headBranch)
+
+	// 次の提案には新しい番号が振られる
+	require.NoError(t, NewBranchService(repository).CreateBranch(ctx, "main", "docgent/other"))
+	other, err := NewProposalRepository(repository, "main", "docgent/other").CreateProposal(nil, domain.ProposalContent{Title: "Other"})
+	require.NoError(t, err)
+	assert.Equal(t, "2", other.Value)
+
+	_, err = proposalRepository.GetProposal(proposalRepository.NewProposalHandle("3"))
+	assert.Error(t, err)
+}
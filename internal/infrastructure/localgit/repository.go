@@ -0,0 +1,192 @@
+package localgit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// errNotFound is returned when a file or a ref does not exist
+var errNotFound = errors.New("not found")
+
+// Repository is a git repository on disk operated through the git command.
+// Files are read and committed with plumbing commands on a temporary index,
+// so neither the working copy nor the checked out branch are touched, and bare repositories work as well.
+type Repository struct {
+	dir         string
+	gitPath     string
+	authorName  string
+	authorEmail string
+	uriTemplate string
+
+	// refLock serializes commits so that concurrent updates of a ref are not lost
+	refLock sync.Mutex
+}
+
+type OpenOption func(*Repository)
+
+// WithGitPath replaces the git command found in PATH
+func WithGitPath(gitPath string) OpenOption {
+	return func(r *Repository) {
+		r.gitPath = gitPath
+	}
+}
+
+// WithAuthor sets the author and committer of Docgent's commits. The default is "Docgent <docgent@localhost>"
+func WithAuthor(name, email string) OpenOption {
+	return func(r *Repository) {
+		r.authorName = name
+		r.authorEmail = email
+	}
+}
+
+// WithURITemplate makes GetURI return URLs of a web viewer instead of file:// URIs.
+// "{commit}" and "{path}" in the template are replaced with the commit SHA and the file path,
+// e.g. "https://git.example.com/docs/blob/{commit}/{path}"
+func WithURITemplate(template string) OpenOption {
+	return func(r *Repository) {
+		r.uriTemplate = template
+	}
+}
+
+// Open opens the repository at dir
+func Open(ctx context.Context, dir string, options ...OpenOption) (*Repository, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve repository path: %w", err)
+	}
+
+	r := &Repository{
+		dir:         absDir,
+		gitPath:     "git",
+		authorName:  "Docgent",
+		authorEmail: "docgent@localhost",
+	}
+	for _, option := range options {
+		option(r)
+	}
+
+	if _, err := r.run(ctx, nil, nil, "rev-parse", "--git-dir"); err != nil {
+		return nil, fmt.Errorf("not a git repository: %s: %w", absDir, err)
+	}
+	return r, nil
+}
+
+// Dir returns the absolute path of the repository
+func (r *Repository) Dir() string {
+	return r.dir
+}
+
+// run runs git in the repository and returns its stdout
+func (r *Repository) run(ctx context.Context, env []string, stdin []byte, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, r.gitPath, args...)
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+r.authorName,
+		"GIT_AUTHOR_EMAIL="+r.authorEmail,
+		"GIT_COMMITTER_NAME="+r.authorName,
+		"GIT_COMMITTER_EMAIL="+r.authorEmail,
+		// Messages are parsed, so they must not be translated
+		"LC_ALL=C",
+	)
+	cmd.Env = append(cmd.Env, env...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// resolve returns the commit SHA of the branch
+func (r *Repository) resolve(ctx context.Context, branch string) (string, error) {
+	out, err := r.run(ctx, nil, nil, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("%w: branch %s", errNotFound, branch)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// readFile returns the content of the file at the revision, or errNotFound
+func (r *Repository) readFile(ctx context.Context, rev, path string) (string, error) {
+	object := rev + ":" + path
+	if _, err := r.run(ctx, nil, nil, "cat-file", "-e", object); err != nil {
+		return "", fmt.Errorf("%w: %s", errNotFound, object)
+	}
+	out, err := r.run(ctx, nil, nil, "cat-file", "blob", object)
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// fileChange is a change of a file in a commit. Content is ignored when Delete is true
+type fileChange struct {
+	Path    string
+	Content string
+	Delete  bool
+}
+
+// commit commits the changes on top of the branch and moves the branch to the new commit
+func (r *Repository) commit(ctx context.Context, branch, message string, changes ...fileChange) error {
+	r.refLock.Lock()
+	defer r.refLock.Unlock()
+
+	parent, err := r.resolve(ctx, branch)
+	if err != nil {
+		return err
+	}
+
+	indexFile, err := os.CreateTemp("", "docgent-index-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index: %w", err)
+	}
+	indexFile.Close()
+	defer os.Remove(indexFile.Name())
+	env := []string{"GIT_INDEX_FILE=" + indexFile.Name()}
+
+	if _, err := r.run(ctx, env, nil, "read-tree", parent); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if change.Delete {
+			if _, err := r.run(ctx, env, nil, "update-index", "--force-remove", "--", change.Path); err != nil {
+				return err
+			}
+			continue
+		}
+		blob, err := r.run(ctx, nil, []byte(change.Content), "hash-object", "-w", "--stdin")
+		if err != nil {
+			return err
+		}
+		cacheInfo := fmt.Sprintf("100644,%s,%s", strings.TrimSpace(blob), change.Path)
+		if _, err := r.run(ctx, env, nil, "update-index", "--add", "--cacheinfo", cacheInfo); err != nil {
+			return err
+		}
+	}
+
+	tree, err := r.run(ctx, env, nil, "write-tree")
+	if err != nil {
+		return err
+	}
+	commitSHA, err := r.run(ctx, nil, []byte(message), "commit-tree", strings.TrimSpace(tree), "-p", parent)
+	if err != nil {
+		return err
+	}
+	// The old value guards against updates by other processes
+	if _, err := r.run(ctx, nil, nil, "update-ref", "refs/heads/"+branch, strings.TrimSpace(commitSHA), parent); err != nil {
+		return fmt.Errorf("failed to update branch %s: %w", branch, err)
+	}
+	return nil
+}
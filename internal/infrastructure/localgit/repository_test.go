@@ -0,0 +1,67 @@
+package localgit
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepository creates a repository whose main branch has the files
+func newTestRepository(t *testing.T, files map[string]string, options ...OpenOption) *Repository {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "--quiet", "--initial-branch=main")
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0o644))
+	}
+	git("add", "-A")
+	git("commit", "--quiet", "--allow-empty", "-m", "initial")
+
+	repository, err := Open(context.Background(), dir, options...)
+	require.NoError(t, err)
+	return repository
+}
+
+func TestOpen(t *testing.T) {
+	_, err := Open(context.Background(), t.TempDir())
+	require.Error(t, err)
+}
+
+func TestRepository_commit(t *testing.T) {
+	repository := newTestRepository(t, map[string]string{"README.md": "# Docs\n", "old.md": "old\n"})
+	ctx := context.Background()
+
+	err := repository.commit(ctx, "main", "Change files",
+		fileChange{Path: "docs/new.md", Content: "new\n"},
+		fileChange{Path: "old.md", Delete: true},
+	)
+	require.NoError(t, err)
+
+	content, err := repository.readFile(ctx, "refs/heads/main", "docs/new.md")
+	require.NoError(t, err)
+	require.Equal(t, "new\n", content)
+	_, err = repository.readFile(ctx, "refs/heads/main", "old.md")
+	require.ErrorIs(t, err, errNotFound)
+
+	// 作業ツリーは変更されない
+	_, err = os.Stat(filepath.Join(repository.Dir(), "docs/new.md"))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(repository.Dir(), "old.md"))
+	require.NoError(t, err)
+}
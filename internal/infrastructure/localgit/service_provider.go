@@ -0,0 +1,34 @@
+package localgit
+
+import (
+	"docgent/internal/domain"
+)
+
+// ServiceProvider creates services of a local repository
+type ServiceProvider struct {
+	repository *Repository
+}
+
+func NewServiceProvider(repository *Repository) *ServiceProvider {
+	return &ServiceProvider{repository: repository}
+}
+
+// NewFileQueryService creates a file query service of the branch
+func (p *ServiceProvider) NewFileQueryService(branch string) *FileQueryService {
+	return NewFileQueryService(p.repository, branch)
+}
+
+// NewFileRepository creates a file repository of the branch
+func (p *ServiceProvider) NewFileRepository(branch string) *FileRepository {
+	return NewFileRepository(p.repository, branch)
+}
+
+// NewBranchService creates a branch service of the repository
+func (p *ServiceProvider) NewBranchService() *BranchService {
+	return NewBranchService(p.repository)
+}
+
+// NewProposalRepository creates a proposal repository of local branches
+func (p *ServiceProvider) NewProposalRepository(baseBranch, headBranch string) domain.ProposalRepository {
+	return NewProposalRepository(p.repository, baseBranch, headBranch)
+}
@@ -0,0 +1,206 @@
+package localrag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"docgent/internal/application/chunking"
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+	"docgent/internal/infrastructure/ragstore"
+)
+
+// ErrFileNotFound is returned when deleting a file that is not in the corpus
+var ErrFileNotFound = errors.New("rag file not found")
+
+// defaultSimilarityTopK is used when the query does not limit the number of documents
+const defaultSimilarityTopK = 10
+
+// corpusFileName is the file in the directory of a corpus that holds its files, chunks and vectors
+const corpusFileName = "corpus.json"
+
+// Corpus implements port.RAGCorpus with vectors stored in a JSON file.
+// All vectors are loaded in memory and searched exhaustively, which is fast enough for the documents of a team.
+type Corpus struct {
+	embedder Embedder
+
+	lock  sync.Mutex
+	store *ragstore.JSONFile[corpusData]
+}
+
+var _ port.RAGCorpus = (*Corpus)(nil)
+
+// corpusData is stored as JSON on disk
+type corpusData struct {
+	// Embedder is the name of the embedder that the vectors were created by
+	Embedder   string     `json:"embedder"`
+	NextFileID int64      `json:"next_file_id"`
+	Files      []fileData `json:"files"`
+}
+
+type fileData struct {
+	ID          int64       `json:"id"`
+	URI         string      `json:"uri"`
+	Description string      `json:"description"`
+	Chunks      []chunkData `json:"chunks"`
+}
+
+type chunkData struct {
+	Text   string    `json:"text"`
+	Vector []float32 `json:"vector"`
+}
+
+// NewCorpus creates a corpus stored in the directory. The directory is created on the first upload
+func NewCorpus(dir string, embedder Embedder) *Corpus {
+	return &Corpus{
+		embedder: embedder,
+		store:    ragstore.NewJSONFile[corpusData](filepath.Join(dir, corpusFileName), "corpus"),
+	}
+}
+
+// Query returns the chunks closest to the query in cosine distance.
+// Chunks farther than vectorDistanceThreshold are excluded unless the threshold is zero.
+// Score is the cosine similarity, so it is 1 minus the distance.
+func (c *Corpus) Query(ctx context.Context, query string, similarityTopK int32, vectorDistanceThreshold float64) ([]port.RAGDocument, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	corpus, err := c.store.Load()
+	if err != nil {
+		return nil, err
+	}
+	if len(corpus.Files) == 0 {
+		return nil, nil
+	}
+	if corpus.Embedder != c.embedder.Name() {
+		return nil, fmt.Errorf("corpus was embedded by %s, not %s", corpus.Embedder, c.embedder.Name())
+	}
+
+	vectors, err := c.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	var documents []port.RAGDocument
+	for _, file := range corpus.Files {
+		for _, chunk := range file.Chunks {
+			similarity := cosineSimilarity(vectors[0], chunk.Vector)
+			if vectorDistanceThreshold > 0 && 1-similarity > vectorDistanceThreshold {
+				continue
+			}
+			documents = append(documents, port.RAGDocument{
+				Content: chunk.Text,
+				Source:  file.URI,
+				Score:   max(similarity, 0),
+			})
+		}
+	}
+
+	sort.SliceStable(documents, func(i, j int) bool {
+		return documents[i].Score > documents[j].Score
+	})
+	if similarityTopK <= 0 {
+		similarityTopK = defaultSimilarityTopK
+	}
+	if len(documents) > int(similarityTopK) {
+		documents = documents[:similarityTopK]
+	}
+	return documents, nil
+}
+
+// UploadFile chunks and embeds the file and adds it with a new ID.
+// Like Vertex AI RAG Engine, a file with the same URI is not replaced, so callers delete the old one.
+func (c *Corpus) UploadFile(ctx context.Context, file io.Reader, uri *data.URI, options ...port.RAGCorpusUploadFileOption) (port.RAGFile, error) {
+	uploadFileOptions := ragstore.UploadFileOptions(options...)
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return port.RAGFile{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	texts := chunking.Split(string(content), uploadFileOptions.ChunkingConfig.ChunkSize, uploadFileOptions.ChunkingConfig.ChunkOverlap)
+	vectors, err := c.embedder.Embed(ctx, texts)
+	if err != nil {
+		return port.RAGFile{}, fmt.Errorf("failed to embed file: %w", err)
+	}
+	chunks := make([]chunkData, len(texts))
+	for i, text := range texts {
+		chunks[i] = chunkData{Text: text, Vector: vectors[i]}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	corpus, err := c.store.Load()
+	if err != nil {
+		return port.RAGFile{}, err
+	}
+	if len(corpus.Files) == 0 {
+		corpus.Embedder = c.embedder.Name()
+	} else if corpus.Embedder != c.embedder.Name() {
+		return port.RAGFile{}, fmt.Errorf("corpus was embedded by %s, not %s", corpus.Embedder, c.embedder.Name())
+	}
+
+	corpus.NextFileID++
+	corpus.Files = append(corpus.Files, fileData{
+		ID:          corpus.NextFileID,
+		URI:         uri.Value(),
+		Description: uploadFileOptions.Description,
+		Chunks:      chunks,
+	})
+	if err := c.store.Save(); err != nil {
+		return port.RAGFile{}, err
+	}
+
+	return port.RAGFile{
+		ID:          corpus.NextFileID,
+		URI:         uri,
+		Description: uploadFileOptions.Description,
+	}, nil
+}
+
+func (c *Corpus) ListFiles(ctx context.Context) ([]port.RAGFile, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	corpus, err := c.store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	ragFiles := make([]port.RAGFile, len(corpus.Files))
+	for i, file := range corpus.Files {
+		uri, err := data.NewURI(file.URI)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create URI: %w", err)
+		}
+		ragFiles[i] = port.RAGFile{
+			ID:          file.ID,
+			URI:         uri,
+			Description: file.Description,
+		}
+	}
+	return ragFiles, nil
+}
+
+func (c *Corpus) DeleteFile(ctx context.Context, fileID int64) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	corpus, err := c.store.Load()
+	if err != nil {
+		return err
+	}
+
+	for i, file := range corpus.Files {
+		if file.ID == fileID {
+			corpus.Files = append(corpus.Files[:i], corpus.Files[i+1:]...)
+			return c.store.Save()
+		}
+	}
+	return fmt.Errorf("failed to delete file: %w: %d", ErrFileNotFound, fileID)
+}
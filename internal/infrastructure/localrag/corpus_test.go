@@ -0,0 +1,94 @@
+package localrag
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+func TestCorpus(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	deployURI := data.NewURIUnsafe("https://github.com/owner/repo/blob/main/docs/deploy.md")
+	lunchURI := data.NewURIUnsafe("https://github.com/owner/repo/blob/main/docs/lunch.md")
+
+	corpus := NewService(dir, NewHashEmbedder(0)).GetCorpus(1)
+	file, err := corpus.UploadFile(ctx, strings.NewReader("# Deploy\n\nRun make deploy to deploy the API server."), deployURI, port.WithRagFileDescription("deploy guide"))
+	require.NoError(t, err)
+	assert.Equal(t, port.RAGFile{ID: 1, URI: deployURI, Description: "deploy guide"}, file)
+	_, err = corpus.UploadFile(ctx, strings.NewReader("# Lunch\n\nThe cafeteria opens at noon."), lunchURI)
+	require.NoError(t, err)
+
+	documents, err := corpus.Query(ctx, "how to deploy the API server", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, documents, 2)
+	assert.Equal(t, deployURI.Value(), documents[0].Source)
+	assert.Equal(t, "# Deploy\n\nRun make deploy to deploy the API server.", documents[0].Content)
+	assert.Greater(t, documents[0].Score, documents[1].Score)
+
+	// 距離のしきい値より遠いチャンクは返さない
+	documents, err = corpus.Query(ctx, "how to deploy the API server", 10, 1-documents[1].Score-0.01)
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+	assert.Equal(t, deployURI.Value(), documents[0].Source)
+
+	// 件数を制限できる
+	documents, err = corpus.Query(ctx, "how to deploy the API server", 1, 0)
+	require.NoError(t, err)
+	assert.Len(t, documents, 1)
+
+	// 別のインスタンスからもディスク上のコーパスを読める
+	reopened := NewCorpus(dir+"/1", NewHashEmbedder(0))
+	files, err := reopened.ListFiles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []port.RAGFile{
+		{ID: 1, URI: deployURI, Description: "deploy guide"},
+		{ID: 2, URI: lunchURI},
+	}, files)
+
+	require.NoError(t, reopened.DeleteFile(ctx, 1))
+	assert.ErrorIs(t, reopened.DeleteFile(ctx, 1), ErrFileNotFound)
+	documents, err = reopened.Query(ctx, "how to deploy the API server", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+	assert.Equal(t, lunchURI.Value(), documents[0].Source)
+
+	// 再アップロードしたファイルには新しいIDが振られる
+	file, err = reopened.UploadFile(ctx, strings.NewReader("# Deploy"), deployURI)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), file.ID)
+	files, err = reopened.ListFiles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), files[1].ID)
+}
+
+func TestCorpus_EmbedderMismatch(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	uri := data.NewURIUnsafe("file:///docs/guide.md")
+	_, err := NewCorpus(dir, NewHashEmbedder(64)).UploadFile(ctx, strings.NewReader("Guide"), uri)
+	require.NoError(t, err)
+
+	corpus := NewCorpus(dir, NewHashEmbedder(128))
+	_, err = corpus.Query(ctx, "guide", 10, 0)
+	assert.Error(t, err)
+	_, err = corpus.UploadFile(ctx, strings.NewReader("Guide"), uri)
+	assert.Error(t, err)
+}
+
+func TestCorpus_Empty(t *testing.T) {
+	corpus := NewCorpus(t.TempDir(), NewHashEmbedder(0))
+
+	documents, err := corpus.Query(context.Background(), "guide", 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, documents)
+	files, err := corpus.ListFiles(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
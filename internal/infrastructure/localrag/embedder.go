@@ -0,0 +1,112 @@
+package localrag
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Embedder converts texts into vectors. Texts with similar meanings should have close vectors in cosine distance
+type Embedder interface {
+	// Name identifies the model and its dimensions. Vectors of different embedders are not comparable
+	Name() string
+	// Embed returns a vector for each text in the same order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// DefaultHashEmbedderDimensions is the number of dimensions of NewHashEmbedder(0)
+const DefaultHashEmbedderDimensions = 512
+
+// HashEmbedder is a deterministic embedder that needs no model.
+// It hashes words and character trigrams into a fixed number of dimensions (the hashing trick),
+// so texts sharing words are close. It does not understand synonyms, but it is good enough for development and tests.
+type HashEmbedder struct {
+	dimensions int
+}
+
+var _ Embedder = (*HashEmbedder)(nil)
+
+func NewHashEmbedder(dimensions int) *HashEmbedder {
+	if dimensions <= 0 {
+		dimensions = DefaultHashEmbedderDimensions
+	}
+	return &HashEmbedder{dimensions: dimensions}
+}
+
+func (e *HashEmbedder) Name() string {
+	return fmt.Sprintf("hash-%d", e.dimensions)
+}
+
+func (e *HashEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = e.embed(text)
+	}
+	return vectors, nil
+}
+
+func (e *HashEmbedder) embed(text string) []float32 {
+	vector := make([]float32, e.dimensions)
+	for _, word := range words(text) {
+		e.add(vector, "w:"+word, 1)
+		// 日本語のように単語を空白で区切らない言語でも一致するように文字の3-gramも加える
+		runes := []rune("^" + word + "$")
+		for i := 0; i+3 <= len(runes); i++ {
+			e.add(vector, "t:"+string(runes[i:i+3]), 0.5)
+		}
+	}
+	normalize(vector)
+	return vector
+}
+
+// add adds the weight to the dimension of the feature. The sign is also hashed so that collisions cancel out on average
+func (e *HashEmbedder) add(vector []float32, feature string, weight float32) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	vector[sum%uint64(e.dimensions)] += weight
+}
+
+// words splits the text into lowercase runs of letters and digits
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func normalize(vector []float32) {
+	var sum float64
+	for _, v := range vector {
+		sum += float64(v) * float64(v)
+	}
+	if sum == 0 {
+		return
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range vector {
+		vector[i] /= norm
+	}
+}
+
+// cosineSimilarity returns the cosine of the angle between the vectors, or 0 if either is zero
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
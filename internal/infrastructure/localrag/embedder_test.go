@@ -0,0 +1,37 @@
+package localrag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashEmbedder_Embed(t *testing.T) {
+	embedder := NewHashEmbedder(0)
+	ctx := context.Background()
+
+	vectors, err := embedder.Embed(ctx, []string{
+		"How to deploy the API server",
+		"Deploy the API server with make deploy",
+		"Lunch menu of the cafeteria",
+		"デプロイ手順",
+		"本番環境へのデプロイ手順について",
+	})
+	require.NoError(t, err)
+	require.Len(t, vectors, 5)
+	assert.Len(t, vectors[0], DefaultHashEmbedderDimensions)
+	assert.Equal(t, "hash-512", embedder.Name())
+
+	// 同じ単語を含むテキストほど近い
+	assert.Greater(t, cosineSimilarity(vectors[0], vectors[1]), cosineSimilarity(vectors[0], vectors[2]))
+	// 空白で区切られていない日本語も文字の3-gramで近くなる
+	assert.Greater(t, cosineSimilarity(vectors[3], vectors[4]), cosineSimilarity(vectors[3], vectors[2]))
+
+	// 同じテキストは同じベクトルになる
+	again, err := embedder.Embed(ctx, []string{"How to deploy the API server"})
+	require.NoError(t, err)
+	assert.Equal(t, vectors[0], again[0])
+	assert.InDelta(t, 1.0, cosineSimilarity(vectors[0], again[0]), 1e-6)
+}
@@ -0,0 +1,42 @@
+package localrag
+
+import (
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"docgent/internal/application/port"
+)
+
+// Service implements port.RAGService with corpora on the local disk.
+// Each corpus is a directory named by its ID under the root directory, so no cloud project is needed.
+type Service struct {
+	dir      string
+	embedder Embedder
+
+	lock    sync.Mutex
+	corpora map[int64]*Corpus
+}
+
+var _ port.RAGService = (*Service)(nil)
+
+func NewService(dir string, embedder Embedder) *Service {
+	return &Service{
+		dir:      dir,
+		embedder: embedder,
+		corpora:  make(map[int64]*Corpus),
+	}
+}
+
+// GetCorpus returns the corpus of the ID. The same instance is returned for the same ID so that writes are serialized
+func (s *Service) GetCorpus(corpusID int64) port.RAGCorpus {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	corpus, ok := s.corpora[corpusID]
+	if !ok {
+		corpus = NewCorpus(filepath.Join(s.dir, strconv.FormatInt(corpusID, 10)), s.embedder)
+		s.corpora[corpusID] = corpus
+	}
+	return corpus
+}
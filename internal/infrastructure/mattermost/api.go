@@ -0,0 +1,177 @@
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// API is a minimal client of the Mattermost REST API v4 authenticated by a bot or personal access token
+type API struct {
+	serverURL  string
+	token      string
+	httpClient *http.Client
+
+	currentUserLock sync.Mutex
+	currentUser     *User
+}
+
+type NewAPIOption func(*API)
+
+// WithHTTPClient replaces http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) NewAPIOption {
+	return func(a *API) {
+		a.httpClient = httpClient
+	}
+}
+
+// NewAPI creates an API of the Mattermost server at serverURL, e.g. https://mattermost.example.com
+func NewAPI(serverURL, token string, options ...NewAPIOption) *API {
+	a := &API{
+		serverURL:  strings.TrimSuffix(serverURL, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+	for _, option := range options {
+		option(a)
+	}
+	return a
+}
+
+// Enabled reports whether the server and the token are configured. Mattermost is optional
+func (a *API) Enabled() bool {
+	return a.serverURL != "" && a.token != ""
+}
+
+// ServerURL returns the URL of the Mattermost server, which is the base of permalinks
+func (a *API) ServerURL() string {
+	return a.serverURL
+}
+
+// CurrentUser returns the user of the token, which is mentioned to call Docgent
+func (a *API) CurrentUser(ctx context.Context) (*User, error) {
+	a.currentUserLock.Lock()
+	defer a.currentUserLock.Unlock()
+	if a.currentUser != nil {
+		return a.currentUser, nil
+	}
+
+	var user User
+	if err := a.do(ctx, http.MethodGet, "/users/me", nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	a.currentUser = &user
+	return a.currentUser, nil
+}
+
+func (a *API) GetUser(ctx context.Context, userID string) (*User, error) {
+	var user User
+	if err := a.do(ctx, http.MethodGet, "/users/"+url.PathEscape(userID), nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+func (a *API) GetTeam(ctx context.Context, teamID string) (*Team, error) {
+	var team Team
+	if err := a.do(ctx, http.MethodGet, "/teams/"+url.PathEscape(teamID), nil, &team); err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	return &team, nil
+}
+
+func (a *API) GetChannel(ctx context.Context, channelID string) (*Channel, error) {
+	var channel Channel
+	if err := a.do(ctx, http.MethodGet, "/channels/"+url.PathEscape(channelID), nil, &channel); err != nil {
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+	return &channel, nil
+}
+
+func (a *API) GetPost(ctx context.Context, postID string) (*Post, error) {
+	var post Post
+	if err := a.do(ctx, http.MethodGet, "/posts/"+url.PathEscape(postID), nil, &post); err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	return &post, nil
+}
+
+// GetPostThread returns the root post and all replies of the thread that the post belongs to
+func (a *API) GetPostThread(ctx context.Context, postID string) (*PostList, error) {
+	var list PostList
+	if err := a.do(ctx, http.MethodGet, "/posts/"+url.PathEscape(postID)+"/thread", nil, &list); err != nil {
+		return nil, fmt.Errorf("failed to get post thread: %w", err)
+	}
+	return &list, nil
+}
+
+// CreatePost posts a message. The post is a reply in the thread of RootID if it is set
+func (a *API) CreatePost(ctx context.Context, post Post) (*Post, error) {
+	var created Post
+	if err := a.do(ctx, http.MethodPost, "/posts", post, &created); err != nil {
+		return nil, fmt.Errorf("failed to create post: %w", err)
+	}
+	return &created, nil
+}
+
+// SaveReaction adds the reaction of the user to the post. emojiName is the name without colons, e.g. "eyes"
+func (a *API) SaveReaction(ctx context.Context, userID, postID, emojiName string) error {
+	reaction := Reaction{UserID: userID, PostID: postID, EmojiName: emojiName}
+	if err := a.do(ctx, http.MethodPost, "/reactions", reaction, nil); err != nil {
+		return fmt.Errorf("failed to save reaction: %w", err)
+	}
+	return nil
+}
+
+// DeleteReaction removes the reaction of the user from the post
+func (a *API) DeleteReaction(ctx context.Context, userID, postID, emojiName string) error {
+	path := "/users/" + url.PathEscape(userID) + "/posts/" + url.PathEscape(postID) + "/reactions/" + url.PathEscape(emojiName)
+	if err := a.do(ctx, http.MethodDelete, path, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete reaction: %w", err)
+	}
+	return nil
+}
+
+// do sends a request to the path under /api/v4. body and out are encoded and decoded as JSON if not nil.
+// Path segments must already be escaped.
+func (a *API) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, a.serverURL+"/api/v4"+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return newErrorResponse(resp)
+	}
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
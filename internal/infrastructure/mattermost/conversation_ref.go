@@ -0,0 +1,90 @@
+package mattermost
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"docgent/internal/domain/data"
+)
+
+// ConversationRef points to a thread on Mattermost.
+// A post outside threads is the root of the thread that replies to it start.
+type ConversationRef struct {
+	serverURL string
+	teamName  string
+	channelID string
+	rootID    string
+	// postID is the post that Docgent was called from. It is the root post if unknown
+	postID string
+}
+
+func NewConversationRef(serverURL, teamName, channelID, rootID, postID string) *ConversationRef {
+	if postID == "" {
+		postID = rootID
+	}
+	return &ConversationRef{
+		serverURL: strings.TrimSuffix(serverURL, "/"),
+		teamName:  teamName,
+		channelID: channelID,
+		rootID:    rootID,
+		postID:    postID,
+	}
+}
+
+// TeamName returns the name of the team in URLs, not the display name
+func (r *ConversationRef) TeamName() string {
+	return r.teamName
+}
+
+func (r *ConversationRef) ChannelID() string {
+	return r.channelID
+}
+
+// RootID returns the ID of the root post of the thread
+func (r *ConversationRef) RootID() string {
+	return r.rootID
+}
+
+// PostID returns the ID of the post that Docgent was called from
+func (r *ConversationRef) PostID() string {
+	return r.postID
+}
+
+// ToURI returns the permalink of the root post, which opens the thread in the Mattermost client
+func (r *ConversationRef) ToURI() *data.URI {
+	return newPermalink(r.serverURL, r.teamName, r.rootID)
+}
+
+// Permalink is a link to a post, https://{server}/{team_name}/pl/{post_id}
+type Permalink struct {
+	TeamName string
+	PostID   string
+}
+
+// Post IDs are 26 characters of lowercase letters and digits
+var rePermalinkPath = regexp.MustCompile(`^/([a-z0-9][a-z0-9_-]*)/pl/([a-z0-9]{26})/?$`)
+
+// ParsePermalink parses a permalink of a post on the server. Links to other servers are rejected
+func ParsePermalink(serverURL string, uri *data.URI) (*Permalink, error) {
+	serverURL = strings.TrimSuffix(serverURL, "/")
+	value := uri.Value()
+	if serverURL == "" || !strings.HasPrefix(value, serverURL+"/") {
+		return nil, fmt.Errorf("invalid URI: %s", uri)
+	}
+	matches := rePermalinkPath.FindStringSubmatch(strings.TrimPrefix(value, serverURL))
+	if matches == nil {
+		return nil, fmt.Errorf("invalid URI: %s", uri)
+	}
+	return &Permalink{TeamName: matches[1], PostID: matches[2]}, nil
+}
+
+func newPermalink(serverURL, teamName, postID string) *data.URI {
+	return data.NewURIUnsafe(fmt.Sprintf("%s/%s/pl/%s", serverURL, teamName, postID))
+}
+
+// IsPermalink reports whether the URI has the path of a permalink, without checking the server.
+// It tells Mattermost threads apart from the sources of other tools where the server URL is unknown.
+func IsPermalink(uri *data.URI) bool {
+	return rePermalinkPath.MatchString(uri.Path())
+}
@@ -0,0 +1,54 @@
+package mattermost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/domain/data"
+)
+
+func TestParsePermalink(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		want    *Permalink
+		wantErr bool
+	}{
+		{
+			name: "投稿のパーマリンク",
+			uri:  "https://chat.example.com/eng/pl/" + rootPostID,
+			want: &Permalink{TeamName: "eng", PostID: rootPostID},
+		},
+		{
+			name:    "他のサーバーのパーマリンク",
+			uri:     "https://other.example.com/eng/pl/" + rootPostID,
+			wantErr: true,
+		},
+		{
+			name:    "チャンネルのリンク",
+			uri:     "https://chat.example.com/eng/channels/town-square",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePermalink("https://chat.example.com/", data.NewURIUnsafe(tt.uri))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConversationRef_ToURI(t *testing.T) {
+	ref := NewConversationRef("https://chat.example.com/", "eng", "channel1", rootPostID, replyPostID)
+
+	assert.Equal(t, "https://chat.example.com/eng/pl/"+rootPostID, ref.ToURI().Value())
+	assert.True(t, IsPermalink(ref.ToURI()))
+	assert.False(t, IsPermalink(data.NewURIUnsafe("https://github.com/org/docs/pull/1")))
+}
@@ -0,0 +1,45 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// ConversationResolver restores the ConversationService of a Mattermost thread from its permalink
+type ConversationResolver struct {
+	api *API
+}
+
+func NewConversationResolver(api *API) *ConversationResolver {
+	return &ConversationResolver{api: api}
+}
+
+func (r *ConversationResolver) Match(uri *data.URI) bool {
+	_, err := ParsePermalink(r.api.ServerURL(), uri)
+	return err == nil
+}
+
+func (r *ConversationResolver) Resolve(uri *data.URI) (port.ConversationService, error) {
+	permalink, err := ParsePermalink(r.api.ServerURL(), uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse permalink: %w", err)
+	}
+	ref, err := resolveConversationRef(context.Background(), r.api, permalink.TeamName, permalink.PostID)
+	if err != nil {
+		return nil, err
+	}
+	// Nobody to mention since the reply is not triggered by a user in the conversation
+	return NewConversationService(r.api, ref, ""), nil
+}
+
+// resolveConversationRef returns the ref of the thread that the post belongs to
+func resolveConversationRef(ctx context.Context, api *API, teamName, postID string) (*ConversationRef, error) {
+	post, err := api.GetPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	return NewConversationRef(api.ServerURL(), teamName, post.ChannelID, post.ThreadRootID(), post.ID), nil
+}
@@ -0,0 +1,112 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// eyesEmoji is the name of the reaction that shows Docgent is working on a post
+const eyesEmoji = "eyes"
+
+type ConversationService struct {
+	api        *API
+	ref        *ConversationRef
+	fromUserID string
+}
+
+func NewConversationService(api *API, ref *ConversationRef, fromUserID string) port.ConversationService {
+	return &ConversationService{
+		api:        api,
+		ref:        ref,
+		fromUserID: fromUserID,
+	}
+}
+
+// Reply posts the input to the thread of the conversation
+func (s *ConversationService) Reply(input string, withMention bool) error {
+	ctx := context.Background()
+
+	message := input
+	// Mattermostのメンションはユーザー名で書く
+	if withMention && s.fromUserID != "" {
+		user, err := s.api.GetUser(ctx, s.fromUserID)
+		if err != nil {
+			return err
+		}
+		message = fmt.Sprintf("@%s\n%s", user.Username, input)
+	}
+
+	// 長い返信は複数の投稿に分ける
+	for _, chunk := range splitMessage(message, maxPostLength) {
+		post := Post{ChannelID: s.ref.ChannelID(), RootID: s.ref.RootID(), Message: chunk}
+		if _, err := s.api.CreatePost(ctx, post); err != nil {
+			return fmt.Errorf("failed to post message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *ConversationService) URI() *data.URI {
+	return s.ref.ToURI()
+}
+
+func (s *ConversationService) GetHistory() (port.ConversationHistory, error) {
+	ctx := context.Background()
+
+	thread, err := s.api.GetPostThread(ctx, s.ref.RootID())
+	if err != nil {
+		return port.ConversationHistory{}, err
+	}
+
+	currentUser, err := s.api.CurrentUser(ctx)
+	if err != nil {
+		return port.ConversationHistory{}, err
+	}
+	mention := "@" + currentUser.Username
+
+	posts := thread.Chronological()
+	conversationMessages := make([]port.ConversationMessage, 0, len(posts))
+	for _, post := range posts {
+		conversationMessages = append(conversationMessages, port.ConversationMessage{
+			Author:       post.UserID,
+			Content:      post.Message,
+			YouMentioned: strings.Contains(post.Message, mention),
+			IsYou:        post.UserID == currentUser.ID,
+		})
+	}
+
+	return port.ConversationHistory{
+		URI:      s.ref.ToURI(),
+		Messages: conversationMessages,
+	}, nil
+}
+
+// MarkEyes adds the eyes reaction of Docgent to the post that Docgent was called from
+func (s *ConversationService) MarkEyes() error {
+	ctx := context.Background()
+	currentUser, err := s.api.CurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.api.SaveReaction(ctx, currentUser.ID, s.ref.PostID(), eyesEmoji); err != nil {
+		return fmt.Errorf("failed to add eyes reaction: %w", err)
+	}
+	return nil
+}
+
+func (s *ConversationService) RemoveEyes() error {
+	ctx := context.Background()
+	currentUser, err := s.api.CurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.api.DeleteReaction(ctx, currentUser.ID, s.ref.PostID(), eyesEmoji); err != nil {
+		return fmt.Errorf("failed to remove eyes reaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package mattermost
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+)
+
+func TestConversationService_GetHistory(t *testing.T) {
+	server := newFakeServer(t, map[string]fakeResponse{
+		"GET /users/me": {Body: map[string]any{"id": "bot", "username": "docgent", "is_bot": true}},
+		"GET /posts/" + rootPostID + "/thread": {Body: thread(
+			post(rootPostID, "", "bob", "Deploy failed", 1),
+			// チャンネルへの参加を示すシステムメッセージ
+			map[string]any{"id": "system", "channel_id": "channel1", "user_id": "carol", "message": "carol joined the channel.", "type": "system_join_channel", "create_at": 2},
+			post(replyPostID, rootPostID, "alice", "@docgent how do we deploy?", 3),
+			post(botPostID, rootPostID, "bot", "Here is the answer", 4),
+		)},
+	})
+	ref := NewConversationRef(server.URL, "eng", "channel1", rootPostID, replyPostID)
+	service := NewConversationService(server.newAPI(), ref, "alice")
+
+	got, err := service.GetHistory()
+
+	assert.NoError(t, err)
+	assert.Equal(t, ref.ToURI(), got.URI)
+	assert.Equal(t, []port.ConversationMessage{
+		{Author: "bob", Content: "Deploy failed"},
+		{Author: "alice", Content: "@docgent how do we deploy?", YouMentioned: true},
+		{Author: "bot", Content: "Here is the answer", IsYou: true},
+	}, got.Messages)
+}
+
+func TestConversationService_Reply(t *testing.T) {
+	t.Run("ユーザー名でメンションしてスレッドに返信する", func(t *testing.T) {
+		server := newFakeServer(t, map[string]fakeResponse{
+			"GET /users/alice": {Body: map[string]any{"id": "alice", "username": "alice.smith"}},
+			"POST /posts":      {StatusCode: http.StatusCreated, Body: post(botPostID, rootPostID, "bot", "", 5)},
+		})
+		ref := NewConversationRef(server.URL, "eng", "channel1", rootPostID, replyPostID)
+		service := NewConversationService(server.newAPI(), ref, "alice")
+
+		err := service.Reply(":warning: エラー: 失敗しました", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"channel_id": "channel1",
+			"root_id":    rootPostID,
+			"message":    "@alice.smith\n:warning: エラー: 失敗しました",
+		}, server.FindRequest(t, http.MethodPost, "/posts").Body)
+	})
+
+	t.Run("長い返信は段落の区切りで分けて投稿する", func(t *testing.T) {
+		server := newFakeServer(t, map[string]fakeResponse{
+			"POST /posts": {StatusCode: http.StatusCreated, Body: post(botPostID, rootPostID, "bot", "", 5)},
+		})
+		ref := NewConversationRef(server.URL, "eng", "channel1", rootPostID, "")
+		service := NewConversationService(server.newAPI(), ref, "")
+
+		paragraph := strings.Repeat("a", 10000)
+		err := service.Reply(paragraph+"\n\n"+paragraph, false)
+
+		assert.NoError(t, err)
+		requests := server.FindRequests(http.MethodPost, "/posts")
+		if assert.Len(t, requests, 2) {
+			assert.Equal(t, paragraph, requests[0].Body.(map[string]any)["message"])
+			assert.Equal(t, paragraph, requests[1].Body.(map[string]any)["message"])
+		}
+	})
+}
+
+func TestConversationService_MarkEyes(t *testing.T) {
+	server := newFakeServer(t, map[string]fakeResponse{
+		"GET /users/me":   {Body: map[string]any{"id": "bot", "username": "docgent", "is_bot": true}},
+		"POST /reactions": {StatusCode: http.StatusCreated, Body: map[string]any{"user_id": "bot", "post_id": replyPostID, "emoji_name": "eyes"}},
+		"DELETE /users/bot/posts/" + replyPostID + "/reactions/eyes": {Body: map[string]any{"status": "OK"}},
+	})
+	ref := NewConversationRef(server.URL, "eng", "channel1", rootPostID, replyPostID)
+	service := NewConversationService(server.newAPI(), ref, "alice")
+
+	assert.NoError(t, service.MarkEyes())
+	assert.NoError(t, service.RemoveEyes())
+	assert.Equal(t, map[string]any{"user_id": "bot", "post_id": replyPostID, "emoji_name": "eyes"}, server.FindRequest(t, http.MethodPost, "/reactions").Body)
+	assert.Len(t, server.FindRequests(http.MethodDelete, "/users/bot/posts/"+replyPostID+"/reactions/eyes"), 1)
+}
@@ -0,0 +1,36 @@
+package mattermost
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrorResponse is an error returned by the Mattermost API
+type ErrorResponse struct {
+	StatusCode int
+	// ID identifies the kind of the error, e.g. "app.post.get.app_error"
+	ID      string
+	Message string
+}
+
+func newErrorResponse(resp *http.Response) *ErrorResponse {
+	e := &ErrorResponse{StatusCode: resp.StatusCode}
+	body, _ := io.ReadAll(resp.Body)
+	var payload struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Message != "" {
+		e.ID = payload.ID
+		e.Message = payload.Message
+	} else {
+		e.Message = string(body)
+	}
+	return e
+}
+
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("mattermost: %d %s", e.StatusCode, e.Message)
+}
@@ -0,0 +1,54 @@
+package mattermost
+
+import (
+	"testing"
+
+	"docgent/internal/testutil/fakeapi"
+)
+
+// Post IDs of the threads in the tests. Permalinks require 26 characters
+const (
+	rootPostID  = "rootpost000000000000000000"
+	replyPostID = "replypost00000000000000000"
+	botPostID   = "botpost0000000000000000000"
+)
+
+// fakeServer is an httptest fake of the Mattermost REST API.
+// Responses are keyed by "METHOD escaped-path" under /api/v4.
+type fakeServer struct {
+	*fakeapi.Server
+}
+
+type fakeResponse = fakeapi.Response
+
+func newFakeServer(t *testing.T, responses map[string]fakeResponse) *fakeServer {
+	return &fakeServer{fakeapi.NewServer(t, fakeapi.Config{
+		AuthHeader:       "Authorization",
+		AuthValue:        "Bearer token",
+		UnauthorizedBody: `{"id":"api.context.session_expired.app_error","message":"Invalid or expired session"}`,
+		NotFoundBody:     `{"id":"app.post.get.app_error","message":"Unable to get the post."}`,
+		PathPrefix:       "/api/v4",
+	}, responses)}
+}
+
+// newAPI returns an API connected to the fake server
+func (f *fakeServer) newAPI() *API {
+	return NewAPI(f.URL, "token")
+}
+
+// post returns the JSON of a post of the user in the thread of rootID, or a root post if rootID is empty
+func post(id, rootID, userID, message string, createAt int64) map[string]any {
+	return map[string]any{"id": id, "root_id": rootID, "channel_id": "channel1", "user_id": userID, "message": message, "create_at": createAt}
+}
+
+// thread returns the JSON of a post list, newest first like the API
+func thread(posts ...map[string]any) map[string]any {
+	order := make([]any, len(posts))
+	byID := make(map[string]any, len(posts))
+	for i, post := range posts {
+		id := post["id"].(string)
+		order[len(posts)-1-i] = id
+		byID[id] = post
+	}
+	return map[string]any{"order": order, "posts": byID}
+}
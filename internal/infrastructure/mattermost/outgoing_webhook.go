@@ -0,0 +1,78 @@
+package mattermost
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// OutgoingWebhook is the request of an outgoing webhook, which is sent when a post starts with its trigger word
+type OutgoingWebhook struct {
+	Token       string `json:"token"`
+	TeamID      string `json:"team_id"`
+	ChannelID   string `json:"channel_id"`
+	PostID      string `json:"post_id"`
+	UserID      string `json:"user_id"`
+	Text        string `json:"text"`
+	TriggerWord string `json:"trigger_word"`
+}
+
+// ParseOutgoingWebhook parses the request, which is a form or JSON according to the content type of the webhook
+func ParseOutgoingWebhook(r *http.Request) (*OutgoingWebhook, error) {
+	webhook, err := parseOutgoingWebhook(r)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.PostID == "" {
+		return nil, errors.New("post_id is missing")
+	}
+	return webhook, nil
+}
+
+func parseOutgoingWebhook(r *http.Request) (*OutgoingWebhook, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		var webhook OutgoingWebhook
+		if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+			return nil, fmt.Errorf("failed to parse webhook: %w", err)
+		}
+		return &webhook, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook: %w", err)
+	}
+	return &OutgoingWebhook{
+		Token:       r.PostForm.Get("token"),
+		TeamID:      r.PostForm.Get("team_id"),
+		ChannelID:   r.PostForm.Get("channel_id"),
+		PostID:      r.PostForm.Get("post_id"),
+		UserID:      r.PostForm.Get("user_id"),
+		Text:        r.PostForm.Get("text"),
+		TriggerWord: r.PostForm.Get("trigger_word"),
+	}, nil
+}
+
+// VerifyToken reports whether the webhook has the token of the webhook configured on Mattermost
+func (w *OutgoingWebhook) VerifyToken(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(w.Token), []byte(token)) == 1
+}
+
+// Event returns the post of the webhook as a posted event called by the trigger word
+func (w *OutgoingWebhook) Event() Event {
+	return Event{
+		Type:      EventTypePosted,
+		TeamID:    w.TeamID,
+		ChannelID: w.ChannelID,
+		Post: &Post{
+			ID:        w.PostID,
+			UserID:    w.UserID,
+			ChannelID: w.ChannelID,
+			Message:   w.Text,
+		},
+		Triggered: true,
+	}
+}
@@ -0,0 +1,75 @@
+package mattermost
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOutgoingWebhook(t *testing.T) {
+	want := &OutgoingWebhook{
+		Token:       "secret",
+		TeamID:      "team1",
+		ChannelID:   "channel1",
+		PostID:      replyPostID,
+		UserID:      "alice",
+		Text:        "docgent how do we deploy?",
+		TriggerWord: "docgent",
+	}
+
+	t.Run("フォーム", func(t *testing.T) {
+		form := url.Values{
+			"token":        {"secret"},
+			"team_id":      {"team1"},
+			"channel_id":   {"channel1"},
+			"post_id":      {replyPostID},
+			"user_id":      {"alice"},
+			"text":         {"docgent how do we deploy?"},
+			"trigger_word": {"docgent"},
+		}
+		r := httptest.NewRequest(http.MethodPost, "/api/mattermost/webhook", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		got, err := ParseOutgoingWebhook(r)
+
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		body := `{"token":"secret","team_id":"team1","channel_id":"channel1","post_id":"` + replyPostID + `","user_id":"alice","text":"docgent how do we deploy?","trigger_word":"docgent"}`
+		r := httptest.NewRequest(http.MethodPost, "/api/mattermost/webhook", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+
+		got, err := ParseOutgoingWebhook(r)
+
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+		assert.True(t, got.VerifyToken("secret"))
+		assert.False(t, got.VerifyToken("other"))
+		assert.False(t, got.VerifyToken(""))
+		assert.True(t, got.Event().MentionsUser("bot"), "トリガーワードで呼ばれた投稿はメンションとして扱う")
+	})
+
+	t.Run("post_idがなければエラー", func(t *testing.T) {
+		tests := []struct {
+			contentType string
+			body        string
+		}{
+			{contentType: "application/x-www-form-urlencoded", body: "token=secret&team_id=team1&text=docgent"},
+			{contentType: "application/json", body: `{"token":"secret","team_id":"team1","text":"docgent"}`},
+		}
+		for _, tt := range tests {
+			r := httptest.NewRequest(http.MethodPost, "/api/mattermost/webhook", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+
+			_, err := ParseOutgoingWebhook(r)
+
+			assert.EqualError(t, err, "post_id is missing", tt.contentType)
+		}
+	})
+}
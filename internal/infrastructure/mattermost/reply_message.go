@@ -0,0 +1,33 @@
+package mattermost
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// maxPostLength is the limit of the message of a post
+const maxPostLength = 16383
+
+// splitMessage splits text into chunks of at most limit characters.
+// Chunks end at paragraph breaks if possible, or at line breaks otherwise, so that Markdown blocks are rarely split.
+func splitMessage(text string, limit int) []string {
+	text = strings.TrimSpace(text)
+	var chunks []string
+	for utf8.RuneCountInString(text) > limit {
+		head := string([]rune(text)[:limit])
+		end := strings.LastIndex(head, "\n\n")
+		if end <= 0 {
+			end = strings.LastIndex(head, "\n")
+		}
+		if end <= 0 {
+			// 改行のない長い行は途中で分割する
+			end = len(head)
+		}
+		chunks = append(chunks, strings.TrimSpace(text[:end]))
+		text = strings.TrimSpace(text[end:])
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
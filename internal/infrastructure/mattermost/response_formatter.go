@@ -0,0 +1,99 @@
+package mattermost
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/tooluse"
+)
+
+var (
+	reFence              = regexp.MustCompile("^\\s*(```|~~~)")
+	reFootnoteDefinition = regexp.MustCompile(`^\[\^([^\]]+)\]:\s*(.*)$`)
+	reFootnoteRef        = regexp.MustCompile(`\[\^([^\]]+)\]`)
+)
+
+// ResponseFormatter implements the port.ResponseFormatter interface for Mattermost
+type ResponseFormatter struct{}
+
+func NewResponseFormatter() port.ResponseFormatter {
+	return &ResponseFormatter{}
+}
+
+// FormatResponse keeps the Markdown as is, since Mattermost renders headings, tables and code blocks, except for footnotes.
+// Sources are numbered in order and listed at the end as "[1] [Name](URI)".
+func (f *ResponseFormatter) FormatResponse(toolUse tooluse.AttemptComplete) (string, error) {
+	footnoteNumbers := make(map[string]int, len(toolUse.Sources))
+	for i, s := range toolUse.Sources {
+		footnoteNumbers[s.ID] = i + 1
+	}
+	footnoteNumber := func(id string) int {
+		if number, ok := footnoteNumbers[id]; ok {
+			return number
+		}
+		number := len(footnoteNumbers) + 1
+		footnoteNumbers[id] = number
+		return number
+	}
+
+	var markdown strings.Builder
+	for _, m := range toolUse.Messages {
+		markdown.WriteString(strings.TrimRight(m.Text, "\n"))
+		for _, sourceID := range m.GetSourceIDs() {
+			markdown.WriteString(fmt.Sprintf("[^%s]", strings.TrimSpace(sourceID)))
+		}
+		markdown.WriteString("\n")
+	}
+
+	var out, footnotes []string
+	lines := strings.Split(markdown.String(), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := reFence.FindStringSubmatch(line); m != nil {
+			// コードブロックの中身は変換しない
+			out = append(out, line)
+			for i++; i < len(lines); i++ {
+				out = append(out, lines[i])
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]) {
+					break
+				}
+			}
+			continue
+		}
+
+		if m := reFootnoteDefinition.FindStringSubmatch(line); m != nil {
+			footnotes = append(footnotes, fmt.Sprintf("[%d] %s", footnoteNumber(m[1]), m[2]))
+			continue
+		}
+
+		line = reFootnoteRef.ReplaceAllStringFunc(line, func(ref string) string {
+			return fmt.Sprintf("[%d]", footnoteNumber(reFootnoteRef.FindStringSubmatch(ref)[1]))
+		})
+		out = append(out, line)
+	}
+
+	text := strings.TrimSpace(strings.Join(out, "\n"))
+
+	var citations []string
+	for i, s := range toolUse.Sources {
+		citations = append(citations, fmt.Sprintf("[%d] %s", i+1, markdownLink(s.URI, s.Name)))
+	}
+	citations = append(citations, footnotes...)
+	if len(citations) > 0 {
+		// 行末の2つのスペースで改行し、1つの段落にまとめる
+		text += "\n\n" + strings.Join(citations, "  \n")
+	}
+
+	return strings.TrimSpace(text), nil
+}
+
+func markdownLink(url, text string) string {
+	if text == "" || text == url {
+		return url
+	}
+	text = strings.NewReplacer("[", "(", "]", ")").Replace(text)
+	return "[" + text + "](" + url + ")"
+}
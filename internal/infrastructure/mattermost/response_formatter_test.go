@@ -0,0 +1,51 @@
+package mattermost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/domain/tooluse"
+)
+
+func TestResponseFormatter_FormatResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		toolUse tooluse.AttemptComplete
+		want    string
+	}{
+		{
+			name: "見出しや表はそのまま",
+			toolUse: tooluse.NewAttemptComplete([]tooluse.Message{
+				tooluse.NewMessage("#### Options\n| Name | Default |\n| --- | --- |\n| draft | false |"),
+			}, nil),
+			want: "#### Options\n| Name | Default |\n| --- | --- |\n| draft | false |",
+		},
+		{
+			name: "ソースに番号を振って末尾に並べる",
+			toolUse: tooluse.NewAttemptComplete([]tooluse.Message{
+				tooluse.NewMessage("Here is the answer:"),
+				{SourceID: "b,a", Text: "- Docgent writes docs"},
+			}, []tooluse.Source{
+				{ID: "a", URI: "https://example.com/a", Name: "A"},
+				{ID: "b", URI: "https://example.com/b", Name: "Guide [draft]"},
+			}),
+			want: "Here is the answer:\n- Docgent writes docs[2][1]\n\n[1] [A](https://example.com/a)  \n[2] [Guide (draft)](https://example.com/b)",
+		},
+		{
+			name: "本文中の脚注を番号に置き換え、コードブロックはそのまま",
+			toolUse: tooluse.NewAttemptComplete([]tooluse.Message{
+				tooluse.NewMessage("Deploys run nightly[^ops].\n```\n[^a] in code\n```\n\n[^ops]: Ops handbook"),
+			}, nil),
+			want: "Deploys run nightly[1].\n```\n[^a] in code\n```\n\n[1] Ops handbook",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewResponseFormatter().FormatResponse(tt.toolUse)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package mattermost
+
+import (
+	"context"
+
+	"docgent/internal/application/port"
+)
+
+// ServiceProvider creates Mattermost services that share the API
+type ServiceProvider struct {
+	api *API
+}
+
+func NewServiceProvider(api *API) *ServiceProvider {
+	return &ServiceProvider{api: api}
+}
+
+// CurrentUser returns the user of the token, which is mentioned to call Docgent
+func (p *ServiceProvider) CurrentUser(ctx context.Context) (*User, error) {
+	return p.api.CurrentUser(ctx)
+}
+
+func (p *ServiceProvider) NewConversationService(ref *ConversationRef, fromUserID string) port.ConversationService {
+	return NewConversationService(p.api, ref, fromUserID)
+}
+
+// ResolveConversationRef returns the ref of the thread that the post in the team belongs to
+func (p *ServiceProvider) ResolveConversationRef(ctx context.Context, teamID, postID string) (*ConversationRef, error) {
+	team, err := p.api.GetTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return resolveConversationRef(ctx, p.api, team.Name, postID)
+}
+
+func (p *ServiceProvider) NewConversationResolver() port.ConversationResolver {
+	return NewConversationResolver(p.api)
+}
+
+func (p *ServiceProvider) NewSourceRepository() *SourceRepository {
+	return NewSourceRepository(p.api)
+}
+
+func (p *ServiceProvider) NewSourceLocator() *SourceLocator {
+	return NewSourceLocator(p.api)
+}
+
+func (p *ServiceProvider) NewResponseFormatter() port.ResponseFormatter {
+	return NewResponseFormatter()
+}
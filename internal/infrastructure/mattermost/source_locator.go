@@ -0,0 +1,49 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// SourceLocator resolves the channel and visibility of Mattermost threads
+type SourceLocator struct {
+	api *API
+}
+
+func NewSourceLocator(api *API) *SourceLocator {
+	return &SourceLocator{api: api}
+}
+
+func (l *SourceLocator) Match(uri *data.URI) bool {
+	_, err := ParsePermalink(l.api.ServerURL(), uri)
+	return err == nil
+}
+
+// Locate returns the channel of the post, which is the container of the channel lists of the source policy
+func (l *SourceLocator) Locate(ctx context.Context, uri *data.URI) (port.SourceLocation, error) {
+	permalink, err := ParsePermalink(l.api.ServerURL(), uri)
+	if err != nil {
+		return port.SourceLocation{}, fmt.Errorf("failed to parse permalink: %w", err)
+	}
+
+	post, err := l.api.GetPost(ctx, permalink.PostID)
+	if err != nil {
+		return port.SourceLocation{}, err
+	}
+	channel, err := l.api.GetChannel(ctx, post.ChannelID)
+	if err != nil {
+		return port.SourceLocation{}, err
+	}
+
+	visibility := port.SourceVisibilityPublic
+	switch channel.Type {
+	case ChannelTypePrivate:
+		visibility = port.SourceVisibilityPrivate
+	case ChannelTypeDirect, ChannelTypeGroup:
+		visibility = port.SourceVisibilityDirectMessage
+	}
+	return port.SourceLocation{ContainerID: channel.ID, Visibility: visibility}, nil
+}
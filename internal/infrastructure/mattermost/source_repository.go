@@ -0,0 +1,52 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"docgent/internal/domain/data"
+)
+
+// SourceRepository reads Mattermost threads as sources from the permalinks of their posts
+type SourceRepository struct {
+	api *API
+}
+
+func NewSourceRepository(api *API) *SourceRepository {
+	return &SourceRepository{api: api}
+}
+
+func (r *SourceRepository) Match(uri *data.URI) bool {
+	_, err := ParsePermalink(r.api.ServerURL(), uri)
+	return err == nil
+}
+
+func (r *SourceRepository) Find(ctx context.Context, uri *data.URI) (*data.Source, error) {
+	permalink, err := ParsePermalink(r.api.ServerURL(), uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse permalink: %w", err)
+	}
+
+	thread, err := r.api.GetPostThread(ctx, permalink.PostID)
+	if err != nil {
+		return nil, err
+	}
+	posts := thread.Chronological()
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("<conversation uri=%q>\n", uri))
+
+	for _, post := range posts {
+		// スレッド内の特定の投稿が指定されている場合、その投稿にマークを付ける
+		if post.ID == permalink.PostID && len(posts) > 1 {
+			content.WriteString(fmt.Sprintf("<message user=%q highlighted=\"true\">\n%s\n</message>\n", post.UserID, post.Message))
+		} else {
+			content.WriteString(fmt.Sprintf("<message user=%q>\n%s\n</message>\n", post.UserID, post.Message))
+		}
+	}
+
+	content.WriteString("</conversation>")
+
+	return data.NewSource(uri, content.String()), nil
+}
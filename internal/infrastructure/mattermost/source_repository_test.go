@@ -0,0 +1,63 @@
+package mattermost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+func TestSourceRepository_Find(t *testing.T) {
+	server := newFakeServer(t, map[string]fakeResponse{
+		"GET /posts/" + replyPostID + "/thread": {Body: thread(
+			post(rootPostID, "", "bob", "Deploy failed", 1),
+			post(replyPostID, rootPostID, "alice", "Fixed by rollback", 2),
+		)},
+	})
+	uri := data.NewURIUnsafe(server.URL + "/eng/pl/" + replyPostID)
+	repository := NewSourceRepository(server.newAPI())
+
+	assert.True(t, repository.Match(uri))
+	got, err := repository.Find(context.Background(), uri)
+
+	assert.NoError(t, err)
+	assert.Equal(t, data.NewSource(uri, `<conversation uri="`+uri.Value()+`">
+<message user="bob">
+Deploy failed
+</message>
+<message user="alice" highlighted="true">
+Fixed by rollback
+</message>
+</conversation>`), got)
+}
+
+func TestSourceLocator_Locate(t *testing.T) {
+	tests := []struct {
+		name        string
+		channelType string
+		want        port.SourceVisibility
+	}{
+		{name: "公開チャンネル", channelType: ChannelTypeOpen, want: port.SourceVisibilityPublic},
+		{name: "非公開チャンネル", channelType: ChannelTypePrivate, want: port.SourceVisibilityPrivate},
+		{name: "DM", channelType: ChannelTypeDirect, want: port.SourceVisibilityDirectMessage},
+		{name: "グループDM", channelType: ChannelTypeGroup, want: port.SourceVisibilityDirectMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t, map[string]fakeResponse{
+				"GET /posts/" + rootPostID: {Body: post(rootPostID, "", "bob", "Deploy failed", 1)},
+				"GET /channels/channel1":   {Body: map[string]any{"id": "channel1", "team_id": "team1", "type": tt.channelType}},
+			})
+			uri := data.NewURIUnsafe(server.URL + "/eng/pl/" + rootPostID)
+
+			got, err := NewSourceLocator(server.newAPI()).Locate(context.Background(), uri)
+
+			assert.NoError(t, err)
+			assert.Equal(t, port.SourceLocation{ContainerID: "channel1", Visibility: tt.want}, got)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package mattermost
+
+import (
+	"slices"
+	"strings"
+)
+
+// Channel types of Mattermost
+const (
+	ChannelTypeOpen    = "O"
+	ChannelTypePrivate = "P"
+	ChannelTypeDirect  = "D"
+	ChannelTypeGroup   = "G"
+)
+
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	IsBot    bool   `json:"is_bot"`
+}
+
+type Team struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Channel struct {
+	ID     string `json:"id"`
+	TeamID string `json:"team_id"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+}
+
+// Post is a message. Replies in a thread have the ID of the root post as RootID
+type Post struct {
+	ID        string `json:"id,omitempty"`
+	CreateAt  int64  `json:"create_at,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	ChannelID string `json:"channel_id"`
+	RootID    string `json:"root_id,omitempty"`
+	Message   string `json:"message"`
+	// Type is empty for messages of users, and starts with "system_" for system messages
+	Type string `json:"type,omitempty"`
+}
+
+// ThreadRootID returns the ID of the root post of the thread that the post belongs to
+func (p Post) ThreadRootID() string {
+	if p.RootID != "" {
+		return p.RootID
+	}
+	return p.ID
+}
+
+// PostList is a list of posts in Order, newest first
+type PostList struct {
+	Order []string        `json:"order"`
+	Posts map[string]Post `json:"posts"`
+}
+
+// Chronological returns the posts of users, oldest first. System messages such as "joined the channel" are skipped
+func (l PostList) Chronological() []Post {
+	var posts []Post
+	for _, id := range l.Order {
+		if post, ok := l.Posts[id]; ok && !strings.HasPrefix(post.Type, "system_") {
+			posts = append(posts, post)
+		}
+	}
+	slices.SortStableFunc(posts, func(a, b Post) int {
+		switch {
+		case a.CreateAt < b.CreateAt:
+			return -1
+		case a.CreateAt > b.CreateAt:
+			return 1
+		}
+		return 0
+	})
+	return posts
+}
+
+type Reaction struct {
+	UserID    string `json:"user_id"`
+	PostID    string `json:"post_id"`
+	EmojiName string `json:"emoji_name"`
+}
@@ -0,0 +1,156 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// Event types of the WebSocket API that Docgent handles
+const (
+	EventTypePosted        = "posted"
+	EventTypeReactionAdded = "reaction_added"
+)
+
+// reconnectDelay is the wait before connecting again after the connection is closed
+const reconnectDelay = 5 * time.Second
+
+// Event is a post or a reaction in a channel of a team. Either Post or Reaction is set according to Type
+type Event struct {
+	Type      string
+	TeamID    string
+	ChannelID string
+	Post      *Post
+	// Mentions are the IDs of the users mentioned in the post
+	Mentions []string
+	// Triggered is set for the posts of outgoing webhooks, whose trigger word calls Docgent
+	Triggered bool
+	Reaction  *Reaction
+}
+
+// MentionsUser reports whether the post calls the user by a mention, or by the trigger word of an outgoing webhook
+func (e Event) MentionsUser(userID string) bool {
+	return e.Triggered || slices.Contains(e.Mentions, userID)
+}
+
+type webSocketEvent struct {
+	Event     string          `json:"event"`
+	Data      json.RawMessage `json:"data"`
+	Broadcast struct {
+		ChannelID string `json:"channel_id"`
+		TeamID    string `json:"team_id"`
+	} `json:"broadcast"`
+}
+
+// WebSocketClient receives the posts and reactions visible to the user of the token over the WebSocket API.
+// Outgoing webhooks do not send reactions, so the reactions that start documentation need this connection.
+type WebSocketClient struct {
+	api    *API
+	dialer *websocket.Dialer
+}
+
+// NewWebSocketClient creates a client of the WebSocket API of the server
+func (a *API) NewWebSocketClient() *WebSocketClient {
+	return &WebSocketClient{api: a, dialer: websocket.DefaultDialer}
+}
+
+// Run connects to the server and passes the events to handle until ctx is canceled.
+// The connection is opened again when it is closed. Events sent while reconnecting are not replayed.
+func (c *WebSocketClient) Run(ctx context.Context, logger *zap.Logger, handle func(Event)) error {
+	for {
+		err := c.connect(ctx, logger, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		logger.Warn("Mattermost WebSocket connection closed, reconnecting", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (c *WebSocketClient) connect(ctx context.Context, logger *zap.Logger, handle func(Event)) error {
+	// http(s):// を ws(s):// に置き換える
+	webSocketURL := "ws" + strings.TrimPrefix(c.api.ServerURL(), "http") + "/api/v4/websocket"
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+c.api.token)
+
+	conn, _, err := c.dialer.DialContext(ctx, webSocketURL, header)
+	if err != nil {
+		return fmt.Errorf("failed to connect to websocket: %w", err)
+	}
+	defer conn.Close()
+
+	// ctx がキャンセルされたら読み込みを止める
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	for {
+		var payload webSocketEvent
+		if err := conn.ReadJSON(&payload); err != nil {
+			return fmt.Errorf("failed to read websocket event: %w", err)
+		}
+		event, ok := parseEvent(payload)
+		if !ok {
+			continue
+		}
+		// リアクションのイベントにはチームがないので、チャンネルから調べる
+		if event.TeamID == "" && event.ChannelID != "" {
+			channel, err := c.api.GetChannel(ctx, event.ChannelID)
+			if err != nil {
+				logger.Warn("Failed to get channel of Mattermost event", zap.String("channel_id", event.ChannelID), zap.Error(err))
+				continue
+			}
+			event.TeamID = channel.TeamID
+		}
+		handle(event)
+	}
+}
+
+// parseEvent returns the events that Docgent handles. Other events are ignored.
+// The post, the mentions and the reaction are JSON encoded as strings in the data.
+func parseEvent(payload webSocketEvent) (Event, bool) {
+	switch payload.Event {
+	case EventTypePosted:
+		var data struct {
+			TeamID   string `json:"team_id"`
+			Post     string `json:"post"`
+			Mentions string `json:"mentions"`
+		}
+		if err := json.Unmarshal(payload.Data, &data); err != nil {
+			return Event{}, false
+		}
+		var post Post
+		if err := json.Unmarshal([]byte(data.Post), &post); err != nil {
+			return Event{}, false
+		}
+		var mentions []string
+		if data.Mentions != "" {
+			json.Unmarshal([]byte(data.Mentions), &mentions)
+		}
+		return Event{Type: payload.Event, TeamID: data.TeamID, ChannelID: post.ChannelID, Post: &post, Mentions: mentions}, true
+	case EventTypeReactionAdded:
+		var data struct {
+			Reaction string `json:"reaction"`
+		}
+		if err := json.Unmarshal(payload.Data, &data); err != nil {
+			return Event{}, false
+		}
+		var reaction Reaction
+		if err := json.Unmarshal([]byte(data.Reaction), &reaction); err != nil {
+			return Event{}, false
+		}
+		return Event{Type: payload.Event, TeamID: payload.Broadcast.TeamID, ChannelID: payload.Broadcast.ChannelID, Reaction: &reaction}, true
+	}
+	return Event{}, false
+}
@@ -0,0 +1,96 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestWebSocketClient_Run(t *testing.T) {
+	rest := newFakeServer(t, map[string]fakeResponse{
+		"GET /channels/channel1": {Body: map[string]any{"id": "channel1", "team_id": "team1", "type": ChannelTypeOpen}},
+	})
+
+	authorized := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/websocket" {
+			rest.ServeHTTP(w, r)
+			return
+		}
+		authorized <- r.Header.Get("Authorization")
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		postJSON, _ := json.Marshal(post(replyPostID, rootPostID, "alice", "@docgent hello", 1))
+		reactionJSON, _ := json.Marshal(map[string]any{"user_id": "alice", "post_id": rootPostID, "emoji_name": "memo"})
+		conn.WriteJSON(map[string]any{"event": "hello", "data": map[string]any{}, "seq": 0})
+		conn.WriteJSON(map[string]any{"event": EventTypePosted, "seq": 1, "data": map[string]any{
+			"team_id": "team1", "post": string(postJSON), "mentions": `["bot"]`,
+		}})
+		conn.WriteJSON(map[string]any{"event": EventTypeReactionAdded, "seq": 2, "data": map[string]any{
+			"reaction": string(reactionJSON),
+		}, "broadcast": map[string]any{"channel_id": "channel1"}})
+		// クライアントが切断するまで待つ
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan Event, 2)
+	done := make(chan error)
+	go func() {
+		done <- NewAPI(server.URL, "token").NewWebSocketClient().Run(ctx, zap.NewNop(), func(event Event) { events <- event })
+	}()
+
+	select {
+	case authorization := <-authorized:
+		assert.Equal(t, "Bearer token", authorization)
+	case <-time.After(5 * time.Second):
+		t.Fatal("websocket was not connected")
+	}
+
+	var got []Event
+	for len(got) < 2 {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case <-time.After(5 * time.Second):
+			t.Fatal("events were not dispatched")
+		}
+	}
+	if assert.Equal(t, EventTypePosted, got[0].Type) {
+		assert.Equal(t, "team1", got[0].TeamID)
+		assert.Equal(t, "@docgent hello", got[0].Post.Message)
+		assert.True(t, got[0].MentionsUser("bot"))
+		assert.False(t, got[0].MentionsUser("carol"))
+	}
+	// リアクションのチームはチャンネルから補われる
+	assert.Equal(t, Event{
+		Type:      EventTypeReactionAdded,
+		TeamID:    "team1",
+		ChannelID: "channel1",
+		Reaction:  &Reaction{UserID: "alice", PostID: rootPostID, EmojiName: "memo"},
+	}, got[1])
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
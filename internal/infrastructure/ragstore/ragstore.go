@@ -0,0 +1,93 @@
+// Package ragstore has the storage shared by the RAG corpora that keep their data on local disk.
+package ragstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"docgent/internal/application/chunking"
+	"docgent/internal/application/port"
+)
+
+// UploadFileOptions applies the options over the default chunking of the corpora that split files by themselves
+func UploadFileOptions(options ...port.RAGCorpusUploadFileOption) *port.RAGCorpusUploadFileOptions {
+	uploadFileOptions := &port.RAGCorpusUploadFileOptions{
+		ChunkingConfig: port.ChunkingConfig{
+			ChunkSize:    chunking.DefaultSize,
+			ChunkOverlap: chunking.DefaultOverlap,
+		},
+	}
+	for _, option := range options {
+		option(uploadFileOptions)
+	}
+	return uploadFileOptions
+}
+
+// JSONFile holds a value stored as a JSON file, which is read on the first access.
+// It is not safe for concurrent use, so the caller must hold its own lock.
+type JSONFile[T any] struct {
+	path string
+	// name describes the file in errors, e.g. "corpus"
+	name string
+
+	loaded bool
+	value  T
+}
+
+// NewJSONFile creates a file at path. The file and its directory are created on the first save
+func NewJSONFile[T any](path, name string) *JSONFile[T] {
+	return &JSONFile[T]{path: path, name: name}
+}
+
+// Load returns the value, which is read from disk once. The value is zero if the file does not exist
+func (f *JSONFile[T]) Load() (*T, error) {
+	if f.loaded {
+		return &f.value, nil
+	}
+
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		f.loaded = true
+		return &f.value, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.name, err)
+	}
+	if err := json.Unmarshal(b, &f.value); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", f.name, err)
+	}
+	f.loaded = true
+	return &f.value, nil
+}
+
+// Save writes the value to a temporary file and renames it, so that a crash does not leave a broken file
+func (f *JSONFile[T]) Save() error {
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", f.name, err)
+	}
+
+	b, err := json.Marshal(f.value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", f.name, err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(f.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %w", f.name, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save %s: %w", f.name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save %s: %w", f.name, err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("failed to save %s: %w", f.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package ragstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"docgent/internal/application/chunking"
+	"docgent/internal/application/port"
+)
+
+func TestUploadFileOptions(t *testing.T) {
+	assert.Equal(t, port.ChunkingConfig{ChunkSize: chunking.DefaultSize, ChunkOverlap: chunking.DefaultOverlap}, UploadFileOptions().ChunkingConfig)
+
+	options := UploadFileOptions(port.WithRagFileChunkingConfig(500, 50), port.WithRagFileDescription("guide"))
+	assert.Equal(t, port.ChunkingConfig{ChunkSize: 500, ChunkOverlap: 50}, options.ChunkingConfig)
+	assert.Equal(t, "guide", options.Description)
+}
+
+func TestJSONFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "index", "data.json")
+
+	// ファイルがなければゼロ値を返す
+	file := NewJSONFile[[]string](path, "index")
+	value, err := file.Load()
+	require.NoError(t, err)
+	assert.Empty(t, *value)
+
+	*value = append(*value, "a", "b")
+	require.NoError(t, file.Save())
+
+	reopened, err := NewJSONFile[[]string](path, "index").Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, *reopened)
+
+	// 一時ファイルは残さない
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestJSONFile_Load_Broken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+
+	_, err := NewJSONFile[[]string](path, "index").Load()
+
+	assert.ErrorContains(t, err, "failed to parse index")
+}
@@ -0,0 +1,29 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// IsChannelMember reports whether the user is a member of the channel.
+// The members are paged through with conversations.members, so the bot must be able to read the channel.
+func IsChannelMember(ctx context.Context, slackAPI *API, channelID, userID string) (bool, error) {
+	params := &slack.GetUsersInConversationParameters{ChannelID: channelID, Limit: 1000}
+	for {
+		members, cursor, err := slackAPI.GetClient().GetUsersInConversationContext(ctx, params)
+		if err != nil {
+			return false, fmt.Errorf("failed to get channel members: %w", err)
+		}
+		for _, member := range members {
+			if member == userID {
+				return true, nil
+			}
+		}
+		if cursor == "" {
+			return false, nil
+		}
+		params.Cursor = cursor
+	}
+}
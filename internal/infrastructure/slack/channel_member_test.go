@@ -0,0 +1,47 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsChannelMember(t *testing.T) {
+	// 2ページに分かれたメンバー一覧を返す
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/conversations.members", r.URL.Path)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "C123", r.Form.Get("channel"))
+		response := map[string]any{"ok": true, "members": []string{"U001", "U002"}, "response_metadata": map[string]string{"next_cursor": "page2"}}
+		if r.Form.Get("cursor") == "page2" {
+			response = map[string]any{"ok": true, "members": []string{"U003"}, "response_metadata": map[string]string{"next_cursor": ""}}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+	slackAPI := &API{client: slack.New("xoxb-test", slack.OptionAPIURL(server.URL+"/"))}
+
+	tests := []struct {
+		name   string
+		userID string
+		want   bool
+	}{
+		{name: "最初のページのメンバー", userID: "U001", want: true},
+		{name: "次のページのメンバー", userID: "U003", want: true},
+		{name: "メンバーでないユーザー", userID: "U999", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IsChannelMember(context.Background(), slackAPI, "C123", tt.userID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
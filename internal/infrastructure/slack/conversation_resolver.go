@@ -0,0 +1,30 @@
+package slack
+
+import (
+	"fmt"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// ConversationResolver restores the ConversationService of a Slack thread from its URI
+type ConversationResolver struct {
+	slackAPI *API
+}
+
+func NewConversationResolver(slackAPI *API) *ConversationResolver {
+	return &ConversationResolver{slackAPI: slackAPI}
+}
+
+func (r *ConversationResolver) Match(uri *data.URI) bool {
+	return uri.Host() == "app.slack.com"
+}
+
+func (r *ConversationResolver) Resolve(uri *data.URI) (port.ConversationService, error) {
+	ref, err := ParseConversationRef(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse conversation ref: %w", err)
+	}
+	// Nobody to mention since the reply is not triggered by a user in the thread
+	return NewConversationService(r.slackAPI, ref, ""), nil
+}
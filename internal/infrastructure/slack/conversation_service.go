@@ -31,9 +31,12 @@ func (s *ConversationService) Reply(input string, withMention bool) error {
 		message = fmt.Sprintf("<@%s>\n%s", s.fromUserID, input)
 	}
 
-	_, _, err := slackClient.PostMessage(s.ref.ChannelID(), slack.MsgOptionText(message, false), slack.MsgOptionTS(s.ref.ThreadTimestamp()))
-	if err != nil {
-		return fmt.Errorf("failed to post message: %w", err)
+	// 長い返信は複数のメッセージに分けて投稿する
+	for _, replyMessage := range newReplyMessages(message) {
+		options := append(replyMessage.options(), slack.MsgOptionTS(s.ref.ThreadTimestamp()))
+		if _, _, err := slackClient.PostMessage(s.ref.ChannelID(), options...); err != nil {
+			return fmt.Errorf("failed to post message: %w", err)
+		}
 	}
 
 	return nil
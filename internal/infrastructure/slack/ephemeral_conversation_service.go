@@ -0,0 +1,84 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// EphemeralConversationService is a conversation of a single message, such as a slash command.
+// Replies are only visible to the user who sent the message.
+type EphemeralConversationService struct {
+	teamID      string
+	channelID   string
+	userID      string
+	text        string
+	responseURL string
+}
+
+// NewEphemeralConversationService creates a conversation that replies through the response URL of a slash command.
+// The response URL accepts up to 5 replies within 30 minutes.
+func NewEphemeralConversationService(teamID, channelID, userID, text, responseURL string) port.ConversationService {
+	return &EphemeralConversationService{
+		teamID:      teamID,
+		channelID:   channelID,
+		userID:      userID,
+		text:        text,
+		responseURL: responseURL,
+	}
+}
+
+func (s *EphemeralConversationService) Reply(input string, withMention bool) error {
+	// 本人にしか見えないので、メンションは付けない
+	for _, replyMessage := range newReplyMessages(input) {
+		if err := respondEphemeral(context.Background(), s.responseURL, replyMessage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// URI is the channel where the message was sent, because the message itself is not posted
+func (s *EphemeralConversationService) URI() *data.URI {
+	return data.NewURIUnsafe(fmt.Sprintf("https://app.slack.com/client/%s/%s", s.teamID, s.channelID))
+}
+
+func (s *EphemeralConversationService) GetHistory() (port.ConversationHistory, error) {
+	return port.ConversationHistory{
+		URI: s.URI(),
+		Messages: []port.ConversationMessage{
+			{Author: s.userID, Content: s.text, YouMentioned: true},
+		},
+	}, nil
+}
+
+func (s *EphemeralConversationService) MarkEyes() error {
+	return nil
+}
+
+func (s *EphemeralConversationService) RemoveEyes() error {
+	return nil
+}
+
+// RespondEphemeral posts a message that is only visible to the user through the response URL of a slash command or an interaction
+func RespondEphemeral(ctx context.Context, responseURL, text string) error {
+	return respondEphemeral(ctx, responseURL, replyMessage{Text: text})
+}
+
+func respondEphemeral(ctx context.Context, responseURL string, message replyMessage) error {
+	webhookMessage := &slack.WebhookMessage{
+		Text:         message.Text,
+		ResponseType: slack.ResponseTypeEphemeral,
+	}
+	if message.Blocks != nil {
+		webhookMessage.Blocks = &slack.Blocks{BlockSet: message.Blocks}
+	}
+	if err := slack.PostWebhookContext(ctx, responseURL, webhookMessage); err != nil {
+		return fmt.Errorf("failed to respond: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// InteractionRequestParser parses requests sent to the interactivity request URL, such as button clicks and modal submissions
+type InteractionRequestParser struct {
+	api    *API
+	logger *zap.Logger
+}
+
+func NewInteractionRequestParser(api *API, logger *zap.Logger) *InteractionRequestParser {
+	return &InteractionRequestParser{api: api, logger: logger}
+}
+
+func (p *InteractionRequestParser) ParseRequest(r *http.Request) (slack.InteractionCallback, error) {
+	sv, err := p.api.NewSecretsVerifier(r.Header)
+	if err != nil {
+		p.logger.Error("failed to create secrets verifier", zap.Error(err))
+		return slack.InteractionCallback{}, fmt.Errorf("failed to create secrets verifier: %w", ErrInvalidHeader)
+	}
+
+	bodyReader := io.TeeReader(r.Body, &sv)
+	body, err := io.ReadAll(bodyReader)
+	if err != nil {
+		p.logger.Error("failed to read request", zap.Error(err))
+		return slack.InteractionCallback{}, fmt.Errorf("failed to read request: %w", ErrInvalidBody)
+	}
+
+	if err := sv.Ensure(); err != nil {
+		p.logger.Error("failed to verify request", zap.Error(err))
+		return slack.InteractionCallback{}, fmt.Errorf("failed to verify request: %w", ErrUnauthorizedRequest)
+	}
+
+	// インタラクションはフォームのpayloadパラメータにJSONで送られる
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		p.logger.Error("failed to parse form", zap.Error(err))
+		return slack.InteractionCallback{}, fmt.Errorf("failed to parse form: %w", ErrInvalidBody)
+	}
+
+	var callback slack.InteractionCallback
+	if err := json.Unmarshal([]byte(form.Get("payload")), &callback); err != nil {
+		p.logger.Error("failed to parse interaction", zap.Error(err))
+		return slack.InteractionCallback{}, fmt.Errorf("failed to parse interaction: %w", ErrInvalidEvent)
+	}
+
+	return callback, nil
+}
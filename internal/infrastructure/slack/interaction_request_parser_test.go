@@ -0,0 +1,68 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestInteractionRequestParser_ParseRequest(t *testing.T) {
+	const signingSecret = "secret"
+	body := url.Values{"payload": {`{"type":"block_actions","team":{"id":"T1"},"actions":[{"block_id":"docgent_proposal_actions","action_id":"docgent_discard_proposal","value":"v"}]}`}}.Encode()
+
+	newRequest := func(secret string) *http.Request {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte("v0:" + timestamp + ":" + body))
+
+		r := httptest.NewRequest(http.MethodPost, "/api/slack/interactions", strings.NewReader(body))
+		r.Header.Set("X-Slack-Request-Timestamp", timestamp)
+		r.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		request *http.Request
+		wantErr error
+	}{
+		{
+			name:    "署名が正しい場合はインタラクションを返す",
+			request: newRequest(signingSecret),
+		},
+		{
+			name:    "署名が正しくない場合はエラー",
+			request: newRequest("other"),
+			wantErr: ErrUnauthorizedRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewInteractionRequestParser(NewAPI("token", signingSecret), zap.NewNop())
+
+			callback, err := parser.ParseRequest(tt.request)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, slack.InteractionTypeBlockActions, callback.Type)
+			assert.Equal(t, "T1", callback.Team.ID)
+			assert.Equal(t, DiscardProposalActionID, callback.ActionCallback.BlockActions[0].ActionID)
+			assert.Equal(t, "v", callback.ActionCallback.BlockActions[0].Value)
+		})
+	}
+}
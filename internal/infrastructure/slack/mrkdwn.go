@@ -0,0 +1,306 @@
+package slack
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	reFence              = regexp.MustCompile("^\\s*(```|~~~)")
+	reFootnoteDefinition = regexp.MustCompile(`^\[\^([^\]]+)\]:\s*(.*)$`)
+	reHeading            = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)\s*#*\s*$`)
+	reRule               = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	reBulletItem         = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	reOrderedItem        = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	reTaskMarker         = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	reQuote              = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	reTableSeparator     = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+
+	reInlineCode  = regexp.MustCompile("`+[^`]*`+")
+	reSlackToken  = regexp.MustCompile(`<(?:[@#!][^>\s]+|(?:https?|mailto):[^>\s]+)>`)
+	reImage       = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	reLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	reFootnoteRef = regexp.MustCompile(`\[\^([^\]]+)\]`)
+	reBold        = regexp.MustCompile(`\*\*([^*]+?)\*\*|__([^_]+?)__`)
+	reItalic      = regexp.MustCompile(`\*([^*\s](?:[^*]*[^*\s])?)\*`)
+	reStrike      = regexp.MustCompile(`~~([^~]+?)~~`)
+	rePlaceholder = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// bold is a temporary marker so that bold text is not taken as italic
+const bold = "\x01"
+
+// listBullets are the bullets of each nesting level of lists
+var listBullets = []string{"•", "◦", "▪"}
+
+// citation is a footnote of a message
+type citation struct {
+	Number int
+	Text   string
+}
+
+// mrkdwnConverter converts Markdown written by the model into Slack mrkdwn, which has its own syntax
+// and has no headings, tables or footnotes.
+type mrkdwnConverter struct {
+	// footnoteNumbers are the numbers shown for footnote IDs
+	footnoteNumbers map[string]int
+	citations       []citation
+}
+
+// newMrkdwnConverter creates a converter. Footnote IDs which are not in footnoteNumbers are numbered after them in order of appearance
+func newMrkdwnConverter(footnoteNumbers map[string]int) *mrkdwnConverter {
+	numbers := make(map[string]int, len(footnoteNumbers))
+	for id, number := range footnoteNumbers {
+		numbers[id] = number
+	}
+	return &mrkdwnConverter{footnoteNumbers: numbers}
+}
+
+// convert returns the mrkdwn of the Markdown. Footnote definitions are removed from the text and collected as citations
+func (c *mrkdwnConverter) convert(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	var out []string
+	var listIndents []int
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := reFence.FindStringSubmatch(line); m != nil {
+			// コードブロックの中身は変換しない
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]); i++ {
+				code = append(code, escapeText(lines[i]))
+			}
+			out = append(out, "```\n"+strings.Join(code, "\n")+"\n```")
+			listIndents = nil
+			continue
+		}
+
+		if m := reFootnoteDefinition.FindStringSubmatch(line); m != nil {
+			c.citations = append(c.citations, citation{Number: c.footnoteNumber(m[1]), Text: c.inline(m[2])})
+			continue
+		}
+
+		if isTableRow(line) && i+1 < len(lines) && reTableSeparator.MatchString(lines[i+1]) {
+			rows := [][]string{splitTableRow(line)}
+			for i += 2; i < len(lines) && isTableRow(lines[i]); i++ {
+				rows = append(rows, splitTableRow(lines[i]))
+			}
+			i--
+			out = append(out, renderTable(rows))
+			listIndents = nil
+			continue
+		}
+
+		if m := reHeading.FindStringSubmatch(line); m != nil {
+			out = append(out, "*"+c.inline(strings.ReplaceAll(m[1], "**", ""))+"*")
+			listIndents = nil
+			continue
+		}
+
+		if reRule.MatchString(line) {
+			out = append(out, "──────────")
+			listIndents = nil
+			continue
+		}
+
+		if m := reBulletItem.FindStringSubmatch(line); m != nil {
+			level := nestingLevel(&listIndents, indentWidth(m[1]))
+			text := m[2]
+			if task := reTaskMarker.FindStringSubmatch(text); task != nil {
+				marker := "☐"
+				if task[1] != " " {
+					marker = "☑"
+				}
+				text = marker + " " + strings.TrimPrefix(text, task[0])
+			}
+			bullet := listBullets[min(level, len(listBullets)-1)]
+			out = append(out, strings.Repeat("    ", level)+bullet+" "+c.inline(text))
+			continue
+		}
+
+		if m := reOrderedItem.FindStringSubmatch(line); m != nil {
+			level := nestingLevel(&listIndents, indentWidth(m[1]))
+			out = append(out, strings.Repeat("    ", level)+m[2]+". "+c.inline(m[3]))
+			continue
+		}
+
+		if m := reQuote.FindStringSubmatch(line); m != nil {
+			out = append(out, "> "+c.inline(m[1]))
+			listIndents = nil
+			continue
+		}
+
+		if strings.TrimSpace(line) != "" {
+			listIndents = nil
+		}
+		out = append(out, c.inline(strings.TrimRight(line, " \t")))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// inline converts links, emphasis and footnote references. Code spans and Slack's own tokens are kept as they are
+func (c *mrkdwnConverter) inline(text string) string {
+	var placeholders []string
+	protect := func(s string) string {
+		placeholders = append(placeholders, s)
+		return fmt.Sprintf("\x00%d\x00", len(placeholders)-1)
+	}
+
+	text = reInlineCode.ReplaceAllStringFunc(text, func(code string) string {
+		return protect(escapeText(code))
+	})
+	text = reSlackToken.ReplaceAllStringFunc(text, protect)
+	text = reImage.ReplaceAllStringFunc(text, func(image string) string {
+		m := reImage.FindStringSubmatch(image)
+		return protect(slackLink(m[2], m[1]))
+	})
+	text = reLink.ReplaceAllStringFunc(text, func(link string) string {
+		m := reLink.FindStringSubmatch(link)
+		return protect(slackLink(m[2], stripEmphasis(m[1])))
+	})
+	text = reFootnoteRef.ReplaceAllStringFunc(text, func(ref string) string {
+		m := reFootnoteRef.FindStringSubmatch(ref)
+		return protect(fmt.Sprintf("[%d]", c.footnoteNumber(m[1])))
+	})
+
+	text = escapeText(text)
+	text = reBold.ReplaceAllStringFunc(text, func(s string) string {
+		m := reBold.FindStringSubmatch(s)
+		return bold + m[1] + m[2] + bold
+	})
+	text = reItalic.ReplaceAllString(text, "_${1}_")
+	text = strings.ReplaceAll(text, bold, "*")
+	text = reStrike.ReplaceAllString(text, "~${1}~")
+
+	return rePlaceholder.ReplaceAllStringFunc(text, func(s string) string {
+		var index int
+		fmt.Sscanf(strings.Trim(s, "\x00"), "%d", &index)
+		return placeholders[index]
+	})
+}
+
+func (c *mrkdwnConverter) footnoteNumber(id string) int {
+	if number, ok := c.footnoteNumbers[id]; ok {
+		return number
+	}
+	number := len(c.footnoteNumbers) + 1
+	for _, n := range c.footnoteNumbers {
+		number = max(number, n+1)
+	}
+	c.footnoteNumbers[id] = number
+	return number
+}
+
+func slackLink(url, text string) string {
+	if text == "" || text == url {
+		return "<" + url + ">"
+	}
+	return "<" + url + "|" + escapeText(text) + ">"
+}
+
+// stripEmphasis removes the emphasis markers, which cannot be used in the text of links
+func stripEmphasis(text string) string {
+	return strings.NewReplacer("**", "", "__", "", "`", "").Replace(text)
+}
+
+func indentWidth(indent string) int {
+	return len(strings.ReplaceAll(indent, "\t", "    "))
+}
+
+// nestingLevel returns the level of a list item from its indent, tracking the indents of the enclosing items
+func nestingLevel(indents *[]int, indent int) int {
+	for len(*indents) > 0 && (*indents)[len(*indents)-1] > indent {
+		*indents = (*indents)[:len(*indents)-1]
+	}
+	if len(*indents) == 0 || (*indents)[len(*indents)-1] < indent {
+		*indents = append(*indents, indent)
+	}
+	return len(*indents) - 1
+}
+
+func isTableRow(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "|")
+}
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := strings.Split(strings.ReplaceAll(line, `\|`, "\x00"), "|")
+	for i, cell := range cells {
+		cell = strings.ReplaceAll(strings.TrimSpace(cell), "\x00", "|")
+		cell = reLink.ReplaceAllString(cell, "$1")
+		cells[i] = stripEmphasis(cell)
+	}
+	return cells
+}
+
+// renderTable renders a table as preformatted text with aligned columns, as mrkdwn has no tables
+func renderTable(rows [][]string) string {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+
+	var lines []string
+	for r, row := range rows {
+		cells := make([]string, len(widths))
+		for i := range widths {
+			var cell string
+			if i < len(row) {
+				cell = row[i]
+			}
+			cells[i] = cell + strings.Repeat(" ", widths[i]-displayWidth(cell))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, " | "), " "))
+		if r == 0 {
+			separators := make([]string, len(widths))
+			for i, width := range widths {
+				separators[i] = strings.Repeat("-", width)
+			}
+			lines = append(lines, strings.Join(separators, "-+-"))
+		}
+	}
+	return "```\n" + escapeText(strings.Join(lines, "\n")) + "\n```"
+}
+
+// displayWidth counts East Asian wide characters as two columns so that columns line up in monospace fonts
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if isWide(r) {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+func isWide(r rune) bool {
+	return r >= 0x1100 && (r <= 0x115f || // Hangul Jamo
+		(r >= 0x2e80 && r <= 0xa4cf && r != 0x303f) || // CJK ... Yi
+		(r >= 0xac00 && r <= 0xd7a3) || // Hangul Syllables
+		(r >= 0xf900 && r <= 0xfaff) || // CJK Compatibility Ideographs
+		(r >= 0xfe30 && r <= 0xfe4f) || // CJK Compatibility Forms
+		(r >= 0xff00 && r <= 0xff60) || // Fullwidth Forms
+		(r >= 0xffe0 && r <= 0xffe6) ||
+		(r >= 0x1f300 && r <= 0x1f64f) || // Emoji
+		(r >= 0x20000 && r <= 0x3fffd))
+}
+
+// truncateRunes cuts s to at most n runes
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,86 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMrkdwnConverter_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "見出しを太字にする",
+			markdown: "## Setup **guide**",
+			want:     "*Setup guide*",
+		},
+		{
+			name:     "強調を変換する",
+			markdown: "**bold**, *italic*, _italic_ and ~~strike~~",
+			want:     "*bold*, _italic_, _italic_ and ~strike~",
+		},
+		{
+			name:     "リンクと画像を変換する",
+			markdown: "See [the **docs**](https://example.com/docs) and ![diagram](https://example.com/a.png)",
+			want:     "See <https://example.com/docs|the docs> and <https://example.com/a.png|diagram>",
+		},
+		{
+			name:     "Slackのメンションやリンクはそのまま残す",
+			markdown: "<@U123> see <https://example.com|this>",
+			want:     "<@U123> see <https://example.com|this>",
+		},
+		{
+			name:     "特殊文字をエスケープする",
+			markdown: "a < b && c > d",
+			want:     "a &lt; b &amp;&amp; c &gt; d",
+		},
+		{
+			name:     "インラインコードの中は変換しない",
+			markdown: "Run `**make** <target>` now",
+			want:     "Run `**make** &lt;target&gt;` now",
+		},
+		{
+			name:     "コードブロックの言語を取り除き中身は変換しない",
+			markdown: "```go\nfunc main() { a := **b** }\n```",
+			want:     "```\nfunc main() { a := **b** }\n```",
+		},
+		{
+			name:     "ネストしたリストを変換する",
+			markdown: "- one\n  - two\n    * three\n- [x] done\n1. first\n   1. nested",
+			want:     "• one\n    ◦ two\n        ▪ three\n• ☑ done\n1. first\n    1. nested",
+		},
+		{
+			name:     "引用と水平線を変換する",
+			markdown: "> **Note**\n\n---",
+			want:     "> *Note*\n\n──────────",
+		},
+		{
+			name:     "表を整形済みテキストにする",
+			markdown: "| Name | Value |\n|:-----|------:|\n| [a](https://example.com) | **1** |\n| 日本 | 22 |",
+			want:     "```\nName | Value\n-----+------\na    | 1\n日本 | 22\n```",
+		},
+		{
+			name:     "脚注を番号にしてソースとして集める",
+			markdown: "Docgent writes docs[^docs].\n\n[^docs]: [Docs](https://example.com/docs)",
+			want:     "Docgent writes docs[1].\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newMrkdwnConverter(nil).convert(tt.markdown))
+		})
+	}
+}
+
+func TestMrkdwnConverter_Citations(t *testing.T) {
+	converter := newMrkdwnConverter(map[string]int{"1": 1})
+
+	got := converter.convert("A[^1] and B[^note].\n\n[^note]: See **this**")
+
+	assert.Equal(t, "A[1] and B[2].\n", got)
+	assert.Equal(t, []citation{{Number: 2, Text: "See *this*"}}, converter.citations)
+}
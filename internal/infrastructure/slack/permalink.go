@@ -0,0 +1,42 @@
+package slack
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// https://{workspace}.slack.com/archives/{channel_id}/p{message_timestamp_without_dot}
+var rePermalinkPath = regexp.MustCompile(`^/archives/([^/]+)/p(\d{10})(\d{6})$`)
+
+// ParsePermalink returns the ref of the message that a permalink points to.
+// Slack permalinks do not contain the team ID, so it must be given.
+// Links may be wrapped in angle brackets as Slack escapes them in slash commands.
+// For replies, the thread is taken from the thread_ts query parameter.
+func ParsePermalink(teamID, link string) (*ConversationRef, error) {
+	link = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(link), "<"), ">")
+	if i := strings.Index(link, "|"); i >= 0 {
+		link = link[:i]
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("invalid permalink: %w", err)
+	}
+	if u.Scheme != "https" || !strings.HasSuffix(u.Host, ".slack.com") {
+		return nil, fmt.Errorf("not a Slack permalink: %s", link)
+	}
+	matches := rePermalinkPath.FindStringSubmatch(u.Path)
+	if matches == nil {
+		return nil, fmt.Errorf("not a Slack message permalink: %s", link)
+	}
+
+	channelID := matches[1]
+	messageTimestamp := matches[2] + "." + matches[3]
+	threadTimestamp := u.Query().Get("thread_ts")
+	if threadTimestamp == "" {
+		threadTimestamp = messageTimestamp
+	}
+	return NewConversationRef(teamID, channelID, threadTimestamp, messageTimestamp), nil
+}
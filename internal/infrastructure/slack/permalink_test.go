@@ -0,0 +1,54 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePermalink(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		want    *ConversationRef
+		wantErr bool
+	}{
+		{
+			name: "スレッドの最初のメッセージのリンクの場合",
+			link: "https://example.slack.com/archives/C789012/p1234567890123456",
+			want: NewConversationRef("T123456", "C789012", "1234567890.123456", "1234567890.123456"),
+		},
+		{
+			name: "スレッド内の返信のリンクの場合",
+			link: "https://example.slack.com/archives/C789012/p1234567890654321?thread_ts=1234567890.123456&cid=C789012",
+			want: NewConversationRef("T123456", "C789012", "1234567890.123456", "1234567890.654321"),
+		},
+		{
+			name: "Slackがエスケープしたリンクの場合",
+			link: "<https://example.slack.com/archives/C789012/p1234567890123456|link>",
+			want: NewConversationRef("T123456", "C789012", "1234567890.123456", "1234567890.123456"),
+		},
+		{
+			name:    "Slack以外のリンクの場合",
+			link:    "https://example.com/archives/C789012/p1234567890123456",
+			wantErr: true,
+		},
+		{
+			name:    "チャンネルのリンクの場合",
+			link:    "https://example.slack.com/archives/C789012",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePermalink("T123456", tt.link)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
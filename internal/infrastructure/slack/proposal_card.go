@@ -0,0 +1,165 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// Action and callback IDs of the proposal card. Interactions with these IDs are sent to the interactivity request URL
+const (
+	OpenProposalActionID     = "docgent_open_proposal"
+	RequestChangesActionID   = "docgent_request_changes"
+	DiscardProposalActionID  = "docgent_discard_proposal"
+	RequestChangesCallbackID = "docgent_request_changes"
+
+	proposalCardActionsBlockID = "docgent_proposal_actions"
+	feedbackBlockID            = "docgent_feedback"
+	feedbackActionID           = "docgent_feedback_input"
+
+	// maxProposalCardFiles keeps the card short. The rest of the files are only counted
+	maxProposalCardFiles = 10
+	// maxProposalCardSources keeps the card short. The rest of the sources are only counted
+	maxProposalCardSources = 5
+)
+
+// ProposalCardFile is a file changed by the proposal
+type ProposalCardFile struct {
+	Path string
+	// OldPath is set if the file is renamed
+	OldPath string
+	// Status is one of added, modified, renamed and deleted
+	Status    string
+	Additions int
+	Deletions int
+}
+
+// ProposalCard is a Block Kit message that shows a proposal with buttons to open, revise and discard it
+type ProposalCard struct {
+	Title string
+	URL   string
+	// Updated is true if an existing proposal was refined
+	Updated bool
+	Files   []ProposalCardFile
+	Sources []string
+	// ActionValue is passed back to the interactivity request URL when a button is clicked
+	ActionValue string
+}
+
+// Text is the fallback text of the card for notifications and clients without Block Kit
+func (c ProposalCard) Text() string {
+	if c.Updated {
+		return "Updated PR: " + c.URL
+	}
+	return "PR: " + c.URL
+}
+
+// Blocks returns the blocks of the card
+func (c ProposalCard) Blocks() []slack.Block {
+	heading := "*PR*"
+	if c.Updated {
+		heading = "*Updated PR*"
+	}
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("%s\n<%s|%s>", heading, c.URL, escapeText(c.Title)), false, false), nil, nil),
+	}
+
+	if len(c.Files) > 0 {
+		blocks = append(blocks, slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, c.filesText(), false, false), nil, nil))
+	}
+	if len(c.Sources) > 0 {
+		blocks = append(blocks, slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, c.sourcesText(), false, false)))
+	}
+
+	openButton := slack.NewButtonBlockElement(OpenProposalActionID, c.ActionValue, slack.NewTextBlockObject(slack.PlainTextType, "Open PR", false, false))
+	openButton.URL = c.URL
+	requestChangesButton := slack.NewButtonBlockElement(RequestChangesActionID, c.ActionValue, slack.NewTextBlockObject(slack.PlainTextType, "Request changes", false, false))
+	discardButton := slack.NewButtonBlockElement(DiscardProposalActionID, c.ActionValue, slack.NewTextBlockObject(slack.PlainTextType, "Discard", false, false)).
+		WithStyle(slack.StyleDanger).
+		WithConfirm(slack.NewConfirmationBlockObject(
+			slack.NewTextBlockObject(slack.PlainTextType, "Discard this PR?", false, false),
+			slack.NewTextBlockObject(slack.PlainTextType, "The PR will be closed and its branch will be deleted.", false, false),
+			slack.NewTextBlockObject(slack.PlainTextType, "Discard", false, false),
+			slack.NewTextBlockObject(slack.PlainTextType, "Cancel", false, false),
+		).WithStyle(slack.StyleDanger))
+	blocks = append(blocks, slack.NewActionBlock(proposalCardActionsBlockID, openButton, requestChangesButton, discardButton))
+
+	return blocks
+}
+
+func (c ProposalCard) filesText() string {
+	var builder strings.Builder
+	builder.WriteString("*Files*")
+	for i, file := range c.Files {
+		if i == maxProposalCardFiles {
+			builder.WriteString(fmt.Sprintf("\n…and %d more", len(c.Files)-maxProposalCardFiles))
+			break
+		}
+		builder.WriteString("\n• ")
+		if file.OldPath != "" {
+			builder.WriteString(fmt.Sprintf("`%s` → ", escapeText(file.OldPath)))
+		}
+		builder.WriteString(fmt.Sprintf("`%s` %s", escapeText(file.Path), file.Status))
+		if file.Additions > 0 || file.Deletions > 0 {
+			builder.WriteString(fmt.Sprintf(" (+%d −%d)", file.Additions, file.Deletions))
+		}
+	}
+	return builder.String()
+}
+
+func (c ProposalCard) sourcesText() string {
+	var builder strings.Builder
+	builder.WriteString("Sources:")
+	for i, source := range c.Sources {
+		if i == maxProposalCardSources {
+			builder.WriteString(fmt.Sprintf(" and %d more", len(c.Sources)-maxProposalCardSources))
+			break
+		}
+		builder.WriteString(fmt.Sprintf(" <%s|[%d]>", source, i+1))
+	}
+	return builder.String()
+}
+
+// DiscardedProposalCardBlocks returns the blocks of a discarded card: the buttons are replaced with who discarded it
+func DiscardedProposalCardBlocks(blocks []slack.Block, userID string) []slack.Block {
+	discarded := make([]slack.Block, 0, len(blocks))
+	for _, block := range blocks {
+		if action, ok := block.(*slack.ActionBlock); ok && action.BlockID == proposalCardActionsBlockID {
+			continue
+		}
+		discarded = append(discarded, block)
+	}
+	return append(discarded, slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf(":wastebasket: Discarded by <@%s>", userID), false, false)))
+}
+
+// NewRequestChangesModal returns the modal to write feedback on a proposal. privateMetadata is passed back on submission
+func NewRequestChangesModal(privateMetadata string) slack.ModalViewRequest {
+	input := slack.NewPlainTextInputBlockElement(slack.NewTextBlockObject(slack.PlainTextType, "What should be changed?", false, false), feedbackActionID)
+	input.Multiline = true
+
+	return slack.ModalViewRequest{
+		Type:            slack.VTModal,
+		CallbackID:      RequestChangesCallbackID,
+		PrivateMetadata: privateMetadata,
+		Title:           slack.NewTextBlockObject(slack.PlainTextType, "Request changes", false, false),
+		Submit:          slack.NewTextBlockObject(slack.PlainTextType, "Send", false, false),
+		Close:           slack.NewTextBlockObject(slack.PlainTextType, "Cancel", false, false),
+		Blocks: slack.Blocks{BlockSet: []slack.Block{
+			slack.NewInputBlock(feedbackBlockID, slack.NewTextBlockObject(slack.PlainTextType, "Feedback", false, false), nil, input),
+		}},
+	}
+}
+
+// RequestChangesFeedback returns the feedback written in the submitted request changes modal
+func RequestChangesFeedback(view slack.View) string {
+	if view.State == nil {
+		return ""
+	}
+	return strings.TrimSpace(view.State.Values[feedbackBlockID][feedbackActionID].Value)
+}
+
+// escapeText escapes the control characters of mrkdwn
+func escapeText(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
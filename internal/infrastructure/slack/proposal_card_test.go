@@ -0,0 +1,119 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProposalCard_Blocks(t *testing.T) {
+	tests := []struct {
+		name  string
+		card  ProposalCard
+		texts []string
+	}{
+		{
+			name: "ファイルとソースがある場合",
+			card: ProposalCard{
+				Title: "Add <setup> guide",
+				URL:   "https://github.com/owner/repo/pull/1",
+				Files: []ProposalCardFile{
+					{Path: "docs/setup.md", Status: "added", Additions: 10},
+					{Path: "docs/new.md", OldPath: "docs/old.md", Status: "renamed"},
+				},
+				Sources:     []string{"https://app.slack.com/client/T1/C1/1.1"},
+				ActionValue: `{"number":1}`,
+			},
+			texts: []string{
+				"*PR*\n<https://github.com/owner/repo/pull/1|Add &lt;setup&gt; guide>",
+				"*Files*\n• `docs/setup.md` added (+10 −0)\n• `docs/old.md` → `docs/new.md` renamed",
+				"Sources: <https://app.slack.com/client/T1/C1/1.1|[1]>",
+			},
+		},
+		{
+			name: "更新された提案の場合",
+			card: ProposalCard{
+				Title:   "Update guide",
+				URL:     "https://github.com/owner/repo/pull/2",
+				Updated: true,
+			},
+			texts: []string{
+				"*Updated PR*\n<https://github.com/owner/repo/pull/2|Update guide>",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := tt.card.Blocks()
+
+			var texts []string
+			for _, block := range blocks[:len(blocks)-1] {
+				switch b := block.(type) {
+				case *slack.SectionBlock:
+					texts = append(texts, b.Text.Text)
+				case *slack.ContextBlock:
+					texts = append(texts, b.ContextElements.Elements[0].(*slack.TextBlockObject).Text)
+				}
+			}
+			assert.Equal(t, tt.texts, texts)
+
+			actions, ok := blocks[len(blocks)-1].(*slack.ActionBlock)
+			assert.True(t, ok)
+			var actionIDs []string
+			for _, element := range actions.Elements.ElementSet {
+				button := element.(*slack.ButtonBlockElement)
+				actionIDs = append(actionIDs, button.ActionID)
+				assert.Equal(t, tt.card.ActionValue, button.Value)
+			}
+			assert.Equal(t, []string{OpenProposalActionID, RequestChangesActionID, DiscardProposalActionID}, actionIDs)
+		})
+	}
+}
+
+func TestDiscardedProposalCardBlocks(t *testing.T) {
+	card := ProposalCard{Title: "Add guide", URL: "https://github.com/owner/repo/pull/1"}
+
+	// Slackから送られてくるメッセージと同じように、JSONから復元したブロックを使う
+	raw, err := json.Marshal(slack.Blocks{BlockSet: card.Blocks()})
+	assert.NoError(t, err)
+	var blocks slack.Blocks
+	assert.NoError(t, json.Unmarshal(raw, &blocks))
+
+	discarded := DiscardedProposalCardBlocks(blocks.BlockSet, "U123")
+
+	assert.Len(t, discarded, 2)
+	assert.IsType(t, &slack.SectionBlock{}, discarded[0])
+	context, ok := discarded[1].(*slack.ContextBlock)
+	assert.True(t, ok)
+	assert.Equal(t, ":wastebasket: Discarded by <@U123>", context.ContextElements.Elements[0].(*slack.TextBlockObject).Text)
+}
+
+func TestRequestChangesFeedback(t *testing.T) {
+	tests := []struct {
+		name string
+		view slack.View
+		want string
+	}{
+		{
+			name: "入力されたフィードバックを返す",
+			view: slack.View{State: &slack.ViewState{Values: map[string]map[string]slack.BlockAction{
+				feedbackBlockID: {feedbackActionID: {Value: "  Add an example\n"}},
+			}}},
+			want: "Add an example",
+		},
+		{
+			name: "状態がない場合は空文字を返す",
+			view: slack.View{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RequestChangesFeedback(tt.view))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package slack
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// maxSectionTextLength is the limit of the text of a section block
+	maxSectionTextLength = 3000
+	// maxContextElementLength is the limit of the text of a context block element
+	maxContextElementLength = 2000
+	// maxContextElements is the limit of the elements of a context block
+	maxContextElements = 10
+)
+
+// reCitationLine matches the citations written by ResponseFormatter: "[1] <https://example.com|Name>"
+var reCitationLine = regexp.MustCompile(`^\[\d+\] \S`)
+
+// replyMessage is a message posted as a reply. Blocks are nil for short plain messages
+type replyMessage struct {
+	Text   string
+	Blocks []slack.Block
+}
+
+func (m replyMessage) options() []slack.MsgOption {
+	options := []slack.MsgOption{slack.MsgOptionText(m.Text, false)}
+	if m.Blocks != nil {
+		options = append(options, slack.MsgOptionBlocks(m.Blocks...))
+	}
+	return options
+}
+
+// newReplyMessages splits a mrkdwn reply into messages which fit in the limits of Slack.
+// The citations at the end of the reply are shown in a context block under the last message.
+func newReplyMessages(text string) []replyMessage {
+	body, citations := splitCitations(text)
+	chunks := splitMrkdwn(body, maxSectionTextLength)
+	if len(chunks) <= 1 && len(citations) == 0 {
+		return []replyMessage{{Text: text}}
+	}
+
+	messages := make([]replyMessage, len(chunks))
+	for i, chunk := range chunks {
+		messages[i] = replyMessage{
+			Text:   chunk,
+			Blocks: []slack.Block{slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, chunk, false, false), nil, nil)},
+		}
+	}
+	if len(citations) > 0 {
+		if len(messages) == 0 {
+			messages = append(messages, replyMessage{})
+		}
+		last := &messages[len(messages)-1]
+		last.Blocks = append(last.Blocks, newCitationBlock(citations))
+		// 通知やBlock Kitを表示できないクライアントのために、テキストにもソースを含める
+		last.Text = strings.TrimSpace(last.Text + "\n\n" + strings.Join(citations, "\n"))
+	}
+	return messages
+}
+
+// splitCitations separates the trailing citation lines from the body of a reply
+func splitCitations(text string) (string, []string) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	start := len(lines)
+	for start > 0 && reCitationLine.MatchString(lines[start-1]) {
+		start--
+	}
+	// 本文の途中の行を誤ってソースとして扱わないように、空行で区切られている場合だけソースとみなす
+	if start == len(lines) || (start > 0 && strings.TrimSpace(lines[start-1]) != "") {
+		return text, nil
+	}
+	return strings.TrimSpace(strings.Join(lines[:start], "\n")), lines[start:]
+}
+
+func newCitationBlock(citations []string) *slack.ContextBlock {
+	var elements []slack.MixedElement
+	var current string
+	for _, line := range citations {
+		line = truncateRunes(line, maxContextElementLength)
+		if current != "" && utf8.RuneCountInString(current)+1+utf8.RuneCountInString(line) > maxContextElementLength {
+			elements = append(elements, slack.NewTextBlockObject(slack.MarkdownType, current, false, false))
+			current = ""
+		}
+		if current != "" {
+			current += "\n"
+		}
+		current += line
+	}
+	if current != "" {
+		elements = append(elements, slack.NewTextBlockObject(slack.MarkdownType, current, false, false))
+	}
+	if len(elements) > maxContextElements {
+		elements = elements[:maxContextElements]
+	}
+	return slack.NewContextBlock("", elements...)
+}
+
+// splitMrkdwn splits text into chunks of at most limit characters at line breaks.
+// A code block split across chunks is closed and reopened so that each chunk renders on its own.
+func splitMrkdwn(text string, limit int) []string {
+	const fence = "```"
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	var chunks []string
+	var current strings.Builder
+	currentLength := 0
+	inCode := false
+
+	flush := func() {
+		chunk := strings.TrimRight(current.String(), "\n")
+		if inCode {
+			chunk += "\n" + fence
+		}
+		if strings.TrimSpace(chunk) != "" {
+			chunks = append(chunks, chunk)
+		}
+		current.Reset()
+		currentLength = 0
+		if inCode {
+			current.WriteString(fence + "\n")
+			currentLength = len(fence) + 1
+		}
+	}
+
+	// 閉じるためのフェンスの分を空けておく
+	lineLimit := limit - len(fence) - 1
+	for _, line := range strings.Split(text, "\n") {
+		for utf8.RuneCountInString(line) > lineLimit-currentLength {
+			if currentLength > len(fence)+1 {
+				flush()
+				continue
+			}
+			// 1行が長すぎる場合は途中で分割する
+			head := truncateRunes(line, lineLimit-currentLength)
+			current.WriteString(head)
+			line = strings.TrimPrefix(line, head)
+			flush()
+		}
+		toggles := strings.Count(line, fence)%2 == 1
+		if toggles && inCode && currentLength == len(fence)+1 {
+			// 開き直しただけのコードブロックは閉じずに取り除く
+			current.Reset()
+			currentLength = 0
+			inCode = false
+			continue
+		}
+		current.WriteString(line + "\n")
+		currentLength += utf8.RuneCountInString(line) + 1
+		if toggles {
+			inCode = !inCode
+		}
+	}
+	inCode = false
+	flush()
+
+	return chunks
+}
@@ -0,0 +1,77 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReplyMessages(t *testing.T) {
+	t.Run("短いメッセージはテキストのまま投稿する", func(t *testing.T) {
+		messages := newReplyMessages("PR: https://github.com/owner/repo/pull/1")
+
+		assert.Equal(t, []replyMessage{{Text: "PR: https://github.com/owner/repo/pull/1"}}, messages)
+	})
+
+	t.Run("ソースをコンテキストブロックにする", func(t *testing.T) {
+		messages := newReplyMessages("Answer[1]\n\n[1] <https://example.com|Example>\n[2] <https://example.org|Other>")
+
+		assert.Len(t, messages, 1)
+		assert.Len(t, messages[0].Blocks, 2)
+		section := messages[0].Blocks[0].(*slack.SectionBlock)
+		assert.Equal(t, "Answer[1]", section.Text.Text)
+		context := messages[0].Blocks[1].(*slack.ContextBlock)
+		assert.Equal(t, "[1] <https://example.com|Example>\n[2] <https://example.org|Other>", context.ContextElements.Elements[0].(*slack.TextBlockObject).Text)
+	})
+
+	t.Run("空行で区切られていない行はソースとみなさない", func(t *testing.T) {
+		text := "Steps:\n[1] Install\n[2] Run"
+
+		assert.Equal(t, []replyMessage{{Text: text}}, newReplyMessages(text))
+	})
+
+	t.Run("長いメッセージは分割する", func(t *testing.T) {
+		paragraph := strings.Repeat("a", 2000)
+		messages := newReplyMessages(paragraph + "\n" + paragraph)
+
+		assert.Len(t, messages, 2)
+		assert.Equal(t, paragraph, messages[0].Text)
+		assert.Equal(t, paragraph, messages[1].Text)
+	})
+}
+
+func TestSplitMrkdwn(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "行の区切りで分割する",
+			text:  "aaaa\nbbbb\ncccc",
+			limit: 14,
+			want:  []string{"aaaa\nbbbb", "cccc"},
+		},
+		{
+			name:  "コードブロックを閉じて開き直す",
+			text:  "```\nline1\nline2\n```",
+			limit: 16,
+			want:  []string{"```\nline1\n```", "```\nline2\n```"},
+		},
+		{
+			name:  "長すぎる行は途中で分割する",
+			text:  strings.Repeat("a", 12),
+			limit: 10,
+			want:  []string{"aaaaaa", "aaaaaa"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitMrkdwn(tt.text, tt.limit))
+		})
+	}
+}
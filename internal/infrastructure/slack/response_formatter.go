@@ -15,29 +15,39 @@ func NewResponseFormatter() port.ResponseFormatter {
 	return &ResponseFormatter{}
 }
 
+// FormatResponse converts the Markdown of the messages into mrkdwn.
+// Sources are numbered in order and listed at the end as "[1] <URI|Name>", which ConversationService shows as a context block.
 func (f *ResponseFormatter) FormatResponse(toolUse tooluse.AttemptComplete) (string, error) {
-	var builder strings.Builder
+	footnoteNumbers := make(map[string]int, len(toolUse.Sources))
+	for i, s := range toolUse.Sources {
+		footnoteNumbers[s.ID] = i + 1
+	}
 
-	// Format the messages
+	var markdown strings.Builder
 	for _, m := range toolUse.Messages {
-		builder.WriteString(m.Text)
-		if m.SourceID != "" {
-			sourceIDs := m.GetSourceIDs()
-			for _, sourceID := range sourceIDs {
-				builder.WriteString(fmt.Sprintf("[^%s]", sourceID))
-			}
+		markdown.WriteString(strings.TrimRight(m.Text, "\n"))
+		for _, sourceID := range m.GetSourceIDs() {
+			markdown.WriteString(fmt.Sprintf("[^%s]", strings.TrimSpace(sourceID)))
 		}
-		builder.WriteString("\n")
+		markdown.WriteString("\n")
 	}
 
-	// Format the sources - Slack uses <URL|text> format for links
-	if len(toolUse.Sources) > 0 {
-		builder.WriteString("\n")
-		for _, s := range toolUse.Sources {
-			// Format: [^ID]: <URI|Name>
-			builder.WriteString(fmt.Sprintf("[^%s]: <%s|%s>\n", s.ID, s.URI, s.Name))
+	converter := newMrkdwnConverter(footnoteNumbers)
+	text := strings.TrimSpace(converter.convert(markdown.String()))
+
+	var citations []string
+	for i, s := range toolUse.Sources {
+		citations = append(citations, fmt.Sprintf("[%d] %s", i+1, slackLink(s.URI, s.Name)))
+	}
+	// 本文中で脚注として書かれたソース
+	for _, c := range converter.citations {
+		if c.Number > len(toolUse.Sources) {
+			citations = append(citations, fmt.Sprintf("[%d] %s", c.Number, c.Text))
 		}
 	}
+	if len(citations) > 0 {
+		text += "\n\n" + strings.Join(citations, "\n")
+	}
 
-	return strings.TrimSpace(builder.String()), nil
+	return strings.TrimSpace(text), nil
 }
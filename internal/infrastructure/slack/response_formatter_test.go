@@ -0,0 +1,44 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/domain/tooluse"
+)
+
+func TestResponseFormatter_FormatResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		toolUse tooluse.AttemptComplete
+		want    string
+	}{
+		{
+			name: "Markdownをmrkdwnに変換する",
+			toolUse: tooluse.NewAttemptComplete([]tooluse.Message{
+				tooluse.NewMessage("## Answer\nSee **the guide**."),
+			}, nil),
+			want: "*Answer*\nSee *the guide*.",
+		},
+		{
+			name: "ソースに番号を振って末尾に並べる",
+			toolUse: tooluse.NewAttemptComplete([]tooluse.Message{
+				tooluse.NewMessage("Here is the answer:"),
+				{SourceID: "b,a", Text: "- Docgent writes docs"},
+			}, []tooluse.Source{
+				{ID: "a", URI: "https://example.com/a", Name: "A"},
+				{ID: "b", URI: "https://example.com/b", Name: "B & C"},
+			}),
+			want: "Here is the answer:\n• Docgent writes docs[2][1]\n\n[1] <https://example.com/a|A>\n[2] <https://example.com/b|B &amp; C>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewResponseFormatter().FormatResponse(tt.toolUse)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
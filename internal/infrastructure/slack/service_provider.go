@@ -1,6 +1,11 @@
 package slack
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+
 	"docgent/internal/application/port"
 )
 
@@ -18,10 +23,62 @@ func (s *ServiceProvider) NewConversationService(ref *ConversationRef, fromUserI
 	return NewConversationService(s.slackAPI, ref, fromUserID)
 }
 
+// ResolveThreadRootRef returns the ref of the thread that the message belongs to
+func (s *ServiceProvider) ResolveThreadRootRef(ctx context.Context, teamID, channelID, messageTimestamp string) (*ConversationRef, error) {
+	return ResolveThreadRootRef(ctx, s.slackAPI, teamID, channelID, messageTimestamp)
+}
+
+// IsChannelMember reports whether the user is a member of the channel
+func (s *ServiceProvider) IsChannelMember(ctx context.Context, channelID, userID string) (bool, error) {
+	return IsChannelMember(ctx, s.slackAPI, channelID, userID)
+}
+
+func (s *ServiceProvider) NewConversationResolver() port.ConversationResolver {
+	return NewConversationResolver(s.slackAPI)
+}
+
 func (s *ServiceProvider) NewSourceRepository() *SourceRepository {
 	return NewSourceRepository(s.slackAPI)
 }
 
+func (s *ServiceProvider) NewSourceLocator() *SourceLocator {
+	return NewSourceLocator(s.slackAPI)
+}
+
 func (s *ServiceProvider) NewResponseFormatter() port.ResponseFormatter {
 	return NewResponseFormatter()
 }
+
+// PostProposalCard posts the card of a proposal to the thread of the conversation
+func (s *ServiceProvider) PostProposalCard(ctx context.Context, ref *ConversationRef, card ProposalCard) error {
+	_, _, err := s.slackAPI.GetClient().PostMessageContext(ctx, ref.ChannelID(),
+		slack.MsgOptionText(card.Text(), false),
+		slack.MsgOptionBlocks(card.Blocks()...),
+		slack.MsgOptionTS(ref.ThreadTimestamp()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to post proposal card: %w", err)
+	}
+	return nil
+}
+
+// OpenRequestChangesModal opens the modal to write feedback on a proposal. The trigger ID of an interaction is valid for 3 seconds
+func (s *ServiceProvider) OpenRequestChangesModal(ctx context.Context, triggerID, privateMetadata string) error {
+	_, err := s.slackAPI.GetClient().OpenViewContext(ctx, triggerID, NewRequestChangesModal(privateMetadata))
+	if err != nil {
+		return fmt.Errorf("failed to open request changes modal: %w", err)
+	}
+	return nil
+}
+
+// UpdateMessage replaces the text and the blocks of a message posted by the app
+func (s *ServiceProvider) UpdateMessage(ctx context.Context, channelID, timestamp, text string, blocks []slack.Block) error {
+	_, _, _, err := s.slackAPI.GetClient().UpdateMessageContext(ctx, channelID, timestamp,
+		slack.MsgOptionText(text, false),
+		slack.MsgOptionBlocks(blocks...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update message: %w", err)
+	}
+	return nil
+}
@@ -1,18 +1,40 @@
 package slack
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
 )
 
 type API struct {
 	client        *slack.Client
 	signingSecret string
+	appToken      string
 }
 
-func NewAPI(token, signingSecret string) *API {
-	return &API{client: slack.New(token), signingSecret: signingSecret}
+type NewAPIOption func(*API)
+
+// WithAppToken sets the app-level token (xapp-...) with the connections:write scope, which Socket Mode requires
+func WithAppToken(appToken string) NewAPIOption {
+	return func(a *API) {
+		a.appToken = appToken
+	}
+}
+
+func NewAPI(token, signingSecret string, options ...NewAPIOption) *API {
+	a := &API{signingSecret: signingSecret}
+	for _, option := range options {
+		option(a)
+	}
+
+	var clientOptions []slack.Option
+	if a.appToken != "" {
+		clientOptions = append(clientOptions, slack.OptionAppLevelToken(a.appToken))
+	}
+	a.client = slack.New(token, clientOptions...)
+	return a
 }
 
 func (a *API) GetClient() *slack.Client {
@@ -22,3 +44,11 @@ func (a *API) GetClient() *slack.Client {
 func (a *API) NewSecretsVerifier(header http.Header) (slack.SecretsVerifier, error) {
 	return slack.NewSecretsVerifier(header, a.signingSecret)
 }
+
+// NewSocketModeClient creates a client that receives events over a WebSocket instead of HTTP requests
+func (a *API) NewSocketModeClient() (*SocketModeClient, error) {
+	if a.appToken == "" {
+		return nil, errors.New("app-level token is required for Socket Mode")
+	}
+	return &SocketModeClient{client: socketmode.New(a.client)}, nil
+}
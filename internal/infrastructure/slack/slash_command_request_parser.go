@@ -0,0 +1,51 @@
+package slack
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// SlashCommandRequestParser parses requests sent to the request URL of a slash command
+type SlashCommandRequestParser struct {
+	api    *API
+	logger *zap.Logger
+}
+
+func NewSlashCommandRequestParser(api *API, logger *zap.Logger) *SlashCommandRequestParser {
+	return &SlashCommandRequestParser{api: api, logger: logger}
+}
+
+func (p *SlashCommandRequestParser) ParseRequest(r *http.Request) (slack.SlashCommand, error) {
+	sv, err := p.api.NewSecretsVerifier(r.Header)
+	if err != nil {
+		p.logger.Error("failed to create secrets verifier", zap.Error(err))
+		return slack.SlashCommand{}, fmt.Errorf("failed to create secrets verifier: %w", ErrInvalidHeader)
+	}
+
+	bodyReader := io.TeeReader(r.Body, &sv)
+	body, err := io.ReadAll(bodyReader)
+	if err != nil {
+		p.logger.Error("failed to read request", zap.Error(err))
+		return slack.SlashCommand{}, fmt.Errorf("failed to read request: %w", ErrInvalidBody)
+	}
+
+	if err := sv.Ensure(); err != nil {
+		p.logger.Error("failed to verify request", zap.Error(err))
+		return slack.SlashCommand{}, fmt.Errorf("failed to verify request: %w", ErrUnauthorizedRequest)
+	}
+
+	// 検証のために読み込んだボディをフォームとして解析し直す
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	command, err := slack.SlashCommandParse(r)
+	if err != nil {
+		p.logger.Error("failed to parse slash command", zap.Error(err))
+		return slack.SlashCommand{}, fmt.Errorf("failed to parse slash command: %w", ErrInvalidEvent)
+	}
+
+	return command, nil
+}
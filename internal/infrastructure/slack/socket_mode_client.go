@@ -0,0 +1,89 @@
+package slack
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+	"go.uber.org/zap"
+)
+
+// SocketModeClient receives Events API events, interactions and slash commands over a Socket Mode WebSocket
+type SocketModeClient struct {
+	client *socketmode.Client
+}
+
+// SocketModeHandlers are called for each request received over Socket Mode
+type SocketModeHandlers struct {
+	Event        func(slackevents.EventsAPIEvent)
+	Interaction  func(slack.InteractionCallback)
+	SlashCommand func(slack.SlashCommand)
+}
+
+// Run connects to Slack and calls the handlers for each request until ctx is canceled.
+// Requests are acknowledged before they are handled, because Slack retries requests which are not acknowledged within 3 seconds.
+// The connection is reestablished by the client when Slack asks to reconnect.
+func (c *SocketModeClient) Run(ctx context.Context, logger *zap.Logger, handlers SocketModeHandlers) error {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt, ok := <-c.client.Events:
+				if !ok {
+					return
+				}
+				c.handle(logger, evt, handlers)
+			}
+		}
+	}()
+
+	return c.client.RunContext(ctx)
+}
+
+func (c *SocketModeClient) handle(logger *zap.Logger, evt socketmode.Event, handlers SocketModeHandlers) {
+	switch evt.Type {
+	case socketmode.EventTypeConnecting:
+		logger.Info("Connecting to Slack with Socket Mode")
+	case socketmode.EventTypeConnected:
+		logger.Info("Connected to Slack with Socket Mode")
+	case socketmode.EventTypeConnectionError, socketmode.EventTypeInvalidAuth, socketmode.EventTypeIncomingError:
+		logger.Warn("Socket Mode error", zap.String("type", string(evt.Type)), zap.Any("data", evt.Data))
+	case socketmode.EventTypeEventsAPI:
+		event, ok := evt.Data.(slackevents.EventsAPIEvent)
+		if !ok {
+			logger.Error("Failed to convert Socket Mode event to EventsAPIEvent")
+			return
+		}
+		c.ack(evt)
+		handlers.Event(event)
+	case socketmode.EventTypeInteractive:
+		callback, ok := evt.Data.(slack.InteractionCallback)
+		if !ok {
+			logger.Error("Failed to convert Socket Mode event to InteractionCallback")
+			return
+		}
+		// An empty acknowledgement closes a submitted modal
+		c.ack(evt)
+		handlers.Interaction(callback)
+	case socketmode.EventTypeSlashCommand:
+		command, ok := evt.Data.(slack.SlashCommand)
+		if !ok {
+			logger.Error("Failed to convert Socket Mode event to SlashCommand")
+			return
+		}
+		// The response is posted later through the response URL
+		c.ack(evt)
+		handlers.SlashCommand(command)
+	default:
+		// Other requests are acknowledged so that Slack does not retry them
+		c.ack(evt)
+	}
+}
+
+func (c *SocketModeClient) ack(evt socketmode.Event) {
+	if evt.Request != nil && evt.Request.EnvelopeID != "" {
+		c.client.Ack(*evt.Request)
+	}
+}
@@ -0,0 +1,51 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// SourceLocator resolves the channel and visibility of Slack threads
+type SourceLocator struct {
+	slackAPI *API
+}
+
+func NewSourceLocator(slackAPI *API) *SourceLocator {
+	return &SourceLocator{slackAPI: slackAPI}
+}
+
+func (l *SourceLocator) Match(uri *data.URI) bool {
+	return uri.Host() == "app.slack.com"
+}
+
+func (l *SourceLocator) Locate(ctx context.Context, uri *data.URI) (port.SourceLocation, error) {
+	ref, err := ParseConversationRef(uri)
+	if err != nil {
+		return port.SourceLocation{}, fmt.Errorf("failed to parse conversation ref: %w", err)
+	}
+	return l.LocateChannel(ctx, ref.ChannelID())
+}
+
+// LocateChannel resolves the visibility of the channel
+func (l *SourceLocator) LocateChannel(ctx context.Context, channelID string) (port.SourceLocation, error) {
+	channel, err := l.slackAPI.GetClient().GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return port.SourceLocation{}, fmt.Errorf("failed to get conversation info: %w", err)
+	}
+
+	visibility := port.SourceVisibilityPublic
+	switch {
+	case channel.IsIM || channel.IsMpIM:
+		visibility = port.SourceVisibilityDirectMessage
+	case channel.IsPrivate:
+		visibility = port.SourceVisibilityPrivate
+	}
+	return port.SourceLocation{ContainerID: channelID, Visibility: visibility}, nil
+}
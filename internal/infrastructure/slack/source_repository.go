@@ -2,9 +2,12 @@ package slack
 
 import (
 	"context"
+	"docgent/internal/application/port"
 	"docgent/internal/domain/data"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/slack-go/slack"
 )
@@ -52,3 +55,69 @@ func (r *SourceRepository) Find(ctx context.Context, uri *data.URI) (*data.Sourc
 
 	return data.NewSource(uri, content.String()), nil
 }
+
+// FindActivitySince returns the messages in the thread posted or edited after the given time
+func (r *SourceRepository) FindActivitySince(ctx context.Context, uri *data.URI, since time.Time) (port.SourceActivity, error) {
+	ref, err := ParseConversationRef(uri)
+	if err != nil {
+		return port.SourceActivity{}, fmt.Errorf("failed to parse conversation ref: %w", err)
+	}
+
+	// 編集されたメッセージも含めるため、投稿日時では絞り込まずにスレッド全体を取得する
+	client := r.slackAPI.GetClient()
+	params := &slack.GetConversationRepliesParameters{
+		ChannelID: ref.ChannelID(),
+		Timestamp: ref.ThreadTimestamp(),
+	}
+	var messages []slack.Message
+	for {
+		page, hasMore, cursor, err := client.GetConversationRepliesContext(ctx, params)
+		if err != nil {
+			return port.SourceActivity{}, fmt.Errorf("failed to get thread messages: %w", err)
+		}
+		messages = append(messages, page...)
+		if !hasMore || cursor == "" {
+			break
+		}
+		params.Cursor = cursor
+	}
+
+	activity := port.SourceActivity{URI: uri}
+	for _, message := range messages {
+		changedAt, err := parseTimestamp(message.Timestamp)
+		if err != nil {
+			return port.SourceActivity{}, err
+		}
+		if message.Edited != nil && message.Edited.Timestamp != "" {
+			if changedAt, err = parseTimestamp(message.Edited.Timestamp); err != nil {
+				return port.SourceActivity{}, err
+			}
+		}
+		if !changedAt.After(since) {
+			continue
+		}
+		activity.Messages = append(activity.Messages, port.ConversationMessage{
+			Author:  message.User,
+			Content: message.Text,
+		})
+	}
+
+	return activity, nil
+}
+
+// parseTimestamp converts a Slack message timestamp (e.g. "1234567890.123456") to time.Time
+func parseTimestamp(ts string) (time.Time, error) {
+	secStr, usecStr, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(secStr, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %s", ts)
+	}
+	var usec int64
+	if usecStr != "" {
+		usec, err = strconv.ParseInt(usecStr, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timestamp: %s", ts)
+		}
+	}
+	return time.Unix(sec, usec*1000), nil
+}
@@ -0,0 +1,43 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+func TestSourceRepository_FindActivitySince(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/conversations.replies", r.URL.Path)
+		json.NewEncoder(w).Encode(map[string]any{
+			"ok": true,
+			"messages": []map[string]any{
+				{"type": "message", "user": "U001", "text": "親メッセージ", "ts": "1700000000.000000"},
+				{"type": "message", "user": "U002", "text": "古いまま", "ts": "1700000100.000000"},
+				{"type": "message", "user": "U003", "text": "後から編集した", "ts": "1700000200.000000", "edited": map[string]string{"user": "U003", "ts": "1700009000.000000"}},
+				{"type": "message", "user": "U004", "text": "新しい返信", "ts": "1700008000.000000"},
+			},
+		})
+	}))
+	defer server.Close()
+	repository := NewSourceRepository(&API{client: slack.New("xoxb-test", slack.OptionAPIURL(server.URL+"/"))})
+	uri := data.NewURIUnsafe("https://app.slack.com/client/T001/C001/1700000000.000000")
+
+	activity, err := repository.FindActivitySince(context.Background(), uri, time.Unix(1700005000, 0))
+
+	assert.NoError(t, err)
+	// 投稿または編集が指定時刻より後のメッセージだけを返す
+	assert.Equal(t, []port.ConversationMessage{
+		{Author: "U003", Content: "後から編集した"},
+		{Author: "U004", Content: "新しい返信"},
+	}, activity.Messages)
+}
@@ -0,0 +1,27 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// ResolveThreadRootRef returns the ref of the thread that the message belongs to.
+// Messages outside of threads are treated as the root of their own thread.
+func ResolveThreadRootRef(ctx context.Context, slackAPI *API, teamID, channelID, messageTimestamp string) (*ConversationRef, error) {
+	messages, _, _, err := slackAPI.GetClient().GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: messageTimestamp,
+		Limit:     1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread messages: %w", err)
+	}
+
+	threadTimestamp := messageTimestamp
+	if len(messages) > 0 && messages[0].ThreadTimestamp != "" {
+		threadTimestamp = messages[0].ThreadTimestamp
+	}
+	return NewConversationRef(teamID, channelID, threadTimestamp, threadTimestamp), nil
+}
@@ -0,0 +1,64 @@
+package transcript
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// youAuthor is the author of the replies of Docgent added to the conversation
+const youAuthor = "docgent"
+
+// ConversationService implements port.ConversationService with messages in memory.
+// Replies are written to the writer, such as stdout, and added to the history as messages of Docgent.
+type ConversationService struct {
+	uri *data.URI
+	out io.Writer
+
+	lock     sync.Mutex
+	messages []port.ConversationMessage
+}
+
+func NewConversationService(uri *data.URI, messages []port.ConversationMessage, out io.Writer) *ConversationService {
+	return &ConversationService{
+		uri:      uri,
+		out:      out,
+		messages: messages,
+	}
+}
+
+func (s *ConversationService) Reply(input string, withMention bool) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := fmt.Fprintln(s.out, input); err != nil {
+		return fmt.Errorf("failed to write reply: %w", err)
+	}
+	s.messages = append(s.messages, port.ConversationMessage{Author: youAuthor, Content: input, IsYou: true})
+	return nil
+}
+
+func (s *ConversationService) GetHistory() (port.ConversationHistory, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	messages := make([]port.ConversationMessage, len(s.messages))
+	copy(messages, s.messages)
+	return port.ConversationHistory{URI: s.uri, Messages: messages}, nil
+}
+
+func (s *ConversationService) URI() *data.URI {
+	return s.uri
+}
+
+// MarkEyes does nothing since there is no message to react to
+func (s *ConversationService) MarkEyes() error {
+	return nil
+}
+
+func (s *ConversationService) RemoveEyes() error {
+	return nil
+}
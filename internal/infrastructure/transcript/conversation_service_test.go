@@ -0,0 +1,58 @@
+package transcript
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+func TestConversationService(t *testing.T) {
+	uri := data.NewURIUnsafe("file:///tmp/thread.json")
+	var out bytes.Buffer
+	service := NewConversationService(uri, []port.ConversationMessage{
+		{Author: "alice", Content: "How do we deploy?", YouMentioned: true},
+	}, &out)
+
+	require.NoError(t, service.Reply("Run make deploy", true))
+
+	// 返信は出力され、Docgentのメッセージとして履歴に追加される
+	assert.Equal(t, "Run make deploy\n", out.String())
+	history, err := service.GetHistory()
+	require.NoError(t, err)
+	assert.Equal(t, port.ConversationHistory{
+		URI: uri,
+		Messages: []port.ConversationMessage{
+			{Author: "alice", Content: "How do we deploy?", YouMentioned: true},
+			{Author: "docgent", Content: "Run make deploy", IsYou: true},
+		},
+	}, history)
+}
+
+func TestSourceRepository_Find(t *testing.T) {
+	uri := data.NewURIUnsafe("file:///tmp/thread.json")
+	service := NewConversationService(uri, []port.ConversationMessage{
+		{Author: "alice", Content: "How do we deploy?"},
+		{Author: "bob", Content: "Run make deploy"},
+	}, &bytes.Buffer{})
+	repository := NewSourceRepository(service)
+
+	assert.True(t, repository.Match(uri))
+	assert.False(t, repository.Match(data.NewURIUnsafe("file:///tmp/other.json")))
+	got, err := repository.Find(context.Background(), uri)
+
+	assert.NoError(t, err)
+	assert.Equal(t, data.NewSource(uri, `<conversation uri="file:///tmp/thread.json">
+<message user="alice">
+How do we deploy?
+</message>
+<message user="bob">
+Run make deploy
+</message>
+</conversation>`), got)
+}
@@ -0,0 +1,38 @@
+package transcript
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"docgent/internal/domain/data"
+)
+
+// SourceRepository serves the conversation of a transcript as a source, so that the model can cite it by its URI
+type SourceRepository struct {
+	conversationService *ConversationService
+}
+
+func NewSourceRepository(conversationService *ConversationService) *SourceRepository {
+	return &SourceRepository{conversationService: conversationService}
+}
+
+func (r *SourceRepository) Match(uri *data.URI) bool {
+	return uri.Equal(r.conversationService.URI())
+}
+
+func (r *SourceRepository) Find(ctx context.Context, uri *data.URI) (*data.Source, error) {
+	history, err := r.conversationService.GetHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("<conversation uri=%q>\n", uri))
+	for _, message := range history.Messages {
+		content.WriteString(fmt.Sprintf("<message user=%q>\n%s\n</message>\n", message.Author, message.Content))
+	}
+	content.WriteString("</conversation>")
+
+	return data.NewSource(uri, content.String()), nil
+}
@@ -0,0 +1,92 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"docgent/internal/application/port"
+	"docgent/internal/domain/data"
+)
+
+// Transcript is a conversation saved as a JSON file, such as an exported Slack thread.
+//
+//	{
+//	  "uri": "https://app.slack.com/client/T001/C001/1700000000.000000",
+//	  "messages": [
+//	    {"author": "alice", "content": "How do we deploy?"},
+//	    {"author": "docgent", "content": "...", "is_you": true}
+//	  ]
+//	}
+//
+// URI is optional. The file:// URI of the transcript is used if it is empty.
+type Transcript struct {
+	URI      string    `json:"uri"`
+	Messages []Message `json:"messages"`
+}
+
+type Message struct {
+	Author       string `json:"author"`
+	Content      string `json:"content"`
+	YouMentioned bool   `json:"you_mentioned"`
+	IsYou        bool   `json:"is_you"`
+}
+
+// Load reads the transcript file at path
+func Load(path string) (*Transcript, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+
+	var transcript Transcript
+	if err := json.Unmarshal(b, &transcript); err != nil {
+		return nil, fmt.Errorf("failed to parse transcript: %w", err)
+	}
+	if len(transcript.Messages) == 0 {
+		return nil, fmt.Errorf("transcript has no messages: %s", path)
+	}
+
+	if transcript.URI == "" {
+		uri, err := FileURI(path)
+		if err != nil {
+			return nil, err
+		}
+		transcript.URI = uri.Value()
+	}
+	return &transcript, nil
+}
+
+// ConversationURI returns the URI of the conversation of the transcript
+func (t *Transcript) ConversationURI() (*data.URI, error) {
+	uri, err := data.NewURI(t.URI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transcript URI: %w", err)
+	}
+	return uri, nil
+}
+
+// ConversationMessages returns the messages in the form of port.ConversationHistory
+func (t *Transcript) ConversationMessages() []port.ConversationMessage {
+	messages := make([]port.ConversationMessage, len(t.Messages))
+	for i, message := range t.Messages {
+		messages[i] = port.ConversationMessage{
+			Author:       message.Author,
+			Content:      message.Content,
+			YouMentioned: message.YouMentioned,
+			IsYou:        message.IsYou,
+		}
+	}
+	return messages
+}
+
+// FileURI returns the file:// URI of the absolute path of the file
+func FileURI(path string) (*data.URI, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	return data.NewURIUnsafe((&url.URL{Scheme: "file", Path: filepath.ToSlash(absPath)}).String()), nil
+}
@@ -0,0 +1,65 @@
+package transcript
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"docgent/internal/application/port"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantURI func(path string) string
+		wantErr bool
+	}{
+		{
+			name:    "URIを指定した場合",
+			content: `{"uri": "https://example.slack.com/archives/C001/p1700000000000000", "messages": [{"author": "alice", "content": "How do we deploy?", "you_mentioned": true}]}`,
+			wantURI: func(string) string { return "https://example.slack.com/archives/C001/p1700000000000000" },
+		},
+		{
+			name:    "URIを省略した場合はファイルのURIを使う",
+			content: `{"messages": [{"author": "alice", "content": "How do we deploy?", "you_mentioned": true}]}`,
+			wantURI: func(path string) string {
+				uri, err := FileURI(path)
+				require.NoError(t, err)
+				return uri.Value()
+			},
+		},
+		{
+			name:    "メッセージがない場合",
+			content: `{"messages": []}`,
+			wantErr: true,
+		},
+		{
+			name:    "JSONが不正な場合",
+			content: `{"messages": `,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "thread.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+
+			got, err := Load(path)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantURI(path), got.URI)
+			assert.Equal(t, []port.ConversationMessage{
+				{Author: "alice", Content: "How do we deploy?", YouMentioned: true},
+			}, got.ConversationMessages())
+		})
+	}
+}
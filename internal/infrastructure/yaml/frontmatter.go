@@ -11,12 +11,32 @@ import (
 
 type Frontmatter struct {
 	Sources []string `yaml:"sources"`
+	// Owners はドキュメントの管理者のGitHubユーザー名またはチーム名です
+	Owners stringList `yaml:"owners,omitempty"`
+}
+
+// stringList は単一の文字列とリストのどちらの書き方も受け付けます
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = stringList{value.Value}
+		return nil
+	}
+	var items []string
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
 }
 
 // GenerateFrontmatter は知識源のリストからYAMLフロントマターを生成します
-func GenerateFrontmatter(sources []*data.URI) (string, error) {
+// owners を指定すると、ドキュメントの管理者も出力します
+func GenerateFrontmatter(sources []*data.URI, owners ...string) (string, error) {
 	frontmatter := Frontmatter{
 		Sources: make([]string, len(sources)),
+		Owners:  owners,
 	}
 	for i, source := range sources {
 		frontmatter.Sources[i] = source.Value()
@@ -56,6 +76,20 @@ func ParseFrontmatter(frontmatter string) ([]*data.URI, error) {
 	return sources, nil
 }
 
+// ParseOwners はYAMLフロントマターからドキュメントの管理者を抽出します
+func ParseOwners(frontmatter string) ([]string, error) {
+	if frontmatter == "" {
+		return nil, nil
+	}
+
+	var fm Frontmatter
+	if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal frontmatter: %w", err)
+	}
+
+	return fm.Owners, nil
+}
+
 // SplitContentAndFrontmatter はファイル内容からフロントマターと本文を分離します
 func SplitContentAndFrontmatter(content string) (frontmatter, body string) {
 	parts := strings.SplitN(content, "---\n", 3)
@@ -103,6 +103,44 @@ func TestParseFrontmatter(t *testing.T) {
 	}
 }
 
+func TestParseOwners(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		expected    []string
+	}{
+		{
+			name:        "正常系：リストで指定",
+			frontmatter: "sources: []\nowners:\n  - alice\n  - \"@org/docs\"\n",
+			expected:    []string{"alice", "@org/docs"},
+		},
+		{
+			name:        "正常系：文字列で指定",
+			frontmatter: "owners: alice\n",
+			expected:    []string{"alice"},
+		},
+		{
+			name:        "正常系：指定なし",
+			frontmatter: "sources: []\n",
+			expected:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owners, err := ParseOwners(tt.frontmatter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, owners)
+		})
+	}
+}
+
+func TestGenerateFrontmatter_WithOwners(t *testing.T) {
+	frontmatter, err := GenerateFrontmatter([]*data.URI{}, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "sources: []\nowners:\n  - alice\n", frontmatter)
+}
+
 func TestSplitContentAndFrontmatter(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -0,0 +1,163 @@
+// Package fakeapi provides an httptest fake of JSON REST APIs for the tests of the API clients.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Config describes how an API authenticates requests and reports errors.
+type Config struct {
+	// AuthHeader and AuthValue are the header every request must have
+	AuthHeader string
+	AuthValue  string
+	// UnauthorizedBody is returned with 401 if the header does not match
+	UnauthorizedBody string
+	// NotFoundBody is returned with 404 if no response matches
+	NotFoundBody string
+	// PathPrefix is trimmed from the request paths, e.g. "/api/v4"
+	PathPrefix string
+	// KeyQuery returns the query appended to the response key as "?query", or "" to key by the path only
+	KeyQuery func(u *url.URL) string
+	// NoContent responds 204 instead of 200 to responses without a body
+	NoContent bool
+}
+
+// RawQuery keys responses by the whole query.
+func RawQuery(u *url.URL) string {
+	return u.RawQuery
+}
+
+// PageQuery keys responses by the page, except the first one.
+func PageQuery(u *url.URL) string {
+	if page := u.Query().Get("page"); page != "" && page != "1" {
+		return "page=" + page
+	}
+	return ""
+}
+
+// Response is a JSON response of the fake server. StatusCode defaults to 200.
+type Response struct {
+	StatusCode int
+	Body       interface{}
+	// NextPage is returned as X-Next-Page if not 0
+	NextPage int
+}
+
+// Request is a request received by the fake server.
+type Request struct {
+	Method string
+	Path   string
+	Query  map[string]string
+	Body   interface{}
+}
+
+// Server is an httptest fake of a REST API.
+// Responses are keyed by "METHOD escaped-path", with the query from Config.KeyQuery appended if any.
+type Server struct {
+	// URL is the base URL of the server
+	URL string
+
+	config    Config
+	responses map[string]Response
+
+	lock     sync.Mutex
+	requests []Request
+}
+
+// NewServer starts a fake server that is closed when the test finishes.
+func NewServer(t *testing.T, config Config, responses map[string]Response) *Server {
+	s := &Server{config: config, responses: responses}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	s.URL = server.URL
+	return s
+}
+
+// ServeHTTP serves the responses and records the requests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(s.config.AuthHeader) != s.config.AuthValue {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, s.config.UnauthorizedBody)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.EscapedPath(), s.config.PathPrefix)
+	var body interface{}
+	if b, _ := io.ReadAll(r.Body); len(b) > 0 {
+		json.Unmarshal(b, &body)
+	}
+	query := map[string]string{}
+	for key := range r.URL.Query() {
+		query[key] = r.URL.Query().Get(key)
+	}
+	s.lock.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Query: query, Body: body})
+	s.lock.Unlock()
+
+	key := r.Method + " " + path
+	if s.config.KeyQuery != nil {
+		if q := s.config.KeyQuery(r.URL); q != "" {
+			key += "?" + q
+		}
+	}
+	resp, ok := s.responses[key]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, s.config.NotFoundBody)
+		return
+	}
+
+	if resp.NextPage != 0 {
+		w.Header().Set("X-Next-Page", fmt.Sprint(resp.NextPage))
+	}
+	statusCode := resp.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	if resp.Body == nil && statusCode == http.StatusOK && s.config.NoContent {
+		statusCode = http.StatusNoContent
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if resp.Body != nil {
+		json.NewEncoder(w).Encode(resp.Body)
+	}
+}
+
+// Requests returns all the requests in the order they were received
+func (s *Server) Requests() []Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// FindRequests returns the requests with the method and the path in the order they were received
+func (s *Server) FindRequests(method, path string) []Request {
+	var requests []Request
+	for _, request := range s.Requests() {
+		if request.Method == method && request.Path == path {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// FindRequest returns the last request with the method and the path
+func (s *Server) FindRequest(t *testing.T, method, path string) Request {
+	requests := s.FindRequests(method, path)
+	if len(requests) == 0 {
+		assert.Fail(t, "リクエストが送信されていません", "%s %s", method, path)
+		return Request{}
+	}
+	return requests[len(requests)-1]
+}